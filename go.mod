@@ -3,6 +3,7 @@ module github.com/rocket-pool/smartnode
 go 1.19
 
 require (
+	filippo.io/age v1.0.0
 	github.com/a8m/envsubst v1.4.2
 	github.com/alessio/shellescape v1.4.1
 	github.com/blang/semver/v4 v4.0.0
@@ -44,6 +45,7 @@ require (
 	github.com/web3-storage/go-w3s-client v0.0.7
 	golang.org/x/crypto v0.6.0
 	golang.org/x/sync v0.1.0
+	golang.org/x/sys v0.5.0
 	golang.org/x/term v0.5.0
 	gopkg.in/yaml.v2 v2.4.0
 )
@@ -167,7 +169,6 @@ require (
 	golang.org/x/exp v0.0.0-20230206171751-46f607a40771 // indirect
 	golang.org/x/mod v0.7.0 // indirect
 	golang.org/x/net v0.7.0 // indirect
-	golang.org/x/sys v0.5.0 // indirect
 	golang.org/x/text v0.7.0 // indirect
 	golang.org/x/tools v0.3.0 // indirect
 	golang.org/x/xerrors v0.0.0-20220907171357-04be3eba64a2 // indirect
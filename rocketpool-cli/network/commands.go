@@ -104,6 +104,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "yes, y",
 						Usage: "Automatically confirm any questions about tree generation",
 					},
+					cli.BoolFlag{
+						Name:  "record-fixture",
+						Usage: "Record every Execution and Beacon client response used during generation into a fixture directory, so the generation can be replayed offline in tests",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -118,6 +122,38 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "verify-rewards-tree",
+				Aliases:   []string{"v"},
+				Usage:     "Verify a published rewards tree file against the Merkle root and totals that were submitted on-chain for its interval",
+				UsageText: "rocketpool network verify-rewards-tree [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "index",
+						Usage: "The index of the rewards interval you want to verify the tree for",
+					},
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The path of the rewards tree file to verify (ignore this flag to use your local copy, which will be downloaded from IPFS if it's missing)",
+					},
+					cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print the verification results as JSON",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return verifyRewardsTree(c)
+
+				},
+			},
+
 			{
 				Name:      "dao-proposals",
 				Aliases:   []string{"d"},
@@ -11,6 +11,7 @@ import (
 
 const (
 	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
 	colorGreen  string = "\033[32m"
 	colorYellow string = "\033[33m"
 )
@@ -70,10 +71,13 @@ func generateRewardsTree(c *cli.Context) error {
 	}
 
 	// Create the generation request
-	_, err = rp.GenerateRewardsTree(index)
+	_, err = rp.GenerateRewardsTree(index, c.Bool("record-fixture"))
 	if err != nil {
 		return err
 	}
+	if c.Bool("record-fixture") {
+		fmt.Printf("The client responses used during generation will be recorded to %s.\n", cfg.Smartnode.GetRewardsTreeFixturePath(index, false))
+	}
 
 	fmt.Printf("Your request to generate the rewards tree for interval %d has been applied, and your `watchtower` container will begin the process during its next duty check (typically 5 minutes).\nYou can follow its progress with %s`rocketpool service logs watchtower`%s.\n\n", index, colorGreen, colorReset)
 
@@ -0,0 +1,135 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/goccy/go-json"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func verifyRewardsTree(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	printJson := c.Bool("json")
+
+	// Get the index
+	var index uint64
+	if c.IsSet("index") {
+		index = c.Uint64("index")
+	} else if printJson {
+		return fmt.Errorf("The --index flag is required when using --json.")
+	} else {
+		indexString := cliutils.Prompt("Which interval would you like to verify the Merkle rewards tree for?", "^\\d+$", "Invalid interval. Please provide a number.")
+		index, err = strconv.ParseUint(indexString, 0, 64)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a valid interval: %w.\n", indexString, err)
+		}
+	}
+
+	// Get the event that was submitted for the interval
+	eventResponse, err := rp.GetRewardsEvent(index)
+	if err != nil {
+		return err
+	}
+
+	// Get the path of the rewards file, downloading it if a local copy doesn't exist
+	path := c.String("file")
+	if path == "" {
+		path, err = homedir.Expand(cfg.Smartnode.GetRewardsTreePath(index, false))
+		if err != nil {
+			return fmt.Errorf("Error expanding rewards tree path: %w", err)
+		}
+		_, err = os.Stat(path)
+		if os.IsNotExist(err) {
+			if !printJson {
+				fmt.Printf("You don't have the rewards tree file for interval %d, downloading it from IPFS... ", index)
+			}
+			_, err = rp.DownloadRewardsFile(index)
+			if err != nil {
+				return fmt.Errorf("Error downloading rewards file for interval %d: %w", index, err)
+			}
+			if !printJson {
+				fmt.Println("done!")
+			}
+		} else if err != nil {
+			return fmt.Errorf("Error checking rewards file %s: %w", path, err)
+		}
+	}
+
+	// Load the file
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Error reading %s: %w", path, err)
+	}
+	var rewardsFile rprewards.RewardsFile
+	err = json.Unmarshal(fileBytes, &rewardsFile)
+	if err != nil {
+		return fmt.Errorf("Error deserializing %s: %w", path, err)
+	}
+	if rewardsFile.Index != index {
+		return fmt.Errorf("%s is the rewards file for interval %d, not interval %d.", path, rewardsFile.Index, index)
+	}
+
+	// Verify it
+	verification, err := rprewards.VerifyRewardsFile(&rewardsFile, eventResponse.Event)
+	if err != nil {
+		return fmt.Errorf("Error verifying %s: %w", path, err)
+	}
+
+	if printJson {
+		bytes, err := json.MarshalIndent(verification, "", "    ")
+		if err != nil {
+			return fmt.Errorf("Error serializing verification results: %w", err)
+		}
+		fmt.Println(string(bytes))
+		return verificationResult(verification)
+	}
+
+	fmt.Printf("Rewards file:         %s\n", path)
+	fmt.Printf("File Merkle root:     %s\n", verification.FileMerkleRoot.Hex())
+	fmt.Printf("Computed Merkle root: %s\n", verification.ComputedMerkleRoot.Hex())
+	fmt.Printf("On-chain Merkle root: %s\n", verification.CanonicalMerkleRoot.Hex())
+	fmt.Printf("Valid proofs:         %d / %d nodes\n\n", verification.ValidProofCount, verification.NodeCount)
+
+	for _, discrepancy := range verification.Discrepancies {
+		fmt.Printf("%s%s: expected %s, got %s%s\n", colorRed, discrepancy.Field, discrepancy.Expected, discrepancy.Actual, colorReset)
+	}
+	for _, discrepancy := range verification.NodeDiscrepancies {
+		fmt.Printf("%sNode %s: %s%s\n", colorRed, discrepancy.Address.Hex(), discrepancy.Reason, colorReset)
+	}
+
+	if verification.Valid {
+		fmt.Printf("%sThe rewards tree for interval %d matches the one submitted by the Oracle DAO.%s\n", colorGreen, index, colorReset)
+	} else {
+		fmt.Printf("\n%sThe rewards tree for interval %d does NOT match the one submitted by the Oracle DAO.%s\n", colorRed, index, colorReset)
+	}
+	return verificationResult(verification)
+
+}
+
+// Exit with a non-zero code if the rewards tree didn't verify, so scripts can check the result without parsing the output.
+// The details have already been printed, so the exit error doesn't have a message.
+func verificationResult(verification *rprewards.RewardsTreeVerification) error {
+	if !verification.Valid {
+		return cli.NewExitError("", 1)
+	}
+	return nil
+}
@@ -50,6 +50,110 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "tasks",
+				Usage:     "Show the status of the tasks run by the node daemon",
+				UsageText: "rocketpool node tasks",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getTasks(c)
+
+				},
+			},
+
+			{
+				Name:    "tx",
+				Aliases: []string{"x"},
+				Usage:   "View and manage the transactions submitted by the node",
+				Subcommands: []cli.Command{
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List the transactions in the node's transaction journal",
+						UsageText: "rocketpool node tx list [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "all, a",
+								Usage: "Include finished transactions that are more than a day old",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return getTransactions(c)
+
+						},
+					},
+
+					{
+						Name:      "speed-up",
+						Aliases:   []string{"s"},
+						Usage:     "Resubmit a pending transaction with higher fees",
+						UsageText: "rocketpool node tx speed-up [options] tx-hash",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the replacement",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+							hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+
+							// Run
+							return speedUpTransaction(c, hash)
+
+						},
+					},
+
+					{
+						Name:      "cancel",
+						Aliases:   []string{"c"},
+						Usage:     "Cancel a pending transaction by replacing it with an empty one",
+						UsageText: "rocketpool node tx cancel [options] tx-hash",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the cancellation",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+							hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+
+							// Run
+							return cancelTransaction(c, hash)
+
+						},
+					},
+				},
+			},
+
 			{
 				Name:      "register",
 				Aliases:   []string{"r"},
@@ -99,6 +203,68 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "projected-rewards",
+				Usage:     "Estimate your RPL and Smoothing Pool rewards for the current interval",
+				UsageText: "rocketpool node projected-rewards",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getProjectedRewards(c)
+
+				},
+			},
+
+			{
+				Name:      "export-income",
+				Usage:     "Export a ledger of every payment your node has received, for tax and accounting purposes",
+				UsageText: "rocketpool node export-income [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "start",
+						Usage: "The first day to include, formatted as YYYY-MM-DD (UTC). Defaults to the Rocket Pool deployment.",
+					},
+					cli.StringFlag{
+						Name:  "end",
+						Usage: "The last day to include, formatted as YYYY-MM-DD (UTC). Defaults to now.",
+					},
+					cli.StringFlag{
+						Name:  "format",
+						Usage: "The format of the ledger: csv or json",
+						Value: "csv",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to write the ledger to (ignore this flag to print it)",
+					},
+					cli.StringFlag{
+						Name:  "price-url",
+						Usage: "A URL to get the fiat price of each payment's asset from, with {asset}, {currency}, {date} and {timestamp} placeholders. It must return a number or a JSON object with a \"price\" field.",
+					},
+					cli.StringFlag{
+						Name:  "currency",
+						Usage: "The fiat currency the price URL returns prices in",
+						Value: "USD",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportIncome(c)
+
+				},
+			},
+
 			{
 				Name:      "set-withdrawal-address",
 				Aliases:   []string{"w"},
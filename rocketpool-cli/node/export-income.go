@@ -0,0 +1,236 @@
+package node
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Settings
+const (
+	incomeDateFormat  string = "2006-01-02"
+	priceFetchTimeout        = 30 * time.Second
+)
+
+// A row of the income ledger
+type incomeLedgerEntry struct {
+	Time            time.Time `json:"time"`
+	Block           uint64    `json:"block"`
+	Type            string    `json:"type"`
+	Asset           string    `json:"asset"`
+	Amount          string    `json:"amount"`
+	TxHash          string    `json:"txHash"`
+	RewardsInterval string    `json:"rewardsInterval,omitempty"`
+	Minipool        string    `json:"minipool,omitempty"`
+	FiatCurrency    string    `json:"fiatCurrency,omitempty"`
+	FiatPrice       *float64  `json:"fiatPrice,omitempty"`
+	FiatValue       *float64  `json:"fiatValue,omitempty"`
+}
+
+func exportIncome(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the date range; the end date is inclusive
+	startTime := time.Unix(0, 0)
+	if c.String("start") != "" {
+		startTime, err = time.Parse(incomeDateFormat, c.String("start"))
+		if err != nil {
+			return fmt.Errorf("Invalid start date '%s', it must be formatted as YYYY-MM-DD: %w", c.String("start"), err)
+		}
+	}
+	endTime := time.Now()
+	if c.String("end") != "" {
+		endDate, err := time.Parse(incomeDateFormat, c.String("end"))
+		if err != nil {
+			return fmt.Errorf("Invalid end date '%s', it must be formatted as YYYY-MM-DD: %w", c.String("end"), err)
+		}
+		endTime = endDate.Add(24*time.Hour - time.Second)
+	}
+	if endTime.Before(startTime) {
+		return fmt.Errorf("The end date must not be before the start date.")
+	}
+	format := strings.ToLower(c.String("format"))
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("Invalid format '%s', it must be csv or json.", format)
+	}
+
+	// Get the income
+	fmt.Fprintf(os.Stderr, "Finding income between %s and %s, this may take a few minutes...\n", startTime.UTC().Format(time.RFC3339), endTime.UTC().Format(time.RFC3339))
+	response, err := rp.NodeIncome(startTime, endTime)
+	if err != nil {
+		return err
+	}
+
+	// Build the ledger
+	priceUrl := c.String("price-url")
+	currency := strings.ToUpper(c.String("currency"))
+	prices := map[string]float64{}
+	ledger := make([]incomeLedgerEntry, 0, len(response.Events))
+	for _, event := range response.Events {
+		entry := incomeLedgerEntry{
+			Time:   event.Time.UTC(),
+			Block:  event.Block,
+			Type:   string(event.Type),
+			Asset:  event.Asset,
+			Amount: formatIncomeAmount(event.Amount),
+			TxHash: event.TxHash.Hex(),
+		}
+		if event.RewardsInterval != nil {
+			entry.RewardsInterval = fmt.Sprint(*event.RewardsInterval)
+		}
+		if event.Minipool != nil {
+			entry.Minipool = event.Minipool.Hex()
+		}
+		if priceUrl != "" {
+			price, err := getFiatPrice(priceUrl, event.Asset, currency, entry.Time, prices)
+			if err != nil {
+				return fmt.Errorf("Error getting the %s price of %s on %s: %w", currency, event.Asset, entry.Time.Format(incomeDateFormat), err)
+			}
+			amount, _ := strconv.ParseFloat(entry.Amount, 64)
+			value := amount * price
+			entry.FiatCurrency = currency
+			entry.FiatPrice = &price
+			entry.FiatValue = &value
+		}
+		ledger = append(ledger, entry)
+	}
+
+	// Serialize it
+	var output bytes.Buffer
+	if format == "json" {
+		ledgerBytes, err := json.MarshalIndent(ledger, "", "    ")
+		if err != nil {
+			return fmt.Errorf("Error serializing income ledger: %w", err)
+		}
+		output.Write(ledgerBytes)
+		output.WriteString("\n")
+	} else {
+		err = writeIncomeCsv(&output, ledger, priceUrl != "", currency)
+		if err != nil {
+			return fmt.Errorf("Error serializing income ledger: %w", err)
+		}
+	}
+
+	// Write it
+	path := c.String("output")
+	if path == "" {
+		fmt.Print(output.String())
+		return nil
+	}
+	err = os.WriteFile(path, output.Bytes(), 0644)
+	if err != nil {
+		return fmt.Errorf("Error writing income ledger to %s: %w", path, err)
+	}
+	fmt.Printf("Wrote %d income events (blocks %d to %d) to %s.\n", len(ledger), response.StartBlock, response.EndBlock, path)
+	return nil
+
+}
+
+// Write the ledger as a CSV file
+func writeIncomeCsv(writer io.Writer, ledger []incomeLedgerEntry, includePrices bool, currency string) error {
+	csvWriter := csv.NewWriter(writer)
+	header := []string{"time", "block", "type", "asset", "amount", "tx_hash", "rewards_interval", "minipool"}
+	if includePrices {
+		currency = strings.ToLower(currency)
+		header = append(header, "price_"+currency, "value_"+currency)
+	}
+	if err := csvWriter.Write(header); err != nil {
+		return err
+	}
+	for _, entry := range ledger {
+		row := []string{
+			entry.Time.Format(time.RFC3339),
+			fmt.Sprint(entry.Block),
+			entry.Type,
+			entry.Asset,
+			entry.Amount,
+			entry.TxHash,
+			entry.RewardsInterval,
+			entry.Minipool,
+		}
+		if includePrices {
+			row = append(row, strconv.FormatFloat(*entry.FiatPrice, 'f', -1, 64), strconv.FormatFloat(*entry.FiatValue, 'f', -1, 64))
+		}
+		if err := csvWriter.Write(row); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// Format an amount in wei as an exact decimal amount of ETH or RPL
+func formatIncomeAmount(amount *big.Int) string {
+	if amount == nil {
+		return "0"
+	}
+	whole, fraction := big.NewInt(0).QuoRem(amount, big.NewInt(1e18), big.NewInt(0))
+	if fraction.Sign() == 0 {
+		return whole.String()
+	}
+	fractionString := strings.TrimRight(fmt.Sprintf("%018s", fraction.String()), "0")
+	return fmt.Sprintf("%s.%s", whole.String(), fractionString)
+}
+
+// Get the price of an asset on the day of an income event from the user's price source.
+// The URL can contain {asset}, {currency}, {date} and {timestamp} placeholders, and must return either a plain number or a JSON object with a "price" field.
+func getFiatPrice(urlTemplate string, asset string, currency string, eventTime time.Time, cache map[string]float64) (float64, error) {
+	date := eventTime.Format(incomeDateFormat)
+	key := asset + "/" + date
+	if price, exists := cache[key]; exists {
+		return price, nil
+	}
+
+	url := strings.NewReplacer(
+		"{asset}", asset,
+		"{currency}", currency,
+		"{date}", date,
+		"{timestamp}", fmt.Sprint(eventTime.Unix()),
+	).Replace(urlTemplate)
+	client := http.Client{
+		Timeout: priceFetchTimeout,
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("price source returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	price, err := strconv.ParseFloat(strings.TrimSpace(string(body)), 64)
+	if err != nil {
+		var priceResponse struct {
+			Price *float64 `json:"price"`
+		}
+		if jsonErr := json.Unmarshal(body, &priceResponse); jsonErr != nil || priceResponse.Price == nil {
+			return 0, fmt.Errorf("price source response was neither a number nor a JSON object with a price: %s", strings.TrimSpace(string(body)))
+		}
+		price = *priceResponse.Price
+	}
+	cache[key] = price
+	return price, nil
+}
@@ -0,0 +1,59 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getProjectedRewards(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the projection
+	fmt.Println("Projecting rewards for the current interval, this may take a few minutes...")
+	rewards, err := rp.NodeProjectedRewards()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Interval %d started on %s and will end on %s (%s from now).\n",
+		rewards.Index,
+		cliutils.GetDateTimeString(uint64(rewards.IntervalStart.Unix())),
+		cliutils.GetDateTimeString(uint64(rewards.IntervalEnd.Unix())),
+		time.Until(rewards.IntervalEnd).Round(time.Second).String())
+	fmt.Printf("This projection is based on the state at slot %d (%s).\n", rewards.SnapshotSlot, cliutils.GetDateTimeString(uint64(rewards.SnapshotTime.Unix())))
+	if rewards.UsedRollingRecord {
+		fmt.Printf("Smoothing Pool rewards are based on attestation performance up to slot %d.\n", rewards.RecordSlot)
+	} else if rewards.UsedTrackedPerformance {
+		fmt.Printf("No rolling record was available, so Smoothing Pool rewards are based on your validators' attestation performance over the last %d epochs tracked by the node daemon and assume every other eligible minipool has performed perfectly.\n", rewards.TrackedEpochs)
+	} else {
+		fmt.Printf("%sNOTE: No rolling record or tracked validator performance was available, so Smoothing Pool rewards assume every eligible minipool has performed perfectly.%s\n", colorYellow, colorReset)
+	}
+
+	fmt.Println("\n=== So Far ===")
+	fmt.Printf("Collateral RPL:     %f RPL\n", rewards.CollateralRpl)
+	fmt.Printf("Oracle DAO RPL:     %f RPL\n", rewards.OracleDaoRpl)
+	fmt.Printf("Smoothing Pool ETH: %f ETH\n", rewards.SmoothingPoolEth)
+
+	fmt.Println("\n=== Projected at the End of the Interval ===")
+	fmt.Printf("Collateral RPL:     %f RPL\n", rewards.ProjectedCollateralRpl)
+	fmt.Printf("Oracle DAO RPL:     %f RPL\n", rewards.ProjectedOracleDaoRpl)
+	fmt.Printf("Smoothing Pool ETH: %f ETH\n", rewards.ProjectedSmoothingPoolEth)
+
+	fmt.Println()
+	fmt.Println("These are estimates; the actual rewards will change based on network activity and your validators' performance for the rest of the interval.")
+
+	// Return
+	return nil
+
+}
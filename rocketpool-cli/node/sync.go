@@ -44,7 +44,13 @@ func printSyncProgress(status *api.ClientManagerStatus, name string) {
 	}
 
 	// A fallback is enabled, so print fallback client status
-	printClientStatus(&status.FallbackClientStatus, fmt.Sprintf("fallback %s client", name))
+	if len(status.FallbackClientStatuses) <= 1 {
+		printClientStatus(&status.FallbackClientStatus, fmt.Sprintf("fallback %s client", name))
+		return
+	}
+	for i := range status.FallbackClientStatuses {
+		printClientStatus(&status.FallbackClientStatuses[i], fmt.Sprintf("fallback %s client #%d", name, i+1))
+	}
 }
 
 func getSyncProgress(c *cli.Context) error {
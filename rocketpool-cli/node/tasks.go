@@ -0,0 +1,69 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getTasks(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the task status
+	response, err := rp.NodeTaskStatus()
+	if err != nil {
+		return err
+	}
+	if !response.StatusAvailable {
+		fmt.Println("The node daemon hasn't reported the status of its tasks yet. Please make sure it's running, then try again.")
+		return nil
+	}
+
+	// Group the tasks by node if the daemon manages more than one
+	nodes := map[common.Address]bool{}
+	for _, task := range response.Tasks {
+		nodes[task.Node] = true
+	}
+	showNodes := len(nodes) > 1
+
+	fmt.Printf("Task status was last updated at %s.\n\n", response.UpdateTime.Format(time.RFC1123))
+	var currentNode common.Address
+	for i, task := range response.Tasks {
+		if showNodes && (i == 0 || task.Node != currentNode) {
+			currentNode = task.Node
+			fmt.Printf("Node %s%s%s:\n\n", colorBlue, currentNode.Hex(), colorReset)
+		}
+
+		if !task.Enabled {
+			fmt.Printf("%s%s%s: disabled\n\n", colorYellow, task.Name, colorReset)
+			continue
+		}
+
+		fmt.Printf("%s%s%s: runs at least every %s\n", colorGreen, task.Name, colorReset, task.Interval)
+		if task.Running {
+			fmt.Println("\tCurrently running.")
+		}
+		if task.LastRunTime.IsZero() {
+			fmt.Println("\tLast run:  never")
+		} else {
+			fmt.Printf("\tLast run:  %s (%s ago)\n", task.LastRunTime.Format(time.RFC1123), time.Since(task.LastRunTime).Round(time.Second))
+		}
+		if task.LastError != "" {
+			fmt.Printf("\tLast error: %s%s%s\n", colorRed, task.LastError, colorReset)
+		}
+		if !task.Running {
+			fmt.Printf("\tNext run:  no later than %s\n", task.NextRunTime.Format(time.RFC1123))
+		}
+		fmt.Println()
+	}
+
+	return nil
+
+}
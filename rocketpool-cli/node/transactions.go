@@ -0,0 +1,221 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getTransactions(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the transactions
+	response, err := rp.NodeTransactions()
+	if err != nil {
+		return err
+	}
+	if len(response.Transactions) == 0 {
+		fmt.Println("The transaction journal is empty.")
+		return nil
+	}
+
+	// Print the transactions, hiding old finished ones unless requested
+	showAll := c.Bool("all")
+	hidden := 0
+	for _, entry := range response.Transactions {
+		if !showAll && entry.Status != transactions.TransactionStatus_Pending && time.Since(entry.SubmitTime) > 24*time.Hour {
+			hidden++
+			continue
+		}
+		printTransaction(entry)
+	}
+	if hidden > 0 {
+		fmt.Printf("%d older finished transactions were hidden; use --all to show them.\n", hidden)
+	}
+	return nil
+
+}
+
+// Print a transaction from the journal
+func printTransaction(entry transactions.JournalEntry) {
+	statusColor := colorGreen
+	switch entry.Status {
+	case transactions.TransactionStatus_Pending:
+		statusColor = colorYellow
+	case transactions.TransactionStatus_Failed, transactions.TransactionStatus_Dropped:
+		statusColor = colorRed
+	case transactions.TransactionStatus_Replaced:
+		statusColor = colorReset
+	}
+
+	fmt.Printf("%s%s%s (%s)\n", statusColor, entry.Hash.Hex(), colorReset, entry.Status)
+	fmt.Printf("\tPurpose:   %s\n", entry.Purpose)
+	fmt.Printf("\tFrom:      %s\n", entry.From.Hex())
+	fmt.Printf("\tNonce:     %d\n", entry.Nonce)
+	fmt.Printf("\tFees:      max %.2f gwei, priority %.2f gwei\n", eth.WeiToGwei(entry.MaxFee), eth.WeiToGwei(entry.MaxPriorityFee))
+	fmt.Printf("\tSubmitted: %s\n", entry.SubmitTime.Format(time.RFC1123))
+	if entry.Replaces != nil {
+		fmt.Printf("\tReplaces:  %s\n", entry.Replaces.Hex())
+	}
+	if entry.BlockNumber != 0 {
+		fmt.Printf("\tBlock:     %d\n", entry.BlockNumber)
+	}
+	fmt.Println()
+}
+
+func speedUpTransaction(c *cli.Context, hash common.Hash) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the transaction can be sped up
+	canSpeedUp, err := rp.CanSpeedUpNodeTransaction(hash)
+	if err != nil {
+		return err
+	}
+	if !canReplaceTransaction(canSpeedUp, "speed up") {
+		return nil
+	}
+	printTransaction(canSpeedUp.Transaction)
+
+	// Assign max fees
+	err = assignReplacementFees(c, rp, canSpeedUp)
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to resubmit transaction %s with higher fees?", hash.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Resubmit the transaction with the same nonce
+	rp.SetCustomNonce(big.NewInt(0).SetUint64(canSpeedUp.Transaction.Nonce))
+	response, err := rp.SpeedUpNodeTransaction(hash)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Resubmitting transaction %s...\n", hash.Hex())
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("The replacement transaction was successfully included in a block.")
+	return nil
+
+}
+
+func cancelTransaction(c *cli.Context, hash common.Hash) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the transaction can be cancelled
+	canCancel, err := rp.CanCancelNodeTransaction(hash)
+	if err != nil {
+		return err
+	}
+	if !canReplaceTransaction(canCancel, "cancel") {
+		return nil
+	}
+	printTransaction(canCancel.Transaction)
+
+	// Assign max fees
+	err = assignReplacementFees(c, rp, canCancel)
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to cancel transaction %s? It will be replaced with an empty transaction that still costs gas.", hash.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Replace the transaction with an empty one that uses the same nonce
+	rp.SetCustomNonce(big.NewInt(0).SetUint64(canCancel.Transaction.Nonce))
+	response, err := rp.CancelNodeTransaction(hash)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Cancelling transaction %s...\n", hash.Hex())
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully cancelled transaction %s.\n", hash.Hex())
+	return nil
+
+}
+
+// Print the reasons a transaction can't be replaced, if there are any
+func canReplaceTransaction(response api.CanReplaceNodeTransactionResponse, action string) bool {
+	if response.NotFound {
+		fmt.Printf("Cannot %s the transaction: it isn't in the transaction journal.\n", action)
+		return false
+	}
+	if response.CanReplace {
+		return true
+	}
+	fmt.Printf("Cannot %s the transaction:\n", action)
+	if response.NotPending {
+		fmt.Printf("The transaction is no longer pending (its status is %s).\n", response.Transaction.Status)
+	}
+	if response.WrongSender {
+		fmt.Printf("The transaction was sent by %s, which is not the node wallet.\n", response.Transaction.From.Hex())
+	}
+	return false
+}
+
+// Get the fees for a replacement transaction, raising them to the minimum the Execution client will accept if necessary
+func assignReplacementFees(c *cli.Context, rp *rocketpool.Client, response api.CanReplaceNodeTransactionResponse) error {
+	err := gas.AssignMaxFeeAndLimit(response.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	maxFee, maxPriorityFee, gasLimit := rp.GetGasSettings()
+	minMaxFee := eth.WeiToGwei(response.MinMaxFee)
+	minMaxPriorityFee := eth.WeiToGwei(response.MinMaxPriorityFee)
+	if maxFee < minMaxFee {
+		fmt.Printf("%sThe replacement needs a max fee of at least %.2f gwei, so that will be used instead.%s\n", colorYellow, minMaxFee, colorReset)
+		maxFee = minMaxFee
+	}
+	if maxPriorityFee < minMaxPriorityFee {
+		fmt.Printf("%sThe replacement needs a priority fee of at least %.2f gwei, so that will be used instead.%s\n", colorYellow, minMaxPriorityFee, colorReset)
+		maxPriorityFee = minMaxPriorityFee
+	}
+	rp.AssignGasSettings(maxFee, maxPriorityFee, gasLimit)
+	return nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const restoreFilePattern string = ".restore-*.tmp"
+
+// Create an encrypted backup of the node's wallet, keys, slashing protection, and settings
+func backupNode(c *cli.Context, backupPath string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode before backing it up.")
+	}
+	dataPath, err := getBackupDataPath(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Check the backup file
+	backupPath, err = homedir.Expand(backupPath)
+	if err != nil {
+		return fmt.Errorf("error expanding backup path: %w", err)
+	}
+	if _, err := os.Stat(backupPath); err == nil {
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%s already exists. Do you want to overwrite it?", backupPath))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Print what's going to happen
+	fmt.Printf("%sNOTE: This backup will contain your node wallet, your validator keys, and a slashing protection interchange file for your validators.\nIt will be encrypted with a passphrase of your choosing - if you lose the passphrase, the backup can't be restored.\nRestoring it will refuse to run while any of its validators are still attesting, so you MUST stop them on this machine before restoring it on another one.%s\n\n", colorYellow, colorReset)
+
+	// Get the passphrase
+	passphrase := promptBackupPassphrase()
+
+	// The daemon owns the node's files, so it creates the backup in the data folder and hands it over to this user
+	response, err := rp.CreateBackup(passphrase, os.Getuid(), os.Getgid())
+	if err != nil {
+		return err
+	}
+	tempPath := filepath.Join(dataPath, response.Filename)
+	if err := moveFile(tempPath, backupPath); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("error saving backup file: %w", err)
+	}
+
+	// Print the results
+	if !response.PasswordIncluded {
+		fmt.Printf("%sNOTE: Your node password isn't stored on disk, so it wasn't included in the backup. Make sure you can provide it to the restored node.%s\n\n", colorYellow, colorReset)
+	}
+	fmt.Printf("Backed up %d files, including %d validator keys, to %s.\n", response.FileCount, len(response.ValidatorPubkeys), backupPath)
+	fmt.Printf("%sStore this file and its passphrase somewhere safe. Anyone with both of them has full control of your node and validators.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+// Restore an encrypted backup of the node
+func restoreNode(c *cli.Context, backupPath string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` and `rocketpool service start` first; the Smartnode needs a synced Beacon Node to make sure the validators in the backup aren't running anywhere else before restoring it.")
+	}
+	dataPath, err := getBackupDataPath(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Copy the backup into the data folder so the daemon can read it
+	backupPath, err = homedir.Expand(backupPath)
+	if err != nil {
+		return fmt.Errorf("error expanding backup path: %w", err)
+	}
+	if err := os.MkdirAll(dataPath, 0700); err != nil {
+		return fmt.Errorf("error creating data folder %s: %w", dataPath, err)
+	}
+	tempFile, err := os.CreateTemp(dataPath, restoreFilePattern)
+	if err != nil {
+		return fmt.Errorf("error copying backup file into the data folder: %w", err)
+	}
+	tempPath := tempFile.Name()
+	tempFile.Close()
+	defer os.Remove(tempPath)
+	if err := copyFile(backupPath, tempPath); err != nil {
+		return fmt.Errorf("error copying backup file into the data folder: %w", err)
+	}
+
+	// Restore it, asking before replacing an existing wallet
+	passphrase := cliutils.PromptPassword("Please enter the passphrase of the backup:", "^.*$", "")
+	fmt.Println("Decrypting the backup and checking if any of its validators are active on the Beacon Chain...")
+	response, err := rp.RestoreBackup(filepath.Base(tempPath), passphrase, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Decrypted backup of %d files from %s (Smartnode v%s).\n\n", response.FileCount, response.Created.Local().Format("2006-01-02 15:04:05"), response.SmartnodeVersion)
+	if len(response.LivePubkeys) > 0 {
+		fmt.Printf("%sThe following validators in the backup attested in epoch(s) %s, so they're still running somewhere (possibly on this machine):%s\n", colorRed, formatEpochs(response.LivenessEpochs), colorReset)
+		for _, pubkey := range response.LivePubkeys {
+			fmt.Printf("\t%s\n", pubkey.Hex())
+		}
+		fmt.Println()
+		return fmt.Errorf("Restoring these validators while they're running elsewhere WILL get them slashed. Stop the validator client that's running them, wait until they miss attestations for at least two full epochs, and try again.")
+	}
+	if len(response.ValidatorPubkeys) > 0 {
+		fmt.Printf("None of the %d validators in the backup attested in epoch(s) %s.\n\n", len(response.ValidatorPubkeys), formatEpochs(response.LivenessEpochs))
+	}
+	if !response.Restored && response.WalletExists {
+		if !cliutils.Confirm(fmt.Sprintf("%sThis node already has a wallet. Restoring the backup will REPLACE it, along with any files in the backup that already exist. Are you sure you want to continue?%s", colorRed, colorReset)) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		response, err = rp.RestoreBackup(filepath.Base(tempPath), passphrase, true)
+		if err != nil {
+			return err
+		}
+		if len(response.LivePubkeys) > 0 {
+			return fmt.Errorf("%d validators in the backup started attesting while it was being restored, so it won't be restored to protect you from being slashed.", len(response.LivePubkeys))
+		}
+	}
+
+	fmt.Printf("Restored %d files, including %d validator keys.\n", response.FileCount, len(response.ValidatorPubkeys))
+	if response.SlashingProtectionImported {
+		fmt.Println("The slashing protection for your validators was imported into your running Validator Client along with their keys.")
+	} else if len(response.ValidatorPubkeys) > 0 {
+		interchangePath := filepath.Join(cfg.Smartnode.GetValidatorKeychainPathInCLI(), backup.SlashingProtectionFilename)
+		fmt.Printf("%sThe slashing protection for your validators was saved to %s. Import it with your Validator Client's slashing protection import command before starting it.%s\n", colorYellow, interchangePath, colorReset)
+	}
+	fmt.Println("Please run `rocketpool service start` to load the restored wallet, keys, and settings.")
+	return nil
+
+}
+
+// Get the data folder on the host
+func getBackupDataPath(cfg *config.RocketPoolConfig) (string, error) {
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return "", fmt.Errorf("error expanding data path: %w", err)
+	}
+	return dataPath, nil
+}
+
+// Format a list of epochs for display
+func formatEpochs(epochs []uint64) string {
+	epochStrings := make([]string, len(epochs))
+	for i, epoch := range epochs {
+		epochStrings[i] = fmt.Sprint(epoch)
+	}
+	return strings.Join(epochStrings, " and ")
+}
+
+// Move a file, copying it if it's on a different filesystem
+func moveFile(source string, destination string) error {
+	if err := os.Rename(source, destination); err == nil {
+		return nil
+	}
+	if err := copyFile(source, destination); err != nil {
+		return err
+	}
+	return os.Remove(source)
+}
+
+// Copy a file into a new or existing file that only the owner can read
+func copyFile(source string, destination string) error {
+	sourceFile, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+	destinationFile, err := os.OpenFile(destination, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(destinationFile, sourceFile); err != nil {
+		destinationFile.Close()
+		return err
+	}
+	return destinationFile.Close()
+}
+
+// Prompt for a new backup passphrase
+func promptBackupPassphrase() string {
+	for {
+		passphrase := cliutils.PromptPassword(
+			"Please enter a passphrase to encrypt the backup with:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("Your passphrase must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm your passphrase:", "^.*$", "")
+		if passphrase == confirmation {
+			return passphrase
+		}
+		fmt.Println("Passphrase confirmation does not match.")
+		fmt.Println("")
+	}
+}
@@ -354,6 +354,33 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "prune-state-cache",
+				Usage:     "Removes the least recently used network states from the state cache until it fits within its configured size",
+				UsageText: "rocketpool service prune-state-cache [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "all, a",
+						Usage: "Remove every state from the cache instead of just the ones over the configured size",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm removing every state",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return pruneStateCache(c)
+
+				},
+			},
+
 			{
 				Name:      "install-update-tracker",
 				Aliases:   []string{"d"},
@@ -472,6 +499,54 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "backup",
+				Usage:     "Creates an encrypted backup of your node wallet, password, validator keys, slashing protection, fee recipient files, rewards trees, and settings",
+				UsageText: "rocketpool service backup [options] backup-file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm overwriting an existing backup file",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					backupPath := c.Args().Get(0)
+
+					// Run command
+					return backupNode(c, backupPath)
+
+				},
+			},
+
+			{
+				Name:      "restore",
+				Usage:     "Restores an encrypted backup created with `rocketpool service backup`. Refuses to restore it if any of its validators are still attesting.",
+				UsageText: "rocketpool service restore [options] backup-file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm replacing the existing wallet",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					backupPath := c.Args().Get(0)
+
+					// Run command
+					return restoreNode(c, backupPath)
+
+				},
+			},
+
 			{
 				Name:      "resync-eth1",
 				Usage:     fmt.Sprintf("%sDeletes the main ETH1 client's chain data and resyncs it from scratch. Only use this as a last resort!%s", colorRed, colorReset),
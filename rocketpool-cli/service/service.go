@@ -1810,3 +1810,31 @@ func checkCpuFeatures() error {
 	fmt.Println("Your CPU supports all required features for 'modern' images.")
 	return nil
 }
+
+// Remove network states from the state cache
+func pruneStateCache(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Prompt for confirmation when emptying the cache
+	all := c.Bool("all")
+	if all && !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to remove every network state from the state cache? Any states that are needed again will have to be rebuilt from your clients.")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Prune the cache
+	response, err := rp.PruneStateCache(all)
+	if err != nil {
+		return err
+	}
+
+	if !response.CacheEnabled {
+		fmt.Println("NOTE: the network state cache is currently disabled. You can enable it in the Smartnode section of `rocketpool service config`.")
+	}
+	fmt.Printf("Removed %d cached states, freeing %s. The state cache is now using %s.\n", response.RemovedFiles, humanize.IBytes(response.FreedBytes), humanize.IBytes(response.RemainingBytes))
+	return nil
+
+}
@@ -48,10 +48,15 @@ func exportWallet(c *cli.Context) error {
 	}
 
 	// Print wallet & return
-	fmt.Println("Node account private key:")
-	fmt.Println("")
-	fmt.Println(export.AccountPrivateKey)
-	fmt.Println("")
+	if export.HasNodeSigner {
+		fmt.Printf("Node account (held by your external signer, so its private key can't be exported): %s\n", export.NodeSignerAddress.Hex())
+		fmt.Println("")
+	} else {
+		fmt.Println("Node account private key:")
+		fmt.Println("")
+		fmt.Println(export.AccountPrivateKey)
+		fmt.Println("")
+	}
 	fmt.Println("Wallet password:")
 	fmt.Println("")
 	fmt.Println(export.Password)
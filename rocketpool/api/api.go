@@ -21,6 +21,9 @@ import (
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
+// The purpose of transactions submitted through the CLI in the transaction journal
+const cliTransactionPurpose string = "cli"
+
 // Waits for an auction transaction
 func waitForTransaction(c *cli.Context, hash common.Hash) (*apitypes.APIResponse, error) {
 
@@ -29,12 +32,24 @@ func waitForTransaction(c *cli.Context, hash common.Hash) (*apitypes.APIResponse
 		return nil, err
 	}
 
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the transaction in the journal so it shows up alongside the daemons' transactions.
+	// The transaction has already been submitted, so a journal error shouldn't stop the wait.
+	_, journalErr := journal.RecordSubmitted(hash, cliTransactionPurpose)
+
 	// Response
 	response := apitypes.APIResponse{}
 	_, err = utils.WaitForTransaction(rp.Client, hash)
 	if err != nil {
 		return nil, err
 	}
+	if journalErr == nil {
+		journal.UpdateStatuses()
+	}
 
 	// Return response
 	return &response, nil
@@ -57,6 +72,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		return err
 	}
 
+	// Apply this command's global flags to any services left over from a previous command run by the API server
+	command.Before = func(c *cli.Context) error {
+		services.UpdateRequestSettings(c)
+		return nil
+	}
+
 	// Register subcommands
 	auction.RegisterSubcommands(&command, "auction", []string{"a"})
 	faucet.RegisterSubcommands(&command, "faucet", []string{"f"})
@@ -86,7 +107,8 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			}
 
 			// Run
-			api.PrintResponse(waitForTransaction(c, hash))
+			response, err := waitForTransaction(c, hash)
+			api.PrintResponse(c, response, err)
 			return nil
 		},
 	})
@@ -28,7 +28,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					response, err := getStatus(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -47,7 +48,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getLots(c))
+					response, err := getLots(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -65,7 +67,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canCreateLot(c))
+					response, err := canCreateLot(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -83,7 +86,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(createLot(c))
+					response, err := createLot(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -109,7 +113,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canBidOnLot(c, lotIndex, amountWei))
+					response, err := canBidOnLot(c, lotIndex, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -135,7 +140,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(bidOnLot(c, lotIndex, amountWei))
+					response, err := bidOnLot(c, lotIndex, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -157,7 +163,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canClaimFromLot(c, lotIndex))
+					response, err := canClaimFromLot(c, lotIndex)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -179,7 +186,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(claimFromLot(c, lotIndex))
+					response, err := claimFromLot(c, lotIndex)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -201,7 +209,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canRecoverRplFromLot(c, lotIndex))
+					response, err := canRecoverRplFromLot(c, lotIndex)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -223,7 +232,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(recoverRplFromLot(c, lotIndex))
+					response, err := recoverRplFromLot(c, lotIndex)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -28,7 +28,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					response, err := getStatus(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -46,7 +47,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canWithdrawRpl(c))
+					response, err := canWithdrawRpl(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -64,7 +66,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(withdrawRpl(c))
+					response, err := withdrawRpl(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
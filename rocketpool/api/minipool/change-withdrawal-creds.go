@@ -156,6 +156,10 @@ func changeWithdrawalCreds(c *cli.Context, minipoolAddress common.Address, mnemo
 	if err != nil {
 		return nil, err
 	}
+	signer, err := services.GetRemoteSigner(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.ChangeWithdrawalCredentialsResponse{}
@@ -210,14 +214,39 @@ func changeWithdrawalCreds(c *cli.Context, minipoolAddress common.Address, mnemo
 		return nil, err
 	}
 
+	// Check if the remote signer holds the withdrawal key
+	withdrawalPubkey := types.BytesToValidatorPubkey(withdrawalKey.PublicKey().Marshal())
+	useSigner := false
+	if signer != nil {
+		useSigner, err = signer.HasKey(withdrawalPubkey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Get signed withdrawal creds change message
-	signature, err := validator.GetSignedWithdrawalCredsChangeMessage(withdrawalKey, validatorIndex, minipoolAddress, signatureDomain)
-	if err != nil {
-		return nil, err
+	var signature types.ValidatorSignature
+	if useSigner {
+		forkInfo, err := getRemoteSignerForkInfo(bc, true)
+		if err != nil {
+			return nil, err
+		}
+		signingRoot, err := validator.GetWithdrawalCredsChangeSigningRoot(withdrawalPubkey, validatorIndex, minipoolAddress, signatureDomain)
+		if err != nil {
+			return nil, err
+		}
+		signature, err = signer.SignWithdrawalCredsChange(withdrawalPubkey, forkInfo, signingRoot, validatorIndex, minipoolAddress)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		signature, err = validator.GetSignedWithdrawalCredsChangeMessage(withdrawalKey, validatorIndex, minipoolAddress, signatureDomain)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Broadcast withdrawal creds change message
-	withdrawalPubkey := types.BytesToValidatorPubkey(withdrawalKey.PublicKey().Marshal())
 	if err := bc.ChangeWithdrawalCredentials(validatorIndex, withdrawalPubkey, minipoolAddress, signature); err != nil {
 		return nil, err
 	}
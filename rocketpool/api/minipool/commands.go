@@ -28,7 +28,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					response, err := getStatus(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -50,7 +51,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canStakeMinipool(c, minipoolAddress))
+					response, err := canStakeMinipool(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -72,7 +74,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(stakeMinipool(c, minipoolAddress))
+					response, err := stakeMinipool(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -94,7 +97,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canPromoteMinipool(c, minipoolAddress))
+					response, err := canPromoteMinipool(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -115,7 +119,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(promoteMinipool(c, minipoolAddress))
+					response, err := promoteMinipool(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -137,7 +142,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canRefundMinipool(c, minipoolAddress))
+					response, err := canRefundMinipool(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -159,7 +165,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(refundMinipool(c, minipoolAddress))
+					response, err := refundMinipool(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -181,7 +188,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canDissolveMinipool(c, minipoolAddress))
+					response, err := canDissolveMinipool(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -203,7 +211,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(dissolveMinipool(c, minipoolAddress))
+					response, err := dissolveMinipool(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -225,7 +234,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canExitMinipool(c, minipoolAddress))
+					response, err := canExitMinipool(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -247,7 +257,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(exitMinipool(c, minipoolAddress))
+					response, err := exitMinipool(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -265,7 +276,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getMinipoolCloseDetailsForNode(c))
+					response, err := getMinipoolCloseDetailsForNode(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -287,7 +299,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(closeMinipool(c, minipoolAddress))
+					response, err := closeMinipool(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -309,7 +322,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canDelegateUpgrade(c, minipoolAddress))
+					response, err := canDelegateUpgrade(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -330,7 +344,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(delegateUpgrade(c, minipoolAddress))
+					response, err := delegateUpgrade(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -352,7 +367,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canDelegateRollback(c, minipoolAddress))
+					response, err := canDelegateRollback(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -373,7 +389,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(delegateRollback(c, minipoolAddress))
+					response, err := delegateRollback(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -399,7 +416,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canSetUseLatestDelegate(c, minipoolAddress, setting))
+					response, err := canSetUseLatestDelegate(c, minipoolAddress, setting)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -424,7 +442,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setUseLatestDelegate(c, minipoolAddress, setting))
+					response, err := setUseLatestDelegate(c, minipoolAddress, setting)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -446,7 +465,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getUseLatestDelegate(c, minipoolAddress))
+					response, err := getUseLatestDelegate(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -468,7 +488,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getDelegate(c, minipoolAddress))
+					response, err := getDelegate(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -490,7 +511,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getPreviousDelegate(c, minipoolAddress))
+					response, err := getPreviousDelegate(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -512,7 +534,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getEffectiveDelegate(c, minipoolAddress))
+					response, err := getEffectiveDelegate(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -536,7 +559,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					nodeAddressStr := c.Args().Get(1)
 
 					// Run
-					api.PrintResponse(getVanityArtifacts(c, depositAmount, nodeAddressStr))
+					response, err := getVanityArtifacts(c, depositAmount, nodeAddressStr)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -562,7 +586,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canBeginReduceBondAmount(c, minipoolAddress, newBondAmountWei))
+					response, err := canBeginReduceBondAmount(c, minipoolAddress, newBondAmountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -587,7 +612,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(beginReduceBondAmount(c, minipoolAddress, newBondAmountWei))
+					response, err := beginReduceBondAmount(c, minipoolAddress, newBondAmountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -609,7 +635,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canReduceBondAmount(c, minipoolAddress))
+					response, err := canReduceBondAmount(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -630,7 +657,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(reduceBondAmount(c, minipoolAddress))
+					response, err := reduceBondAmount(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -648,7 +676,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getDistributeBalanceDetails(c))
+					response, err := getDistributeBalanceDetails(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -669,7 +698,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(distributeBalance(c, minipoolAddress))
+					response, err := distributeBalance(c, minipoolAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -695,7 +725,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(importKey(c, minipoolAddress, mnemonic))
+					response, err := importKey(c, minipoolAddress, mnemonic)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -721,7 +752,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canChangeWithdrawalCreds(c, minipoolAddress, mnemonic))
+					response, err := canChangeWithdrawalCreds(c, minipoolAddress, mnemonic)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -746,7 +778,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(changeWithdrawalCreds(c, minipoolAddress, mnemonic))
+					response, err := changeWithdrawalCreds(c, minipoolAddress, mnemonic)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -764,7 +797,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getMinipoolRescueDissolvedDetailsForNode(c))
+					response, err := getMinipoolRescueDissolvedDetailsForNode(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -790,7 +824,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(rescueDissolvedMinipool(c, minipoolAddress, depositAmount))
+					response, err := rescueDissolvedMinipool(c, minipoolAddress, depositAmount)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -8,6 +8,8 @@ import (
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
@@ -79,6 +81,10 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	if err != nil {
 		return nil, err
 	}
+	signer, err := services.GetRemoteSigner(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.ExitMinipoolResponse{}
@@ -89,10 +95,13 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 		return nil, err
 	}
 
-	// Get validator private key
-	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
-	if err != nil {
-		return nil, err
+	// Check if the remote signer holds the validator key
+	useSigner := false
+	if signer != nil {
+		useSigner, err = signer.HasKey(validatorPubkey)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Get beacon head
@@ -114,9 +123,29 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	}
 
 	// Get signed voluntary exit message
-	signature, err := validator.GetSignedExitMessage(validatorKey, validatorIndex, head.Epoch, signatureDomain)
-	if err != nil {
-		return nil, err
+	var signature types.ValidatorSignature
+	if useSigner {
+		forkInfo, err := getRemoteSignerForkInfo(bc, false)
+		if err != nil {
+			return nil, err
+		}
+		signingRoot, err := validator.GetExitMessageSigningRoot(validatorIndex, head.Epoch, signatureDomain)
+		if err != nil {
+			return nil, err
+		}
+		signature, err = signer.SignVoluntaryExit(validatorPubkey, forkInfo, signingRoot, validatorIndex, head.Epoch)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+		if err != nil {
+			return nil, err
+		}
+		signature, err = validator.GetSignedExitMessage(validatorKey, validatorIndex, head.Epoch, signatureDomain)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Broadcast voluntary exit message
@@ -128,3 +157,29 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	return &response, nil
 
 }
+
+// Get the fork details the remote signer uses to compute a signature domain
+func getRemoteSignerForkInfo(bc beacon.Client, useGenesisFork bool) (web3signer.ForkInfo, error) {
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return web3signer.ForkInfo{}, err
+	}
+	if useGenesisFork {
+		return web3signer.ForkInfo{
+			PreviousVersion:       eth2Config.GenesisForkVersion,
+			CurrentVersion:        eth2Config.GenesisForkVersion,
+			Epoch:                 0,
+			GenesisValidatorsRoot: eth2Config.GenesisValidatorsRoot,
+		}, nil
+	}
+	fork, err := bc.GetFork()
+	if err != nil {
+		return web3signer.ForkInfo{}, err
+	}
+	return web3signer.ForkInfo{
+		PreviousVersion:       fork.PreviousVersion,
+		CurrentVersion:        fork.CurrentVersion,
+		Epoch:                 fork.Epoch,
+		GenesisValidatorsRoot: eth2Config.GenesisValidatorsRoot,
+	}, nil
+}
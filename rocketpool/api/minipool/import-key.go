@@ -11,6 +11,9 @@ import (
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
@@ -32,6 +35,18 @@ func importKey(c *cli.Context, minipoolAddress common.Address, mnemonic string)
 	if err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	km, err := services.GetKeymanagerClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.ImportKeyResponse{}
@@ -88,6 +103,27 @@ func importKey(c *cli.Context, minipoolAddress common.Address, mnemonic string)
 		return nil, fmt.Errorf("error saving keystore: %w", err)
 	}
 
+	// Load the key into the VC without restarting it if possible.
+	// The validator has been signing with another VC, so its slashing protection has to cover everything up to now.
+	if km != nil {
+		var slashingProtection []byte
+		feeRecipient, err := rpsvc.ReadFeeRecipientFile(cfg)
+		if err == nil {
+			slashingProtection, err = backup.GetSlashingProtection(bc, []types.ValidatorPubkey{pubkey})
+		}
+		if err == nil {
+			err = validator.ImportValidatorKeys(cfg, nil, km, []keymanager.ValidatorKey{{
+				Key:            validatorKey,
+				DerivationPath: derivationPath,
+			}}, feeRecipient, string(slashingProtection))
+		}
+		if err != nil {
+			response.KeymanagerError = err.Error()
+		} else {
+			response.LoadedByKeymanager = true
+		}
+	}
+
 	// Return response
 	return &response, nil
 }
@@ -28,7 +28,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getNodeFee(c))
+					response, err := getNodeFee(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -47,7 +48,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getRplPrice(c))
+					response, err := getRplPrice(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -66,7 +68,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStats(c))
+					response, err := getStats(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -85,7 +88,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getTimezones(c))
+					response, err := getTimezones(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -108,7 +112,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canGenerateRewardsTree(c, index))
+					response, err := canGenerateRewardsTree(c, index)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -117,21 +122,31 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 			{
 				Name:      "generate-rewards-tree",
 				Usage:     "Set a request marker for the watchtower to generate the rewards tree for the given interval",
-				UsageText: "rocketpool api network generate-rewards-tree index",
+				UsageText: "rocketpool api network generate-rewards-tree index [record-fixture]",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
+					if len(c.Args()) != 1 {
+						if err := cliutils.ValidateArgCount(c, 2); err != nil {
+							return err
+						}
 					}
 
 					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
 					if err != nil {
 						return err
 					}
+					recordFixture := false
+					if len(c.Args()) == 2 {
+						recordFixture, err = cliutils.ValidateBool("record-fixture", c.Args().Get(1))
+						if err != nil {
+							return err
+						}
+					}
 
 					// Run
-					api.PrintResponse(generateRewardsTree(c, index))
+					response, err := generateRewardsTree(c, index, recordFixture)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -150,7 +165,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getActiveDAOProposals(c))
+					response, err := getActiveDAOProposals(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -174,7 +190,32 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(downloadRewardsFile(c, interval))
+					response, err := downloadRewardsFile(c, interval)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "rewards-event",
+				Usage:     "Get the event that was emitted when the rewards tree for the given interval was submitted",
+				UsageText: "rocketpool api network rewards-event interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					response, err := getRewardsEvent(c, interval)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -193,7 +234,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(isAtlasDeployed(c))
+					response, err := isAtlasDeployed(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -211,7 +253,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getLatestDelegate(c))
+					response, err := getLatestDelegate(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -7,6 +7,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/urfave/cli"
 )
@@ -51,7 +52,7 @@ func canGenerateRewardsTree(c *cli.Context, index uint64) (*api.CanNetworkGenera
 
 }
 
-func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewardsTreeResponse, error) {
+func generateRewardsTree(c *cli.Context, index uint64, recordFixture bool) (*api.NetworkGenerateRewardsTreeResponse, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -66,12 +67,20 @@ func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewa
 	requestPath := cfg.Smartnode.GetRegenerateRewardsTreeRequestPath(index, true)
 	requestFile, err := os.Create(requestPath)
 	if requestFile != nil {
-		requestFile.Close()
+		defer requestFile.Close()
 	}
 	if err != nil {
 		return nil, fmt.Errorf("Error creating request marker: %w", err)
 	}
 
+	// Ask the watchtower to record the client responses as a test fixture if requested
+	if recordFixture {
+		_, err = requestFile.WriteString(config.RecordRewardsTreeFixtureRequest)
+		if err != nil {
+			return nil, fmt.Errorf("Error writing request marker: %w", err)
+		}
+	}
+
 	return &response, nil
 
 }
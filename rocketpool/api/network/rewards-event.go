@@ -0,0 +1,39 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/urfave/cli"
+)
+
+func getRewardsEvent(c *cli.Context, interval uint64) (*api.NetworkRewardsEventResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkRewardsEventResponse{}
+
+	// Get the event that was emitted when the interval was submitted
+	event, err := rewards.GetIntervalEvent(rp, cfg, interval, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting event for interval %d: %w", interval, err)
+	}
+	response.Event = event
+
+	// Return response
+	return &response, nil
+}
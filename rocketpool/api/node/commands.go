@@ -28,7 +28,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					response, err := getStatus(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -47,7 +48,134 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getSyncProgress(c))
+					response, err := getSyncProgress(c)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "task-status",
+				Usage:     "Get the status of the node daemon's tasks",
+				UsageText: "rocketpool api node task-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					response, err := getTaskStatus(c)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "tx-list",
+				Usage:     "Get the transactions in the node's transaction journal",
+				UsageText: "rocketpool api node tx-list",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					response, err := getTransactions(c)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-speed-up-tx",
+				Usage:     "Check whether a pending transaction can be resubmitted with higher fees",
+				UsageText: "rocketpool api node can-speed-up-tx tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					response, err := canReplaceTransaction(c, hash, false)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+			{
+				Name:      "speed-up-tx",
+				Usage:     "Resubmit a pending transaction with higher fees; requires the transaction's nonce",
+				UsageText: "rocketpool api --nonce value node speed-up-tx tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					response, err := replaceTransaction(c, hash, false)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-cancel-tx",
+				Usage:     "Check whether a pending transaction can be cancelled",
+				UsageText: "rocketpool api node can-cancel-tx tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					response, err := canReplaceTransaction(c, hash, true)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+			{
+				Name:      "cancel-tx",
+				Usage:     "Cancel a pending transaction by replacing it with an empty one; requires the transaction's nonce",
+				UsageText: "rocketpool api --nonce value node cancel-tx tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					response, err := replaceTransaction(c, hash, true)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -69,7 +197,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canRegisterNode(c, timezoneLocation))
+					response, err := canRegisterNode(c, timezoneLocation)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -91,7 +220,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(registerNode(c, timezoneLocation))
+					response, err := registerNode(c, timezoneLocation)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -118,7 +248,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canSetWithdrawalAddress(c, withdrawalAddress, confirm))
+					response, err := canSetWithdrawalAddress(c, withdrawalAddress, confirm)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -145,7 +276,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setWithdrawalAddress(c, withdrawalAddress, confirm))
+					response, err := setWithdrawalAddress(c, withdrawalAddress, confirm)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -163,7 +295,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canConfirmWithdrawalAddress(c))
+					response, err := canConfirmWithdrawalAddress(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -180,7 +313,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(confirmWithdrawalAddress(c))
+					response, err := confirmWithdrawalAddress(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -202,7 +336,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canSetTimezoneLocation(c, timezoneLocation))
+					response, err := canSetTimezoneLocation(c, timezoneLocation)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -224,7 +359,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setTimezoneLocation(c, timezoneLocation))
+					response, err := setTimezoneLocation(c, timezoneLocation)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -246,7 +382,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeSwapRpl(c, amountWei))
+					response, err := canNodeSwapRpl(c, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -268,7 +405,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(approveFsRpl(c, amountWei))
+					response, err := approveFsRpl(c, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -294,7 +432,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(waitForApprovalAndSwapFsRpl(c, amountWei, hash))
+					response, err := waitForApprovalAndSwapFsRpl(c, amountWei, hash)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -315,7 +454,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getSwapApprovalGas(c, amountWei))
+					response, err := getSwapApprovalGas(c, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -332,7 +472,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(allowanceFsRpl(c))
+					response, err := allowanceFsRpl(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -354,7 +495,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(swapRpl(c, amountWei))
+					response, err := swapRpl(c, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -376,7 +518,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeStakeRpl(c, amountWei))
+					response, err := canNodeStakeRpl(c, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -398,7 +541,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(approveRpl(c, amountWei))
+					response, err := approveRpl(c, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -424,7 +568,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(waitForApprovalAndStakeRpl(c, amountWei, hash))
+					response, err := waitForApprovalAndStakeRpl(c, amountWei, hash)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -445,7 +590,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStakeApprovalGas(c, amountWei))
+					response, err := getStakeApprovalGas(c, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -462,7 +608,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(allowanceRpl(c))
+					response, err := allowanceRpl(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -484,7 +631,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(stakeRpl(c, amountWei))
+					response, err := stakeRpl(c, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -514,7 +662,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canSetStakeRplForAllowed(c, callerAddress, allowed))
+					response, err := canSetStakeRplForAllowed(c, callerAddress, allowed)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -544,7 +693,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setStakeRplForAllowed(c, callerAddress, allowed))
+					response, err := setStakeRplForAllowed(c, callerAddress, allowed)
+					api.PrintResponse(c, response, err)
 
 					return nil
 				},
@@ -566,7 +716,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeWithdrawRpl(c, amountWei))
+					response, err := canNodeWithdrawRpl(c, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -588,7 +739,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(nodeWithdrawRpl(c, amountWei))
+					response, err := nodeWithdrawRpl(c, amountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -618,7 +770,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeDeposit(c, amountWei, minNodeFee, salt))
+					response, err := canNodeDeposit(c, amountWei, minNodeFee, salt)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -658,7 +811,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					// Run
 					response, err := nodeDeposit(c, amountWei, minNodeFee, salt, useCreditBalance, submit)
 					if submit {
-						api.PrintResponse(response, err)
+						api.PrintResponse(c, response, err)
 					} // else nodeDeposit already printed the encoded transaction
 					return nil
 
@@ -685,7 +838,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeSend(c, amountWei, token))
+					response, err := canNodeSend(c, amountWei, token)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -715,7 +869,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(nodeSend(c, amountWei, token, toAddress))
+					response, err := nodeSend(c, amountWei, token, toAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -741,7 +896,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeBurn(c, amountWei, token))
+					response, err := canNodeBurn(c, amountWei, token)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -767,7 +923,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(nodeBurn(c, amountWei, token))
+					response, err := nodeBurn(c, amountWei, token)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -785,7 +942,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeClaimRpl(c))
+					response, err := canNodeClaimRpl(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -802,7 +960,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(nodeClaimRpl(c))
+					response, err := nodeClaimRpl(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -820,7 +979,54 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getRewards(c))
+					response, err := getRewards(c)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "projected-rewards",
+				Usage:     "Estimate the node's rewards for the current interval",
+				UsageText: "rocketpool api node projected-rewards",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					response, err := getProjectedRewards(c)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "income",
+				Usage:     "Get every payment the node received between two times, given as Unix timestamps",
+				UsageText: "rocketpool api node income start-time end-time",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					startTime, err := cliutils.ValidateUint("start time", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					endTime, err := cliutils.ValidateUint("end time", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					response, err := getIncome(c, startTime, endTime)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -838,7 +1044,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getDepositContractInfo(c))
+					response, err := getDepositContractInfo(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -858,7 +1065,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					data := c.Args().Get(0)
 
 					// Run
-					api.PrintResponse(sign(c, data))
+					response, err := sign(c, data)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -878,7 +1086,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					message := c.Args().Get(0)
 
 					// Run
-					api.PrintResponse(signMessage(c, message))
+					response, err := signMessage(c, message)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -901,7 +1110,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(estimateSetSnapshotDelegateGas(c, delegate))
+					response, err := estimateSetSnapshotDelegateGas(c, delegate)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -923,7 +1133,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setSnapshotDelegate(c, delegate))
+					response, err := setSnapshotDelegate(c, delegate)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -941,7 +1152,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(estimateClearSnapshotDelegateGas(c))
+					response, err := estimateClearSnapshotDelegateGas(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -958,7 +1170,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(clearSnapshotDelegate(c))
+					response, err := clearSnapshotDelegate(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -976,7 +1189,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(isFeeDistributorInitialized(c))
+					response, err := isFeeDistributorInitialized(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -993,7 +1207,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getInitializeFeeDistributorGas(c))
+					response, err := getInitializeFeeDistributorGas(c)
+					api.PrintResponse(c, response, err)
 					return nil
 				},
 			},
@@ -1015,7 +1230,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(estimateSetSnapshotDelegateGas(c, delegate))
+					response, err := estimateSetSnapshotDelegateGas(c, delegate)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1032,7 +1248,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(initializeFeeDistributor(c))
+					response, err := initializeFeeDistributor(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1050,7 +1267,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canDistribute(c))
+					response, err := canDistribute(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1072,7 +1290,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setSnapshotDelegate(c, delegate))
+					response, err := setSnapshotDelegate(c, delegate)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1089,7 +1308,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(distribute(c))
+					response, err := distribute(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1106,7 +1326,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(nodeClaimRpl(c))
+					response, err := nodeClaimRpl(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1124,7 +1345,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getRewardsInfo(c))
+					response, err := getRewardsInfo(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1142,7 +1364,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					indicesString := c.Args().Get(0)
 
 					// Run
-					api.PrintResponse(canClaimRewards(c, indicesString))
+					response, err := canClaimRewards(c, indicesString)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1160,7 +1383,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					indicesString := c.Args().Get(0)
 
 					// Run
-					api.PrintResponse(claimRewards(c, indicesString))
+					response, err := claimRewards(c, indicesString)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1183,7 +1407,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canClaimAndStakeRewards(c, indicesString, stakeAmount))
+					response, err := canClaimAndStakeRewards(c, indicesString, stakeAmount)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1206,7 +1431,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(claimAndStakeRewards(c, indicesString, stakeAmount))
+					response, err := claimAndStakeRewards(c, indicesString, stakeAmount)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1224,7 +1450,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getSmoothingPoolRegistrationStatus(c))
+					response, err := getSmoothingPoolRegistrationStatus(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1245,7 +1472,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canSetSmoothingPoolStatus(c, status))
+					response, err := canSetSmoothingPoolStatus(c, status)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1266,7 +1494,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setSmoothingPoolStatus(c, status))
+					response, err := setSmoothingPoolStatus(c, status)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1283,7 +1512,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(resolveEnsName(c, c.Args().Get(0)))
+					response, err := resolveEnsName(c, c.Args().Get(0))
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1304,7 +1534,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						return err
 					}
 					// Run
-					api.PrintResponse(reverseResolveEnsName(c, address))
+					response, err := reverseResolveEnsName(c, address)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1338,7 +1569,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canCreateVacantMinipool(c, amountWei, minNodeFee, salt, pubkey))
+					response, err := canCreateVacantMinipool(c, amountWei, minNodeFee, salt, pubkey)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1371,7 +1603,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(createVacantMinipool(c, amountWei, minNodeFee, salt, pubkey))
+					response, err := createVacantMinipool(c, amountWei, minNodeFee, salt, pubkey)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1389,7 +1622,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(checkCollateral(c))
+					response, err := checkCollateral(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1407,7 +1641,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getNodeEthBalance(c))
+					response, err := getNodeEthBalance(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1433,7 +1668,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canSendMessage(c, address, message))
+					response, err := canSendMessage(c, address, message)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1458,7 +1694,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(sendMessage(c, address, message))
+					response, err := sendMessage(c, address, message)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
@@ -443,7 +444,7 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 		if err != nil {
 			return nil, err
 		}
-		fmt.Printf("%x\n", b)
+		fmt.Fprintf(apiutils.GetResponseWriter(c), "%x\n", b)
 	}
 
 	response.TxHash = tx.Hash()
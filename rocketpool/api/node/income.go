@@ -0,0 +1,371 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Minipools with at least this much ETH when they're distributed have been fully withdrawn from the Beacon Chain
+var fullWithdrawalThreshold = eth.EthToWei(8)
+
+func getIncome(c *cli.Context, startTimestamp uint64, endTimestamp uint64) (*api.NodeIncomeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeIncomeResponse{
+		Events: []api.IncomeEvent{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the event log interval
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+
+	// Get the block range for the requested time range
+	startTime := time.Unix(int64(startTimestamp), 0)
+	endTime := time.Unix(int64(endTimestamp), 0)
+	latestHeader, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block header: %w", err)
+	}
+	deployBlock, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("deploy.block")))
+	if err != nil {
+		return nil, fmt.Errorf("error getting Rocket Pool deployment block: %w", err)
+	}
+	deployHeader, err := rp.Client.HeaderByNumber(context.Background(), deployBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Rocket Pool deployment block header: %w", err)
+	}
+	startBlock := deployBlock.Uint64()
+	if startTime.After(time.Unix(int64(deployHeader.Time), 0)) {
+		startHeader, err := rprewards.GetELBlockHeaderForTime(startTime, rp)
+		if err != nil {
+			return nil, fmt.Errorf("error getting block for %s: %w", startTime, err)
+		}
+		startBlock = startHeader.Number.Uint64()
+		if time.Unix(int64(startHeader.Time), 0).Before(startTime) {
+			// The block is the last one before the start time, so begin with the one after it
+			startBlock++
+		}
+	}
+	endBlock := latestHeader.Number.Uint64()
+	if endTime.Before(time.Unix(int64(latestHeader.Time), 0)) {
+		endHeader, err := rprewards.GetELBlockHeaderForTime(endTime, rp)
+		if err != nil {
+			return nil, fmt.Errorf("error getting block for %s: %w", endTime, err)
+		}
+		endBlock = endHeader.Number.Uint64()
+	}
+	if startBlock > endBlock {
+		return nil, fmt.Errorf("there aren't any blocks between %s and %s", startTime, endTime)
+	}
+	response.StartBlock = startBlock
+	response.EndBlock = endBlock
+	fromBlock := big.NewInt(0).SetUint64(startBlock)
+	toBlock := big.NewInt(0).SetUint64(endBlock)
+
+	// Get the income from each source
+	claimEvents, err := getRewardsClaimIncome(rp, cfg, nodeAccount.Address, fromBlock, toBlock, intervalSize)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards claims: %w", err)
+	}
+	minipoolEvents, err := getMinipoolIncome(rp, nodeAccount.Address, fromBlock, toBlock, intervalSize)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool distributions: %w", err)
+	}
+	distributorEvents, err := getFeeDistributorIncome(rp, nodeAccount.Address, fromBlock, toBlock, intervalSize)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee distributor distributions: %w", err)
+	}
+	response.Events = append(response.Events, claimEvents...)
+	response.Events = append(response.Events, minipoolEvents...)
+	response.Events = append(response.Events, distributorEvents...)
+
+	// Get the time of each event's block
+	blockTimes := map[uint64]time.Time{}
+	for i, event := range response.Events {
+		blockTime, exists := blockTimes[event.Block]
+		if !exists {
+			header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(event.Block))
+			if err != nil {
+				return nil, fmt.Errorf("error getting header for block %d: %w", event.Block, err)
+			}
+			blockTime = time.Unix(int64(header.Time), 0)
+			blockTimes[event.Block] = blockTime
+		}
+		response.Events[i].Time = blockTime
+	}
+	sort.SliceStable(response.Events, func(i, j int) bool {
+		return response.Events[i].Block < response.Events[j].Block
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the RPL and Smoothing Pool ETH the node claimed from the Merkle distributor
+func getRewardsClaimIncome(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, fromBlock *big.Int, toBlock *big.Int, intervalSize *big.Int) ([]api.IncomeEvent, error) {
+	contractName := "rocketMerkleDistributorMainnet"
+	distributor, err := rp.GetContract(contractName, nil)
+	if err != nil {
+		return nil, err
+	}
+	claimEvent, exists := distributor.ABI.Events["RewardsClaimed"]
+	if !exists {
+		return nil, fmt.Errorf("%s does not have a RewardsClaimed event", contractName)
+	}
+	topics := [][]common.Hash{{claimEvent.ID}, {nodeAddress.Hash()}}
+
+	// Get the node's claims from every version of the distributor; this includes the ones replaced by Oracle DAO upgrades
+	logs, err := eth.FilterContractLogs(rp, contractName, eth.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Topics:    topics,
+	}, intervalSize, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Versions replaced by upgrade contracts don't show up in the upgrade history, so they have to be looked up like the old rewards pools
+	previousAddresses := cfg.Smartnode.GetPreviousMerkleDistributorAddresses()
+	if len(previousAddresses) > 0 {
+		previousLogs, err := eth.GetLogs(rp, previousAddresses, topics, intervalSize, fromBlock, toBlock, nil)
+		if err != nil {
+			return nil, err
+		}
+		logs = mergeLogs(logs, previousLogs)
+	}
+
+	events := []api.IncomeEvent{}
+	for _, log := range logs {
+		values := map[string]interface{}{}
+		err = claimEvent.Inputs.UnpackIntoMap(values, log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding claim in transaction %s: %w", log.TxHash.Hex(), err)
+		}
+		rewardIndices, ok1 := values["rewardIndex"].([]*big.Int)
+		amountsRpl, ok2 := values["amountRPL"].([]*big.Int)
+		amountsEth, ok3 := values["amountETH"].([]*big.Int)
+		if !ok1 || !ok2 || !ok3 || len(amountsRpl) != len(rewardIndices) || len(amountsEth) != len(rewardIndices) {
+			return nil, fmt.Errorf("unexpected claim format in transaction %s", log.TxHash.Hex())
+		}
+
+		for i, rewardIndex := range rewardIndices {
+			interval := rewardIndex.Uint64()
+
+			// Use the rewards file to split the RPL into collateral and Oracle DAO rewards if it's available
+			split := false
+			info, err := rprewards.GetIntervalInfo(rp, cfg, nodeAddress, interval, nil)
+			if err == nil && info.TreeFileExists && info.MerkleRootValid && info.NodeExists {
+				total := big.NewInt(0).Add(&info.CollateralRplAmount.Int, &info.ODaoRplAmount.Int)
+				if total.Cmp(amountsRpl[i]) == 0 {
+					events = appendIncomeEvent(events, log, api.IncomeEventType_CollateralRpl, "RPL", &info.CollateralRplAmount.Int, &interval, nil)
+					events = appendIncomeEvent(events, log, api.IncomeEventType_OracleDaoRpl, "RPL", &info.ODaoRplAmount.Int, &interval, nil)
+					split = true
+				}
+			}
+			if !split {
+				events = appendIncomeEvent(events, log, api.IncomeEventType_RplRewards, "RPL", amountsRpl[i], &interval, nil)
+			}
+			events = appendIncomeEvent(events, log, api.IncomeEventType_SmoothingPoolEth, "ETH", amountsEth[i], &interval, nil)
+		}
+	}
+	return events, nil
+}
+
+// Get the ETH the node received from distributing and refunding its minipools
+func getMinipoolIncome(rp *rocketpool.RocketPool, nodeAddress common.Address, fromBlock *big.Int, toBlock *big.Int, intervalSize *big.Int) ([]api.IncomeEvent, error) {
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(addresses) == 0 {
+		return []api.IncomeEvent{}, nil
+	}
+
+	// Get the events from each minipool delegate version
+	minipoolEvents := map[common.Hash]abi.Event{}
+	for _, address := range addresses {
+		mp, err := minipool.NewMinipool(rp, address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating binding for minipool %s: %w", address.Hex(), err)
+		}
+		contractAbi := mp.GetContract().ABI
+		for _, name := range []string{"EtherWithdrawalProcessed", "EtherWithdrawn"} {
+			event, exists := contractAbi.Events[name]
+			if exists {
+				minipoolEvents[event.ID] = event
+			}
+		}
+	}
+	eventIds := make([]common.Hash, 0, len(minipoolEvents))
+	for id := range minipoolEvents {
+		eventIds = append(eventIds, id)
+	}
+
+	// Get the distributions and refunds
+	logs, err := eth.GetLogs(rp, addresses, [][]common.Hash{eventIds}, intervalSize, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []api.IncomeEvent{}
+	for _, log := range logs {
+		minipoolAddress := log.Address
+		event := minipoolEvents[log.Topics[0]]
+		values := map[string]interface{}{}
+		err = event.Inputs.UnpackIntoMap(values, log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s event in transaction %s: %w", event.Name, log.TxHash.Hex(), err)
+		}
+
+		switch event.Name {
+		case "EtherWithdrawalProcessed":
+			nodeAmount, ok1 := values["nodeAmount"].(*big.Int)
+			totalBalance, ok2 := values["totalBalance"].(*big.Int)
+			if !ok1 || !ok2 {
+				return nil, fmt.Errorf("unexpected distribution format in transaction %s", log.TxHash.Hex())
+			}
+			eventType := api.IncomeEventType_MinipoolDistribution
+			if totalBalance.Cmp(fullWithdrawalThreshold) >= 0 {
+				// This includes the node's bond, so it isn't all income
+				eventType = api.IncomeEventType_MinipoolWithdrawal
+			}
+			events = appendIncomeEvent(events, log, eventType, "ETH", nodeAmount, nil, &minipoolAddress)
+		case "EtherWithdrawn":
+			amount, ok := values["amount"].(*big.Int)
+			if !ok {
+				return nil, fmt.Errorf("unexpected refund format in transaction %s", log.TxHash.Hex())
+			}
+			events = appendIncomeEvent(events, log, api.IncomeEventType_MinipoolRefund, "ETH", amount, nil, &minipoolAddress)
+		}
+	}
+	return events, nil
+}
+
+// Get the ETH the node received from distributing its fee distributor
+func getFeeDistributorIncome(rp *rocketpool.RocketPool, nodeAddress common.Address, fromBlock *big.Int, toBlock *big.Int, intervalSize *big.Int) ([]api.IncomeEvent, error) {
+	distributorAddress, err := node.GetDistributorAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	distributor, err := node.NewDistributor(rp, distributorAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	distributeEvent, exists := distributor.Contract.ABI.Events["FeesDistributed"]
+	if !exists {
+		return nil, fmt.Errorf("the fee distributor does not have a FeesDistributed event")
+	}
+
+	logs, err := eth.GetLogs(rp, []common.Address{distributorAddress}, [][]common.Hash{{distributeEvent.ID}, {nodeAddress.Hash()}}, intervalSize, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []api.IncomeEvent{}
+	for _, log := range logs {
+		nodeAmount, err := decodeFeeDistribution(distributeEvent, log)
+		if err != nil {
+			return nil, err
+		}
+		events = appendIncomeEvent(events, log, api.IncomeEventType_FeeDistributor, "ETH", nodeAmount, nil, nil)
+	}
+	return events, nil
+}
+
+// Get the node's share of a fee distributor's FeesDistributed event
+func decodeFeeDistribution(distributeEvent abi.Event, log types.Log) (*big.Int, error) {
+	values := map[string]interface{}{}
+	err := distributeEvent.Inputs.UnpackIntoMap(values, log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding distribution in transaction %s: %w", log.TxHash.Hex(), err)
+	}
+	nodeAmount, ok := values["_nodeAmount"].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected distribution format in transaction %s", log.TxHash.Hex())
+	}
+	return nodeAmount, nil
+}
+
+// Combine two sets of logs, dropping any that are in both
+func mergeLogs(logs []types.Log, others []types.Log) []types.Log {
+	type logId struct {
+		txHash common.Hash
+		index  uint
+	}
+	seen := map[logId]bool{}
+	for _, log := range logs {
+		seen[logId{log.TxHash, log.Index}] = true
+	}
+	for _, log := range others {
+		id := logId{log.TxHash, log.Index}
+		if !seen[id] {
+			seen[id] = true
+			logs = append(logs, log)
+		}
+	}
+	return logs
+}
+
+// Add an income event to the list if it has a nonzero amount
+func appendIncomeEvent(events []api.IncomeEvent, log types.Log, eventType api.IncomeEventType, asset string, amount *big.Int, interval *uint64, minipoolAddress *common.Address) []api.IncomeEvent {
+	if amount == nil || amount.Sign() == 0 {
+		return events
+	}
+	return append(events, api.IncomeEvent{
+		Block:           log.BlockNumber,
+		Type:            eventType,
+		Asset:           asset,
+		Amount:          big.NewInt(0).Set(amount),
+		TxHash:          log.TxHash,
+		RewardsInterval: interval,
+		Minipool:        minipoolAddress,
+	})
+}
@@ -0,0 +1,58 @@
+package node
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// The FeesDistributed event emitted by the node distributor delegate
+const feesDistributedAbi = `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"_nodeAddress","type":"address"},{"indexed":false,"internalType":"uint256","name":"_userAmount","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"_nodeAmount","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"_time","type":"uint256"}],"name":"FeesDistributed","type":"event"}]`
+
+func TestDecodeFeeDistribution(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(feesDistributedAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := parsed.Events["FeesDistributed"]
+
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(3), big.NewInt(7), big.NewInt(1700000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := types.Log{
+		Topics: []common.Hash{event.ID, common.HexToAddress("0x01").Hash()},
+		Data:   data,
+	}
+
+	nodeAmount, err := decodeFeeDistribution(event, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nodeAmount.Cmp(big.NewInt(7)) != 0 {
+		t.Fatalf("expected a node amount of 7, got %s", nodeAmount)
+	}
+
+	log.Data = data[:32]
+	if _, err := decodeFeeDistribution(event, log); err == nil {
+		t.Fatal("truncated event data should fail to decode")
+	}
+}
+
+func TestMergeLogs(t *testing.T) {
+	first := types.Log{TxHash: common.HexToHash("0x01"), Index: 0}
+	second := types.Log{TxHash: common.HexToHash("0x01"), Index: 1}
+	third := types.Log{TxHash: common.HexToHash("0x02"), Index: 0}
+
+	merged := mergeLogs([]types.Log{first, second}, []types.Log{second, third, third})
+	if len(merged) != 3 {
+		t.Fatalf("expected 3 logs, got %d", len(merged))
+	}
+	if merged[2].TxHash != third.TxHash || merged[2].Index != third.Index {
+		t.Fatalf("expected the new log to be appended, got %v", merged[2])
+	}
+}
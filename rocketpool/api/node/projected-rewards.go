@@ -0,0 +1,227 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const projectedRewardsColor = color.FgHiBlack
+
+func getProjectedRewards(c *cli.Context) (*api.NodeProjectedRewardsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeProjectedRewardsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the state at the latest finalized slot
+	logger := log.NewColorLogger(projectedRewardsColor)
+	beaconCfg, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	beaconHead, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	latestFinalizedSlot := (beaconHead.FinalizedEpoch+1)*beaconCfg.SlotsPerEpoch - 1
+	stateMgr, err := state.NewNetworkStateManager(rp, cfg, ec, bc, &logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, err := stateMgr.GetStateForSlot(latestFinalizedSlot)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state for slot %d: %w", latestFinalizedSlot, err)
+	}
+	currentIndex := networkState.NetworkDetails.RewardIndex
+	if currentIndex == 0 {
+		return nil, fmt.Errorf("rewards can't be projected during the first rewards interval")
+	}
+
+	// Get the timing of the interval
+	genesisTime := time.Unix(int64(beaconCfg.GenesisTime), 0)
+	snapshotTime := genesisTime.Add(time.Duration(networkState.BeaconSlotNumber*beaconCfg.SecondsPerSlot) * time.Second)
+	intervalStart := networkState.NetworkDetails.IntervalStart
+	intervalDuration := networkState.NetworkDetails.IntervalDuration
+	response.Index = currentIndex
+	response.IntervalStart = intervalStart
+	response.IntervalEnd = intervalStart.Add(intervalDuration)
+	response.SnapshotSlot = networkState.BeaconSlotNumber
+	response.SnapshotTime = snapshotTime
+
+	// Get the start slot of the current interval
+	found, event, err := rewards.GetRewardsEvent(rp, currentIndex-1, cfg.Smartnode.GetPreviousRewardsPoolAddresses(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting event for rewards interval %d: %w", currentIndex-1, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("event for rewards interval %d not found", currentIndex-1)
+	}
+	startSlot, err := rprewards.GetStartSlotForInterval(event, bc, beaconCfg)
+	if err != nil {
+		return nil, fmt.Errorf("error getting start slot for interval %d: %w", currentIndex, err)
+	}
+
+	// Get the EL block for the snapshot
+	snapshotElBlockHeader, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(int64(networkState.ElBlockNumber)))
+	if err != nil {
+		return nil, fmt.Errorf("error getting EL block %d: %w", networkState.ElBlockNumber, err)
+	}
+
+	// Load the latest rolling record checkpoint if there is one; it isn't updated here since that would take too long
+	var record *rprewards.RollingRecord
+	if cfg.Smartnode.UseRollingRecords.Value.(bool) {
+		recordMgr, err := rprewards.NewRollingRecordManager(&logger, &logger, cfg, rp, bc, stateMgr, startSlot, beaconCfg, currentIndex)
+		if err != nil {
+			return nil, fmt.Errorf("error creating rolling record manager: %w", err)
+		}
+		record, err = recordMgr.LoadBestRecordFromDisk(startSlot, latestFinalizedSlot, currentIndex)
+		if err != nil {
+			return nil, fmt.Errorf("error loading rolling record checkpoint from disk: %w", err)
+		}
+		if record.LastDutiesSlot < startSlot {
+			// There wasn't a checkpoint for this interval, so fall back to the approximation
+			record = nil
+		} else {
+			response.UsedRollingRecord = true
+			response.RecordSlot = record.LastDutiesSlot
+		}
+	}
+
+	// Without a record, use the performance the node daemon has tracked for the node's own validators
+	var participation map[common.Address]float64
+	if record == nil {
+		performance, err := loadValidatorPerformance(cfg, nodeAccount.Address, intervalStart, beaconCfg)
+		if err != nil {
+			return nil, err
+		}
+		if performance != nil {
+			participation = getParticipationRates(performance)
+			response.UsedTrackedPerformance = true
+			response.TrackedEpochs = performance.LastEpoch - performance.StartEpoch + 1
+		}
+	}
+
+	// Project the rewards for the part of the interval that has passed
+	treegen, err := rprewards.NewTreeGenerator(&logger, "[Projection]", rp, cfg, bc, currentIndex, intervalStart, snapshotTime, networkState.BeaconSlotNumber, snapshotElBlockHeader, 1, networkState, record)
+	if err != nil {
+		return nil, fmt.Errorf("error creating rewards tree generator: %w", err)
+	}
+	rewardsFile, err := treegen.ProjectTree(participation)
+	if err != nil {
+		return nil, fmt.Errorf("error projecting rewards: %w", err)
+	}
+	nodeRewards, exists := rewardsFile.NodeRewards[nodeAccount.Address]
+	if exists {
+		response.CollateralRpl = eth.WeiToEth(&nodeRewards.CollateralRpl.Int)
+		response.OracleDaoRpl = eth.WeiToEth(&nodeRewards.OracleDaoRpl.Int)
+		response.SmoothingPoolEth = eth.WeiToEth(&nodeRewards.SmoothingPoolEth.Int)
+	}
+
+	// Extrapolate them to the end of the interval
+	elapsed := snapshotTime.Sub(intervalStart)
+	scale := float64(1)
+	if elapsed > 0 && elapsed < intervalDuration {
+		scale = intervalDuration.Seconds() / elapsed.Seconds()
+	}
+	response.ProjectedCollateralRpl = response.CollateralRpl * scale
+	response.ProjectedOracleDaoRpl = response.OracleDaoRpl * scale
+	response.ProjectedSmoothingPoolEth = response.SmoothingPoolEth * scale
+
+	// Return response
+	return &response, nil
+
+}
+
+// Load the validator performance saved by the node daemon, or nil if it hasn't tracked the current interval yet
+func loadValidatorPerformance(cfg *config.RocketPoolConfig, nodeAddress common.Address, intervalStart time.Time, beaconCfg beacon.Eth2Config) (*api.ValidatorPerformanceSnapshot, error) {
+	path := cfg.Smartnode.GetValidatorPerformancePath(nodeAddress, true)
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading validator performance file %s: %w", path, err)
+	}
+
+	var performance api.ValidatorPerformanceSnapshot
+	err = json.Unmarshal(bytes, &performance)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing validator performance file %s: %w", path, err)
+	}
+
+	// Ignore performance from a previous interval
+	genesisTime := time.Unix(int64(beaconCfg.GenesisTime), 0)
+	intervalStartEpoch := uint64(0)
+	if intervalStart.After(genesisTime) {
+		intervalStartEpoch = uint64(intervalStart.Sub(genesisTime).Seconds()) / beaconCfg.SecondsPerEpoch
+	}
+	if performance.IntervalStartEpoch != intervalStartEpoch {
+		return nil, nil
+	}
+	return &performance, nil
+}
+
+// Get the fraction of attestation duties each of the node's minipools completed, by minipool address
+func getParticipationRates(performance *api.ValidatorPerformanceSnapshot) map[common.Address]float64 {
+	participation := map[common.Address]float64{}
+	for _, validator := range performance.Validators {
+		if validator.AttestationDuties == 0 {
+			continue
+		}
+		completed := validator.AttestationDuties - validator.MissedAttestations
+		participation[validator.MinipoolAddress] = float64(completed) / float64(validator.AttestationDuties)
+	}
+	return participation
+}
@@ -0,0 +1,47 @@
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getTaskStatus(c *cli.Context) (*api.NodeTaskStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTaskStatusResponse{}
+
+	// Read the status file written by the node daemon; it won't exist until the daemon has started
+	statusPath := cfg.Smartnode.GetNodeTaskStatusPath(true)
+	bytes, err := os.ReadFile(statusPath)
+	if os.IsNotExist(err) {
+		return &response, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading task status file %s: %w", statusPath, err)
+	}
+
+	var status api.NodeTaskStatusFile
+	err = json.Unmarshal(bytes, &status)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing task status file %s: %w", statusPath, err)
+	}
+	response.StatusAvailable = true
+	response.UpdateTime = status.UpdateTime
+	response.Tasks = status.Tasks
+
+	// Return response
+	return &response, nil
+
+}
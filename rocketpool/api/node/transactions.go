@@ -0,0 +1,187 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// The gas limit of a plain ETH transfer, used to cancel transactions
+const cancelGasLimit uint64 = 21000
+
+func getTransactions(c *cli.Context) (*api.NodeTransactionsResponse, error) {
+
+	// Get services
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTransactionsResponse{}
+
+	// Refresh the journal with the latest status of each transaction
+	err = journal.UpdateStatuses()
+	if err != nil {
+		return nil, err
+	}
+	response.Transactions, err = journal.GetEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func canReplaceTransaction(c *cli.Context, hash common.Hash, cancel bool) (*api.CanReplaceNodeTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanReplaceNodeTransactionResponse{}
+
+	// Get the transaction
+	err = journal.UpdateStatuses()
+	if err != nil {
+		return nil, err
+	}
+	entry, exists, err := journal.GetEntry(hash)
+	if err != nil {
+		return nil, err
+	}
+	response.NotFound = !exists
+	if !exists {
+		return &response, nil
+	}
+	response.Transaction = entry
+	response.NotPending = (entry.Status != transactions.TransactionStatus_Pending)
+
+	// Check that the node wallet sent it
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.WrongSender = (entry.From != nodeAccount.Address)
+
+	// Replacements need to raise both fees by the minimum amount the Execution client will accept
+	response.MinMaxFee, response.MinMaxPriorityFee, _ = transactions.GetBumpedFees(entry.MaxFee, entry.MaxPriorityFee, transactions.MinFeeBumpPercent, nil)
+	gasLimit := entry.GasLimit
+	if cancel {
+		gasLimit = cancelGasLimit
+	}
+	response.GasInfo = rocketpool.GasInfo{
+		EstGasLimit:  gasLimit,
+		SafeGasLimit: gasLimit,
+	}
+
+	// Update & return response
+	response.CanReplace = !(response.NotPending || response.WrongSender)
+	return &response, nil
+
+}
+
+func replaceTransaction(c *cli.Context, hash common.Hash, cancel bool) (*api.ReplaceNodeTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ReplaceNodeTransactionResponse{}
+
+	// Get the transaction
+	entry, exists, err := journal.GetEntry(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("transaction %s is not in the transaction journal", hash.Hex())
+	}
+
+	// The replacement must use the same nonce as the original
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+	if opts.Nonce == nil || opts.Nonce.Uint64() != entry.Nonce {
+		return nil, fmt.Errorf("replacing transaction %s requires its nonce (%d)", hash.Hex(), entry.Nonce)
+	}
+
+	// Make sure the new fees are high enough
+	minMaxFee, minMaxPriorityFee, _ := transactions.GetBumpedFees(entry.MaxFee, entry.MaxPriorityFee, transactions.MinFeeBumpPercent, nil)
+	if opts.GasFeeCap == nil || opts.GasFeeCap.Cmp(minMaxFee) < 0 || opts.GasTipCap == nil || opts.GasTipCap.Cmp(minMaxPriorityFee) < 0 {
+		return nil, fmt.Errorf("the replacement for transaction %s must have a max fee of at least %s wei and a priority fee of at least %s wei", hash.Hex(), minMaxFee.String(), minMaxPriorityFee.String())
+	}
+
+	// Build the replacement; a cancellation is an empty transfer to the node itself
+	to := entry.To
+	value := entry.Value
+	data := []byte(entry.Data)
+	gasLimit := entry.GasLimit
+	purpose := entry.Purpose
+	if cancel {
+		to = &entry.From
+		value = big.NewInt(0)
+		data = nil
+		gasLimit = cancelGasLimit
+		purpose = fmt.Sprintf("cancel %s", entry.Purpose)
+	}
+	tx, err := transactions.SignReplacement(w, entry, to, value, data, gasLimit, opts.GasFeeCap, opts.GasTipCap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Submit it
+	err = ec.SendTransaction(context.Background(), tx)
+	if err != nil {
+		return nil, err
+	}
+	_, err = journal.Record(tx, entry.From, purpose, &entry.Hash)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = tx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
@@ -28,7 +28,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					response, err := getStatus(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -47,7 +48,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getMembers(c))
+					response, err := getMembers(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -66,7 +68,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getProposals(c))
+					response, err := getProposals(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -90,7 +93,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getProposal(c, id))
+					response, err := getProposal(c, id)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -116,7 +120,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeInvite(c, memberAddress, memberId, c.Args().Get(2)))
+					response, err := canProposeInvite(c, memberAddress, memberId, c.Args().Get(2))
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -142,7 +147,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeInvite(c, memberAddress, memberId, c.Args().Get(2)))
+					response, err := proposeInvite(c, memberAddress, memberId, c.Args().Get(2))
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -160,7 +166,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeLeave(c))
+					response, err := canProposeLeave(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -178,7 +185,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeLeave(c))
+					response, err := proposeLeave(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -204,7 +212,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeKick(c, memberAddress, fineAmountWei))
+					response, err := canProposeKick(c, memberAddress, fineAmountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -230,7 +239,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeKick(c, memberAddress, fineAmountWei))
+					response, err := proposeKick(c, memberAddress, fineAmountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -252,7 +262,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canCancelProposal(c, proposalId))
+					response, err := canCancelProposal(c, proposalId)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -274,7 +285,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(cancelProposal(c, proposalId))
+					response, err := cancelProposal(c, proposalId)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -296,7 +308,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canVoteOnProposal(c, proposalId))
+					response, err := canVoteOnProposal(c, proposalId)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -322,7 +335,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(voteOnProposal(c, proposalId, support))
+					response, err := voteOnProposal(c, proposalId, support)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -344,7 +358,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canExecuteProposal(c, proposalId))
+					response, err := canExecuteProposal(c, proposalId)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -366,7 +381,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(executeProposal(c, proposalId))
+					response, err := executeProposal(c, proposalId)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -384,7 +400,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canJoin(c))
+					response, err := canJoin(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -402,7 +419,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(approveRpl(c))
+					response, err := approveRpl(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -424,7 +442,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(waitForApprovalAndJoin(c, hash))
+					response, err := waitForApprovalAndJoin(c, hash)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -442,7 +461,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canLeave(c))
+					response, err := canLeave(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -464,7 +484,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(leave(c, bondRefundAddress))
+					response, err := leave(c, bondRefundAddress)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -486,7 +507,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingMembersQuorum(c, quorum))
+					response, err := canProposeSettingMembersQuorum(c, quorum)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -507,7 +529,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingMembersQuorum(c, quorum))
+					response, err := proposeSettingMembersQuorum(c, quorum)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -529,7 +552,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingMembersRplBond(c, bondAmountWei))
+					response, err := canProposeSettingMembersRplBond(c, bondAmountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -550,7 +574,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingMembersRplBond(c, bondAmountWei))
+					response, err := proposeSettingMembersRplBond(c, bondAmountWei)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -572,7 +597,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingMinipoolUnbondedMax(c, unbondedMinipoolMax))
+					response, err := canProposeSettingMinipoolUnbondedMax(c, unbondedMinipoolMax)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -593,7 +619,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingMinipoolUnbondedMax(c, unbondedMinipoolMax))
+					response, err := proposeSettingMinipoolUnbondedMax(c, unbondedMinipoolMax)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -615,7 +642,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingProposalCooldown(c, proposalCooldownBlocks))
+					response, err := canProposeSettingProposalCooldown(c, proposalCooldownBlocks)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -636,7 +664,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalCooldown(c, proposalCooldownBlocks))
+					response, err := proposeSettingProposalCooldown(c, proposalCooldownBlocks)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -658,7 +687,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingProposalVoteTimespan(c, proposalVoteTimespan))
+					response, err := canProposeSettingProposalVoteTimespan(c, proposalVoteTimespan)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -679,7 +709,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalVoteTimespan(c, proposalVoteTimespan))
+					response, err := proposeSettingProposalVoteTimespan(c, proposalVoteTimespan)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -701,7 +732,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingProposalVoteDelayTimespan(c, proposalDelayTimespan))
+					response, err := canProposeSettingProposalVoteDelayTimespan(c, proposalDelayTimespan)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -722,7 +754,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalVoteDelayTimespan(c, proposalDelayTimespan))
+					response, err := proposeSettingProposalVoteDelayTimespan(c, proposalDelayTimespan)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -744,7 +777,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingProposalExecuteTimespan(c, proposalExecuteTimespan))
+					response, err := canProposeSettingProposalExecuteTimespan(c, proposalExecuteTimespan)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -765,7 +799,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalExecuteTimespan(c, proposalExecuteTimespan))
+					response, err := proposeSettingProposalExecuteTimespan(c, proposalExecuteTimespan)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -787,7 +822,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingProposalActionTimespan(c, proposalActionTimespan))
+					response, err := canProposeSettingProposalActionTimespan(c, proposalActionTimespan)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -808,7 +844,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalActionTimespan(c, proposalActionTimespan))
+					response, err := proposeSettingProposalActionTimespan(c, proposalActionTimespan)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -830,7 +867,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingScrubPeriod(c, scrubPeriod))
+					response, err := canProposeSettingScrubPeriod(c, scrubPeriod)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -851,7 +889,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingScrubPeriod(c, scrubPeriod))
+					response, err := proposeSettingScrubPeriod(c, scrubPeriod)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -873,7 +912,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingPromotionScrubPeriod(c, scrubPeriod))
+					response, err := canProposeSettingPromotionScrubPeriod(c, scrubPeriod)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -894,7 +934,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingPromotionScrubPeriod(c, scrubPeriod))
+					response, err := proposeSettingPromotionScrubPeriod(c, scrubPeriod)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -916,7 +957,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingScrubPenaltyEnabled(c, enabled))
+					response, err := canProposeSettingScrubPenaltyEnabled(c, enabled)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -937,7 +979,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingScrubPenaltyEnabled(c, enabled))
+					response, err := proposeSettingScrubPenaltyEnabled(c, enabled)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -959,7 +1002,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingBondReductionWindowStart(c, windowStart))
+					response, err := canProposeSettingBondReductionWindowStart(c, windowStart)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -980,7 +1024,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingBondReductionWindowStart(c, windowStart))
+					response, err := proposeSettingBondReductionWindowStart(c, windowStart)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1002,7 +1047,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingBondReductionWindowLength(c, windowLength))
+					response, err := canProposeSettingBondReductionWindowLength(c, windowLength)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1023,7 +1069,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingBondReductionWindowLength(c, windowLength))
+					response, err := proposeSettingBondReductionWindowLength(c, windowLength)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1036,7 +1083,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Action: func(c *cli.Context) error {
 
 					// Run
-					api.PrintResponse(getMemberSettings(c))
+					response, err := getMemberSettings(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1048,7 +1096,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Action: func(c *cli.Context) error {
 
 					// Run
-					api.PrintResponse(getProposalSettings(c))
+					response, err := getProposalSettings(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -1060,7 +1109,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Action: func(c *cli.Context) error {
 
 					// Run
-					api.PrintResponse(getMinipoolSettings(c))
+					response, err := getMinipoolSettings(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
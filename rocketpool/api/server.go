@@ -0,0 +1,271 @@
+package api
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	apiServerTokenLength int   = 32
+	apiServerMaxBodySize int64 = 1024 * 1024
+	ApiServerColor             = color.FgHiMagenta
+	ApiServerErrorColor        = color.FgRed
+	ApiServerCommand           = "api-server"
+)
+
+// Serves the API command tree over HTTP on a unix socket so callers don't need to start a new process for every command
+type apiServer struct {
+	app          *cli.App
+	settingsPath string
+	settingsTime time.Time
+	token        []byte
+	lock         sync.Mutex // Guards settingsTime and resetting the services
+	log          log.ColorLogger
+	errLog       log.ColorLogger
+}
+
+// Register the API server command
+func RegisterServerCommand(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Run the Rocket Pool API server",
+		Flags: []cli.Flag{
+			cli.UintFlag{
+				Name:  "password-fd",
+				Usage: "Read the node password from this file descriptor once at start-up instead of using the configured password backend",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return runServer(c)
+		},
+	})
+}
+
+// Run the API server
+func runServer(c *cli.Context) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Keep a password handed over by the node daemon for the life of the server, since it can only be read once
+	if c.IsSet("password-fd") {
+		pm := passwords.NewEnvPasswordManager(fmt.Sprintf("fd:%d", c.Uint("password-fd")))
+		if _, err := pm.GetPassword(); err != nil {
+			return fmt.Errorf("error reading the node password: %w", err)
+		}
+		services.PinPasswordManager(pm)
+	}
+
+	server := &apiServer{
+		app:          c.App,
+		settingsPath: os.ExpandEnv(c.GlobalString("settings")),
+		log:          log.NewColorLogger(ApiServerColor),
+		errLog:       log.NewColorLogger(ApiServerErrorColor),
+	}
+	server.settingsTime, err = getModTime(server.settingsPath)
+	if err != nil {
+		return err
+	}
+
+	// The socket and token are owned by the owner of the data directory so the CLI can use them without root access
+	socketPath := cfg.Smartnode.GetApiSocketPath(true)
+	tokenPath := cfg.Smartnode.GetApiTokenPath(true)
+	uid, gid, err := getOwner(filepath.Dir(socketPath))
+	if err != nil {
+		return err
+	}
+
+	// Create a new token every time the server starts
+	server.token, err = createToken(tokenPath, uid, gid)
+	if err != nil {
+		return err
+	}
+
+	// Create the socket
+	err = os.Remove(socketPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing old API socket %s: %w", socketPath, err)
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("error creating API socket %s: %w", socketPath, err)
+	}
+	defer listener.Close()
+	err = os.Chown(socketPath, uid, gid)
+	if err != nil {
+		return fmt.Errorf("error setting owner of API socket %s: %w", socketPath, err)
+	}
+	err = os.Chmod(socketPath, 0600)
+	if err != nil {
+		return fmt.Errorf("error setting permissions of API socket %s: %w", socketPath, err)
+	}
+
+	// Serve
+	mux := http.NewServeMux()
+	mux.HandleFunc(apitypes.APIServerRoute, server.handleRequest)
+	server.log.Printlnf("API server listening on %s.", socketPath)
+	return http.Serve(listener, mux)
+
+}
+
+// Handle a single API request
+func (s *apiServer) handleRequest(w http.ResponseWriter, r *http.Request) {
+
+	// Check the request
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token := []byte(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	if subtle.ConstantTimeCompare(token, s.token) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request apitypes.APIServerRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, apiServerMaxBodySize)).Decode(&request)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error decoding request: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	// Recreate the services if the config has changed since they were created
+	s.reloadServices()
+
+	// Run the command, capturing its response
+	var response bytes.Buffer
+	s.runCommand(s.getCommandArgs(&request), &response)
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(response.Bytes())
+	if err != nil {
+		s.errLog.Error(fmt.Errorf("error writing API response: %w", err))
+	}
+
+}
+
+// Reset the service singletons if the settings file has changed since they were created.
+// Commands that are already running keep the services they already have.
+func (s *apiServer) reloadServices() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	settingsTime, err := getModTime(s.settingsPath)
+	if err != nil {
+		s.errLog.Error(err)
+	} else if !settingsTime.Equal(s.settingsTime) {
+		s.log.Println("Settings file has changed, reloading services.")
+		services.ResetServices()
+		s.settingsTime = settingsTime
+	}
+}
+
+// Run a command on its own copy of the App so commands can run concurrently; the copy captures the command's response,
+// and doesn't print help or usage text or exit the process on errors
+func (s *apiServer) runCommand(args []string, response io.Writer) {
+	app := *s.app
+	app.Before = nil
+	app.Writer = io.Discard
+	app.ErrWriter = io.Discard
+	app.ExitErrHandler = func(c *cli.Context, err error) {}
+	app.Metadata = map[string]interface{}{
+		api.ResponseWriterKey: response,
+	}
+
+	err := app.Run(args)
+	if err != nil {
+		api.PrintErrorResponse(cli.NewContext(&app, nil, nil), err)
+	}
+}
+
+// Build the full command line for a request, as though it had been run with docker exec
+func (s *apiServer) getCommandArgs(request *apitypes.APIServerRequest) []string {
+	args := []string{
+		s.app.Name,
+		"--settings", s.settingsPath,
+		"--maxFee", strconv.FormatFloat(request.MaxFee, 'f', -1, 64),
+		"--maxPrioFee", strconv.FormatFloat(request.MaxPrioFee, 'f', -1, 64),
+		"--gasLimit", strconv.FormatUint(request.GasLimit, 10),
+	}
+	if request.Nonce != "" {
+		args = append(args, "--nonce", request.Nonce)
+	}
+	if request.IgnoreSyncCheck {
+		args = append(args, "--ignore-sync-check")
+	}
+	if request.ForceFallbacks {
+		args = append(args, "--force-fallbacks")
+	}
+	args = append(args, "api")
+	return append(args, request.Args...)
+}
+
+// Create a new random token and save it to disk
+func createToken(path string, uid int, gid int) ([]byte, error) {
+	tokenBytes := make([]byte, apiServerTokenLength)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error generating API token: %w", err)
+	}
+	token := []byte(hex.EncodeToString(tokenBytes))
+
+	err = os.WriteFile(path, token, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error writing API token to %s: %w", path, err)
+	}
+	err = os.Chown(path, uid, gid)
+	if err != nil {
+		return nil, fmt.Errorf("error setting owner of API token %s: %w", path, err)
+	}
+	return token, nil
+}
+
+// Get the owner of a folder
+func getOwner(folder string) (int, int, error) {
+	info, err := os.Stat(folder)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error reading %s: %w", folder, err)
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return os.Getuid(), os.Getgid(), nil
+	}
+	return int(stat.Uid), int(stat.Gid), nil
+}
+
+// Get the last modification time of a file
+func getModTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error reading settings file %s: %w", path, err)
+	}
+	return info.ModTime(), nil
+}
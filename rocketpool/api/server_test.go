@@ -0,0 +1,88 @@
+package api
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli"
+
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+// Create an API server with commands that respond immediately, block until released, or exit with an error code
+func newTestServer(release chan struct{}) *apiServer {
+	app := cli.NewApp()
+	app.Name = "rocketpool"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{Name: "settings"},
+		cli.Float64Flag{Name: "maxFee"},
+		cli.Float64Flag{Name: "maxPrioFee"},
+		cli.Uint64Flag{Name: "gasLimit"},
+	}
+	app.Commands = []cli.Command{{
+		Name: "api",
+		Subcommands: []cli.Command{{
+			Name: "fast",
+			Action: func(c *cli.Context) error {
+				api.PrintResponse(c, &apitypes.APIResponse{}, nil)
+				return nil
+			},
+		}, {
+			Name: "slow",
+			Action: func(c *cli.Context) error {
+				<-release
+				api.PrintResponse(c, &apitypes.APIResponse{Error: "slow"}, nil)
+				return nil
+			},
+		}, {
+			Name: "exit",
+			Action: func(c *cli.Context) error {
+				return cli.NewExitError("exit requested", 3)
+			},
+		}},
+	}}
+	app.Setup()
+	return &apiServer{
+		app:          app,
+		settingsPath: "/tmp/user-settings.yml",
+	}
+}
+
+func TestConcurrentCommands(t *testing.T) {
+	release := make(chan struct{})
+	server := newTestServer(release)
+
+	// Start a command that blocks until it's released
+	var slowResponse bytes.Buffer
+	slowDone := make(chan struct{})
+	go func() {
+		server.runCommand(server.getCommandArgs(&apitypes.APIServerRequest{Args: []string{"slow"}}), &slowResponse)
+		close(slowDone)
+	}()
+
+	// Other commands must not wait for it
+	var fastResponse bytes.Buffer
+	server.runCommand(server.getCommandArgs(&apitypes.APIServerRequest{Args: []string{"fast"}}), &fastResponse)
+	if !strings.Contains(fastResponse.String(), `"status":"success"`) {
+		t.Fatalf("unexpected response from the fast command: %s", fastResponse.String())
+	}
+
+	close(release)
+	<-slowDone
+	if !strings.Contains(slowResponse.String(), `"error":"slow"`) || strings.Contains(slowResponse.String(), "success") {
+		t.Fatalf("unexpected response from the slow command: %s", slowResponse.String())
+	}
+}
+
+func TestExitErrorDoesNotExit(t *testing.T) {
+	server := newTestServer(nil)
+
+	// An exit error would normally end the process, so reaching the check below means it was handled
+	var response bytes.Buffer
+	server.runCommand(server.getCommandArgs(&apitypes.APIServerRequest{Args: []string{"exit"}}), &response)
+	if !strings.Contains(response.String(), "exit requested") {
+		t.Fatalf("expected the exit error in the response, got: %s", response.String())
+	}
+}
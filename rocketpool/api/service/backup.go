@@ -0,0 +1,351 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Settings
+const (
+	backupFilePattern    string = ".backup-*.tmp"
+	restoreStagingFolder string = ".restore-staging"
+)
+
+// Create an encrypted backup of the node's wallet, keys, slashing protection, and settings in the data folder.
+// The daemon owns these files, so the backup is made here and handed over to the given user afterwards.
+func createBackup(c *cli.Context, passphrase string, uid int, gid int) (*api.CreateBackupResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CreateBackupResponse{}
+
+	// Create the backup file
+	dataPath := getDataPath(cfg)
+	file, err := os.CreateTemp(dataPath, backupFilePattern)
+	if err != nil {
+		return nil, fmt.Errorf("error creating backup file: %w", err)
+	}
+	response.Filename = filepath.Base(file.Name())
+	manifest, err := writeBackup(c, cfg, file, passphrase, &response)
+	closeErr := file.Close()
+	if err == nil && closeErr != nil {
+		err = fmt.Errorf("error writing backup file: %w", closeErr)
+	}
+	if err == nil {
+		err = os.Chown(file.Name(), uid, gid)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return nil, err
+	}
+	response.FileCount = len(manifest.Files)
+	response.ValidatorPubkeys = manifest.ValidatorPubkeys
+
+	// Return response
+	return &response, nil
+
+}
+
+// Restore an encrypted backup that was copied into the data folder.
+// Nothing is restored if any of its validators are still attesting, if the node already has a wallet and force isn't set,
+// or if the Validator Client is running and their slashing protection can't be imported into it.
+func restoreBackup(c *cli.Context, filename string, passphrase string, force bool) (*api.RestoreBackupResponse, error) {
+
+	// Get services
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RestoreBackupResponse{}
+
+	// Decrypt and extract the backup into a staging folder
+	dataPath := getDataPath(cfg)
+	if filename != filepath.Base(filename) || !strings.HasPrefix(filename, ".") {
+		return nil, fmt.Errorf("invalid backup filename %s", filename)
+	}
+	file, err := os.Open(filepath.Join(dataPath, filename))
+	if err != nil {
+		return nil, fmt.Errorf("error opening backup file: %w", err)
+	}
+	defer file.Close()
+	stagingPath := filepath.Join(dataPath, restoreStagingFolder)
+	if err := os.RemoveAll(stagingPath); err != nil {
+		return nil, fmt.Errorf("error removing old staging folder %s: %w", stagingPath, err)
+	}
+	manifest, err := backup.Extract(file, passphrase, stagingPath)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(stagingPath)
+	response.Created = manifest.Created
+	response.SmartnodeVersion = manifest.SmartnodeVersion
+	response.FileCount = len(manifest.Files)
+	response.ValidatorPubkeys = manifest.ValidatorPubkeys
+
+	// Make sure it's for the right network
+	network := string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	if manifest.Network != network {
+		return nil, fmt.Errorf("This backup is for the %s network, but your node is configured for %s. Please change your node's network with `rocketpool service config` before restoring it.", manifest.Network, network)
+	}
+
+	// Make sure none of the validators are running anywhere else
+	response.LivePubkeys, response.LivenessEpochs, err = getLivePubkeys(bc, manifest.ValidatorPubkeys)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't check if the validators in the backup are already running, so the backup won't be restored to protect you from being slashed: %w", err)
+	}
+	if len(response.LivePubkeys) > 0 {
+		return &response, nil
+	}
+
+	// Don't replace an existing wallet unless that was confirmed
+	if _, err := os.Stat(cfg.Smartnode.GetWalletPath()); err == nil {
+		response.WalletExists = true
+		if !force {
+			return &response, nil
+		}
+	}
+
+	// Refresh the slashing protection so it also covers anything the validators signed after the backup was made
+	interchangePath := path.Join(backup.DataRoot, "validators", backup.SlashingProtectionFilename)
+	var interchange []byte
+	if len(manifest.ValidatorPubkeys) > 0 {
+		interchange, err = backup.GetSlashingProtection(bc, manifest.ValidatorPubkeys)
+		if err != nil {
+			return nil, err
+		}
+		stagedInterchangePath := filepath.Join(stagingPath, filepath.FromSlash(interchangePath))
+		if err := os.MkdirAll(filepath.Dir(stagedInterchangePath), 0700); err != nil {
+			return nil, fmt.Errorf("error writing slashing protection: %w", err)
+		}
+		if err := os.WriteFile(stagedInterchangePath, interchange, 0600); err != nil {
+			return nil, fmt.Errorf("error writing slashing protection: %w", err)
+		}
+		if !hasFile(manifest, interchangePath) {
+			manifest.Files = append(manifest.Files, backup.File{Path: interchangePath, Mode: 0600})
+		}
+	}
+
+	// The Validator Client only checks its own slashing protection database, not the interchange file.
+	// If it's running, the slashing protection has to be imported into it along with the keys before they're restored, or it
+	// would load them on its next restart without any; a stopped one has to import the file before it's started.
+	// The remote signer keeps its own slashing protection, so this doesn't apply to it.
+	if len(interchange) > 0 && cfg.Smartnode.UseRemoteSigner.Value != true {
+		imported, err := importSlashingProtection(c, cfg, bc, filepath.Join(stagingPath, backup.DataRoot, "validators"), manifest.ValidatorPubkeys, interchange)
+		if err != nil {
+			return nil, err
+		}
+		response.SlashingProtectionImported = imported
+	}
+
+	// Restore the files
+	err = backup.Install(stagingPath, manifest, map[string]string{
+		backup.DataRoot:   dataPath,
+		backup.ConfigRoot: filepath.Dir(os.ExpandEnv(c.GlobalString("settings"))),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error restoring backup: %w", err)
+	}
+	response.Restored = true
+
+	// Return response
+	return &response, nil
+
+}
+
+// Import the slashing protection and keys of the restored validators into the Validator Client through its keymanager API
+// if it's running, and return whether it was. Fails if it's running but its keymanager API is disabled.
+func importSlashingProtection(c *cli.Context, cfg *config.RocketPoolConfig, bc beacon.Client, keychainPath string, pubkeys []types.ValidatorPubkey, interchange []byte) (bool, error) {
+
+	// Get services
+	km, err := services.GetKeymanagerClient(c)
+	if err != nil {
+		return false, err
+	}
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the Validator Client is running; in Native mode it can only be reached through the keymanager API
+	validatorRunning := km != nil
+	if !cfg.IsNativeMode {
+		d, err := services.GetDocker(c)
+		if err != nil {
+			return false, err
+		}
+		validatorRunning, err = validator.IsValidatorRunning(cfg, bc, d)
+		if err != nil {
+			return false, fmt.Errorf("Couldn't check if the Validator Client is running, so the backup won't be restored to protect you from being slashed: %w", err)
+		}
+	}
+	if !validatorRunning {
+		return false, nil
+	}
+	if km == nil {
+		return false, fmt.Errorf("Your Validator Client is running, but its keymanager API is disabled, so the slashing protection in the backup can't be imported into it. Please stop your Validator Client or enable its keymanager API in `rocketpool service config`, then try again.")
+	}
+
+	// Load the keys from the backup
+	keystores := services.GetValidatorKeystores(keychainPath, pm)
+	keys := make([]keymanager.ValidatorKey, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		for _, ks := range keystores {
+			key, err := ks.LoadValidatorKey(pubkey)
+			if err != nil {
+				return false, fmt.Errorf("error loading the key for validator %s from the backup: %w", pubkey.Hex(), err)
+			}
+			if key != nil {
+				keys[i].Key = key
+				break
+			}
+		}
+		if keys[i].Key == nil {
+			return false, fmt.Errorf("couldn't find the key for validator %s in the backup", pubkey.Hex())
+		}
+	}
+
+	// Import them with the slashing protection
+	feeRecipient, err := rpsvc.ReadFeeRecipientFile(cfg)
+	if err != nil {
+		return false, err
+	}
+	err = validator.ImportValidatorKeys(cfg, nil, km, keys, feeRecipient, string(interchange))
+	if err != nil {
+		return false, fmt.Errorf("Couldn't import the slashing protection in the backup into your Validator Client, so the backup won't be restored to protect you from being slashed: %w", err)
+	}
+	return true, nil
+
+}
+
+// Write the node's files into a backup
+func writeBackup(c *cli.Context, cfg *config.RocketPoolConfig, file *os.File, passphrase string, response *api.CreateBackupResponse) (*backup.Manifest, error) {
+
+	writer, err := backup.NewWriter(file, passphrase, shared.RocketPoolVersion, string(cfg.Smartnode.Network.Value.(cfgtypes.Network)))
+	if err != nil {
+		return nil, err
+	}
+	dataPath := getDataPath(cfg)
+
+	// Wallet and password
+	exists, err := writer.AddFile(cfg.Smartnode.GetWalletPath(), backup.DataRoot+"/wallet")
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("The node wallet hasn't been initialized yet, so there's nothing to back up.")
+	}
+	response.PasswordIncluded, err = writer.AddFile(cfg.Smartnode.GetPasswordPath(), backup.DataRoot+"/password")
+	if err != nil {
+		return nil, err
+	}
+
+	// Validator keystores for every client and the fee recipient files; an old slashing protection file is replaced below
+	_, err = writer.AddFolder(cfg.Smartnode.GetValidatorKeychainPath(), backup.DataRoot+"/validators", func(relPath string) bool {
+		return relPath == backup.SlashingProtectionFilename
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.AddFolder(cfg.Smartnode.GetCustomKeyPath(), backup.DataRoot+"/custom-keys", nil); err != nil {
+		return nil, err
+	}
+	if _, err := writer.AddFile(cfg.Smartnode.GetCustomKeyPasswordFilePath(), backup.DataRoot+"/custom-key-passwords"); err != nil {
+		return nil, err
+	}
+
+	// Slashing protection for the validators
+	pubkeys := writer.ValidatorPubkeys()
+	if len(pubkeys) > 0 {
+		if err := services.RequireBeaconClientSynced(c); err != nil {
+			return nil, fmt.Errorf("The Beacon Node is needed to export the slashing protection of your validators: %w", err)
+		}
+		bc, err := services.GetBeaconClient(c)
+		if err != nil {
+			return nil, err
+		}
+		interchange, err := backup.GetSlashingProtection(bc, pubkeys)
+		if err != nil {
+			return nil, err
+		}
+		if err := writer.AddData(interchange, backup.DataRoot+"/validators/"+backup.SlashingProtectionFilename); err != nil {
+			return nil, err
+		}
+	}
+
+	// Rewards trees
+	rewardsTreesPath := filepath.Join(dataPath, config.RewardsTreesFolder)
+	if _, err := writer.AddFolder(rewardsTreesPath, backup.DataRoot+"/"+config.RewardsTreesFolder, nil); err != nil {
+		return nil, err
+	}
+
+	// Additional wallets
+	for _, folder := range cfg.Smartnode.GetAdditionalWalletFolders(true) {
+		relPath, err := filepath.Rel(dataPath, folder)
+		if err != nil || relPath == "." || strings.HasPrefix(relPath, "..") {
+			return nil, fmt.Errorf("additional wallet folder %s isn't in the data folder", folder)
+		}
+		if _, err := writer.AddFolder(folder, backup.DataRoot+"/"+filepath.ToSlash(relPath), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// Settings
+	settingsPath := os.ExpandEnv(c.GlobalString("settings"))
+	exists, err = writer.AddFile(settingsPath, backup.ConfigRoot+"/"+filepath.Base(settingsPath))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("settings file %s not found: %w", settingsPath, fs.ErrNotExist)
+	}
+
+	return writer.Close()
+
+}
+
+// Check if a backup has a file
+func hasFile(manifest *backup.Manifest, archivePath string) bool {
+	for _, file := range manifest.Files {
+		if file.Path == archivePath {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the data folder as the daemon sees it
+func getDataPath(cfg *config.RocketPoolConfig) string {
+	return filepath.Dir(cfg.Smartnode.GetWalletPath())
+}
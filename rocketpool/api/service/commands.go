@@ -1,6 +1,9 @@
 package service
 
 import (
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -27,7 +30,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(terminateDataFolder(c))
+					response, err := terminateDataFolder(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -46,7 +50,31 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getClientStatus(c))
+					response, err := getClientStatus(c)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "prune-state-cache",
+				Usage:     "Removes the least recently used network states from the state cache until it fits within its configured size, or removes all of them",
+				UsageText: "rocketpool api service prune-state-cache all",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					all, err := cliutils.ValidateBool("all", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					response, err := pruneStateCache(c, all)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -64,7 +92,89 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(restartVc(c))
+					response, err := restartVc(c)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "validator-liveness",
+				Usage:     "Checks whether any of the given validators were seen attesting in either of the last two completed epochs",
+				UsageText: "rocketpool api service validator-liveness pubkeys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					pubkeys := []types.ValidatorPubkey{}
+					for _, element := range strings.Split(c.Args().Get(0), ",") {
+						pubkey, err := cliutils.ValidatePubkey("pubkey", element)
+						if err != nil {
+							return err
+						}
+						pubkeys = append(pubkeys, pubkey)
+					}
+
+					// Run
+					response, err := getValidatorLiveness(c, pubkeys)
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "create-backup",
+				Usage:     "Creates an encrypted backup of the node in the data folder and gives it to the given user",
+				UsageText: "rocketpool api service create-backup passphrase uid gid",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					passphrase, err := cliutils.ValidateNodePassword("passphrase", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					uid, err := cliutils.ValidateUint("uid", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					gid, err := cliutils.ValidateUint("gid", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					response, err := createBackup(c, passphrase, int(uid), int(gid))
+					api.PrintResponse(c, response, err)
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "restore-backup",
+				Usage:     "Restores an encrypted backup that was copied into the data folder",
+				UsageText: "rocketpool api service restore-backup filename passphrase force",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					force, err := cliutils.ValidateBool("force", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					response, err := restoreBackup(c, c.Args().Get(0), c.Args().Get(1), force)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
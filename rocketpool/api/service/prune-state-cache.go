@@ -0,0 +1,43 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Removes the least recently used network states from the state cache until it fits within its configured size, or removes all of them
+func pruneStateCache(c *cli.Context, all bool) (*api.PruneStateCacheResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PruneStateCacheResponse{}
+	response.CacheEnabled = (cfg.Smartnode.UseStateCache.Value == true)
+
+	// Get the size to prune down to
+	var maxSize uint64
+	if !all {
+		maxSize = cfg.Smartnode.StateCacheSize.Value.(uint64) * 1024 * 1024
+	}
+
+	// Prune the cache
+	cache := state.NewStateCache(cfg.Smartnode.GetStateCachePath(true), maxSize, nil)
+	result, err := cache.Prune(maxSize)
+	if err != nil {
+		return nil, err
+	}
+	response.RemovedFiles = result.RemovedFiles
+	response.FreedBytes = result.FreedBytes
+	response.RemainingBytes = result.RemainingBytes
+
+	// Return response
+	return &response, nil
+
+}
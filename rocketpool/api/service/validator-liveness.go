@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const validatorLivenessBatchSize int = 100
+
+// Checks whether any of the given validators were seen attesting in either of the last two completed epochs
+func getValidatorLiveness(c *cli.Context, pubkeys []types.ValidatorPubkey) (*api.ValidatorLivenessResponse, error) {
+
+	// Get services
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ValidatorLivenessResponse{}
+	response.LivePubkeys, response.Epochs, err = getLivePubkeys(bc, pubkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the validators that were seen attesting in either of the last two completed epochs, and the epochs that were checked.
+// Attestations for an epoch can be included until the end of the next one, so the epoch in progress isn't checked.
+func getLivePubkeys(bc beacon.Client, pubkeys []types.ValidatorPubkey) ([]types.ValidatorPubkey, []uint64, error) {
+
+	// Get the indices of the validators that are on the Beacon Chain
+	livePubkeys := []types.ValidatorPubkey{}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	pubkeysByIndex := map[string]types.ValidatorPubkey{}
+	indices := []string{}
+	for pubkey, status := range statuses {
+		if status.Exists {
+			pubkeysByIndex[status.Index] = pubkey
+			indices = append(indices, status.Index)
+		}
+	}
+
+	// Get the last two completed epochs
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	epochs := []uint64{}
+	for _, offset := range []uint64{2, 1} {
+		if head.Epoch >= offset {
+			epochs = append(epochs, head.Epoch-offset)
+		}
+	}
+	if len(indices) == 0 {
+		return livePubkeys, epochs, nil
+	}
+
+	// Check them
+	live := map[string]bool{}
+	for _, epoch := range epochs {
+		for i := 0; i < len(indices); i += validatorLivenessBatchSize {
+			end := i + validatorLivenessBatchSize
+			if end > len(indices) {
+				end = len(indices)
+			}
+			liveness, err := bc.GetValidatorLiveness(indices[i:end], epoch)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error getting validator liveness for epoch %d: %w", epoch, err)
+			}
+			for index, isLive := range liveness {
+				if isLive {
+					live[index] = true
+				}
+			}
+		}
+	}
+	for index := range live {
+		livePubkeys = append(livePubkeys, pubkeysByIndex[index])
+	}
+	return livePubkeys, epochs, nil
+
+}
@@ -28,7 +28,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					response, err := getStatus(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -51,7 +52,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setPassword(c, password))
+					response, err := setPassword(c, password)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -76,7 +78,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(initWallet(c))
+					response, err := initWallet(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -114,7 +117,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(recoverWallet(c, mnemonic))
+					response, err := recoverWallet(c, mnemonic)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -147,7 +151,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(searchAndRecoverWallet(c, mnemonic, address))
+					response, err := searchAndRecoverWallet(c, mnemonic, address)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -166,7 +171,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(rebuildWallet(c))
+					response, err := rebuildWallet(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -204,7 +210,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(testRecoverWallet(c, mnemonic))
+					response, err := testRecoverWallet(c, mnemonic)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -237,7 +244,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(testSearchAndRecoverWallet(c, mnemonic, address))
+					response, err := testSearchAndRecoverWallet(c, mnemonic, address)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -256,7 +264,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(exportWallet(c))
+					response, err := exportWallet(c)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -274,7 +283,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setEnsName(c, c.Args().Get(0), true))
+					response, err := setEnsName(c, c.Args().Get(0), true)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -291,7 +301,8 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setEnsName(c, c.Args().Get(0), false))
+					response, err := setEnsName(c, c.Args().Get(0), false)
+					api.PrintResponse(c, response, err)
 					return nil
 
 				},
@@ -41,6 +41,17 @@ func exportWallet(c *cli.Context) (*api.ExportWalletResponse, error) {
 	}
 	response.Wallet = wallet
 
+	// The node account's key never leaves an external signer, so only its address can be exported
+	if w.HasNodeSigner() {
+		account, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		response.HasNodeSigner = true
+		response.NodeSignerAddress = account.Address
+		return &response, nil
+	}
+
 	// Get account private key
 	privateKey, err := w.GetNodePrivateKeyBytes()
 	if err != nil {
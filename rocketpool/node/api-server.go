@@ -0,0 +1,90 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/api"
+	"github.com/rocket-pool/smartnode/shared/services"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+var apiServerRestartDelay, _ = time.ParseDuration("15s")
+
+// Start the API server alongside the daemon.
+// It runs in its own process so it gets its own service singletons, which it resets whenever the settings file changes;
+// if it ever stops, it's restarted after a short delay.
+// With the environment password backend, the daemon's environment variable or file descriptor can only be read once, so the
+// daemon reads the password itself and hands it to each server process over a pipe.
+func startApiServer(c *cli.Context, logger *log.ColorLogger) error {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("error getting the daemon executable path for the API server: %w", err)
+	}
+
+	password := ""
+	if cfg.Smartnode.PasswordBackend.Value.(cfgtypes.PasswordBackend) == cfgtypes.PasswordBackend_Env {
+		pm, err := services.GetPasswordManager(c)
+		if err != nil {
+			return err
+		}
+		if pm.IsPasswordSet() {
+			password, err = pm.GetPassword()
+			if err != nil {
+				return fmt.Errorf("error reading the node password for the API server: %w", err)
+			}
+		}
+	}
+
+	go func() {
+		for {
+			err := runApiServer(executable, c.GlobalString("settings"), password)
+			if err != nil {
+				logger.Printlnf("API server stopped (%s), restarting in %s.", err.Error(), apiServerRestartDelay)
+			} else {
+				logger.Printlnf("API server stopped, restarting in %s.", apiServerRestartDelay)
+			}
+			time.Sleep(apiServerRestartDelay)
+		}
+	}()
+	return nil
+}
+
+// Run an API server process until it stops, passing it the node password if one is provided
+func runApiServer(executable string, settingsPath string, password string) error {
+	args := []string{"--settings", settingsPath, api.ApiServerCommand}
+	cmd := exec.Command(executable, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if password != "" {
+		reader, writer, err := os.Pipe()
+		if err != nil {
+			return fmt.Errorf("error creating the password pipe: %w", err)
+		}
+		defer reader.Close()
+
+		// The password is far smaller than the pipe buffer, so it can be written before the server starts reading
+		_, err = writer.WriteString(password)
+		writer.Close()
+		if err != nil {
+			return fmt.Errorf("error writing the password pipe: %w", err)
+		}
+
+		// The first extra file is fd 3 in the child
+		cmd.ExtraFiles = []*os.File{reader}
+		cmd.Args = append(cmd.Args, "--password-fd", "3")
+	}
+
+	return cmd.Run()
+}
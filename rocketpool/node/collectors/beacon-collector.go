@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -39,10 +40,13 @@ type BeaconCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// The logger
+	log log.ColorLogger
 }
 
 // Create a new BeaconCollector instance
-func NewBeaconCollector(rp *rocketpool.RocketPool, bc beacon.Client, ec rocketpool.ExecutionClient, nodeAddress common.Address, stateLocker *StateLocker) *BeaconCollector {
+func NewBeaconCollector(logger log.ColorLogger, rp *rocketpool.RocketPool, bc beacon.Client, ec rocketpool.ExecutionClient, nodeAddress common.Address, stateLocker *StateLocker) *BeaconCollector {
 	subsystem := "beacon"
 	return &BeaconCollector{
 		activeSyncCommittee: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active_sync_committee"),
@@ -63,6 +67,7 @@ func NewBeaconCollector(rp *rocketpool.RocketPool, bc beacon.Client, ec rocketpo
 		nodeAddress: nodeAddress,
 		stateLocker: stateLocker,
 		logPrefix:   "Beacon Collector",
+		log:         logger,
 	}
 }
 
@@ -183,5 +188,5 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 
 // Log error messages
 func (collector *BeaconCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.log.Errorf("[%s] %s", collector.logPrefix, err.Error())
 }
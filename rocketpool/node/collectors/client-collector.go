@@ -0,0 +1,139 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services"
+)
+
+// Represents the collector for the health of the Execution and Beacon clients
+type ClientCollector struct {
+	// Whether the client is healthy enough to be used
+	healthy *prometheus.Desc
+
+	// Whether requests are currently being sent to the client
+	active *prometheus.Desc
+
+	// Whether the client reports that it's synced
+	synced *prometheus.Desc
+
+	// The number of blocks (or slots) the client is behind the best client
+	headLag *prometheus.Desc
+
+	// The moving average of the client's response time
+	latency *prometheus.Desc
+
+	// The moving average of the fraction of requests that couldn't reach the client
+	errorRate *prometheus.Desc
+
+	// The client's overall health score
+	score *prometheus.Desc
+
+	// The total number of requests sent to the client
+	requests *prometheus.Desc
+
+	// The total number of requests that couldn't reach the client
+	failures *prometheus.Desc
+
+	// The EC manager
+	ec *services.ExecutionClientManager
+
+	// The BC manager
+	bc *services.BeaconClientManager
+}
+
+// Create a new ClientCollector instance
+func NewClientCollector(ec *services.ExecutionClientManager, bc *services.BeaconClientManager) *ClientCollector {
+	subsystem := "client"
+	labels := []string{"type", "endpoint"}
+	return &ClientCollector{
+		healthy: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "healthy"),
+			"Whether the client is healthy enough to be used",
+			labels, nil,
+		),
+		active: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active"),
+			"Whether requests are currently being sent to the client",
+			labels, nil,
+		),
+		synced: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "synced"),
+			"Whether the client reports that it's synced",
+			labels, nil,
+		),
+		headLag: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "head_lag"),
+			"The number of blocks (or slots) the client is behind the best client",
+			labels, nil,
+		),
+		latency: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "latency_seconds"),
+			"The moving average of the client's response time to health checks",
+			labels, nil,
+		),
+		errorRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "error_rate"),
+			"The moving average of the fraction of requests that couldn't reach the client",
+			labels, nil,
+		),
+		score: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "health_score"),
+			"The client's health score, from 0 (unusable) to 1",
+			labels, nil,
+		),
+		requests: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "requests_total"),
+			"The total number of requests sent to the client",
+			labels, nil,
+		),
+		failures: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failures_total"),
+			"The total number of requests that couldn't reach the client",
+			labels, nil,
+		),
+		ec: ec,
+		bc: bc,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ClientCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.healthy
+	channel <- collector.active
+	channel <- collector.synced
+	channel <- collector.headLag
+	channel <- collector.latency
+	channel <- collector.errorRate
+	channel <- collector.score
+	channel <- collector.requests
+	channel <- collector.failures
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ClientCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.collectStats(channel, "execution", collector.ec.GetEndpointStats())
+	collector.collectStats(channel, "consensus", collector.bc.GetEndpointStats())
+}
+
+// Report the stats for each of a manager's clients
+func (collector *ClientCollector) collectStats(channel chan<- prometheus.Metric, clientType string, stats []services.ClientEndpointStats) {
+	for _, endpoint := range stats {
+		channel <- prometheus.MustNewConstMetric(
+			collector.healthy, prometheus.GaugeValue, boolToFloat(endpoint.Healthy), clientType, endpoint.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.active, prometheus.GaugeValue, boolToFloat(endpoint.Active), clientType, endpoint.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.synced, prometheus.GaugeValue, boolToFloat(endpoint.Synced), clientType, endpoint.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.headLag, prometheus.GaugeValue, float64(endpoint.HeadLag), clientType, endpoint.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.latency, prometheus.GaugeValue, endpoint.Latency.Seconds(), clientType, endpoint.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.errorRate, prometheus.GaugeValue, endpoint.ErrorRate, clientType, endpoint.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.score, prometheus.GaugeValue, endpoint.Score, clientType, endpoint.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.requests, prometheus.CounterValue, float64(endpoint.Requests), clientType, endpoint.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.failures, prometheus.CounterValue, float64(endpoint.Failures), clientType, endpoint.Name)
+	}
+}
+
+// Convert a bool to a gauge value
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
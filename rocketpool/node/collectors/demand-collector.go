@@ -1,11 +1,10 @@
 package collectors
 
 import (
-	"fmt"
-
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 const namespace = "rocketpool"
@@ -35,10 +34,13 @@ type DemandCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// The logger
+	log log.ColorLogger
 }
 
 // Create a new DemandCollector instance
-func NewDemandCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker) *DemandCollector {
+func NewDemandCollector(logger log.ColorLogger, rp *rocketpool.RocketPool, stateLocker *StateLocker) *DemandCollector {
 	subsystem := "demand"
 	return &DemandCollector{
 		depositPoolBalance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "deposit_pool_balance"),
@@ -64,6 +66,7 @@ func NewDemandCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker) *De
 		rp:          rp,
 		stateLocker: stateLocker,
 		logPrefix:   "Demand Collector",
+		log:         logger,
 	}
 }
 
@@ -104,5 +107,5 @@ func (collector *DemandCollector) Collect(channel chan<- prometheus.Metric) {
 
 // Log error messages
 func (collector *DemandCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.log.Errorf("[%s] %s", collector.logPrefix, err.Error())
 }
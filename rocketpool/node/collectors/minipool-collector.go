@@ -0,0 +1,195 @@
+package collectors
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// The states of a minipool's bond reduction
+const (
+	bondReductionState_None      string = "none"
+	bondReductionState_Waiting   string = "waiting"
+	bondReductionState_Ready     string = "ready"
+	bondReductionState_Expired   string = "expired"
+	bondReductionState_Cancelled string = "cancelled"
+)
+
+// Represents the collector for the metrics of each of the node's minipools
+type MinipoolCollector struct {
+	// The status of the minipool and its validator
+	status *prometheus.Desc
+
+	// The amount of ETH the node bonded to the minipool
+	bond *prometheus.Desc
+
+	// The commission the node gets from the minipool's rewards
+	nodeFee *prometheus.Desc
+
+	// The balance of the minipool's validator on the Beacon Chain
+	beaconBalance *prometheus.Desc
+
+	// The ETH balance of the minipool contract on the Execution layer
+	balance *prometheus.Desc
+
+	// The node's share of the minipool contract's distributable balance
+	pendingRewards *prometheus.Desc
+
+	// The amount of ETH waiting to be refunded to the node
+	refundBalance *prometheus.Desc
+
+	// The version of the minipool's delegate
+	delegateVersion *prometheus.Desc
+
+	// The state of the minipool's bond reduction
+	bondReductionState *prometheus.Desc
+
+	// The bond the minipool will have once its pending bond reduction completes
+	bondReductionTarget *prometheus.Desc
+
+	// The node's address
+	nodeAddress common.Address
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+}
+
+// Create a new MinipoolCollector instance
+func NewMinipoolCollector(nodeAddress common.Address, stateLocker *StateLocker) *MinipoolCollector {
+	subsystem := "minipool"
+	labels := []string{"address", "validator_index"}
+	return &MinipoolCollector{
+		status: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "status"),
+			"The status of the minipool and its validator",
+			append(labels, "status", "validator_status"), nil,
+		),
+		bond: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "bond"),
+			"The amount of ETH the node bonded to the minipool",
+			labels, nil,
+		),
+		nodeFee: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_fee"),
+			"The commission the node gets from the minipool's rewards",
+			labels, nil,
+		),
+		beaconBalance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "beacon_balance"),
+			"The balance of the minipool's validator on the Beacon Chain",
+			labels, nil,
+		),
+		balance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "balance"),
+			"The ETH balance of the minipool contract on the Execution layer",
+			labels, nil,
+		),
+		pendingRewards: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "pending_rewards"),
+			"The node's share of the minipool contract's distributable balance",
+			labels, nil,
+		),
+		refundBalance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "refund_balance"),
+			"The amount of ETH waiting to be refunded to the node",
+			labels, nil,
+		),
+		delegateVersion: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "delegate_version"),
+			"The version of the minipool's delegate",
+			labels, nil,
+		),
+		bondReductionState: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "bond_reduction_state"),
+			"The state of the minipool's bond reduction",
+			append(labels, "state"), nil,
+		),
+		bondReductionTarget: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "bond_reduction_target"),
+			"The bond the minipool will have once its pending bond reduction completes",
+			labels, nil,
+		),
+		nodeAddress: nodeAddress,
+		stateLocker: stateLocker,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *MinipoolCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.status
+	channel <- collector.bond
+	channel <- collector.nodeFee
+	channel <- collector.beaconBalance
+	channel <- collector.balance
+	channel <- collector.pendingRewards
+	channel <- collector.refundBalance
+	channel <- collector.delegateVersion
+	channel <- collector.bondReductionState
+	channel <- collector.bondReductionTarget
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *MinipoolCollector) Collect(channel chan<- prometheus.Metric) {
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		return
+	}
+
+	// Get the time of the state's slot for the bond reduction windows
+	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
+	secondsSinceGenesis := time.Duration(state.BeaconSlotNumber*state.BeaconConfig.SecondsPerSlot) * time.Second
+	blockTime := genesisTime.Add(secondsSinceGenesis)
+
+	for _, mpd := range state.MinipoolDetailsByNode[collector.nodeAddress] {
+		validatorIndex := ""
+		validatorStatus := "unknown"
+		beaconBalance := float64(0)
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if exists && validator.Exists {
+			validatorIndex = validator.Index
+			validatorStatus = string(validator.Status)
+			beaconBalance = eth.WeiToEth(eth.GweiToWei(float64(validator.Balance)))
+		}
+		address := mpd.MinipoolAddress.Hex()
+
+		reductionState, reductionTarget := getBondReductionState(state, mpd.ReduceBondTime, mpd.ReduceBondCancelled, mpd.ReduceBondValue, blockTime)
+
+		channel <- prometheus.MustNewConstMetric(
+			collector.status, prometheus.GaugeValue, 1, address, validatorIndex, mpd.Status.String(), validatorStatus)
+		channel <- prometheus.MustNewConstMetric(
+			collector.bond, prometheus.GaugeValue, eth.WeiToEth(mpd.NodeDepositBalance), address, validatorIndex)
+		channel <- prometheus.MustNewConstMetric(
+			collector.nodeFee, prometheus.GaugeValue, eth.WeiToEth(mpd.NodeFee), address, validatorIndex)
+		channel <- prometheus.MustNewConstMetric(
+			collector.beaconBalance, prometheus.GaugeValue, beaconBalance, address, validatorIndex)
+		channel <- prometheus.MustNewConstMetric(
+			collector.balance, prometheus.GaugeValue, eth.WeiToEth(mpd.Balance), address, validatorIndex)
+		channel <- prometheus.MustNewConstMetric(
+			collector.pendingRewards, prometheus.GaugeValue, eth.WeiToEth(mpd.NodeShareOfBalance), address, validatorIndex)
+		channel <- prometheus.MustNewConstMetric(
+			collector.refundBalance, prometheus.GaugeValue, eth.WeiToEth(mpd.NodeRefundBalance), address, validatorIndex)
+		channel <- prometheus.MustNewConstMetric(
+			collector.delegateVersion, prometheus.GaugeValue, float64(mpd.Version), address, validatorIndex)
+		channel <- prometheus.MustNewConstMetric(
+			collector.bondReductionState, prometheus.GaugeValue, 1, address, validatorIndex, reductionState)
+		channel <- prometheus.MustNewConstMetric(
+			collector.bondReductionTarget, prometheus.GaugeValue, reductionTarget, address, validatorIndex)
+	}
+}
+
+// Get the state of a minipool's bond reduction and the bond it will be reduced to, if one is in progress
+func getBondReductionState(state *state.NetworkState, reduceBondTime *big.Int, cancelled bool, reduceBondValue *big.Int, blockTime time.Time) (string, float64) {
+	if reduceBondTime == nil || reduceBondTime.Sign() == 0 {
+		return bondReductionState_None, 0
+	}
+	if cancelled {
+		return bondReductionState_Cancelled, 0
+	}
+
+	reductionWindowStart := state.NetworkDetails.BondReductionWindowStart
+	reductionWindowEnd := reductionWindowStart + state.NetworkDetails.BondReductionWindowLength
+	timeSinceReductionStart := blockTime.Sub(time.Unix(reduceBondTime.Int64(), 0))
+	switch {
+	case timeSinceReductionStart > reductionWindowEnd:
+		return bondReductionState_Expired, 0
+	case timeSinceReductionStart >= reductionWindowStart:
+		return bondReductionState_Ready, eth.WeiToEth(reduceBondValue)
+	default:
+		return bondReductionState_Waiting, eth.WeiToEth(reduceBondValue)
+	}
+}
@@ -3,7 +3,6 @@ package collectors
 import (
 	"context"
 	"fmt"
-	"log"
 	"math"
 	"math/big"
 	"time"
@@ -17,6 +16,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -111,15 +111,18 @@ type NodeCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// The logger
+	log log.ColorLogger
 }
 
 // Create a new NodeCollector instance
-func NewNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *NodeCollector {
+func NewNodeCollector(logger log.ColorLogger, rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *NodeCollector {
 
 	// Get the event log interval
 	eventLogInterval, err := cfg.GetEventLogInterval()
 	if err != nil {
-		log.Printf("Error getting event log interval: %s\n", err.Error())
+		logger.Errorf("Error getting event log interval: %s", err.Error())
 		return nil
 	}
 
@@ -209,6 +212,7 @@ func NewNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress c
 		cfg:              cfg,
 		stateLocker:      stateLocker,
 		logPrefix:        "Node Collector",
+		log:              logger,
 	}
 }
 
@@ -549,5 +553,5 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 
 // Log error messages
 func (collector *NodeCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.log.Errorf("[%s] %s", collector.logPrefix, err.Error())
 }
@@ -1,10 +1,9 @@
 package collectors
 
 import (
-	"fmt"
-
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 // Represents the collector for the ODAO metrics
@@ -29,10 +28,13 @@ type OdaoCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// The logger
+	log log.ColorLogger
 }
 
 // Create a new DemandCollector instance
-func NewOdaoCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker) *OdaoCollector {
+func NewOdaoCollector(logger log.ColorLogger, rp *rocketpool.RocketPool, stateLocker *StateLocker) *OdaoCollector {
 	subsystem := "odao"
 	return &OdaoCollector{
 		currentEth1Block: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "current_eth1_block"),
@@ -54,6 +56,7 @@ func NewOdaoCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker) *Odao
 		rp:          rp,
 		stateLocker: stateLocker,
 		logPrefix:   "ODAO Collector",
+		log:         logger,
 	}
 }
 
@@ -90,5 +93,5 @@ func (collector *OdaoCollector) Collect(channel chan<- prometheus.Metric) {
 
 // Log error messages
 func (collector *OdaoCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.log.Errorf("[%s] %s", collector.logPrefix, err.Error())
 }
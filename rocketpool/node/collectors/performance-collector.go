@@ -1,11 +1,10 @@
 package collectors
 
 import (
-	"fmt"
-
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 // Represents the collector for the Performance metrics
@@ -36,10 +35,13 @@ type PerformanceCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// The logger
+	log log.ColorLogger
 }
 
 // Create a new PerformanceCollector instance
-func NewPerformanceCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker) *PerformanceCollector {
+func NewPerformanceCollector(logger log.ColorLogger, rp *rocketpool.RocketPool, stateLocker *StateLocker) *PerformanceCollector {
 	subsystem := "performance"
 	return &PerformanceCollector{
 		ethUtilizationRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "eth_utilization_rate"),
@@ -69,6 +71,7 @@ func NewPerformanceCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker
 		rp:          rp,
 		stateLocker: stateLocker,
 		logPrefix:   "Performance Collector",
+		log:         logger,
 	}
 }
 
@@ -113,5 +116,5 @@ func (collector *PerformanceCollector) Collect(channel chan<- prometheus.Metric)
 
 // Log error messages
 func (collector *PerformanceCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.log.Errorf("[%s] %s", collector.logPrefix, err.Error())
 }
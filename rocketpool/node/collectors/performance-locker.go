@@ -0,0 +1,32 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+type PerformanceLocker struct {
+	snapshot *api.ValidatorPerformanceSnapshot
+
+	// Internal fields
+	lock *sync.Mutex
+}
+
+func NewPerformanceLocker() *PerformanceLocker {
+	return &PerformanceLocker{
+		lock: &sync.Mutex{},
+	}
+}
+
+func (l *PerformanceLocker) UpdateSnapshot(snapshot *api.ValidatorPerformanceSnapshot) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.snapshot = snapshot
+}
+
+func (l *PerformanceLocker) GetSnapshot() *api.ValidatorPerformanceSnapshot {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.snapshot
+}
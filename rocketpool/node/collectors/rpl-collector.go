@@ -1,12 +1,11 @@
 package collectors
 
 import (
-	"fmt"
-
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 // Represents the collector for the RPL metrics
@@ -34,10 +33,13 @@ type RplCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// The logger
+	log log.ColorLogger
 }
 
 // Create a new RplCollector instance
-func NewRplCollector(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *RplCollector {
+func NewRplCollector(logger log.ColorLogger, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *RplCollector {
 	subsystem := "rpl"
 	return &RplCollector{
 		rplPrice: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rpl_price"),
@@ -60,6 +62,7 @@ func NewRplCollector(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, st
 		cfg:         cfg,
 		stateLocker: stateLocker,
 		logPrefix:   "RPL Collector",
+		log:         logger,
 	}
 }
 
@@ -101,5 +104,5 @@ func (collector *RplCollector) Collect(channel chan<- prometheus.Metric) {
 
 // Log error messages
 func (collector *RplCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.log.Errorf("[%s] %s", collector.logPrefix, err.Error())
 }
@@ -1,12 +1,11 @@
 package collectors
 
 import (
-	"fmt"
-
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 // Represents the collector for Smoothing Pool metrics
@@ -25,10 +24,13 @@ type SmoothingPoolCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// The logger
+	log log.ColorLogger
 }
 
 // Create a new SmoothingPoolCollector instance
-func NewSmoothingPoolCollector(rp *rocketpool.RocketPool, ec *services.ExecutionClientManager, stateLocker *StateLocker) *SmoothingPoolCollector {
+func NewSmoothingPoolCollector(logger log.ColorLogger, rp *rocketpool.RocketPool, ec *services.ExecutionClientManager, stateLocker *StateLocker) *SmoothingPoolCollector {
 	subsystem := "smoothing_pool"
 	return &SmoothingPoolCollector{
 		ethBalanceOnSmoothingPool: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "eth_balance"),
@@ -39,6 +41,7 @@ func NewSmoothingPoolCollector(rp *rocketpool.RocketPool, ec *services.Execution
 		ec:          ec,
 		stateLocker: stateLocker,
 		logPrefix:   "SP Collector",
+		log:         logger,
 	}
 }
 
@@ -63,5 +66,5 @@ func (collector *SmoothingPoolCollector) Collect(channel chan<- prometheus.Metri
 
 // Log error messages
 func (collector *SmoothingPoolCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.log.Errorf("[%s] %s", collector.logPrefix, err.Error())
 }
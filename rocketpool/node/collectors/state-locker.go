@@ -30,6 +30,12 @@ func (l *StateLocker) UpdateState(state *state.NetworkState, totalEffectiveStake
 	}
 }
 
+func (l *StateLocker) UpdateTotalEffectiveRPLStake(totalEffectiveStake *big.Int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.totalEffectiveStake = totalEffectiveStake
+}
+
 func (l *StateLocker) GetState() *state.NetworkState {
 	l.lock.Lock()
 	defer l.lock.Unlock()
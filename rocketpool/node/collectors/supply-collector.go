@@ -7,6 +7,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -35,10 +36,13 @@ type SupplyCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// The logger
+	log log.ColorLogger
 }
 
 // Create a new PerformanceCollector instance
-func NewSupplyCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker) *SupplyCollector {
+func NewSupplyCollector(logger log.ColorLogger, rp *rocketpool.RocketPool, stateLocker *StateLocker) *SupplyCollector {
 	subsystem := "supply"
 	return &SupplyCollector{
 		nodeCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_count"),
@@ -64,6 +68,7 @@ func NewSupplyCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker) *Su
 		rp:          rp,
 		stateLocker: stateLocker,
 		logPrefix:   "Supply Collector",
+		log:         logger,
 	}
 }
 
@@ -158,5 +163,5 @@ func (collector *SupplyCollector) Collect(channel chan<- prometheus.Metric) {
 
 // Log error messages
 func (collector *SupplyCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.log.Errorf("[%s] %s", collector.logPrefix, err.Error())
 }
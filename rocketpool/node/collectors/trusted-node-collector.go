@@ -2,7 +2,6 @@ package collectors
 
 import (
 	"fmt"
-	"log"
 	"math/big"
 	"strconv"
 	"sync"
@@ -19,6 +18,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -66,15 +66,18 @@ type TrustedNodeCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// The logger
+	log log.ColorLogger
 }
 
 // Create a new NodeCollector instance
-func NewTrustedNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *TrustedNodeCollector {
+func NewTrustedNodeCollector(logger log.ColorLogger, rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *TrustedNodeCollector {
 
 	// Get the event log interval
 	eventLogInterval, err := cfg.GetEventLogInterval()
 	if err != nil {
-		log.Printf("Error getting event log interval: %s\n", err.Error())
+		logger.Errorf("Error getting event log interval: %s", err.Error())
 		return nil
 	}
 
@@ -111,6 +114,7 @@ func NewTrustedNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAd
 		eventLogInterval: big.NewInt(int64(eventLogInterval)),
 		stateLocker:      stateLocker,
 		logPrefix:        "ODAO Stats Collector",
+		log:              logger,
 	}
 }
 
@@ -336,5 +340,5 @@ func (collector *TrustedNodeCollector) Collect(channel chan<- prometheus.Metric)
 
 // Log error messages
 func (collector *TrustedNodeCollector) logError(err error) {
-	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.log.Errorf("[%s] %s", collector.logPrefix, err.Error())
 }
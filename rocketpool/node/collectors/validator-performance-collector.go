@@ -0,0 +1,178 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the duty performance of the node's validators during the current rewards interval
+type ValidatorPerformanceCollector struct {
+	// The number of attestation duties that have been checked
+	attestationDuties *prometheus.Desc
+
+	// The number of attestations that weren't included in time
+	missedAttestations *prometheus.Desc
+
+	// The average number of slots it took for attestations to be included
+	inclusionDelay *prometheus.Desc
+
+	// The fraction of attestation duties that were included in time
+	participationRate *prometheus.Desc
+
+	// The number of blocks the validator was scheduled to propose
+	proposalDuties *prometheus.Desc
+
+	// The number of scheduled blocks that weren't proposed
+	missedProposals *prometheus.Desc
+
+	// The number of sync committee signatures the validator was expected to contribute
+	syncDuties *prometheus.Desc
+
+	// The number of sync committee signatures that were missing from blocks
+	missedSyncDuties *prometheus.Desc
+
+	// The fraction of attestation duties that were included in time, across all of the node's validators
+	nodeParticipationRate *prometheus.Desc
+
+	// The first epoch of the current rewards interval
+	intervalStartEpoch *prometheus.Desc
+
+	// The first epoch that was tracked
+	startEpoch *prometheus.Desc
+
+	// The latest epoch that was tracked
+	lastEpoch *prometheus.Desc
+
+	// The thread-safe locker for the tracked performance
+	performanceLocker *PerformanceLocker
+}
+
+// Create a new ValidatorPerformanceCollector instance
+func NewValidatorPerformanceCollector(performanceLocker *PerformanceLocker) *ValidatorPerformanceCollector {
+	subsystem := "validator"
+	labels := []string{"address", "validator_index"}
+	return &ValidatorPerformanceCollector{
+		attestationDuties: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "attestation_duties"),
+			"The number of attestation duties that have been checked during the current interval",
+			labels, nil,
+		),
+		missedAttestations: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missed_attestations"),
+			"The number of attestations that weren't included in time during the current interval",
+			labels, nil,
+		),
+		inclusionDelay: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "inclusion_delay"),
+			"The average number of slots it took for attestations to be included during the current interval",
+			labels, nil,
+		),
+		participationRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "participation_rate"),
+			"The fraction of attestation duties that were included in time during the current interval",
+			labels, nil,
+		),
+		proposalDuties: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "proposal_duties"),
+			"The number of blocks the validator was scheduled to propose during the current interval",
+			labels, nil,
+		),
+		missedProposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missed_proposals"),
+			"The number of scheduled blocks that weren't proposed during the current interval",
+			labels, nil,
+		),
+		syncDuties: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "sync_duties"),
+			"The number of sync committee signatures the validator was expected to contribute during the current interval",
+			labels, nil,
+		),
+		missedSyncDuties: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missed_sync_duties"),
+			"The number of sync committee signatures that were missing from blocks during the current interval",
+			labels, nil,
+		),
+		nodeParticipationRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_participation_rate"),
+			"The fraction of attestation duties that were included in time during the current interval, across all of the node's validators",
+			nil, nil,
+		),
+		intervalStartEpoch: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "performance_interval_start_epoch"),
+			"The first epoch of the current rewards interval",
+			nil, nil,
+		),
+		startEpoch: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "performance_start_epoch"),
+			"The first epoch that validator performance was tracked for",
+			nil, nil,
+		),
+		lastEpoch: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "performance_last_epoch"),
+			"The latest epoch that validator performance was tracked for",
+			nil, nil,
+		),
+		performanceLocker: performanceLocker,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ValidatorPerformanceCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.attestationDuties
+	channel <- collector.missedAttestations
+	channel <- collector.inclusionDelay
+	channel <- collector.participationRate
+	channel <- collector.proposalDuties
+	channel <- collector.missedProposals
+	channel <- collector.syncDuties
+	channel <- collector.missedSyncDuties
+	channel <- collector.nodeParticipationRate
+	channel <- collector.intervalStartEpoch
+	channel <- collector.startEpoch
+	channel <- collector.lastEpoch
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ValidatorPerformanceCollector) Collect(channel chan<- prometheus.Metric) {
+	// Get the latest performance
+	snapshot := collector.performanceLocker.GetSnapshot()
+	if snapshot == nil {
+		return
+	}
+
+	totalDuties := uint64(0)
+	totalMissed := uint64(0)
+	for _, performance := range snapshot.Validators {
+		address := performance.MinipoolAddress.Hex()
+		index := performance.ValidatorIndex
+		included := performance.AttestationDuties - performance.MissedAttestations
+		totalDuties += performance.AttestationDuties
+		totalMissed += performance.MissedAttestations
+
+		inclusionDelay := float64(0)
+		if included > 0 {
+			inclusionDelay = float64(performance.TotalInclusionDelay) / float64(included)
+		}
+		participationRate := float64(1)
+		if performance.AttestationDuties > 0 {
+			participationRate = float64(included) / float64(performance.AttestationDuties)
+		}
+
+		channel <- prometheus.MustNewConstMetric(
+			collector.attestationDuties, prometheus.GaugeValue, float64(performance.AttestationDuties), address, index)
+		channel <- prometheus.MustNewConstMetric(
+			collector.missedAttestations, prometheus.GaugeValue, float64(performance.MissedAttestations), address, index)
+		channel <- prometheus.MustNewConstMetric(
+			collector.inclusionDelay, prometheus.GaugeValue, inclusionDelay, address, index)
+		channel <- prometheus.MustNewConstMetric(
+			collector.participationRate, prometheus.GaugeValue, participationRate, address, index)
+		channel <- prometheus.MustNewConstMetric(
+			collector.proposalDuties, prometheus.GaugeValue, float64(performance.ProposalDuties), address, index)
+		channel <- prometheus.MustNewConstMetric(
+			collector.missedProposals, prometheus.GaugeValue, float64(performance.MissedProposals), address, index)
+		channel <- prometheus.MustNewConstMetric(
+			collector.syncDuties, prometheus.GaugeValue, float64(performance.SyncDuties), address, index)
+		channel <- prometheus.MustNewConstMetric(
+			collector.missedSyncDuties, prometheus.GaugeValue, float64(performance.MissedSyncDuties), address, index)
+	}
+
+	nodeParticipationRate := float64(1)
+	if totalDuties > 0 {
+		nodeParticipationRate = float64(totalDuties-totalMissed) / float64(totalDuties)
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeParticipationRate, prometheus.GaugeValue, nodeParticipationRate)
+	channel <- prometheus.MustNewConstMetric(
+		collector.intervalStartEpoch, prometheus.GaugeValue, float64(snapshot.IntervalStartEpoch))
+	channel <- prometheus.MustNewConstMetric(
+		collector.startEpoch, prometheus.GaugeValue, float64(snapshot.StartEpoch))
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastEpoch, prometheus.GaugeValue, float64(snapshot.LastEpoch))
+}
@@ -18,7 +18,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -30,6 +32,8 @@ type distributeMinipools struct {
 	log                 log.ColorLogger
 	cfg                 *config.RocketPoolConfig
 	w                   *wallet.Wallet
+	journal             *transactions.Journal
+	notifier            *notifications.Notifier
 	rp                  *rocketpool.RocketPool
 	bc                  beacon.Client
 	d                   *client.Client
@@ -43,14 +47,18 @@ type distributeMinipools struct {
 }
 
 // Create distribute minipools task
-func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distributeMinipools, error) {
+func newDistributeMinipools(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*distributeMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
+	notifier, err := services.GetNotifier(c)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +85,7 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 	} else {
 		// Safety clamp
 		if distributeThreshold >= 8 {
-			logger.Printlnf("WARNING: Auto-distribute threshold is more than 8 ETH (%.6f ETH), reducing to 7.5 ETH for safety", distributeThreshold)
+			logger.Warnf("Auto-distribute threshold is more than 8 ETH (%.6f ETH), reducing to 7.5 ETH for safety", distributeThreshold)
 			distributeThreshold = 7.5
 		} else if distributeThreshold == 0 {
 			logger.Println("Auto-distribute threshold is 0, disabling auto-distribute.")
@@ -98,7 +106,7 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warnf("priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
@@ -110,6 +118,8 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 		log:                 logger,
 		cfg:                 cfg,
 		w:                   w,
+		journal:             journal,
+		notifier:            notifier,
 		rp:                  rp,
 		bc:                  bc,
 		d:                   d,
@@ -163,7 +173,7 @@ func (t *distributeMinipools) run(state *state.NetworkState) error {
 	for _, mpd := range minipools {
 		success, err := t.distributeMinipool(mpd, opts)
 		if err != nil {
-			t.log.Println(fmt.Errorf("Could not distribute balance of minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
+			t.log.With(log.Minipool(mpd.MinipoolAddress)).Error(fmt.Errorf("Could not distribute balance of minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
 			return err
 		}
 		if success {
@@ -208,7 +218,8 @@ func (t *distributeMinipools) getDistributableMinipools(nodeAddress common.Addre
 func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDetails, callOpts *bind.CallOpts) (bool, error) {
 
 	// Log
-	t.log.Printlnf("Distributing minipool %s (total balance of %.6f ETH)...", mpd.MinipoolAddress.Hex(), eth.WeiToEth(mpd.Balance))
+	logger := t.log.With(log.Minipool(mpd.MinipoolAddress))
+	logger.Printlnf("Distributing minipool %s (total balance of %.6f ETH)...", mpd.MinipoolAddress.Hex(), eth.WeiToEth(mpd.Balance))
 
 	mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, callOpts)
 	if err != nil {
@@ -247,7 +258,7 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	}
 
 	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &logger, maxFee, t.gasLimit) {
 		return false, nil
 	}
 
@@ -262,13 +273,21 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("distribute minipool %s", mpd.MinipoolAddress.Hex()), t.w, &logger)
 	if err != nil {
 		return false, err
 	}
 
 	// Log
-	t.log.Printlnf("Successfully distributed balance of minipool %s.", mp.GetAddress().Hex())
+	logger.Printlnf("Successfully distributed balance of minipool %s.", mp.GetAddress().Hex())
+	t.notifier.Notify(notifications.Event{
+		Type:     notifications.EventType_MinipoolDistributed,
+		Node:     &opts.From,
+		Minipool: &mpd.MinipoolAddress,
+		TxHash:   &hash,
+		Title:    "Minipool balance distributed",
+		Message:  fmt.Sprintf("The balance of minipool %s has been distributed.", mpd.MinipoolAddress.Hex()),
+	})
 
 	// Return
 	return true, nil
@@ -112,17 +112,16 @@ func (d *downloadRewardsTrees) run(state *state.NetworkState) error {
 
 	// Download missing intervals
 	for _, missingInterval := range missingIntervals {
-		fmt.Printf("Downloading interval %d file... ", missingInterval)
+		d.log.Printlnf("Downloading interval %d file...", missingInterval)
 		intervalInfo, err := rprewards.GetIntervalInfo(d.rp, d.cfg, nodeAccount.Address, missingInterval, nil)
 		if err != nil {
 			return fmt.Errorf("error getting interval %d info: %w", missingInterval, err)
 		}
 		err = rprewards.DownloadRewardsFile(d.cfg, missingInterval, intervalInfo.CID, true)
 		if err != nil {
-			fmt.Println()
 			return err
 		}
-		fmt.Println("done!")
+		d.log.Printlnf("Downloaded interval %d file.", missingInterval)
 	}
 
 	return nil
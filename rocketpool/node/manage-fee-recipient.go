@@ -6,11 +6,14 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -28,20 +31,21 @@ type manageFeeRecipient struct {
 	rp  *rocketpool.RocketPool
 	d   *client.Client
 	bc  beacon.Client
+	n   *notifications.Notifier
+	km  *keymanager.Client
+
+	// Only the primary node manages the fee recipient file; the Validator Client is shared with any other nodes
+	isPrimary bool
 }
 
 // Create manage fee recipient task
-func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger) (*manageFeeRecipient, error) {
+func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet, isPrimary bool) (*manageFeeRecipient, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
-	if err != nil {
-		return nil, err
-	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
@@ -54,16 +58,27 @@ func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger) (*manageFeeRe
 	if err != nil {
 		return nil, err
 	}
+	n, err := services.GetNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+	km, err := services.GetKeymanagerClient(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	return &manageFeeRecipient{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		rp:  rp,
-		d:   d,
-		bc:  bc,
+		c:         c,
+		log:       logger,
+		cfg:       cfg,
+		w:         w,
+		rp:        rp,
+		d:         d,
+		bc:        bc,
+		n:         n,
+		km:        km,
+		isPrimary: isPrimary,
 	}, nil
 
 }
@@ -99,27 +114,55 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		correctFeeRecipient = feeRecipientInfo.FeeDistributorAddress
 	}
 
+	// Get the node's minipool validator keys; the fee recipients of any other keys in the VC are left alone
+	minipoolPubkeys := []types.ValidatorPubkey{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		minipoolPubkeys = append(minipoolPubkeys, mpd.Pubkey)
+	}
+
 	// Check if the VC is using the correct fee recipient
 	fileExists, correctAddress, err := rpsvc.CheckFeeRecipientFile(correctFeeRecipient, m.cfg)
 	if err != nil {
 		return fmt.Errorf("error validating fee recipient files: %w", err)
 	}
 
+	// Other nodes can't change the fee recipient without breaking the primary node's, so they can only check it
+	if !m.isPrimary {
+		if fileExists && !correctAddress {
+			return fmt.Errorf("node %s needs a fee recipient of %s but the Validator Client it shares with the primary node uses a different one; "+
+				"every node that shares a Validator Client must use the same fee recipient (for example, by joining the Smoothing Pool)", nodeAccount.Address.Hex(), correctFeeRecipient.Hex())
+		}
+		return nil
+	}
+
 	if !fileExists {
 		m.log.Println("Fee recipient files don't all exist, regenerating...")
 	} else if !correctAddress {
-		m.log.Printlnf("WARNING: Fee recipient files did not contain the correct fee recipient of %s, regenerating...", correctFeeRecipient.Hex())
+		m.log.Warnf("Fee recipient files did not contain the correct fee recipient of %s, regenerating...", correctFeeRecipient.Hex())
 	} else {
-		// Files are all correct, return.
+		// Files are all correct, but fee recipients set through the keymanager API override them so check those too
+		if m.km != nil {
+			updatedCount, err := validator.SyncFeeRecipient(m.cfg, m.km, minipoolPubkeys, correctFeeRecipient)
+			if err != nil {
+				m.log.Debugf("Couldn't check the fee recipients with the Validator Client's keymanager API: %s", err.Error())
+			} else if updatedCount > 0 {
+				m.log.Warnf("%d validator key(s) did not use the correct fee recipient of %s, updated them with the Validator Client's keymanager API.", updatedCount, correctFeeRecipient.Hex())
+			}
+		}
 		return nil
 	}
 
 	// Regenerate the fee recipient files
 	err = rpsvc.UpdateFeeRecipientFile(correctFeeRecipient, m.cfg)
 	if err != nil {
-		m.log.Println("***ERROR***")
-		m.log.Printlnf("Error updating fee recipient files: %s", err.Error())
+		m.log.Errorf("Error updating fee recipient files: %s", err.Error())
 		m.log.Println("Shutting down the validator client for safety to prevent you from being penalized...")
+		m.n.Notify(notifications.Event{
+			Type:    notifications.EventType_FeeRecipientError,
+			Node:    &nodeAccount.Address,
+			Title:   "Validator client stopped",
+			Message: fmt.Sprintf("Your fee recipient files couldn't be updated (%s), so your validator client has been shut down to prevent you from being penalized.", err.Error()),
+		})
 
 		err = validator.StopValidator(m.cfg, m.bc, &m.log, m.d)
 		if err != nil {
@@ -128,15 +171,15 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		return nil
 	}
 
-	// Restart the VC
-	m.log.Println("Fee recipient files updated successfully! Restarting validator client...")
-	err = validator.RestartValidator(m.cfg, m.bc, &m.log, m.d)
+	// Update the VC
+	m.log.Println("Fee recipient files updated successfully! Updating validator client...")
+	err = validator.UpdateFeeRecipient(m.cfg, m.bc, &m.log, m.d, m.km, minipoolPubkeys, correctFeeRecipient)
 	if err != nil {
-		return fmt.Errorf("error restarting validator client: %w", err)
+		return fmt.Errorf("error updating validator client: %w", err)
 	}
 
 	// Log & return
-	m.log.Println("Successfully restarted, you are now validating safely.")
+	m.log.Println("Successfully updated, you are now validating safely.")
 	return nil
 
 }
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
@@ -14,17 +15,13 @@ import (
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, nodes []managedNode, stateLockers map[common.Address]*collectors.StateLocker, performanceLockers map[common.Address]*collectors.PerformanceLocker) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return err
 	}
-	w, err := services.GetWallet(c)
-	if err != nil {
-		return err
-	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return err
@@ -51,21 +48,15 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 		}
 	}
 
-	nodeAccount, err := w.GetNodeAccount()
-	if err != nil {
-		return fmt.Errorf("Error getting node account: %w", err)
-	}
-
-	// Create the collectors
-	demandCollector := collectors.NewDemandCollector(rp, stateLocker)
-	performanceCollector := collectors.NewPerformanceCollector(rp, stateLocker)
-	supplyCollector := collectors.NewSupplyCollector(rp, stateLocker)
-	rplCollector := collectors.NewRplCollector(rp, cfg, stateLocker)
-	odaoCollector := collectors.NewOdaoCollector(rp, stateLocker)
-	nodeCollector := collectors.NewNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
-	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
-	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address, stateLocker)
-	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(rp, ec, stateLocker)
+	// Create the network collectors; they can use any node's state, so they use the primary node's
+	stateLocker := stateLockers[nodes[0].address]
+	demandCollector := collectors.NewDemandCollector(logger, rp, stateLocker)
+	performanceCollector := collectors.NewPerformanceCollector(logger, rp, stateLocker)
+	supplyCollector := collectors.NewSupplyCollector(logger, rp, stateLocker)
+	rplCollector := collectors.NewRplCollector(logger, rp, cfg, stateLocker)
+	odaoCollector := collectors.NewOdaoCollector(logger, rp, stateLocker)
+	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(logger, rp, ec, stateLocker)
+	clientCollector := collectors.NewClientCollector(ec, bc)
 
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
@@ -74,20 +65,29 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	registry.MustRegister(supplyCollector)
 	registry.MustRegister(rplCollector)
 	registry.MustRegister(odaoCollector)
-	registry.MustRegister(nodeCollector)
-	registry.MustRegister(trustedNodeCollector)
-	registry.MustRegister(beaconCollector)
 	registry.MustRegister(smoothingPoolCollector)
+	registry.MustRegister(clientCollector)
 
-	// Set up snapshot checking if enabled
+	// Create the collectors for each node, labelling their metrics with the node's address
 	votingId := cfg.Smartnode.GetVotingSnapshotID()
-	if s != nil {
-		votingDelegate, err := s.Delegation(nil, nodeAccount.Address, votingId)
-		if err != nil {
-			return fmt.Errorf("Error getting node delegate: %w", err)
+	for _, node := range nodes {
+		nodeRegistry := prometheus.WrapRegistererWith(prometheus.Labels{"node": node.address.Hex()}, registry)
+		nodeStateLocker := stateLockers[node.address]
+		nodeLogger := logger.With(log.Node(node.address))
+		nodeRegistry.MustRegister(collectors.NewNodeCollector(nodeLogger, rp, bc, node.address, cfg, nodeStateLocker))
+		nodeRegistry.MustRegister(collectors.NewTrustedNodeCollector(nodeLogger, rp, bc, node.address, cfg, nodeStateLocker))
+		nodeRegistry.MustRegister(collectors.NewBeaconCollector(nodeLogger, rp, bc, ec, node.address, nodeStateLocker))
+		nodeRegistry.MustRegister(collectors.NewMinipoolCollector(node.address, nodeStateLocker))
+		nodeRegistry.MustRegister(collectors.NewValidatorPerformanceCollector(performanceLockers[node.address]))
+
+		// Set up snapshot checking if enabled
+		if s != nil {
+			votingDelegate, err := s.Delegation(nil, node.address, votingId)
+			if err != nil {
+				return fmt.Errorf("Error getting delegate for node %s: %w", node.address.Hex(), err)
+			}
+			nodeRegistry.MustRegister(collectors.NewSnapshotCollector(nodeLogger, rp, cfg, node.address, votingDelegate))
 		}
-		snapshotCollector := collectors.NewSnapshotCollector(rp, cfg, nodeAccount.Address, votingDelegate)
-		registry.MustRegister(snapshotCollector)
 	}
 
 	// Start the HTTP server
@@ -1,6 +1,9 @@
 package node
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -11,16 +14,21 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool/api"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -39,11 +47,18 @@ const (
 	PromoteMinipoolsColor        = color.FgMagenta
 	ReduceBondAmountColor        = color.FgHiBlue
 	DistributeMinipoolsColor     = color.FgHiGreen
+	TrackPerformanceColor        = color.FgCyan
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
 )
 
+// A node managed by the daemon
+type managedNode struct {
+	address common.Address
+	wallet  *wallet.Wallet
+}
+
 // Register node command
 func RegisterCommands(app *cli.App, name string, aliases []string) {
 	app.Commands = append(app.Commands, cli.Command{
@@ -59,14 +74,28 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 // Run daemon
 func run(c *cli.Context) error {
 
+	// Configure logging
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	log.Configure(cfg.Smartnode.GetLogSettings())
+
 	// Handle the initial fee recipient file deployment
-	err := deployDefaultFeeRecipientFile(c)
+	err = deployDefaultFeeRecipientFile(c)
 	if err != nil {
 		return err
 	}
 
 	// Clean up old fee recipient files
-	err = removeLegacyFeeRecipientFiles(c)
+	warningLog := log.NewColorLogger(WarningColor)
+	err = removeLegacyFeeRecipientFiles(c, &warningLog)
+	if err != nil {
+		return err
+	}
+
+	// Create the token for the Validator Client's keymanager API
+	err = deployKeymanagerApiToken(c)
 	if err != nil {
 		return err
 	}
@@ -74,21 +103,28 @@ func run(c *cli.Context) error {
 	// Configure
 	configureHTTP()
 
+	// Start the API server so the CLI doesn't need to start a new process for every command
+	apiServerLog := log.NewColorLogger(api.ApiServerErrorColor)
+	err = startApiServer(c, &apiServerLog)
+	if err != nil {
+		return err
+	}
+
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
 		return err
 	}
 
 	// Get services
-	cfg, err := services.GetConfig(c)
+	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return err
 	}
-	rp, err := services.GetRocketPool(c)
+	wallets, err := services.GetNodeWallets(c)
 	if err != nil {
 		return err
 	}
-	w, err := services.GetWallet(c)
+	ec, err := services.GetEthClient(c)
 	if err != nil {
 		return err
 	}
@@ -96,134 +132,102 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-
-	nodeAccount, err := w.GetNodeAccount()
+	notifier, err := services.GetNotifier(c)
 	if err != nil {
-		return fmt.Errorf("error getting node account: %w", err)
+		return err
 	}
 
 	// Initialize loggers
 	errorLog := log.NewColorLogger(ErrorColor)
 	updateLog := log.NewColorLogger(UpdateColor)
 
-	// Create the state manager
-	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
+	// Get the nodes to manage
+	nodes, err := getManagedNodes(rp, wallets, &updateLog)
 	if err != nil {
 		return err
 	}
-	stateLocker := collectors.NewStateLocker()
 
-	// Initialize tasks
-	manageFeeRecipient, err := newManageFeeRecipient(c, log.NewColorLogger(ManageFeeRecipientColor))
-	if err != nil {
-		return err
-	}
-	distributeMinipools, err := newDistributeMinipools(c, log.NewColorLogger(DistributeMinipoolsColor))
-	if err != nil {
-		return err
-	}
-	stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewColorLogger(StakePrelaunchMinipoolsColor))
+	// Create the state manager
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
 	if err != nil {
 		return err
 	}
-	promoteMinipools, err := newPromoteMinipools(c, log.NewColorLogger(PromoteMinipoolsColor))
-	if err != nil {
-		return err
+	stateLockers := map[common.Address]*collectors.StateLocker{}
+	performanceLockers := map[common.Address]*collectors.PerformanceLocker{}
+	for _, node := range nodes {
+		stateLockers[node.address] = collectors.NewStateLocker()
+		performanceLockers[node.address] = collectors.NewPerformanceLocker()
 	}
-	downloadRewardsTrees, err := newDownloadRewardsTrees(c, log.NewColorLogger(DownloadRewardsTreesColor))
-	if err != nil {
-		return err
-	}
-	reduceBonds, err := newReduceBonds(c, log.NewColorLogger(ReduceBondAmountColor))
-	if err != nil {
-		return err
-	}
-
-	// Wait group to handle the various threads
-	wg := new(sync.WaitGroup)
-	wg.Add(2)
 
 	// Timestamp for caching total effective RPL stake
 	lastTotalEffectiveStakeTime := time.Unix(0, 0)
 
-	// Run task loop
-	go func() {
-		for {
-			// Check the EC status
-			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
-			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
-			}
-
-			// Check the BC status
-			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
-			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
-			}
-
-			// Update the network state
-			updateTotalEffectiveStake := false
-			if time.Since(lastTotalEffectiveStakeTime) > totalEffectiveStakeCooldown {
-				updateTotalEffectiveStake = true
-				lastTotalEffectiveStakeTime = time.Now() // Even if the call below errors out, this will prevent contant errors related to this flag
-			}
-			state, totalEffectiveStake, err := updateNetworkState(m, &updateLog, nodeAccount.Address, updateTotalEffectiveStake)
-			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
-			}
-			stateLocker.UpdateState(state, totalEffectiveStake)
+	// Create the task scheduler; the network state for a node is refreshed once for each batch of its due tasks
+	scheduler := newTaskScheduler(func(nodeAddress common.Address) (*state.NetworkState, error) {
+		// Check the EC status
+		err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+		notifier.CheckClientStatus("execution", ec.GetLastStatus())
+		if err != nil {
+			return nil, err
+		}
 
-			// Manage the fee recipient for the node
-			if err := manageFeeRecipient.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+		// Check the BC status
+		err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
+		notifier.CheckClientStatus("consensus", bc.GetLastStatus())
+		if err != nil {
+			return nil, err
+		}
 
-			// Run the rewards download check
-			if err := downloadRewardsTrees.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+		// Update the network state
+		updateTotalEffectiveStake := false
+		if time.Since(lastTotalEffectiveStakeTime) > totalEffectiveStakeCooldown {
+			updateTotalEffectiveStake = true
+			lastTotalEffectiveStakeTime = time.Now() // Even if the call below errors out, this will prevent contant errors related to this flag
+		}
+		state, totalEffectiveStake, err := updateNetworkState(m, &updateLog, nodeAddress, updateTotalEffectiveStake)
+		if err != nil {
+			return nil, err
+		}
+		stateLockers[nodeAddress].UpdateState(state, totalEffectiveStake)
 
-			// Run the minipool stake check
-			if err := stakePrelaunchMinipools.run(state); err != nil {
-				errorLog.Println(err)
+		// The total effective stake is network-wide, so every node's metrics can use it
+		if totalEffectiveStake != nil {
+			for _, stateLocker := range stateLockers {
+				stateLocker.UpdateTotalEffectiveRPLStake(totalEffectiveStake)
 			}
-			time.Sleep(taskCooldown)
+		}
+		return state, nil
+	}, cfg.Smartnode.GetNodeTaskStatusPath(true), &updateLog, &errorLog, notifier, cfg.Smartnode.NotificationTaskFailures.Value.(uint64))
 
-			// Run the balance distribution check
-			if err := distributeMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+	// Register tasks for each node; each one still runs at least once per interval, but chain events can bring it forward
+	for i, node := range nodes {
+		isPrimary := (i == 0)
+		err = registerNodeTasks(c, cfg, scheduler, node, isPrimary, performanceLockers[node.address])
+		if err != nil {
+			return err
+		}
+	}
 
-			// Run the reduce bond check
-			if err := reduceBonds.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+	// Wait group to handle the various threads
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
 
-			// Run the minipool promotion check
-			if err := promoteMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
+	// Watch for chain events
+	ctx := context.Background()
+	go scheduler.watchBeaconEvents(ctx, bc)
+	go scheduler.watchExecutionHeads(ctx, ec)
 
-			time.Sleep(tasksInterval)
-		}
+	// Run task loop
+	go func() {
+		scheduler.run()
 		wg.Done()
 	}()
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), nodes, stateLockers, performanceLockers)
 		if err != nil {
-			errorLog.Println(err)
+			errorLog.Error(err)
 		}
 		wg.Done()
 	}()
@@ -234,6 +238,97 @@ func run(c *cli.Context) error {
 
 }
 
+// Get the nodes the daemon should manage from their wallets; the primary node always comes first.
+// Additional nodes that aren't registered yet are skipped until the daemon is restarted.
+func getManagedNodes(rp *rocketpool.RocketPool, wallets []*wallet.Wallet, logger *log.ColorLogger) ([]managedNode, error) {
+	nodes := []managedNode{}
+	addresses := map[common.Address]bool{}
+	for i, w := range wallets {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, fmt.Errorf("error getting node account: %w", err)
+		}
+		if addresses[nodeAccount.Address] {
+			logger.With(log.Node(nodeAccount.Address)).Warnf("node %s is configured more than once, ignoring the duplicate.", nodeAccount.Address.Hex())
+			continue
+		}
+
+		// The primary node is already known to be registered
+		if i > 0 {
+			exists, err := node.GetNodeExists(rp, nodeAccount.Address, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error checking if node %s is registered: %w", nodeAccount.Address.Hex(), err)
+			}
+			if !exists {
+				logger.With(log.Node(nodeAccount.Address)).Warnf("node %s is not registered with Rocket Pool, it will not be managed until it's registered and the node daemon is restarted.", nodeAccount.Address.Hex())
+				continue
+			}
+			logger.With(log.Node(nodeAccount.Address)).Printlnf("Managing additional node %s.", nodeAccount.Address.Hex())
+		}
+
+		addresses[nodeAccount.Address] = true
+		nodes = append(nodes, managedNode{
+			address: nodeAccount.Address,
+			wallet:  w,
+		})
+	}
+	return nodes, nil
+}
+
+// Register the tasks for a node with the scheduler
+func registerNodeTasks(c *cli.Context, cfg *config.RocketPoolConfig, scheduler *taskScheduler, node managedNode, isPrimary bool, performanceLocker *collectors.PerformanceLocker) error {
+	err := scheduler.addTask("manage-fee-recipient", node.address, cfg.Smartnode.ManageFeeRecipientEnabled.Value.(bool), getTaskInterval(cfg.Smartnode.ManageFeeRecipientInterval), func() (nodeTask, error) {
+		return newManageFeeRecipient(c, newTaskLogger(ManageFeeRecipientColor, "manage-fee-recipient", node.address), node.wallet, isPrimary)
+	}, trigger_ExecutionHead, trigger_ChainReorg)
+	if err != nil {
+		return err
+	}
+
+	// Rewards trees are the same for every node, so only the primary node needs to download them
+	if isPrimary {
+		err = scheduler.addTask("download-rewards-trees", node.address, cfg.Smartnode.DownloadRewardsTreesEnabled.Value.(bool), getTaskInterval(cfg.Smartnode.DownloadRewardsTreesInterval), func() (nodeTask, error) {
+			return newDownloadRewardsTrees(c, newTaskLogger(DownloadRewardsTreesColor, "download-rewards-trees", node.address))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = scheduler.addTask("stake-prelaunch-minipools", node.address, cfg.Smartnode.StakePrelaunchMinipoolsEnabled.Value.(bool), getTaskInterval(cfg.Smartnode.StakePrelaunchMinipoolsInterval), func() (nodeTask, error) {
+		return newStakePrelaunchMinipools(c, newTaskLogger(StakePrelaunchMinipoolsColor, "stake-prelaunch-minipools", node.address), node.wallet)
+	}, trigger_BeaconHead, trigger_ChainReorg)
+	if err != nil {
+		return err
+	}
+	err = scheduler.addTask("distribute-minipools", node.address, cfg.Smartnode.DistributeMinipoolsEnabled.Value.(bool), getTaskInterval(cfg.Smartnode.DistributeMinipoolsInterval), func() (nodeTask, error) {
+		return newDistributeMinipools(c, newTaskLogger(DistributeMinipoolsColor, "distribute-minipools", node.address), node.wallet)
+	})
+	if err != nil {
+		return err
+	}
+	err = scheduler.addTask("reduce-bonds", node.address, cfg.Smartnode.ReduceBondsEnabled.Value.(bool), getTaskInterval(cfg.Smartnode.ReduceBondsInterval), func() (nodeTask, error) {
+		return newReduceBonds(c, newTaskLogger(ReduceBondAmountColor, "reduce-bonds", node.address), node.wallet)
+	}, trigger_ExecutionHead, trigger_ChainReorg)
+	if err != nil {
+		return err
+	}
+	err = scheduler.addTask("promote-minipools", node.address, cfg.Smartnode.PromoteMinipoolsEnabled.Value.(bool), getTaskInterval(cfg.Smartnode.PromoteMinipoolsInterval), func() (nodeTask, error) {
+		return newPromoteMinipools(c, newTaskLogger(PromoteMinipoolsColor, "promote-minipools", node.address), node.wallet)
+	}, trigger_ExecutionHead, trigger_ChainReorg)
+	if err != nil {
+		return err
+	}
+	err = scheduler.addTask("track-validator-performance", node.address, cfg.Smartnode.TrackPerformanceEnabled.Value.(bool), getTaskInterval(cfg.Smartnode.TrackPerformanceInterval), func() (nodeTask, error) {
+		return newTrackValidatorPerformance(c, newTaskLogger(TrackPerformanceColor, "track-validator-performance", node.address), node.address, performanceLocker)
+	})
+	return err
+}
+
+// Create the logger for one of a node's tasks
+func newTaskLogger(colorAttr color.Attribute, task string, nodeAddress common.Address) log.ColorLogger {
+	return log.NewColorLogger(colorAttr).With(log.Task(task), log.Node(nodeAddress))
+}
+
 // Configure HTTP transport settings
 func configureHTTP() {
 
@@ -283,8 +378,49 @@ func deployDefaultFeeRecipientFile(c *cli.Context) error {
 
 }
 
+// Create the token file the Validator Client's keymanager API uses if it doesn't exist yet
+func deployKeymanagerApiToken(c *cli.Context) error {
+
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Native mode users configure their own Validator Client's token
+	if cfg.IsNativeMode || cfg.Smartnode.UseKeymanagerApi.Value != true {
+		return nil
+	}
+
+	tokenPath := cfg.Smartnode.GetKeymanagerApiTokenPath()
+	_, err = os.Stat(tokenPath)
+	if os.IsNotExist(err) {
+		// Make sure the validators dir is created
+		err = os.MkdirAll(filepath.Dir(tokenPath), 0755)
+		if err != nil {
+			return fmt.Errorf("could not create validators directory: %w", err)
+		}
+
+		// Create the file; Lighthouse requires this format, and every other client accepts any token
+		tokenBytes := make([]byte, 32)
+		_, err = rand.Read(tokenBytes)
+		if err != nil {
+			return fmt.Errorf("could not generate keymanager API token: %w", err)
+		}
+		token := "api-token-0x" + hex.EncodeToString(tokenBytes)
+		err = os.WriteFile(tokenPath, []byte(token), 0600)
+		if err != nil {
+			return fmt.Errorf("could not write keymanager API token to %s: %w", tokenPath, err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("Error checking keymanager API token status: %w", err)
+	}
+
+	return nil
+
+}
+
 // Remove the old fee recipient files that were created in v1.5.0
-func removeLegacyFeeRecipientFiles(c *cli.Context) error {
+func removeLegacyFeeRecipientFiles(c *cli.Context, logger *log.ColorLogger) error {
 
 	legacyFeeRecipientFile := "rp-fee-recipient.txt"
 
@@ -303,7 +439,7 @@ func removeLegacyFeeRecipientFiles(c *cli.Context) error {
 		if !os.IsNotExist(err) {
 			err = os.Remove(oldFile)
 			if err != nil {
-				fmt.Printf("NOTE: Couldn't remove old fee recipient file (%s): %s\nThis file is no longer used, you may remove it manually if you wish.\n", oldFile, err.Error())
+				logger.Warnf("Couldn't remove old fee recipient file (%s): %s. This file is no longer used, you may remove it manually if you wish.", oldFile, err.Error())
 			}
 		}
 	}
@@ -321,3 +457,12 @@ func updateNetworkState(m *state.NetworkStateManager, log *log.ColorLogger, node
 	}
 	return state, totalEffectiveStake, nil
 }
+
+// Get the configured interval for a task, falling back to the default if it hasn't been set
+func getTaskInterval(param cfgtypes.Parameter) time.Duration {
+	minutes, ok := param.Value.(uint64)
+	if !ok || minutes == 0 {
+		return tasksInterval
+	}
+	return time.Duration(minutes) * time.Minute
+}
@@ -19,7 +19,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -31,6 +33,8 @@ type promoteMinipools struct {
 	log            log.ColorLogger
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
+	journal        *transactions.Journal
+	notifier       *notifications.Notifier
 	rp             *rocketpool.RocketPool
 	d              *client.Client
 	gasThreshold   float64
@@ -40,14 +44,18 @@ type promoteMinipools struct {
 }
 
 // Create promote minipools task
-func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipools, error) {
+func newPromoteMinipools(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*promoteMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
+	notifier, err := services.GetNotifier(c)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +83,7 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warnf("priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
@@ -87,6 +95,8 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 		log:            logger,
 		cfg:            cfg,
 		w:              w,
+		journal:        journal,
+		notifier:       notifier,
 		rp:             rp,
 		d:              d,
 		gasThreshold:   gasThreshold,
@@ -130,7 +140,7 @@ func (t *promoteMinipools) run(state *state.NetworkState) error {
 	for _, mpd := range minipools {
 		_, err := t.promoteMinipool(mpd, opts)
 		if err != nil {
-			t.log.Println(fmt.Errorf("Could not promote minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
+			t.log.With(log.Minipool(mpd.MinipoolAddress)).Error(fmt.Errorf("Could not promote minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
 			return err
 		}
 	}
@@ -164,7 +174,7 @@ func (t *promoteMinipools) getVacantMinipools(nodeAddress common.Address, state
 			if remainingTime < 0 {
 				vacantMinipools = append(vacantMinipools, mpd)
 			} else {
-				t.log.Printlnf("Minipool %s has %s left until it can be promoted.", mpd.MinipoolAddress.Hex(), remainingTime)
+				t.log.With(log.Minipool(mpd.MinipoolAddress)).Printlnf("Minipool %s has %s left until it can be promoted.", mpd.MinipoolAddress.Hex(), remainingTime)
 			}
 		}
 	}
@@ -178,7 +188,8 @@ func (t *promoteMinipools) getVacantMinipools(nodeAddress common.Address, state
 func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, callOpts *bind.CallOpts) (bool, error) {
 
 	// Log
-	t.log.Printlnf("Promoting minipool %s...", mpd.MinipoolAddress.Hex())
+	logger := t.log.With(log.Minipool(mpd.MinipoolAddress))
+	logger.Printlnf("Promoting minipool %s...", mpd.MinipoolAddress.Hex())
 
 	// Get the updated minipool interface
 	mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, callOpts)
@@ -218,19 +229,19 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 	}
 
 	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &logger, maxFee, t.gasLimit) {
 		// Check for the timeout buffer
 		creationTime := time.Unix(mpd.StatusTime.Int64(), 0)
 		isDue, timeUntilDue, err := api.IsTransactionDue(t.rp, creationTime)
 		if err != nil {
-			t.log.Printlnf("Error checking if minipool is due: %s\nPromoting now for safety...", err.Error())
+			logger.Warnf("Error checking if minipool is due: %s\nPromoting now for safety...", err.Error())
 		}
 		if !isDue {
-			t.log.Printlnf("Time until promoting will be forced for safety: %s", timeUntilDue)
+			logger.Printlnf("Time until promoting will be forced for safety: %s", timeUntilDue)
 			return false, nil
 		}
 
-		t.log.Println("NOTICE: The minipool has exceeded half of the timeout period, so it will be force-promoted at the current gas price.")
+		logger.Println("NOTICE: The minipool has exceeded half of the timeout period, so it will be force-promoted at the current gas price.")
 	}
 
 	opts.GasFeeCap = maxFee
@@ -244,13 +255,21 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("promote minipool %s", mpd.MinipoolAddress.Hex()), t.w, &logger)
 	if err != nil {
 		return false, err
 	}
 
 	// Log
-	t.log.Printlnf("Successfully promoted minipool %s.", mpd.MinipoolAddress.Hex())
+	logger.Printlnf("Successfully promoted minipool %s.", mpd.MinipoolAddress.Hex())
+	t.notifier.Notify(notifications.Event{
+		Type:     notifications.EventType_MinipoolPromoted,
+		Node:     &opts.From,
+		Minipool: &mpd.MinipoolAddress,
+		TxHash:   &hash,
+		Title:    "Minipool promoted",
+		Message:  fmt.Sprintf("Minipool %s has been promoted and is now a regular minipool.", mpd.MinipoolAddress.Hex()),
+	})
 
 	// Return
 	return true, nil
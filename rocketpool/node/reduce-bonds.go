@@ -21,7 +21,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -33,6 +35,8 @@ type reduceBonds struct {
 	log            log.ColorLogger
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
+	journal        *transactions.Journal
+	notifier       *notifications.Notifier
 	rp             *rocketpool.RocketPool
 	d              *client.Client
 	gasThreshold   float64
@@ -52,14 +56,18 @@ type minipoolBondReductionDetails struct {
 }
 
 // Create reduce bonds task
-func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error) {
+func newReduceBonds(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*reduceBonds, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
+	notifier, err := services.GetNotifier(c)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +101,7 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warnf("priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
@@ -105,6 +113,8 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 		log:            logger,
 		cfg:            cfg,
 		w:              w,
+		journal:        journal,
+		notifier:       notifier,
 		rp:             rp,
 		d:              d,
 		gasThreshold:   gasThreshold,
@@ -175,7 +185,7 @@ func (t *reduceBonds) run(state *state.NetworkState) error {
 	for _, mp := range minipools {
 		success, err := t.reduceBond(mp, windowStart, windowLength, latestBlockTime, opts)
 		if err != nil {
-			t.log.Println(fmt.Errorf("could not reduce bond for minipool %s: %w", mp.MinipoolAddress.Hex(), err))
+			t.log.With(log.Minipool(mp.MinipoolAddress)).Error(fmt.Errorf("could not reduce bond for minipool %s: %w", mp.MinipoolAddress.Hex(), err))
 			return err
 		}
 		if success {
@@ -283,20 +293,20 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 	opts.GasLimit = gas.Uint64()
 
 	// Distribute
-	fmt.Printf("Distributing rewards...\n")
+	t.log.Println("Distributing rewards...")
 	hash, err := distributor.Distribute(opts)
 	if err != nil {
 		return false, err
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, "distribute fee distributor balance", t.w, &t.log)
 	if err != nil {
 		return false, err
 	}
 
 	// Log & return
-	fmt.Println("Successfully distributed your fee distributor's balance. Your rewards should arrive in your withdrawal address shortly.")
+	t.log.Println("Successfully distributed your fee distributor's balance. Your rewards should arrive in your withdrawal address shortly.")
 	return true, nil
 }
 
@@ -320,6 +330,21 @@ func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowS
 		depositBalance := eth.WeiToEth(mpd.NodeDepositBalance)
 		timeSinceReductionStart := latestBlockTime.Sub(reduceBondTime)
 
+		// Let the operator know if the Oracle DAO cancelled the reduction
+		if depositBalance == 16 && reduceBondCancelled && mpd.Status == types.Staking {
+			// Only report each cancellation once, even across restarts
+			reported := t.notifier.NotifyOnce(fmt.Sprintf("%s/%d", mpd.MinipoolAddress.Hex(), reduceBondTime.Unix()), notifications.Event{
+				Type:     notifications.EventType_BondReductionCancelled,
+				Node:     &nodeAddress,
+				Minipool: &mpd.MinipoolAddress,
+				Title:    "Minipool bond reduction cancelled",
+				Message:  fmt.Sprintf("The Oracle DAO cancelled the bond reduction for minipool %s, so its bond can't be reduced.", mpd.MinipoolAddress.Hex()),
+			})
+			if reported {
+				t.log.With(log.Minipool(mpd.MinipoolAddress)).Warnf("The bond reduction for minipool %s was cancelled by the Oracle DAO.", mpd.MinipoolAddress.Hex())
+			}
+		}
+
 		if depositBalance == 16 &&
 			timeSinceReductionStart < (windowStart+windowLength) &&
 			!reduceBondCancelled &&
@@ -328,7 +353,7 @@ func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowS
 				reduceableMinipools = append(reduceableMinipools, mpd)
 			} else {
 				remainingTime := windowStart - timeSinceReductionStart
-				t.log.Printlnf("Minipool %s has %s left until it can have its bond reduced.", mpd.MinipoolAddress.Hex(), remainingTime)
+				t.log.With(log.Minipool(mpd.MinipoolAddress)).Printlnf("Minipool %s has %s left until it can have its bond reduced.", mpd.MinipoolAddress.Hex(), remainingTime)
 			}
 		}
 	}
@@ -342,7 +367,8 @@ func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowS
 func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart time.Duration, windowLength time.Duration, latestBlockTime time.Time, callOpts *bind.CallOpts) (bool, error) {
 
 	// Log
-	t.log.Printlnf("Reducing bond for minipool %s...", mpd.MinipoolAddress.Hex())
+	logger := t.log.With(log.Minipool(mpd.MinipoolAddress))
+	logger.Printlnf("Reducing bond for minipool %s...", mpd.MinipoolAddress.Hex())
 
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
@@ -390,10 +416,10 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 	}
 
 	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &logger, maxFee, t.gasLimit) {
 		timeSinceReductionStart := latestBlockTime.Sub(reduceBondTime)
 		remainingTime := (windowStart + windowLength) - timeSinceReductionStart
-		t.log.Printlnf("Time until bond reduction times out: %s", remainingTime)
+		logger.Printlnf("Time until bond reduction times out: %s", remainingTime)
 		return false, nil
 	}
 
@@ -408,13 +434,21 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("reduce bond of minipool %s", mpd.MinipoolAddress.Hex()), t.w, &logger)
 	if err != nil {
 		return false, err
 	}
 
 	// Log
-	t.log.Printlnf("Successfully reduced bond for minipool %s.", mpd.MinipoolAddress.Hex())
+	logger.Printlnf("Successfully reduced bond for minipool %s.", mpd.MinipoolAddress.Hex())
+	t.notifier.Notify(notifications.Event{
+		Type:     notifications.EventType_BondReduced,
+		Node:     &opts.From,
+		Minipool: &mpd.MinipoolAddress,
+		TxHash:   &hash,
+		Title:    "Minipool bond reduced",
+		Message:  fmt.Sprintf("The bond of minipool %s has been reduced.", mpd.MinipoolAddress.Hex()),
+	})
 
 	// Return
 	return true, nil
@@ -0,0 +1,464 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+var eventTaskInterval, _ = time.ParseDuration("1m")
+var eventStreamRetryDelay, _ = time.ParseDuration("30s")
+var executionHeadPollInterval, _ = time.ParseDuration("12s")
+
+// Chain events that can make a task due before its deadline
+type taskTrigger int
+
+const (
+	trigger_BeaconHead taskTrigger = iota
+	trigger_FinalizedCheckpoint
+	trigger_ChainReorg
+	trigger_ExecutionHead
+)
+
+// A task run by the node daemon
+type nodeTask interface {
+	run(state *state.NetworkState) error
+}
+
+// A task for one node along with its scheduling rules and the outcome of its last run
+type scheduledTask struct {
+	name        string
+	node        common.Address
+	task        nodeTask
+	enabled     bool
+	deadline    time.Duration
+	minInterval time.Duration
+	triggers    []taskTrigger
+	lastRun     time.Time
+	lastError   error
+	failures    uint64
+	pending     bool
+	running     bool
+}
+
+// Get the next time the task should run; disabled tasks never run
+func (t *scheduledTask) nextRunTime() time.Time {
+	if !t.enabled {
+		return time.Time{}
+	}
+	if t.pending {
+		return t.lastRun.Add(t.minInterval)
+	}
+	return t.lastRun.Add(t.deadline)
+}
+
+// Check if the task is triggered by the given event
+func (t *scheduledTask) isTriggeredBy(trigger taskTrigger) bool {
+	for _, taskTrigger := range t.triggers {
+		if taskTrigger == trigger {
+			return true
+		}
+	}
+	return false
+}
+
+// Runs node daemon tasks when chain events arrive or their deadlines pass, instead of on a fixed loop
+type taskScheduler struct {
+	tasks      []*scheduledTask
+	triggers   chan taskTrigger
+	getState   func(nodeAddress common.Address) (*state.NetworkState, error)
+	statusPath string
+	log        *log.ColorLogger
+	errLog     *log.ColorLogger
+	notifier   *notifications.Notifier
+
+	// The number of consecutive failures of a task before a notification is sent; 0 disables them
+	failureThreshold uint64
+}
+
+// Create a new task scheduler; getState is called once per pass for each node with due tasks to refresh the network state they share,
+// and the status of every task is written to statusPath so the API can report it
+func newTaskScheduler(getState func(nodeAddress common.Address) (*state.NetworkState, error), statusPath string, logger *log.ColorLogger, errorLogger *log.ColorLogger, notifier *notifications.Notifier, failureThreshold uint64) *taskScheduler {
+	return &taskScheduler{
+		tasks:            []*scheduledTask{},
+		triggers:         make(chan taskTrigger, 64),
+		getState:         getState,
+		statusPath:       statusPath,
+		log:              logger,
+		errLog:           errorLogger,
+		notifier:         notifier,
+		failureThreshold: failureThreshold,
+	}
+}
+
+// Register a task for a node that runs at least once per interval, and no more than once per minute when one of its triggers fires.
+// Disabled tasks are never created, but are still listed in the status report.
+func (s *taskScheduler) addTask(name string, node common.Address, enabled bool, interval time.Duration, create func() (nodeTask, error), triggers ...taskTrigger) error {
+	scheduledTask := &scheduledTask{
+		name:        name,
+		node:        node,
+		enabled:     enabled,
+		deadline:    interval,
+		minInterval: eventTaskInterval,
+		triggers:    triggers,
+	}
+	if interval < eventTaskInterval {
+		scheduledTask.minInterval = interval
+	}
+
+	if enabled {
+		task, err := create()
+		if err != nil {
+			return fmt.Errorf("error creating task %s for node %s: %w", name, node.Hex(), err)
+		}
+		scheduledTask.task = task
+	} else {
+		s.log.With(log.Task(name), log.Node(node)).Printlnf("Task %s is disabled for node %s.", name, node.Hex())
+	}
+
+	s.tasks = append(s.tasks, scheduledTask)
+	return nil
+}
+
+// Notify the scheduler of a chain event
+func (s *taskScheduler) trigger(trigger taskTrigger) {
+	select {
+	case s.triggers <- trigger:
+	default:
+		// The scheduler is already busy with plenty of pending events, so this one can be dropped
+	}
+}
+
+// Run the scheduler loop forever
+func (s *taskScheduler) run() {
+	s.saveStatus()
+	if s.getEnabledTaskCount() == 0 {
+		s.log.Println("All node tasks are disabled.")
+		select {}
+	}
+
+	for {
+		// Sleep until the next task is due or a new event arrives
+		wait := time.Until(s.nextRunTime())
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case trigger := <-s.triggers:
+				timer.Stop()
+				s.markPending(trigger)
+				continue
+			case <-timer.C:
+			}
+		}
+
+		// Drain any events that arrived in the meantime
+		s.drainTriggers()
+
+		// Run the due tasks
+		dueTasks := s.getDueTasks(time.Now())
+		if len(dueTasks) == 0 {
+			continue
+		}
+		if err := s.runTasks(dueTasks); err != nil {
+			s.errLog.Error(err)
+			time.Sleep(taskCooldown)
+		}
+	}
+}
+
+// Mark every task that listens for the event as pending
+func (s *taskScheduler) markPending(trigger taskTrigger) {
+	for _, task := range s.tasks {
+		if task.isTriggeredBy(trigger) {
+			task.pending = true
+		}
+	}
+}
+
+// Process all queued events without blocking
+func (s *taskScheduler) drainTriggers() {
+	for {
+		select {
+		case trigger := <-s.triggers:
+			s.markPending(trigger)
+		default:
+			return
+		}
+	}
+}
+
+// Get the earliest time any task is due
+func (s *taskScheduler) nextRunTime() time.Time {
+	var next time.Time
+	first := true
+	for _, task := range s.tasks {
+		if !task.enabled {
+			continue
+		}
+		taskTime := task.nextRunTime()
+		if first || taskTime.Before(next) {
+			next = taskTime
+			first = false
+		}
+	}
+	return next
+}
+
+// Get the number of tasks that are enabled
+func (s *taskScheduler) getEnabledTaskCount() int {
+	count := 0
+	for _, task := range s.tasks {
+		if task.enabled {
+			count++
+		}
+	}
+	return count
+}
+
+// Get the tasks that should run at the provided time
+func (s *taskScheduler) getDueTasks(now time.Time) []*scheduledTask {
+	dueTasks := []*scheduledTask{}
+	for _, task := range s.tasks {
+		if task.enabled && !task.nextRunTime().After(now) {
+			dueTasks = append(dueTasks, task)
+		}
+	}
+	return dueTasks
+}
+
+// Run the provided tasks, refreshing the network state once for each node they belong to
+func (s *taskScheduler) runTasks(tasks []*scheduledTask) error {
+	nodes := []common.Address{}
+	tasksByNode := map[common.Address][]*scheduledTask{}
+	for _, task := range tasks {
+		if _, exists := tasksByNode[task.node]; !exists {
+			nodes = append(nodes, task.node)
+		}
+		tasksByNode[task.node] = append(tasksByNode[task.node], task)
+	}
+
+	failedNodes := 0
+	for _, node := range nodes {
+		err := s.runNodeTasks(node, tasksByNode[node])
+		if err != nil {
+			s.errLog.With(log.Node(node)).Error(err)
+			failedNodes++
+		}
+	}
+	if failedNodes > 0 {
+		return fmt.Errorf("couldn't run the due tasks for %d of %d nodes", failedNodes, len(nodes))
+	}
+	return nil
+}
+
+// Refresh the network state for a node and run its provided tasks with it
+func (s *taskScheduler) runNodeTasks(node common.Address, tasks []*scheduledTask) error {
+	state, err := s.getState(node)
+	if err != nil {
+		// Record the failure against each task so it shows up in the status report; they'll be retried on the next pass
+		for _, task := range tasks {
+			task.lastError = err
+			s.recordResult(task, err)
+		}
+		s.saveStatus()
+		return fmt.Errorf("error getting network state for node %s: %w", node.Hex(), err)
+	}
+
+	for _, task := range tasks {
+		task.running = true
+		s.saveStatus()
+
+		fields := []log.Field{log.Task(task.name), log.Node(node), log.Slot(state.BeaconSlotNumber)}
+		s.log.With(fields...).Debugf("Running task %s for node %s at slot %d.", task.name, node.Hex(), state.BeaconSlotNumber)
+		err := task.task.run(state)
+		if err != nil {
+			s.errLog.With(fields...).Error(err)
+		}
+		task.lastRun = time.Now()
+		task.lastError = err
+		s.recordResult(task, err)
+		task.pending = false
+		task.running = false
+		s.saveStatus()
+	}
+	return nil
+}
+
+// Track a task's consecutive failures, and send a notification when it starts failing repeatedly or recovers afterwards
+func (s *taskScheduler) recordResult(task *scheduledTask, err error) {
+	if err == nil {
+		if s.failureThreshold > 0 && task.failures >= s.failureThreshold {
+			s.notifier.Notify(notifications.Event{
+				Type:    notifications.EventType_TaskRecovered,
+				Node:    &task.node,
+				Title:   fmt.Sprintf("Task %s has recovered", task.name),
+				Message: fmt.Sprintf("The %s task completed successfully after failing %d times in a row.", task.name, task.failures),
+			})
+		}
+		task.failures = 0
+		return
+	}
+
+	task.failures++
+	if s.failureThreshold > 0 && task.failures == s.failureThreshold {
+		s.notifier.Notify(notifications.Event{
+			Type:    notifications.EventType_TaskFailing,
+			Node:    &task.node,
+			Title:   fmt.Sprintf("Task %s is failing", task.name),
+			Message: fmt.Sprintf("The %s task has failed %d times in a row. The latest error was: %s", task.name, task.failures, err.Error()),
+		})
+	}
+}
+
+// Get the current status of every registered task
+func (s *taskScheduler) getStatus() []api.NodeTaskStatus {
+	status := make([]api.NodeTaskStatus, len(s.tasks))
+	for i, task := range s.tasks {
+		status[i] = api.NodeTaskStatus{
+			Name:        task.name,
+			Node:        task.node,
+			Enabled:     task.enabled,
+			Interval:    task.deadline,
+			Running:     task.running,
+			LastRunTime: task.lastRun,
+		}
+		if task.lastError != nil {
+			status[i].LastError = task.lastError.Error()
+		}
+		if task.enabled {
+			status[i].NextRunTime = task.nextRunTime()
+		}
+	}
+	return status
+}
+
+// Write the status of every task to disk so the API can report it
+func (s *taskScheduler) saveStatus() {
+	bytes, err := json.Marshal(api.NodeTaskStatusFile{
+		UpdateTime: time.Now(),
+		Tasks:      s.getStatus(),
+	})
+	if err != nil {
+		s.errLog.Error(fmt.Errorf("error serializing task status: %w", err))
+		return
+	}
+
+	// Write to a temporary file first so the API never reads a partial file
+	err = os.MkdirAll(filepath.Dir(s.statusPath), 0755)
+	if err != nil {
+		s.errLog.Error(fmt.Errorf("error creating task status directory: %w", err))
+		return
+	}
+	tempPath := s.statusPath + ".tmp"
+	err = os.WriteFile(tempPath, bytes, 0644)
+	if err != nil {
+		s.errLog.Error(fmt.Errorf("error writing task status to %s: %w", tempPath, err))
+		return
+	}
+	err = os.Rename(tempPath, s.statusPath)
+	if err != nil {
+		s.errLog.Error(fmt.Errorf("error writing task status to %s: %w", s.statusPath, err))
+	}
+}
+
+// Forward events from the Beacon node's event stream to the scheduler, reconnecting whenever the stream drops
+func (s *taskScheduler) watchBeaconEvents(ctx context.Context, bc *services.BeaconClientManager) {
+	topics := []beacon.EventTopic{
+		beacon.EventTopic_Head,
+		beacon.EventTopic_FinalizedCheckpoint,
+		beacon.EventTopic_ChainReorg,
+	}
+	events := make(chan beacon.BeaconEvent, 64)
+
+	go func() {
+		for {
+			select {
+			case event := <-events:
+				switch event.Topic {
+				case beacon.EventTopic_Head:
+					s.trigger(trigger_BeaconHead)
+				case beacon.EventTopic_FinalizedCheckpoint:
+					s.trigger(trigger_FinalizedCheckpoint)
+				case beacon.EventTopic_ChainReorg:
+					s.log.With(log.Slot(event.Slot)).Warnf("Chain reorg of depth %d detected at slot %d.", event.Depth, event.Slot)
+					s.trigger(trigger_ChainReorg)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		err := bc.StreamEvents(ctx, topics, events)
+		if ctx.Err() != nil {
+			return
+		}
+		s.errLog.Error(fmt.Errorf("Beacon event stream stopped, tasks will run on their deadlines until it reconnects: %w", err))
+		time.Sleep(eventStreamRetryDelay)
+	}
+}
+
+// Forward new Execution layer heads to the scheduler, using a subscription if the client supports it or polling otherwise
+func (s *taskScheduler) watchExecutionHeads(ctx context.Context, ec *services.ExecutionClientManager) {
+	headers := make(chan *types.Header, 16)
+	for {
+		sub, err := ec.SubscribeNewHead(ctx, headers)
+		if err != nil {
+			// HTTP connections don't support subscriptions, so fall back to polling
+			s.pollExecutionHeads(ctx, ec)
+			return
+		}
+
+		subscribed := true
+		for subscribed {
+			select {
+			case <-headers:
+				s.trigger(trigger_ExecutionHead)
+			case err := <-sub.Err():
+				s.errLog.Error(fmt.Errorf("Execution client head subscription stopped: %w", err))
+				subscribed = false
+			case <-ctx.Done():
+				sub.Unsubscribe()
+				return
+			}
+		}
+		time.Sleep(eventStreamRetryDelay)
+	}
+}
+
+// Poll the Execution client for new blocks
+func (s *taskScheduler) pollExecutionHeads(ctx context.Context, ec *services.ExecutionClientManager) {
+	ticker := time.NewTicker(executionHeadPollInterval)
+	defer ticker.Stop()
+
+	var lastBlock uint64
+	for {
+		select {
+		case <-ticker.C:
+			blockNumber, err := ec.BlockNumber(ctx)
+			if err != nil {
+				continue
+			}
+			if blockNumber != lastBlock {
+				lastBlock = blockNumber
+				s.trigger(trigger_ExecutionHead)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -20,7 +20,11 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -33,9 +37,12 @@ type stakePrelaunchMinipools struct {
 	log            log.ColorLogger
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
+	journal        *transactions.Journal
+	notifier       *notifications.Notifier
 	rp             *rocketpool.RocketPool
 	bc             beacon.Client
 	d              *client.Client
+	km             *keymanager.Client
 	gasThreshold   float64
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -43,14 +50,18 @@ type stakePrelaunchMinipools struct {
 }
 
 // Create stake prelaunch minipools task
-func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakePrelaunchMinipools, error) {
+func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*stakePrelaunchMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
+	notifier, err := services.GetNotifier(c)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +77,10 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	if err != nil {
 		return nil, err
 	}
+	km, err := services.GetKeymanagerClient(c)
+	if err != nil {
+		return nil, err
+	}
 
 	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
 
@@ -82,7 +97,7 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warnf("priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
@@ -94,9 +109,12 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		log:            logger,
 		cfg:            cfg,
 		w:              w,
+		journal:        journal,
+		notifier:       notifier,
 		rp:             rp,
 		bc:             bc,
 		d:              d,
+		km:             km,
 		gasThreshold:   gasThreshold,
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
@@ -140,21 +158,37 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 	t.log.Printlnf("%d minipool(s) are ready for staking...", len(minipools))
 
 	// Stake minipools
-	successCount := 0
+	stakedKeys := []keymanager.ValidatorKey{}
+	missingKeys := false
 	for _, mpd := range minipools {
 		success, err := t.stakeMinipool(mpd, state, opts)
 		if err != nil {
-			t.log.Println(fmt.Errorf("Could not stake minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
+			t.log.With(log.Minipool(mpd.MinipoolAddress)).Error(fmt.Errorf("Could not stake minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
+			if len(stakedKeys) > 0 || missingKeys {
+				// Make sure the minipools that were already staked still get their keys loaded
+				if loadErr := t.loadValidatorKeys(stakedKeys, missingKeys); loadErr != nil {
+					t.log.Warnf("Couldn't load the validator keys of the minipools that were staked: %s", loadErr.Error())
+				}
+			}
 			return err
 		}
 		if success {
-			successCount++
+			validatorKey, derivationPath, err := t.w.GetValidatorKeyAndPathByPubkey(mpd.Pubkey)
+			if err != nil {
+				t.log.With(log.Minipool(mpd.MinipoolAddress)).Warnf("Couldn't get the validator key for minipool %s to load it into the Validator Client: %s", mpd.MinipoolAddress.Hex(), err.Error())
+				missingKeys = true
+				continue
+			}
+			stakedKeys = append(stakedKeys, keymanager.ValidatorKey{
+				Key:            validatorKey,
+				DerivationPath: derivationPath,
+			})
 		}
 	}
 
-	// Restart validator process if any minipools were staked successfully
-	if successCount > 0 {
-		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+	// Load the keys into the validator process if any minipools were staked successfully
+	if len(stakedKeys) > 0 || missingKeys {
+		if err := t.loadValidatorKeys(stakedKeys, missingKeys); err != nil {
 			return err
 		}
 	}
@@ -164,6 +198,25 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 
 }
 
+// Load the keys of newly staked minipools into the validator process.
+// If some of the keys couldn't be retrieved, it's restarted instead so it picks up every key from disk.
+func (t *stakePrelaunchMinipools) loadValidatorKeys(stakedKeys []keymanager.ValidatorKey, missingKeys bool) error {
+	km := t.km
+	var feeRecipient common.Address
+	if missingKeys {
+		km = nil
+	} else {
+		var err error
+		feeRecipient, err = rpsvc.ReadFeeRecipientFile(t.cfg)
+		if err != nil {
+			// The new keys can't be given the right fee recipient, so let the validator process pick it up from the file when it restarts
+			t.log.Warnf("Couldn't get the fee recipient for the new validator keys: %s", err.Error())
+			km = nil
+		}
+	}
+	return validator.LoadValidatorKeys(t.cfg, t.bc, &t.log, t.d, km, stakedKeys, feeRecipient)
+}
+
 // Get prelaunch minipools
 func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Address, state *state.NetworkState, opts *bind.CallOpts) ([]*rpstate.NativeMinipoolDetails, error) {
 
@@ -190,7 +243,7 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 			if remainingTime < 0 {
 				prelaunchMinipools = append(prelaunchMinipools, mpd)
 			} else {
-				t.log.Printlnf("Minipool %s has %s left until it can be staked.", mpd.MinipoolAddress.Hex(), remainingTime)
+				t.log.With(log.Minipool(mpd.MinipoolAddress)).Printlnf("Minipool %s has %s left until it can be staked.", mpd.MinipoolAddress.Hex(), remainingTime)
 			}
 		}
 	}
@@ -204,7 +257,8 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetails, state *state.NetworkState, callOpts *bind.CallOpts) (bool, error) {
 
 	// Log
-	t.log.Printlnf("Staking minipool %s...", mpd.MinipoolAddress.Hex())
+	logger := t.log.With(log.Minipool(mpd.MinipoolAddress))
+	logger.Printlnf("Staking minipool %s...", mpd.MinipoolAddress.Hex())
 
 	mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, callOpts)
 	if err != nil {
@@ -269,19 +323,19 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	}
 
 	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &logger, maxFee, t.gasLimit) {
 		// Check for the timeout buffer
 		prelaunchTime := time.Unix(mpd.StatusTime.Int64(), 0)
 		isDue, timeUntilDue, err := api.IsTransactionDue(t.rp, prelaunchTime)
 		if err != nil {
-			t.log.Printlnf("Error checking if minipool is due: %s\nStaking now for safety...", err.Error())
+			logger.Warnf("Error checking if minipool is due: %s\nStaking now for safety...", err.Error())
 		}
 		if !isDue {
-			t.log.Printlnf("Time until staking will be forced for safety: %s", timeUntilDue)
+			logger.Printlnf("Time until staking will be forced for safety: %s", timeUntilDue)
 			return false, nil
 		}
 
-		t.log.Println("NOTICE: The minipool has exceeded half of the timeout period, so it will be force-staked at the current gas price.")
+		logger.Println("NOTICE: The minipool has exceeded half of the timeout period, so it will be force-staked at the current gas price.")
 	}
 
 	opts.GasFeeCap = maxFee
@@ -299,13 +353,21 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("stake minipool %s", mpd.MinipoolAddress.Hex()), t.w, &logger)
 	if err != nil {
 		return false, err
 	}
 
 	// Log
-	t.log.Printlnf("Successfully staked minipool %s.", mp.GetAddress().Hex())
+	logger.Printlnf("Successfully staked minipool %s.", mp.GetAddress().Hex())
+	t.notifier.Notify(notifications.Event{
+		Type:     notifications.EventType_MinipoolStaked,
+		Node:     &opts.From,
+		Minipool: &mpd.MinipoolAddress,
+		TxHash:   &hash,
+		Title:    "Minipool staked",
+		Message:  fmt.Sprintf("Minipool %s has been staked and its validator will be activated on the Beacon Chain soon.", mpd.MinipoolAddress.Hex()),
+	})
 
 	// Return
 	return true, nil
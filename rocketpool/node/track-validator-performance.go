@@ -0,0 +1,359 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How far back to go when tracking starts partway through an interval, since catching up on a whole interval would take too long
+	maxPerformanceBackfillEpochs uint64 = 225
+
+	// The most epochs processed in one run, so the task doesn't hold up the others for too long
+	maxPerformanceEpochsPerRun uint64 = 32
+
+	performanceThreadLimit int = 8
+)
+
+// Track validator performance task
+type trackValidatorPerformance struct {
+	log         log.ColorLogger
+	bc          beacon.Client
+	nodeAddress common.Address
+	locker      *collectors.PerformanceLocker
+
+	// Where the performance is saved so the API can use it for rewards projections
+	snapshotPath string
+
+	// The rewards interval being tracked
+	intervalStart      time.Time
+	intervalStartEpoch uint64
+	startEpoch         uint64
+	nextEpoch          uint64
+
+	// The performance of each validator, by index
+	validators map[string]*api.ValidatorPerformance
+
+	// Attestation duties that haven't been included in a block yet, by slot, committee index and position in the committee
+	pendingAttestations map[uint64]map[uint64]map[int]string
+}
+
+// Create track validator performance task
+func newTrackValidatorPerformance(c *cli.Context, logger log.ColorLogger, nodeAddress common.Address, locker *collectors.PerformanceLocker) (*trackValidatorPerformance, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &trackValidatorPerformance{
+		log:          logger,
+		bc:           bc,
+		nodeAddress:  nodeAddress,
+		locker:       locker,
+		snapshotPath: cfg.Smartnode.GetValidatorPerformancePath(nodeAddress, true),
+	}, nil
+
+}
+
+// Track the duties of the node's validators in the finalized epochs since the last run
+func (t *trackValidatorPerformance) run(state *state.NetworkState) error {
+
+	// Get the finalized epoch
+	head, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Errorf("error getting Beacon head: %w", err)
+	}
+
+	// Start over when a new rewards interval begins
+	if !state.NetworkDetails.IntervalStart.Equal(t.intervalStart) {
+		t.reset(state, head.FinalizedEpoch)
+	}
+	if t.nextEpoch > head.FinalizedEpoch {
+		return nil
+	}
+
+	// Get the node's validators
+	minipools := map[string]common.Address{}
+	indices := []string{}
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists {
+			continue
+		}
+		minipools[validator.Index] = mpd.MinipoolAddress
+		indices = append(indices, validator.Index)
+	}
+
+	// Process the finalized epochs
+	lastEpoch := head.FinalizedEpoch
+	if lastEpoch-t.nextEpoch >= maxPerformanceEpochsPerRun {
+		lastEpoch = t.nextEpoch + maxPerformanceEpochsPerRun - 1
+	}
+	if len(indices) > 0 {
+		t.log.Printlnf("Tracking validator performance for epochs %d to %d...", t.nextEpoch, lastEpoch)
+	}
+	for epoch := t.nextEpoch; epoch <= lastEpoch; epoch++ {
+		if len(indices) > 0 {
+			err = t.processEpoch(epoch, state.BeaconConfig.SlotsPerEpoch, minipools, indices)
+			if err != nil {
+				return fmt.Errorf("error processing epoch %d: %w", epoch, err)
+			}
+		}
+		t.nextEpoch = epoch + 1
+	}
+
+	// Publish the results
+	snapshot := &api.ValidatorPerformanceSnapshot{
+		IntervalStartEpoch: t.intervalStartEpoch,
+		StartEpoch:         t.startEpoch,
+		LastEpoch:          lastEpoch,
+		Validators:         make([]api.ValidatorPerformance, 0, len(t.validators)),
+	}
+	for _, performance := range t.validators {
+		snapshot.Validators = append(snapshot.Validators, *performance)
+	}
+	sort.Slice(snapshot.Validators, func(i, j int) bool {
+		return snapshot.Validators[i].MinipoolAddress.Hex() < snapshot.Validators[j].MinipoolAddress.Hex()
+	})
+	t.locker.UpdateSnapshot(snapshot)
+	err = t.saveSnapshot(snapshot)
+	if err != nil {
+		return fmt.Errorf("error saving validator performance: %w", err)
+	}
+
+	return nil
+
+}
+
+// Write the performance to disk so the API can use it when projecting rewards
+func (t *trackValidatorPerformance) saveSnapshot(snapshot *api.ValidatorPerformanceSnapshot) error {
+	bytes, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("error serializing snapshot: %w", err)
+	}
+
+	// Write to a temporary file first so the API never reads a partial file
+	err = os.MkdirAll(filepath.Dir(t.snapshotPath), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating directory for %s: %w", t.snapshotPath, err)
+	}
+	tempPath := t.snapshotPath + ".tmp"
+	err = os.WriteFile(tempPath, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, t.snapshotPath)
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", t.snapshotPath, err)
+	}
+	return nil
+}
+
+// Clear the tracked performance and start tracking the current rewards interval
+func (t *trackValidatorPerformance) reset(state *state.NetworkState, finalizedEpoch uint64) {
+	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
+	intervalStartEpoch := uint64(0)
+	if state.NetworkDetails.IntervalStart.After(genesisTime) {
+		intervalStartEpoch = uint64(state.NetworkDetails.IntervalStart.Sub(genesisTime).Seconds()) / state.BeaconConfig.SecondsPerEpoch
+	}
+	startEpoch := intervalStartEpoch
+	if finalizedEpoch > maxPerformanceBackfillEpochs && finalizedEpoch-maxPerformanceBackfillEpochs > startEpoch {
+		startEpoch = finalizedEpoch - maxPerformanceBackfillEpochs
+	}
+
+	t.intervalStart = state.NetworkDetails.IntervalStart
+	t.intervalStartEpoch = intervalStartEpoch
+	t.startEpoch = startEpoch
+	t.nextEpoch = startEpoch
+	t.validators = map[string]*api.ValidatorPerformance{}
+	t.pendingAttestations = map[uint64]map[uint64]map[int]string{}
+	if startEpoch > intervalStartEpoch {
+		t.log.Printlnf("Tracking validator performance from epoch %d; the current interval started at epoch %d, but earlier epochs are skipped to save time.", startEpoch, intervalStartEpoch)
+	}
+}
+
+// Record the duties of the node's validators in an epoch and check which of them were performed
+func (t *trackValidatorPerformance) processEpoch(epoch uint64, slotsPerEpoch uint64, minipools map[string]common.Address, indices []string) error {
+
+	// Get the duties for the epoch and the blocks proposed in it
+	var wg errgroup.Group
+	wg.SetLimit(performanceThreadLimit)
+	var proposerDuties map[string]uint64
+	var syncPositions map[string][]uint64
+	blocks := make([]*beacon.BeaconBlock, slotsPerEpoch)
+	wg.Go(func() error {
+		committees, err := t.bc.GetCommitteesForEpoch(&epoch)
+		if err != nil {
+			return fmt.Errorf("error getting committees: %w", err)
+		}
+		defer committees.Release()
+		for idx := 0; idx < committees.Count(); idx++ {
+			for position, validator := range committees.Validators(idx) {
+				if _, exists := minipools[validator]; !exists {
+					continue
+				}
+				slot := committees.Slot(idx)
+				committeeIndex := committees.Index(idx)
+				slotDuties, exists := t.pendingAttestations[slot]
+				if !exists {
+					slotDuties = map[uint64]map[int]string{}
+					t.pendingAttestations[slot] = slotDuties
+				}
+				committeeDuties, exists := slotDuties[committeeIndex]
+				if !exists {
+					committeeDuties = map[int]string{}
+					slotDuties[committeeIndex] = committeeDuties
+				}
+				committeeDuties[position] = validator
+			}
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		var err error
+		proposerDuties, err = t.bc.GetValidatorProposerDuties(indices, epoch)
+		if err != nil {
+			return fmt.Errorf("error getting proposer duties: %w", err)
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		var err error
+		syncPositions, err = t.bc.GetValidatorSyncCommitteeIndices(indices, epoch)
+		if err != nil {
+			return fmt.Errorf("error getting sync committee duties: %w", err)
+		}
+		return nil
+	})
+	for i := uint64(0); i < slotsPerEpoch; i++ {
+		i := i
+		slot := epoch*slotsPerEpoch + i
+		wg.Go(func() error {
+			block, found, err := t.bc.GetBeaconBlock(fmt.Sprint(slot))
+			if err != nil {
+				return fmt.Errorf("error getting block for slot %d: %w", slot, err)
+			}
+			if found {
+				blocks[i] = &block
+			}
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return err
+	}
+
+	// Check the blocks for proposals, attestations and sync committee signatures
+	proposals := map[string]uint64{}
+	for _, block := range blocks {
+		if block == nil {
+			continue
+		}
+		if _, exists := minipools[block.ProposerIndex]; exists {
+			proposals[block.ProposerIndex]++
+		}
+
+		for _, attestation := range block.Attestations {
+			if block.Slot-attestation.SlotIndex > slotsPerEpoch {
+				// Ignore attestations delayed by more than 32 slots, like the rewards tree does
+				continue
+			}
+			committeeDuties, exists := t.pendingAttestations[attestation.SlotIndex][attestation.CommitteeIndex]
+			if !exists {
+				continue
+			}
+			for position, validator := range committeeDuties {
+				if attestation.AggregationBits.BitAt(uint64(position)) {
+					performance := t.getPerformance(validator, minipools)
+					performance.AttestationDuties++
+					performance.TotalInclusionDelay += block.Slot - attestation.SlotIndex
+					delete(committeeDuties, position)
+				}
+			}
+		}
+
+		if len(block.SyncCommitteeBits) == 0 {
+			// Blocks before Altair don't have sync committees
+			continue
+		}
+		for validator, positions := range syncPositions {
+			if _, exists := minipools[validator]; !exists {
+				continue
+			}
+			performance := t.getPerformance(validator, minipools)
+			for _, position := range positions {
+				performance.SyncDuties++
+				if !block.SyncCommitteeBits.BitAt(position) {
+					performance.MissedSyncDuties++
+				}
+			}
+		}
+	}
+
+	// Check for missed proposals
+	for validator, count := range proposerDuties {
+		if _, exists := minipools[validator]; !exists || count == 0 {
+			continue
+		}
+		performance := t.getPerformance(validator, minipools)
+		performance.ProposalDuties += count
+		if proposals[validator] < count {
+			performance.MissedProposals += count - proposals[validator]
+		}
+	}
+
+	// Any attestations that haven't been included by now are missed
+	lastSlot := (epoch+1)*slotsPerEpoch - 1
+	for slot, slotDuties := range t.pendingAttestations {
+		if slot+slotsPerEpoch > lastSlot {
+			continue
+		}
+		for _, committeeDuties := range slotDuties {
+			for _, validator := range committeeDuties {
+				performance := t.getPerformance(validator, minipools)
+				performance.AttestationDuties++
+				performance.MissedAttestations++
+			}
+		}
+		delete(t.pendingAttestations, slot)
+	}
+
+	return nil
+
+}
+
+// Get the performance record of a validator, creating it if it doesn't exist yet
+func (t *trackValidatorPerformance) getPerformance(validator string, minipools map[string]common.Address) *api.ValidatorPerformance {
+	performance, exists := t.validators[validator]
+	if !exists {
+		performance = &api.ValidatorPerformance{
+			MinipoolAddress: minipools[validator],
+			ValidatorIndex:  validator,
+		}
+		t.validators[validator] = performance
+	}
+	return performance
+}
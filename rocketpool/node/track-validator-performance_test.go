@@ -0,0 +1,209 @@
+package node
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+const testSlotsPerEpoch uint64 = 4
+
+// A single committee
+type testCommittee struct {
+	slot       uint64
+	index      uint64
+	validators []string
+}
+
+// A list of committees that implements beacon.Committees
+type testCommittees []testCommittee
+
+func (c testCommittees) Index(idx int) uint64 {
+	return c[idx].index
+}
+func (c testCommittees) Slot(idx int) uint64 {
+	return c[idx].slot
+}
+func (c testCommittees) Validators(idx int) []string {
+	return c[idx].validators
+}
+func (c testCommittees) Count() int {
+	return len(c)
+}
+func (c testCommittees) Release() {
+}
+
+// A Beacon client that serves duties and blocks from memory
+type performanceBeaconClient struct {
+	beacon.Client
+	committees map[uint64]testCommittees
+	proposers  map[uint64]map[string]uint64
+	sync       map[uint64]map[string][]uint64
+	blocks     map[uint64]beacon.BeaconBlock
+}
+
+func (bc *performanceBeaconClient) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	return bc.committees[*epoch], nil
+}
+
+func (bc *performanceBeaconClient) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
+	return bc.proposers[epoch], nil
+}
+
+func (bc *performanceBeaconClient) GetValidatorSyncCommitteeIndices(indices []string, epoch uint64) (map[string][]uint64, error) {
+	return bc.sync[epoch], nil
+}
+
+func (bc *performanceBeaconClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	slot, err := strconv.ParseUint(blockId, 10, 64)
+	if err != nil {
+		return beacon.BeaconBlock{}, false, err
+	}
+	block, exists := bc.blocks[slot]
+	return block, exists, nil
+}
+
+// Create aggregation bits for a committee of the provided size with the provided positions set
+func aggregationBits(size uint64, positions ...uint64) bitfield.Bitlist {
+	bits := bitfield.NewBitlist(size)
+	for _, position := range positions {
+		bits.SetBitAt(position, true)
+	}
+	return bits
+}
+
+// Create sync committee bits with the provided positions set
+func syncBits(positions ...uint64) bitfield.Bitvector512 {
+	bits := bitfield.NewBitvector512()
+	for _, position := range positions {
+		bits.SetBitAt(position, true)
+	}
+	return bits
+}
+
+// Create an attestation for the first committee of a slot
+func attestation(slot uint64, bits bitfield.Bitlist) beacon.AttestationInfo {
+	return beacon.AttestationInfo{
+		AggregationBits: bits,
+		SlotIndex:       slot,
+		CommitteeIndex:  0,
+	}
+}
+
+func TestProcessEpoch(t *testing.T) {
+	minipool := common.HexToAddress("0x01")
+
+	// The node's validator is "1", at position 0 of the committee for slot 1; validator "2" belongs to someone else
+	committee := map[uint64]testCommittees{
+		0: {{slot: 1, index: 0, validators: []string{"1", "2"}}},
+	}
+
+	tests := []struct {
+		name       string
+		committees map[uint64]testCommittees
+		proposers  map[uint64]map[string]uint64
+		sync       map[uint64]map[string][]uint64
+		blocks     map[uint64]beacon.BeaconBlock
+		expected   api.ValidatorPerformance
+	}{
+		{
+			name:       "included attestation",
+			committees: committee,
+			blocks: map[uint64]beacon.BeaconBlock{
+				2: {Slot: 2, Attestations: []beacon.AttestationInfo{attestation(1, aggregationBits(2, 0, 1))}},
+			},
+			expected: api.ValidatorPerformance{AttestationDuties: 1, TotalInclusionDelay: 1},
+		},
+		{
+			name:       "attestation included in the next epoch",
+			committees: committee,
+			blocks: map[uint64]beacon.BeaconBlock{
+				5: {Slot: 5, Attestations: []beacon.AttestationInfo{attestation(1, aggregationBits(2, 0))}},
+			},
+			expected: api.ValidatorPerformance{AttestationDuties: 1, TotalInclusionDelay: 4},
+		},
+		{
+			name:       "missed attestation",
+			committees: committee,
+			blocks: map[uint64]beacon.BeaconBlock{
+				// Only the other validator's attestation was included
+				2: {Slot: 2, Attestations: []beacon.AttestationInfo{attestation(1, aggregationBits(2, 1))}},
+			},
+			expected: api.ValidatorPerformance{AttestationDuties: 1, MissedAttestations: 1},
+		},
+		{
+			name:       "attestation included too late",
+			committees: committee,
+			blocks: map[uint64]beacon.BeaconBlock{
+				6: {Slot: 6, Attestations: []beacon.AttestationInfo{attestation(1, aggregationBits(2, 0))}},
+			},
+			expected: api.ValidatorPerformance{AttestationDuties: 1, MissedAttestations: 1},
+		},
+		{
+			name: "proposals",
+			proposers: map[uint64]map[string]uint64{
+				0: {"1": 2, "2": 1},
+			},
+			blocks: map[uint64]beacon.BeaconBlock{
+				0: {Slot: 0, ProposerIndex: "1"},
+				1: {Slot: 1, ProposerIndex: "2"},
+			},
+			expected: api.ValidatorPerformance{ProposalDuties: 2, MissedProposals: 1},
+		},
+		{
+			name: "sync committee",
+			sync: map[uint64]map[string][]uint64{
+				0: {"1": {0, 3}},
+			},
+			blocks: map[uint64]beacon.BeaconBlock{
+				0: {Slot: 0, SyncCommitteeBits: syncBits(0)},
+				1: {Slot: 1, SyncCommitteeBits: syncBits(0, 3)},
+				// Blocks from before Altair don't have sync committees
+				2: {Slot: 2},
+			},
+			expected: api.ValidatorPerformance{SyncDuties: 4, MissedSyncDuties: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			task := &trackValidatorPerformance{
+				bc: &performanceBeaconClient{
+					committees: test.committees,
+					proposers:  test.proposers,
+					sync:       test.sync,
+					blocks:     test.blocks,
+				},
+				validators:          map[string]*api.ValidatorPerformance{},
+				pendingAttestations: map[uint64]map[uint64]map[int]string{},
+			}
+
+			// Process enough epochs for every attestation duty to either be included or expire
+			minipools := map[string]common.Address{"1": minipool}
+			for epoch := uint64(0); epoch < 3; epoch++ {
+				if err := task.processEpoch(epoch, testSlotsPerEpoch, minipools, []string{"1"}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, exists := task.validators["2"]; exists {
+				t.Fatalf("tracked a validator that doesn't belong to the node")
+			}
+			expected := test.expected
+			expected.MinipoolAddress = minipool
+			expected.ValidatorIndex = "1"
+			performance, exists := task.validators["1"]
+			if !exists {
+				t.Fatalf("validator wasn't tracked")
+			}
+			if *performance != expected {
+				t.Fatalf("expected %+v, got %+v", expected, *performance)
+			}
+		})
+	}
+}
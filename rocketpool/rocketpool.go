@@ -132,6 +132,7 @@ func main() {
 
 	// Register commands
 	api.RegisterCommands(app, "api", []string{"a"})
+	api.RegisterServerCommand(app, api.ApiServerCommand, nil)
 	node.RegisterCommands(app, "node", []string{"n"})
 	watchtower.RegisterCommands(app, "watchtower", []string{"w"})
 
@@ -145,7 +146,7 @@ func main() {
 	// Run application
 	if err := app.Run(os.Args); err != nil {
 		if commandName == "api" {
-			apiutils.PrintErrorResponse(err)
+			apiutils.PrintErrorResponse(nil, err)
 		} else {
 			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
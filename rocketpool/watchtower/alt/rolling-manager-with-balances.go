@@ -124,7 +124,7 @@ func (r *RollingRecordManager_MergedDuties) ProcessNewHeadState(state *state.Net
 		}
 
 		// Get the state of the network
-		state, err = r.mgr.GetStateForSlot(latestBlock.Slot)
+		state, err = r.mgr.GetFullStateForSlot(latestBlock.Slot)
 		if err != nil {
 			return fmt.Errorf("error getting network state: %w", err)
 		}
@@ -228,7 +228,7 @@ func (r *RollingRecordManager_MergedDuties) ProcessNewHeadState(state *state.Net
 			finalTarget := latestFinalizedBlock.Slot
 			finalizedState := state
 			if finalTarget != state.BeaconSlotNumber {
-				finalizedState, err = r.mgr.GetStateForSlot(finalTarget)
+				finalizedState, err = r.mgr.GetFullStateForSlot(finalTarget)
 				if err != nil {
 					r.handleError(fmt.Errorf("error getting state for latest finalized slot (%d): %w", finalTarget, err))
 					return
@@ -356,7 +356,7 @@ func (r *RollingRecordManager_MergedDuties) ProcessNewHeadState(state *state.Net
 				}
 
 				// Generate the network balances state
-				state, err := r.mgr.GetStateForSlot(networkBalanceSlot)
+				state, err := r.mgr.GetFullStateForSlot(networkBalanceSlot)
 				if err != nil {
 					r.handleError(fmt.Errorf("error getting state for network balances slot: %w", err))
 					return
@@ -373,7 +373,7 @@ func (r *RollingRecordManager_MergedDuties) ProcessNewHeadState(state *state.Net
 
 				// Generate the rewards state
 				if networkBalanceSlot != rewardsSlot {
-					state, err = r.mgr.GetStateForSlot(networkBalanceSlot)
+					state, err = r.mgr.GetFullStateForSlot(networkBalanceSlot)
 					if err != nil {
 						r.handleError(fmt.Errorf("error getting state for network balances slot: %w", err))
 						return
@@ -398,7 +398,7 @@ func (r *RollingRecordManager_MergedDuties) ProcessNewHeadState(state *state.Net
 				}
 
 				// Generate the network balances state
-				state, err := r.mgr.GetStateForSlot(networkBalanceSlot)
+				state, err := r.mgr.GetFullStateForSlot(networkBalanceSlot)
 				if err != nil {
 					r.handleError(fmt.Errorf("error getting state for network balances slot: %w", err))
 					return
@@ -414,7 +414,7 @@ func (r *RollingRecordManager_MergedDuties) ProcessNewHeadState(state *state.Net
 
 			} else { // Report rewards only
 				// Generate the rewards state
-				state, err := r.mgr.GetStateForSlot(rewardsSlot)
+				state, err := r.mgr.GetFullStateForSlot(rewardsSlot)
 				if err != nil {
 					r.handleError(fmt.Errorf("error getting state for network balances slot: %w", err))
 					return
@@ -442,8 +442,8 @@ func (r *RollingRecordManager_MergedDuties) ProcessNewHeadState(state *state.Net
 
 // Print an error and unlock the mutex
 func (r *RollingRecordManager_MergedDuties) handleError(err error) {
-	r.errLog.Printlnf("%s %s", r.logPrefix, err.Error())
-	r.errLog.Println("*** Rolling Record processing failed. ***")
+	r.errLog.Errorf("%s %s", r.logPrefix, err.Error())
+	r.errLog.Errorf("*** Rolling Record processing failed. ***")
 	r.lock.Lock()
 	r.isRunning = false
 	r.lock.Unlock()
@@ -480,7 +480,7 @@ func (r *RollingRecordManager_MergedDuties) runNetworkBalancesReport(state *stat
 		path := filepath.Join(r.cfg.Smartnode.GetRecordsPath(), filename)
 		file, err := os.Create(path)
 		if err != nil {
-			r.errLog.Printlnf("%s WARNING: couldn't create marker file indicating network balance update: %s", r.logPrefix, err.Error())
+			r.errLog.Warnf("%s couldn't create marker file indicating network balance update: %s", r.logPrefix, err.Error())
 		} else {
 			file.Close()
 		}
@@ -529,7 +529,7 @@ func (r *RollingRecordManager_MergedDuties) runRewardsIntervalReport(state *stat
 			path := filepath.Join(r.cfg.Smartnode.GetRecordsPath(), filename)
 			file, err := os.Create(path)
 			if err != nil {
-				r.errLog.Printlnf("%s WARNING: couldn't create marker file indicating rewards update: %s", r.logPrefix, err.Error())
+				r.errLog.Warnf("%s couldn't create marker file indicating rewards update: %s", r.logPrefix, err.Error())
 			} else {
 				file.Close()
 			}
@@ -580,7 +580,7 @@ func (r *RollingRecordManager_MergedDuties) hasSubmittedNetworkBalances(blockNum
 			return false, nil
 		}
 		if err != nil {
-			r.errLog.Printlnf("%s WARNING: couldn't check if network balances for block %d have already been processed: %s", r.logPrefix, blockNumber, err.Error())
+			r.errLog.Warnf("%s couldn't check if network balances for block %d have already been processed: %s", r.logPrefix, blockNumber, err.Error())
 			return false, nil
 		}
 		return true, nil
@@ -639,7 +639,7 @@ func (r *RollingRecordManager_MergedDuties) hasSubmittedRewards(index uint64, is
 			return false, nil
 		}
 		if err != nil {
-			r.errLog.Printlnf("%s WARNING: couldn't check if rewards for interval %d have already been processed: %s", r.logPrefix, index, err.Error())
+			r.errLog.Warnf("%s couldn't check if rewards for interval %d have already been processed: %s", r.logPrefix, index, err.Error())
 			return false, nil
 		}
 		return true, nil
@@ -895,7 +895,7 @@ func (r *RollingRecordManager_MergedDuties) LoadBestRecordFromDisk(startSlot uin
 		fullFilename := filepath.Join(recordsPath, filename)
 		record, err := r.loadRecordFromFile(fullFilename, checksum)
 		if err != nil {
-			r.log.Printlnf("%s WARNING: error loading record from file [%s]: %s... attempting previous file", r.logPrefix, fullFilename, err.Error())
+			r.log.Warnf("%s error loading record from file [%s]: %s... attempting previous file", r.logPrefix, fullFilename, err.Error())
 			continue
 		}
 
@@ -18,6 +18,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -34,6 +35,7 @@ type cancelBondReductions struct {
 	errLog           log.ColorLogger
 	cfg              *config.RocketPoolConfig
 	w                *wallet.Wallet
+	journal          *transactions.Journal
 	rp               *rocketpool.RocketPool
 	ec               rocketpool.ExecutionClient
 	coll             *collectors.BondReductionCollector
@@ -50,6 +52,10 @@ func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -71,6 +77,7 @@ func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger
 		errLog:           errorLogger,
 		cfg:              cfg,
 		w:                w,
+		journal:          journal,
 		rp:               rp,
 		ec:               ec,
 		coll:             coll,
@@ -252,20 +259,20 @@ func (t *cancelBondReductions) cancelBondReduction(address common.Address, reaso
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("cancel bond reduction of minipool %s", address.Hex()), t.w, &t.log)
 	if err != nil {
 		t.printMessage(fmt.Sprintf("error waiting for cancel transaction: %s", err.Error()))
 		return
 	}
 
 	// Log
-	t.log.Printlnf("Successfully voted to cancel the bond reduction of minipool %s.", address.Hex())
+	t.log.With(log.Minipool(address)).Printlnf("Successfully voted to cancel the bond reduction of minipool %s.", address.Hex())
 
 }
 
 func (t *cancelBondReductions) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Bond reduction cancel check failed. ***")
+	t.errLog.Error(err)
+	t.errLog.Errorf("*** Bond reduction cancel check failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
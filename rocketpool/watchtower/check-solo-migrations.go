@@ -18,6 +18,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -37,6 +38,7 @@ type checkSoloMigrations struct {
 	errLog           log.ColorLogger
 	cfg              *config.RocketPoolConfig
 	w                *wallet.Wallet
+	journal          *transactions.Journal
 	rp               *rocketpool.RocketPool
 	ec               rocketpool.ExecutionClient
 	bc               beacon.Client
@@ -54,6 +56,10 @@ func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -79,6 +85,7 @@ func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger
 		errLog:           errorLogger,
 		cfg:              cfg,
 		w:                w,
+		journal:          journal,
 		rp:               rp,
 		ec:               ec,
 		bc:               bc,
@@ -304,20 +311,20 @@ func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("scrub vacant minipool %s", address.Hex()), t.w, &t.log)
 	if err != nil {
 		t.printMessage(fmt.Sprintf("error waiting for scrub transaction: %s", err.Error()))
 		return
 	}
 
 	// Log
-	t.log.Printlnf("Successfully voted to scrub minipool %s.", mp.GetAddress().Hex())
+	t.log.With(log.Minipool(mp.GetAddress())).Printlnf("Successfully voted to scrub minipool %s.", mp.GetAddress().Hex())
 
 }
 
 func (t *checkSoloMigrations) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Solo migration check failed. ***")
+	t.errLog.Error(err)
+	t.errLog.Errorf("*** Solo migration check failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
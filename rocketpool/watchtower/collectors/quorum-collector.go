@@ -0,0 +1,77 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services"
+)
+
+// Represents the collector for the Execution client quorum read metrics
+type QuorumCollector struct {
+
+	// The number of reads that were cross-checked across the Execution clients
+	checksDesc *prometheus.Desc
+
+	// The number of reads where the Execution clients disagreed
+	mismatchesDesc *prometheus.Desc
+
+	// The number of reads that couldn't be cross-checked because too few Execution clients responded
+	unavailableDesc *prometheus.Desc
+
+	// The time of the latest mismatch
+	lastMismatchTimeDesc *prometheus.Desc
+
+	// The EC manager
+	ec *services.ExecutionClientManager
+}
+
+// Create a new QuorumCollector instance
+func NewQuorumCollector(ec *services.ExecutionClientManager) *QuorumCollector {
+	subsystem := "quorum"
+	labels := []string{"method"}
+	return &QuorumCollector{
+		checksDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "checks_total"),
+			"The number of reads that were cross-checked across the Execution clients",
+			labels, nil,
+		),
+		mismatchesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "mismatches_total"),
+			"The number of reads where the Execution clients disagreed",
+			labels, nil,
+		),
+		unavailableDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "unavailable_total"),
+			"The number of reads that couldn't be cross-checked because too few Execution clients responded",
+			labels, nil,
+		),
+		lastMismatchTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_mismatch_time"),
+			"The time of the latest mismatch",
+			labels, nil,
+		),
+		ec: ec,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *QuorumCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.checksDesc
+	channel <- collector.mismatchesDesc
+	channel <- collector.unavailableDesc
+	channel <- collector.lastMismatchTimeDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *QuorumCollector) Collect(channel chan<- prometheus.Metric) {
+	for _, stats := range collector.ec.GetQuorumStats() {
+		channel <- prometheus.MustNewConstMetric(
+			collector.checksDesc, prometheus.CounterValue, float64(stats.Checks), stats.Method)
+		channel <- prometheus.MustNewConstMetric(
+			collector.mismatchesDesc, prometheus.CounterValue, float64(stats.Mismatches), stats.Method)
+		channel <- prometheus.MustNewConstMetric(
+			collector.unavailableDesc, prometheus.CounterValue, float64(stats.Unavailable), stats.Method)
+
+		lastMismatchTime := float64(0)
+		if !stats.LastMismatchTime.IsZero() {
+			lastMismatchTime = float64(stats.LastMismatchTime.Unix())
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastMismatchTimeDesc, prometheus.GaugeValue, lastMismatchTime, stats.Method)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -26,12 +27,13 @@ const MinipoolStatusBatchSize = 20
 
 // Dissolve timed out minipools task
 type dissolveTimedOutMinipools struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	ec  rocketpool.ExecutionClient
-	rp  *rocketpool.RocketPool
+	c       *cli.Context
+	log     log.ColorLogger
+	cfg     *config.RocketPoolConfig
+	w       *wallet.Wallet
+	journal *transactions.Journal
+	ec      rocketpool.ExecutionClient
+	rp      *rocketpool.RocketPool
 }
 
 // Create dissolve timed out minipools task
@@ -42,6 +44,10 @@ func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger) (*diss
 	if err != nil {
 		return nil, err
 	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -57,12 +63,13 @@ func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger) (*diss
 
 	// Return task
 	return &dissolveTimedOutMinipools{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		ec:  ec,
-		rp:  rp,
+		c:       c,
+		log:     logger,
+		cfg:     cfg,
+		w:       w,
+		journal: journal,
+		ec:      ec,
+		rp:      rp,
 	}, nil
 
 }
@@ -92,7 +99,7 @@ func (t *dissolveTimedOutMinipools) run(state *state.NetworkState) error {
 	// Dissolve minipools
 	for _, mp := range minipools {
 		if err := t.dissolveMinipool(mp); err != nil {
-			t.log.Println(fmt.Errorf("Could not dissolve minipool %s: %w", mp.GetAddress().Hex(), err))
+			t.log.With(log.Minipool(mp.GetAddress())).Error(fmt.Errorf("Could not dissolve minipool %s: %w", mp.GetAddress().Hex(), err))
 		}
 	}
 
@@ -136,7 +143,7 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools(state *state.NetworkSta
 func (t *dissolveTimedOutMinipools) dissolveMinipool(mp minipool.Minipool) error {
 
 	// Log
-	t.log.Printlnf("Dissolving minipool %s...", mp.GetAddress().Hex())
+	t.log.With(log.Minipool(mp.GetAddress())).Printlnf("Dissolving minipool %s...", mp.GetAddress().Hex())
 
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
@@ -168,13 +175,13 @@ func (t *dissolveTimedOutMinipools) dissolveMinipool(mp minipool.Minipool) error
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("dissolve minipool %s", mp.GetAddress().Hex()), t.w, &t.log)
 	if err != nil {
 		return err
 	}
 
 	// Log
-	t.log.Printlnf("Successfully dissolved minipool %s.", mp.GetAddress().Hex())
+	t.log.With(log.Minipool(mp.GetAddress())).Printlnf("Successfully dissolved minipool %s.", mp.GetAddress().Hex())
 
 	// Return
 	return nil
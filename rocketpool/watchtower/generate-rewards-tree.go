@@ -21,8 +21,10 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/replay"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
@@ -115,8 +117,15 @@ func (t *generateRewardsTree) run() error {
 				return fmt.Errorf("Error parsing index from [%s]: %w", filename, err)
 			}
 
-			// Delete the file
+			// Check if the client responses should be recorded
 			path := filepath.Join(requestDir, filename)
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("Error reading request file [%s]: %w", path, err)
+			}
+			recordFixture := strings.TrimSpace(string(contents)) == config.RecordRewardsTreeFixtureRequest
+
+			// Delete the file
 			err = os.Remove(path)
 			if err != nil {
 				return fmt.Errorf("Error removing request file [%s]: %w", path, err)
@@ -126,7 +135,7 @@ func (t *generateRewardsTree) run() error {
 			t.lock.Lock()
 			t.isRunning = true
 			t.lock.Unlock()
-			go t.generateRewardsTree(index)
+			go t.generateRewardsTree(index, recordFixture)
 
 			// Return after the first request, do others at other intervals
 			return nil
@@ -136,14 +145,39 @@ func (t *generateRewardsTree) run() error {
 	return nil
 }
 
-func (t *generateRewardsTree) generateRewardsTree(index uint64) {
+func (t *generateRewardsTree) generateRewardsTree(index uint64, recordFixture bool) {
 
 	// Begin generation of the tree
 	generationPrefix := fmt.Sprintf("[Interval %d Tree]", index)
 	t.log.Printlnf("%s Starting generation of Merkle rewards tree for interval %d.", generationPrefix, index)
 
+	// Wrap the clients so their responses get recorded if requested
+	ec := t.ec
+	bc := t.bc
+	client := t.rp
+	m := t.m
+	var recording *replay.Recording
+	if recordFixture {
+		t.log.Printlnf("%s Client responses will be recorded to %s.", generationPrefix, t.cfg.Smartnode.GetRewardsTreeFixturePath(index, true))
+		recording = replay.NewRecording()
+		ec = replay.NewRecordingExecutionClient(t.ec, recording)
+		bc = replay.NewRecordingBeaconClient(t.bc, recording)
+		var err error
+		client, err = rocketpool.NewRocketPool(ec, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error creating recording Rocket Pool client: %w", generationPrefix, err))
+			return
+		}
+		// A cached state would skip the client calls, leaving them out of the fixture
+		m, err = state.NewUncachedNetworkStateManager(client, t.cfg, ec, bc, &t.log)
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error creating recording network state manager: %w", generationPrefix, err))
+			return
+		}
+	}
+
 	// Find the event for this interval
-	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(t.rp, t.cfg, index, nil)
+	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(client, t.cfg, index, nil)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error getting event for interval %d: %w", generationPrefix, index, err))
 		return
@@ -151,21 +185,20 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 	t.log.Printlnf("%s Found snapshot event: Beacon block %s, execution block %s", generationPrefix, rewardsEvent.ConsensusBlock.String(), rewardsEvent.ExecutionBlock.String())
 
 	// Get the EL block
-	elBlockHeader, err := t.ec.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	elBlockHeader, err := ec.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error getting execution block: %w", generationPrefix, err))
 		return
 	}
 
 	// Try getting the rETH address as a canary to see if the block is available
-	client := t.rp
 	opts := &bind.CallOpts{
 		BlockNumber: elBlockHeader.Number,
 	}
 	address, err := client.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
 	if err != nil {
 		errMessage := err.Error()
-		t.log.Printlnf("%s Error getting state for block %d: %s", generationPrefix, elBlockHeader.Number.Uint64(), errMessage)
+		t.log.Errorf("%s Error getting state for block %d: %s", generationPrefix, elBlockHeader.Number.Uint64(), errMessage)
 		if strings.Contains(errMessage, "missing trie node") || // Geth
 			strings.Contains(errMessage, "No state available for block") || // Nethermind
 			strings.Contains(errMessage, "Internal error") { // Besu
@@ -174,12 +207,16 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 			archiveEcUrl := t.cfg.Smartnode.ArchiveECUrl.Value.(string)
 			if archiveEcUrl != "" {
 				t.log.Printlnf("%s Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", generationPrefix, elBlockHeader.Number.Uint64(), archiveEcUrl)
-				ec, err := ethclient.Dial(archiveEcUrl)
+				var archiveEc rocketpool.ExecutionClient
+				archiveEc, err = ethclient.Dial(archiveEcUrl)
 				if err != nil {
 					t.handleError(fmt.Errorf("Error connecting to archive EC: %w", err))
 					return
 				}
-				client, err = rocketpool.NewRocketPool(ec, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
+				if recording != nil {
+					archiveEc = replay.NewRecordingExecutionClient(archiveEc, recording)
+				}
+				client, err = rocketpool.NewRocketPool(archiveEc, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
 				if err != nil {
 					t.handleError(fmt.Errorf("%s Error creating Rocket Pool client connected to archive EC: %w", err))
 					return
@@ -207,22 +244,22 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 	}
 
 	// Get the state for the target slot
-	state, err := t.m.GetStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+	state, err := m.GetFullStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
 	if err != nil {
 		t.handleError(fmt.Errorf("%s error getting state for beacon slot %d: %w", generationPrefix, rewardsEvent.ConsensusBlock.Uint64(), err))
 		return
 	}
 
 	// Generate the tree
-	t.generateRewardsTreeImpl(client, index, generationPrefix, rewardsEvent, elBlockHeader, state)
+	t.generateRewardsTreeImpl(client, bc, index, generationPrefix, rewardsEvent, elBlockHeader, state, recording)
 }
 
 // Implementation for rewards tree generation using a viable EC
-func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState) {
+func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, bc beacon.Client, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState, recording *replay.Recording) {
 
 	// Generate the rewards file
 	start := time.Now()
-	treegen, err := rprewards.NewTreeGenerator(&t.log, generationPrefix, rp, t.cfg, t.bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), state, nil)
+	treegen, err := rprewards.NewTreeGenerator(&t.log, generationPrefix, rp, t.cfg, bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), state, nil)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error creating Merkle tree generator: %w", generationPrefix, err))
 		return
@@ -233,14 +270,14 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 		return
 	}
 	for address, network := range rewardsFile.InvalidNetworkNodes {
-		t.log.Printlnf("%s WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", generationPrefix, address.Hex(), network)
+		t.log.Warnf("%s Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", generationPrefix, address.Hex(), network)
 	}
 	t.log.Printlnf("%s Finished in %s", generationPrefix, time.Since(start).String())
 
 	// Validate the Merkle root
 	root := common.BytesToHash(rewardsFile.MerkleTree.Root())
 	if root != rewardsEvent.MerkleRoot {
-		t.log.Printlnf("%s WARNING: your Merkle tree had a root of %s, but the canonical Merkle tree's root was %s. This file will not be usable for claiming rewards.", generationPrefix, root.Hex(), rewardsEvent.MerkleRoot.Hex())
+		t.log.Warnf("%s your Merkle tree had a root of %s, but the canonical Merkle tree's root was %s. This file will not be usable for claiming rewards.", generationPrefix, root.Hex(), rewardsEvent.MerkleRoot.Hex())
 	} else {
 		t.log.Printlnf("%s Your Merkle tree's root of %s matches the canonical root! You will be able to use this file for claiming rewards.", generationPrefix, rewardsFile.MerkleRoot)
 	}
@@ -273,6 +310,18 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 		return
 	}
 
+	// Save the recorded client responses as a replayable fixture
+	if recording != nil {
+		fixturePath := t.cfg.Smartnode.GetRewardsTreeFixturePath(index, true)
+		beaconCount, executionCount := recording.Count()
+		t.log.Printlnf("%s Saving %d Beacon and %d Execution responses to fixture %s...", generationPrefix, beaconCount, executionCount, fixturePath)
+		err = rprewards.SaveTreeGenerationFixture(fixturePath, t.cfg.Smartnode.Network.Value.(cfgtypes.Network), recording, rewardsFile, rewardsEvent.MerkleRoot)
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error saving fixture to %s: %w", generationPrefix, fixturePath, err))
+			return
+		}
+	}
+
 	t.log.Printlnf("%s Merkle tree generation complete!", generationPrefix)
 	t.lock.Lock()
 	t.isRunning = false
@@ -281,8 +330,8 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 }
 
 func (t *generateRewardsTree) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Rewards tree generation failed. ***")
+	t.errLog.Error(err)
+	t.errLog.Errorf("*** Rewards tree generation failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
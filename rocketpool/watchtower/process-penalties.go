@@ -23,6 +23,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
 
@@ -42,6 +43,7 @@ type processPenalties struct {
 	errLog         log.ColorLogger
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
+	journal        *transactions.Journal
 	rp             *rocketpool.RocketPool
 	ec             rocketpool.ExecutionClient
 	bc             beacon.Client
@@ -66,6 +68,10 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 	if err != nil {
 		return nil, err
 	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -96,7 +102,7 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warnf("priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
@@ -116,6 +122,7 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 		errLog:         errorLogger,
 		cfg:            cfg,
 		w:              w,
+		journal:        journal,
 		ec:             ec,
 		bc:             bc,
 		rp:             rp,
@@ -331,8 +338,8 @@ func (t *processPenalties) run() error {
 }
 
 func (t *processPenalties) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Illegal fee recipient check failed. ***")
+	t.errLog.Error(err)
+	t.errLog.Errorf("*** Illegal fee recipient check failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
@@ -399,7 +406,7 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 	}
 	isOptedIn, err := node.GetSmoothingPoolRegistrationState(t.rp, nodeAddress, &opts)
 	if err != nil {
-		t.log.Printlnf("*** WARNING: Couldn't check if node %s was opted into the smoothing pool for slot %d (execution block %d), skipping check... error: %w\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
+		t.log.Warnf("Couldn't check if node %s was opted into the smoothing pool for slot %d (execution block %d), skipping check... error: %s", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
 		isOptedIn = false
 	}
 
@@ -422,7 +429,7 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 		// Get the opt out time
 		optOutTime, err := node.GetSmoothingPoolRegistrationChanged(t.rp, nodeAddress, &opts)
 		if err != nil {
-			t.log.Printlnf("*** WARNING: Couldn't check when node %s opted out of the smoothing pool for slot %d (execution block %d), skipping check... error: %w\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
+			t.log.Warnf("Couldn't check when node %s opted out of the smoothing pool for slot %d (execution block %d), skipping check... error: %s", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
 		} else if optOutTime != time.Unix(0, 0) {
 			// Get the time of the epoch before this one
 			blockEpoch := block.Slot / t.beaconConfig.SlotsPerEpoch
@@ -479,7 +486,7 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 		return fmt.Errorf("Could not check if penality has already been applied for block %d, minipool %s: %w", block.Slot, minipoolAddress.Hex(), err)
 	}
 	if penaltyExecuted {
-		t.log.Printlnf("NOTE: Minipool %s was already penalized on block %d, skipping...", minipoolAddress.Hex(), block.Slot)
+		t.log.With(log.Minipool(minipoolAddress)).Printlnf("NOTE: Minipool %s was already penalized on block %d, skipping...", minipoolAddress.Hex(), block.Slot)
 		return nil
 	}
 
@@ -525,7 +532,7 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("submit penalty for minipool %s", minipoolAddress.Hex()), t.w, &t.log)
 	if err != nil {
 		return err
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -19,12 +20,13 @@ import (
 
 // Respond to challenges task
 type respondChallenges struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
-	m   *state.NetworkStateManager
+	c       *cli.Context
+	log     log.ColorLogger
+	cfg     *config.RocketPoolConfig
+	w       *wallet.Wallet
+	journal *transactions.Journal
+	rp      *rocketpool.RocketPool
+	m       *state.NetworkStateManager
 }
 
 // Create respond to challenges task
@@ -35,6 +37,10 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.Netwo
 	if err != nil {
 		return nil, err
 	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -46,12 +52,13 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.Netwo
 
 	// Return task
 	return &respondChallenges{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		rp:  rp,
-		m:   m,
+		c:       c,
+		log:     logger,
+		cfg:     cfg,
+		w:       w,
+		journal: journal,
+		rp:      rp,
+		m:       m,
 	}, nil
 
 }
@@ -115,7 +122,7 @@ func (t *respondChallenges) run() error {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, "respond to challenge", t.w, &t.log)
 	if err != nil {
 		return err
 	}
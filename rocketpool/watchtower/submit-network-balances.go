@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -42,6 +43,7 @@ type submitNetworkBalances struct {
 	errLog    *log.ColorLogger
 	cfg       *config.RocketPoolConfig
 	w         *wallet.Wallet
+	journal   *transactions.Journal
 	ec        rocketpool.ExecutionClient
 	rp        *rocketpool.RocketPool
 	bc        beacon.Client
@@ -74,6 +76,10 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 	if err != nil {
 		return nil, err
 	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -99,6 +105,7 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 		errLog:    &errorLogger,
 		cfg:       cfg,
 		w:         w,
+		journal:   journal,
 		ec:        ec,
 		rp:        rp,
 		bc:        bc,
@@ -248,8 +255,8 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 }
 
 func (t *submitNetworkBalances) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Balance report failed. ***")
+	t.errLog.Error(err)
+	t.errLog.Errorf("*** Balance report failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
@@ -543,7 +550,7 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("submit network balances for block %d", balances.Block), t.w, t.log)
 	if err != nil {
 		return fmt.Errorf("error waiting for transaction: %w", err)
 	}
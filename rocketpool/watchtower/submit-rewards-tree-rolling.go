@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -41,6 +42,7 @@ type submitRewardsTree_Rolling struct {
 	errLog      log.ColorLogger
 	cfg         *config.RocketPoolConfig
 	w           *wallet.Wallet
+	journal     *transactions.Journal
 	ec          rocketpool.ExecutionClient
 	rp          *rocketpool.RocketPool
 	bc          beacon.Client
@@ -61,6 +63,10 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 	if err != nil {
 		return nil, err
 	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -107,7 +113,7 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 	}
 
 	// Get the start slot of the current interval
-	startSlot, err := getStartSlotForInterval(event, bc, beaconCfg)
+	startSlot, err := rprewards.GetStartSlotForInterval(event, bc, beaconCfg)
 	if err != nil {
 		return nil, fmt.Errorf("error getting start slot for interval %d: %w", currentIndex, err)
 	}
@@ -122,6 +128,7 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 		cfg:         cfg,
 		ec:          ec,
 		w:           w,
+		journal:     journal,
 		rp:          rp,
 		bc:          bc,
 		stateMgr:    stateMgr,
@@ -154,37 +161,6 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 
 }
 
-// Gets the start slot for the given interval
-func getStartSlotForInterval(previousIntervalEvent rewards.RewardsEvent, bc beacon.Client, beaconConfig beacon.Eth2Config) (uint64, error) {
-	// Sanity check to confirm the BN can access the block from the previous interval
-	_, exists, err := bc.GetBeaconBlock(previousIntervalEvent.ConsensusBlock.String())
-	if err != nil {
-		return 0, fmt.Errorf("error verifying block from previous interval: %w", err)
-	}
-	if !exists {
-		return 0, fmt.Errorf("couldn't retrieve CL block from previous interval (slot %d); this likely means you checkpoint sync'd your Beacon Node and it has not backfilled to the previous interval yet so it cannot be used for tree generation", previousIntervalEvent.ConsensusBlock.Uint64())
-	}
-
-	previousEpoch := previousIntervalEvent.ConsensusBlock.Uint64() / beaconConfig.SlotsPerEpoch
-	nextEpoch := previousEpoch + 1
-	consensusStartBlock := nextEpoch * beaconConfig.SlotsPerEpoch
-
-	// Get the first block that isn't missing
-	for {
-		_, exists, err := bc.GetBeaconBlock(fmt.Sprint(consensusStartBlock))
-		if err != nil {
-			return 0, fmt.Errorf("error getting EL data for BC slot %d: %w", consensusStartBlock, err)
-		}
-		if !exists {
-			consensusStartBlock++
-		} else {
-			break
-		}
-	}
-
-	return consensusStartBlock, nil
-}
-
 // Update the rolling record and run the submission process if applicable
 func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 	// Wait for clients to sync
@@ -225,7 +201,7 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 			}
 
 			// Get the state of the network
-			headState, err = t.stateMgr.GetStateForSlot(latestBlock.Slot)
+			headState, err = t.stateMgr.GetFullStateForSlot(latestBlock.Slot)
 			if err != nil {
 				t.handleError(fmt.Errorf("error getting network state: %w", err))
 				return
@@ -319,7 +295,7 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 				t.handleError(fmt.Errorf("error creating state manager for rewards slot: %w", err))
 				return
 			}
-			state, err := stateMgr.GetStateForSlot(rewardsSlot)
+			state, err := stateMgr.GetFullStateForSlot(rewardsSlot)
 			if err != nil {
 				t.handleError(fmt.Errorf("error getting state for rewards slot: %w", err))
 				return
@@ -351,8 +327,8 @@ func (t *submitRewardsTree_Rolling) printMessage(message string) {
 
 // Print an error and unlock the mutex
 func (t *submitRewardsTree_Rolling) handleError(err error) {
-	t.errLog.Printlnf("%s %s", t.logPrefix, err.Error())
-	t.errLog.Println("*** Rolling Record processing failed. ***")
+	t.errLog.Errorf("%s %s", t.logPrefix, err.Error())
+	t.errLog.Errorf("*** Rolling Record processing failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
@@ -397,7 +373,7 @@ func (t *submitRewardsTree_Rolling) getTrueRewardsIntervalSubmissionSlot(targetS
 
 		// If the block was missing, try the previous one
 		if !exists {
-			t.log.Printlnf("%s Slot %d was missing, trying the previous one...", t.logPrefix, targetSlot)
+			t.log.With(log.Slot(targetSlot)).Printlnf("%s Slot %d was missing, trying the previous one...", t.logPrefix, targetSlot)
 			targetSlot--
 		} else {
 			// Ok, we have the first proposed finalized block - this is the one to use for the snapshot!
@@ -414,7 +390,7 @@ func (t *submitRewardsTree_Rolling) isExistingFileValid(rewardsTreePath string,
 		return nil, nil, false, true
 	}
 	if err != nil {
-		t.log.Printlnf("%s WARNING: failed to check if [%s] exists: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
+		t.log.Warnf("%s failed to check if [%s] exists: %s; regenerating file...", t.logPrefix, rewardsTreePath, err.Error())
 		return nil, nil, false, true
 	}
 
@@ -423,14 +399,14 @@ func (t *submitRewardsTree_Rolling) isExistingFileValid(rewardsTreePath string,
 	filename := filepath.Base(rewardsTreePath)
 	fileBytes, err := os.ReadFile(rewardsTreePath)
 	if err != nil {
-		t.log.Printlnf("%s WARNING: failed to read %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
+		t.log.Warnf("%s failed to read %s: %s; regenerating file...", t.logPrefix, rewardsTreePath, err.Error())
 		return nil, nil, false, true
 	}
 
 	// Unmarshal it
 	err = json.Unmarshal(fileBytes, &proofWrapper)
 	if err != nil {
-		t.log.Printlnf("%s WARNING: failed to deserialize %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
+		t.log.Warnf("%s failed to deserialize %s: %s; regenerating file...", t.logPrefix, rewardsTreePath, err.Error())
 		return nil, nil, false, true
 	}
 
@@ -438,7 +414,7 @@ func (t *submitRewardsTree_Rolling) isExistingFileValid(rewardsTreePath string,
 		// Get the CID for it
 		cid, err := rprewards.GetCidForRewardsFile(&proofWrapper, filename)
 		if err != nil {
-			t.log.Printlnf("%s WARNING: failed to get CID for %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
+			t.log.Warnf("%s failed to get CID for %s: %s; regenerating file...", t.logPrefix, rewardsTreePath, err.Error())
 			return nil, nil, false, true
 		}
 
@@ -459,7 +435,7 @@ func (t *submitRewardsTree_Rolling) isExistingFileValid(rewardsTreePath string,
 
 		hasSubmitted, err := rewards.GetTrustedNodeSubmittedSpecificRewards(t.rp, nodeAddress, submission, nil)
 		if err != nil {
-			t.log.Printlnf("%s WARNING: could not check if node has previously submitted file %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
+			t.log.Warnf("%s could not check if node has previously submitted file %s: %s; regenerating file...", t.logPrefix, rewardsTreePath, err.Error())
 			return nil, nil, false, true
 		}
 		if !hasSubmitted {
@@ -547,7 +523,7 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 
 	// Log
 	if intervalsPassed > 1 {
-		t.log.Printlnf("WARNING: %d intervals have passed since the last rewards checkpoint was submitted! Rolling them into one...", intervalsPassed)
+		t.log.Warnf("%d intervals have passed since the last rewards checkpoint was submitted! Rolling them into one...", intervalsPassed)
 	}
 	t.log.Printlnf("Rewards checkpoint has passed, starting Merkle tree generation for interval %d in the background.\n%s Snapshot Beacon block = %d, EL block = %d, running from %s to %s", currentIndex, t.logPrefix, snapshotBeaconBlock, elBlockIndex, startTime, endTime)
 
@@ -710,7 +686,7 @@ func (t *submitRewardsTree_Rolling) submitRewardsSnapshot(index *big.Int, consen
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("submit rewards tree for interval %s", index.String()), t.w, &t.log)
 	if err != nil {
 		return err
 	}
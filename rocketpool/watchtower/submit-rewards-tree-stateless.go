@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -42,6 +43,7 @@ type submitRewardsTree_Stateless struct {
 	errLog           *log.ColorLogger
 	cfg              *config.RocketPoolConfig
 	w                *wallet.Wallet
+	journal          *transactions.Journal
 	rp               *rocketpool.RocketPool
 	ec               rocketpool.ExecutionClient
 	bc               beacon.Client
@@ -59,6 +61,10 @@ func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, erro
 	if err != nil {
 		return nil, err
 	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -85,6 +91,7 @@ func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, erro
 		ec:               ec,
 		bc:               bc,
 		w:                w,
+		journal:          journal,
 		rp:               rp,
 		lock:             lock,
 		isRunning:        false,
@@ -118,7 +125,7 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 			return nil
 		} else {
 			// Create the state, since it's not done except for manual generators
-			state, err = t.m.GetStateForSlot(beaconSlot)
+			state, err = t.m.GetFullStateForSlot(beaconSlot)
 			if err != nil {
 				return fmt.Errorf("error getting state for beacon slot %d: %w", beaconSlot, err)
 			}
@@ -231,8 +238,8 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 }
 
 func (t *submitRewardsTree_Stateless) handleError(err error) {
-	t.errLog.Println(fmt.Errorf("%s %w", t.generationPrefix, err))
-	t.errLog.Println("*** Rewards tree generation failed. ***")
+	t.errLog.Error(fmt.Errorf("%s %w", t.generationPrefix, err))
+	t.errLog.Errorf("*** Rewards tree generation failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
@@ -252,13 +259,13 @@ func (t *submitRewardsTree_Stateless) isExistingFileValid(rewardsTreePath string
 		var proofWrapper rprewards.RewardsFile
 		fileBytes, err := os.ReadFile(rewardsTreePath)
 		if err != nil {
-			t.log.Printlnf("WARNING: failed to read %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
+			t.log.Warnf("failed to read %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
 			return false
 		}
 
 		err = json.Unmarshal(fileBytes, &proofWrapper)
 		if err != nil {
-			t.log.Printlnf("WARNING: failed to deserialize %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
+			t.log.Warnf("failed to deserialize %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
 			return false
 		}
 
@@ -309,7 +316,7 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 
 	// Log
 	if uint64(intervalsPassed) > 1 {
-		t.log.Printlnf("WARNING: %d intervals have passed since the last rewards checkpoint was submitted! Rolling them into one...", uint64(intervalsPassed))
+		t.log.Warnf("%d intervals have passed since the last rewards checkpoint was submitted! Rolling them into one...", uint64(intervalsPassed))
 	}
 	t.log.Printlnf("Rewards checkpoint has passed, starting Merkle tree generation for interval %d in the background.\n%s Snapshot Beacon block = %d, EL block = %d, running from %s to %s", currentIndex, t.generationPrefix, snapshotBeaconBlock, elBlockIndex, startTime, endTime)
 
@@ -320,7 +327,7 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 	}
 
 	// Create a new state for the target block
-	state, err := mgr.GetStateForSlot(snapshotBeaconBlock)
+	state, err := mgr.GetFullStateForSlot(snapshotBeaconBlock)
 	if err != nil {
 		return fmt.Errorf("couldn't get network state for EL block %d, Beacon slot %d: %w", elBlockIndex, snapshotBeaconBlock, err)
 	}
@@ -484,7 +491,7 @@ func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, cons
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("submit rewards tree for interval %s", index.String()), t.w, t.log)
 	if err != nil {
 		return err
 	}
@@ -571,7 +578,7 @@ func (t *submitRewardsTree_Stateless) getSnapshotConsensusBlock(endTime time.Tim
 
 		// If the block was missing, try the previous one
 		if !exists {
-			t.log.Printlnf("Slot %d was missing, trying the previous one...", targetSlot)
+			t.log.With(log.Slot(targetSlot)).Printlnf("Slot %d was missing, trying the previous one...", targetSlot)
 			targetSlot--
 		} else {
 			// Ok, we have the first proposed finalized block - this is the one to use for the snapshot!
@@ -27,6 +27,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -198,6 +199,7 @@ type submitRplPrice struct {
 	cfg       *config.RocketPoolConfig
 	ec        rocketpool.ExecutionClient
 	w         *wallet.Wallet
+	journal   *transactions.Journal
 	rp        *rocketpool.RocketPool
 	bc        beacon.Client
 	lock      *sync.Mutex
@@ -212,6 +214,10 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 	if err != nil {
 		return nil, err
 	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -232,15 +238,16 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 	// Return task
 	lock := &sync.Mutex{}
 	return &submitRplPrice{
-		c:      c,
-		log:    logger,
-		errLog: errorLogger,
-		cfg:    cfg,
-		ec:     ec,
-		w:      w,
-		rp:     rp,
-		bc:     bc,
-		lock:   lock,
+		c:       c,
+		log:     logger,
+		errLog:  errorLogger,
+		cfg:     cfg,
+		ec:      ec,
+		w:       w,
+		journal: journal,
+		rp:      rp,
+		bc:      bc,
+		lock:    lock,
 	}, nil
 
 }
@@ -268,28 +275,28 @@ func (t *submitRplPrice) run(state *state.NetworkState) error {
 	err = t.submitOptimismPrice()
 	if err != nil {
 		// Error is not fatal for this task so print and continue
-		t.log.Printlnf("Error submitting Optimism price: %s", err.Error())
+		t.log.Errorf("Error submitting Optimism price: %s", err.Error())
 	}
 
 	// Check if Polygon rate is stale and submit
 	err = t.submitPolygonPrice()
 	if err != nil {
 		// Error is not fatal for this task so print and continue
-		t.log.Printlnf("Error submitting Polygon price: %s", err.Error())
+		t.log.Errorf("Error submitting Polygon price: %s", err.Error())
 	}
 
 	// Check if Arbitrum rate is stale and submit
 	err = t.submitArbitrumPrice()
 	if err != nil {
 		// Error is not fatal for this task so print and continue
-		t.log.Printlnf("Error submitting Arbitrum price: %s", err.Error())
+		t.log.Errorf("Error submitting Arbitrum price: %s", err.Error())
 	}
 
 	// Check if zkSync rate is stale and submit
 	err = t.submitZkSyncEraPrice()
 	if err != nil {
 		// Error is not fatal for this task so print and continue
-		t.log.Printlnf("Error submitting zkSync Era price: %s", err.Error())
+		t.log.Errorf("Error submitting zkSync Era price: %s", err.Error())
 	}
 
 	// Log
@@ -403,8 +410,8 @@ func (t *submitRplPrice) run(state *state.NetworkState) error {
 }
 
 func (t *submitRplPrice) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Price report failed. ***")
+	t.errLog.Error(err)
+	t.errLog.Errorf("*** Price report failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
@@ -533,7 +540,7 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice *big.Int) e
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("submit RPL price for block %d", blockNumber), t.w, &t.log)
 	if err != nil {
 		return err
 	}
@@ -673,7 +680,7 @@ func (t *submitRplPrice) submitOptimismPrice() error {
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, &t.log)
+		err = t.journal.PrintAndWaitForTransaction(t.cfg, tx.Hash(), "submit RPL price to Optimism", t.w, &t.log)
 		if err != nil {
 			return err
 		}
@@ -813,7 +820,7 @@ func (t *submitRplPrice) submitPolygonPrice() error {
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, &t.log)
+		err = t.journal.PrintAndWaitForTransaction(t.cfg, tx.Hash(), "submit RPL price to Polygon", t.w, &t.log)
 		if err != nil {
 			return err
 		}
@@ -978,7 +985,7 @@ func (t *submitRplPrice) submitArbitrumPrice() error {
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, &t.log)
+		err = t.journal.PrintAndWaitForTransaction(t.cfg, tx.Hash(), "submit RPL price to Arbitrum", t.w, &t.log)
 		if err != nil {
 			return err
 		}
@@ -1136,7 +1143,7 @@ func (t *submitRplPrice) submitZkSyncEraPrice() error {
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, &t.log)
+		err = t.journal.PrintAndWaitForTransaction(t.cfg, tx.Hash(), "submit RPL price to zkSync Era", t.w, &t.log)
 		if err != nil {
 			return err
 		}
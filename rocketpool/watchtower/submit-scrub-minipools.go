@@ -26,6 +26,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -45,6 +46,7 @@ type submitScrubMinipools struct {
 	errLog    log.ColorLogger
 	cfg       *config.RocketPoolConfig
 	w         *wallet.Wallet
+	journal   *transactions.Journal
 	rp        *rocketpool.RocketPool
 	ec        rocketpool.ExecutionClient
 	bc        beacon.Client
@@ -90,6 +92,10 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
+	journal, err := services.GetTransactionJournal(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -115,6 +121,7 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 		errLog:    errorLogger,
 		cfg:       cfg,
 		w:         w,
+		journal:   journal,
 		rp:        rp,
 		ec:        ec,
 		bc:        bc,
@@ -251,8 +258,8 @@ func (t *submitScrubMinipools) run(state *state.NetworkState) error {
 }
 
 func (t *submitScrubMinipools) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Minipool scrub check failed. ***")
+	t.errLog.Error(err)
+	t.errLog.Errorf("*** Minipool scrub check failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
@@ -270,7 +277,7 @@ func (t *submitScrubMinipools) initializeMinipoolDetails(minipools []rpstate.Nat
 		// Create a minipool contract wrapper for the given address
 		mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, opts)
 		if err != nil {
-			t.log.Printf("Error creating minipool wrapper for %s: %s", mpd.MinipoolAddress.Hex(), err.Error())
+			t.log.Errorf("Error creating minipool wrapper for %s: %s", mpd.MinipoolAddress.Hex(), err.Error())
 			continue
 		}
 
@@ -318,7 +325,7 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(state *state.Ne
 	for _, minipool := range minipoolsToScrub {
 		err := t.submitVoteScrubMinipool(minipool)
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+			t.log.With(log.Minipool(minipool.GetAddress())).Warnf("Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
 		}
 	}
 
@@ -375,7 +382,7 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 		// Get the MinipoolPrestaked event
 		prestakeData, err := minipool.GetPrestakeEvent(t.it.eventLogInterval, nil)
 		if err != nil {
-			t.log.Printlnf("Error getting prestake event for minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+			t.log.With(log.Minipool(minipool.GetAddress())).Errorf("Error getting prestake event for minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
 			continue
 		}
 
@@ -394,7 +401,7 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 		if err != nil {
 			// The signature is illegal
 			t.log.Println("=== SCRUB DETECTED ON PRESTAKE EVENT ===")
-			t.log.Printlnf("Invalid prestake data for minipool %s:", minipool.GetAddress().Hex())
+			t.log.With(log.Minipool(minipool.GetAddress())).Printlnf("Invalid prestake data for minipool %s:", minipool.GetAddress().Hex())
 			t.log.Printlnf("\tError: %s", err.Error())
 			t.log.Println("========================================")
 
@@ -412,7 +419,7 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 	for _, minipool := range minipoolsToScrub {
 		err := t.submitVoteScrubMinipool(minipool)
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+			t.log.With(log.Minipool(minipool.GetAddress())).Warnf("Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
 		}
 	}
 
@@ -457,7 +464,7 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 			err := prdeposit.VerifyDepositSignature(depositData, t.it.depositDomain)
 			if err != nil {
 				// This isn't a valid deposit, so ignore it
-				t.log.Printlnf("Invalid deposit for minipool %s:", minipool.GetAddress().Hex())
+				t.log.With(log.Minipool(minipool.GetAddress())).Printlnf("Invalid deposit for minipool %s:", minipool.GetAddress().Hex())
 				t.log.Printlnf("\tTX Hash: %s", deposit.TxHash.Hex())
 				t.log.Printlnf("\tBlock: %d, TX Index: %d, Deposit Index: %d", deposit.BlockNumber, deposit.TxIndex, depositIndex)
 				t.log.Printlnf("\tError: %s", err.Error())
@@ -490,7 +497,7 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 	for _, minipool := range minipoolsToScrub {
 		err := t.submitVoteScrubMinipool(minipool)
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+			t.log.With(log.Minipool(minipool.GetAddress())).Warnf("Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
 		}
 	}
 
@@ -507,7 +514,7 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 	// Warn if there are any remaining minipools - this should never happen
 	remainingMinipools := len(t.it.minipools)
 	if remainingMinipools > 0 {
-		t.log.Printlnf("WARNING: %d minipools did not have deposit information", remainingMinipools)
+		t.log.Warnf("%d minipools did not have deposit information", remainingMinipools)
 	} else {
 		return nil
 	}
@@ -527,7 +534,7 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 
 		// Verify this is actually a prelaunch minipool
 		if mpd.Status != types.Prelaunch {
-			t.log.Printlnf("\tMinipool %s is under review but is in %d status?", minipool.GetAddress().Hex(), types.MinipoolDepositTypes[mpd.Status])
+			t.log.With(log.Minipool(minipool.GetAddress())).Printlnf("\tMinipool %s is under review but is in %s status?", minipool.GetAddress().Hex(), types.MinipoolDepositTypes[mpd.Status])
 			continue
 		}
 
@@ -550,7 +557,7 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 	for _, minipool := range minipoolsToScrub {
 		err := t.submitVoteScrubMinipool(minipool)
 		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+			t.log.With(log.Minipool(minipool.GetAddress())).Warnf("Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
 		}
 	}
 
@@ -562,7 +569,7 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) error {
 
 	// Log
-	t.log.Printlnf("Voting to scrub minipool %s...", mp.GetAddress().Hex())
+	t.log.With(log.Minipool(mp.GetAddress())).Printlnf("Voting to scrub minipool %s...", mp.GetAddress().Hex())
 
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
@@ -594,13 +601,13 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) err
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = t.journal.PrintAndWaitForTransaction(t.cfg, hash, fmt.Sprintf("scrub minipool %s", mp.GetAddress().Hex()), t.w, &t.log)
 	if err != nil {
 		return err
 	}
 
 	// Log
-	t.log.Printlnf("Successfully voted to scrub the minipool %s.", mp.GetAddress().Hex())
+	t.log.With(log.Minipool(mp.GetAddress())).Printlnf("Successfully voted to scrub the minipool %s.", mp.GetAddress().Hex())
 
 	// Return
 	return nil
@@ -61,6 +61,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 // Run daemon
 func run(c *cli.Context) error {
 
+	// Configure logging
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	log.Configure(cfg.Smartnode.GetLogSettings())
+
 	// Configure
 	configureHTTP()
 
@@ -70,15 +77,15 @@ func run(c *cli.Context) error {
 	}
 
 	// Get services
-	cfg, err := services.GetConfig(c)
+	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return err
 	}
-	rp, err := services.GetRocketPool(c)
+	w, err := services.GetWallet(c)
 	if err != nil {
 		return err
 	}
-	w, err := services.GetWallet(c)
+	ec, err := services.GetEthClient(c)
 	if err != nil {
 		return err
 	}
@@ -87,20 +94,30 @@ func run(c *cli.Context) error {
 		return err
 	}
 
+	// Initialize error logger
+	errorLog := log.NewColorLogger(ErrorColor)
+	updateLog := log.NewColorLogger(UpdateColor)
+
+	// Check if quorum reads are enabled
+	if cfg.Smartnode.UseQuorumReads.Value.(bool) {
+		if len(cfg.GetFallbackEcHttpUrls()) == 0 {
+			return fmt.Errorf("quorum reads are enabled, but there are no fallback Execution clients to compare the primary client with")
+		}
+		ec.EnableQuorumReads()
+		updateLog.Infof("Quorum reads are enabled; Oracle DAO duties will only be submitted if all of your Execution clients agree.")
+	}
+
 	// Check if rolling records are enabled
 	useRollingRecords := cfg.Smartnode.UseRollingRecords.Value.(bool)
 	if useRollingRecords {
-		fmt.Println("***NOTE: EXPERIMENTAL ROLLING RECORDS ARE ENABLED, BE ADVISED!***")
+		updateLog.Warnf("Experimental rolling records are enabled, be advised!")
 	}
 
 	// Initialize the metrics reporters
 	scrubCollector := collectors.NewScrubCollector()
 	bondReductionCollector := collectors.NewBondReductionCollector()
 	soloMigrationCollector := collectors.NewSoloMigrationCollector()
-
-	// Initialize error logger
-	errorLog := log.NewColorLogger(ErrorColor)
-	updateLog := log.NewColorLogger(UpdateColor)
+	quorumCollector := collectors.NewQuorumCollector(ec)
 
 	// Create the state manager
 	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
@@ -115,52 +132,52 @@ func run(c *cli.Context) error {
 	}
 
 	// Initialize tasks
-	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor), m)
+	respondChallenges, err := newRespondChallenges(c, newTaskLogger(RespondChallengesColor, "respond-challenges", nodeAccount.Address), m)
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog)
+	submitRplPrice, err := newSubmitRplPrice(c, newTaskLogger(SubmitRplPriceColor, "submit-rpl-price", nodeAccount.Address), newTaskLogger(ErrorColor, "submit-rpl-price", nodeAccount.Address))
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog)
+	submitNetworkBalances, err := newSubmitNetworkBalances(c, newTaskLogger(SubmitNetworkBalancesColor, "submit-network-balances", nodeAccount.Address), newTaskLogger(ErrorColor, "submit-network-balances", nodeAccount.Address))
 	if err != nil {
 		return fmt.Errorf("error during network balances check: %w", err)
 	}
-	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewColorLogger(DissolveTimedOutMinipoolsColor))
+	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, newTaskLogger(DissolveTimedOutMinipoolsColor, "dissolve-timed-out-minipools", nodeAccount.Address))
 	if err != nil {
 		return fmt.Errorf("error during timed-out minipools check: %w", err)
 	}
-	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector)
+	submitScrubMinipools, err := newSubmitScrubMinipools(c, newTaskLogger(SubmitScrubMinipoolsColor, "submit-scrub-minipools", nodeAccount.Address), newTaskLogger(ErrorColor, "submit-scrub-minipools", nodeAccount.Address), scrubCollector)
 	if err != nil {
 		return fmt.Errorf("error during scrub check: %w", err)
 	}
 	var submitRewardsTree_Stateless *submitRewardsTree_Stateless
 	var submitRewardsTree_Rolling *submitRewardsTree_Rolling
 	if !useRollingRecords {
-		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, newTaskLogger(SubmitRewardsTreeColor, "submit-rewards-tree", nodeAccount.Address), newTaskLogger(ErrorColor, "submit-rewards-tree", nodeAccount.Address), m)
 		if err != nil {
 			return fmt.Errorf("error during stateless rewards tree check: %w", err)
 		}
 	} else {
-		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, newTaskLogger(SubmitRewardsTreeColor, "submit-rewards-tree", nodeAccount.Address), newTaskLogger(ErrorColor, "submit-rewards-tree", nodeAccount.Address), m)
 		if err != nil {
 			return fmt.Errorf("error during rolling rewards tree check: %w", err)
 		}
 	}
-	/*processPenalties, err := newProcessPenalties(c, log.NewColorLogger(ProcessPenaltiesColor), errorLog)
+	/*processPenalties, err := newProcessPenalties(c, newTaskLogger(ProcessPenaltiesColor, "process-penalties", nodeAccount.Address), newTaskLogger(ErrorColor, "process-penalties", nodeAccount.Address))
 	if err != nil {
 		return fmt.Errorf("error during penalties check: %w", err)
 	}*/
-	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+	generateRewardsTree, err := newGenerateRewardsTree(c, newTaskLogger(SubmitRewardsTreeColor, "generate-rewards-tree", nodeAccount.Address), newTaskLogger(ErrorColor, "generate-rewards-tree", nodeAccount.Address), m)
 	if err != nil {
 		return fmt.Errorf("error during manual tree generation check: %w", err)
 	}
-	cancelBondReductions, err := newCancelBondReductions(c, log.NewColorLogger(CancelBondsColor), errorLog, bondReductionCollector)
+	cancelBondReductions, err := newCancelBondReductions(c, newTaskLogger(CancelBondsColor, "cancel-bond-reductions", nodeAccount.Address), newTaskLogger(ErrorColor, "cancel-bond-reductions", nodeAccount.Address), bondReductionCollector)
 	if err != nil {
 		return fmt.Errorf("error during bond reduction cancel check: %w", err)
 	}
-	checkSoloMigrations, err := newCheckSoloMigrations(c, log.NewColorLogger(CheckSoloMigrationsColor), errorLog, soloMigrationCollector)
+	checkSoloMigrations, err := newCheckSoloMigrations(c, newTaskLogger(CheckSoloMigrationsColor, "check-solo-migrations", nodeAccount.Address), newTaskLogger(ErrorColor, "check-solo-migrations", nodeAccount.Address), soloMigrationCollector)
 	if err != nil {
 		return fmt.Errorf("error during solo migration check: %w", err)
 	}
@@ -182,7 +199,7 @@ func run(c *cli.Context) error {
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
 			if err != nil {
-				errorLog.Println(err)
+				errorLog.Error(err)
 				time.Sleep(taskCooldown)
 				continue
 			}
@@ -190,7 +207,7 @@ func run(c *cli.Context) error {
 			// Check the BC status
 			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
 			if err != nil {
-				errorLog.Println(err)
+				errorLog.Error(err)
 				time.Sleep(taskCooldown)
 				continue
 			}
@@ -199,7 +216,7 @@ func run(c *cli.Context) error {
 			//latestBlock, err := m.GetLatestFinalizedBeaconBlock()
 			latestBlock, err := m.GetLatestBeaconBlock()
 			if err != nil {
-				errorLog.Println(fmt.Errorf("error getting latest Beacon block: %w", err))
+				errorLog.Error(fmt.Errorf("error getting latest Beacon block: %w", err))
 				time.Sleep(taskCooldown)
 				continue
 			}
@@ -207,85 +224,85 @@ func run(c *cli.Context) error {
 			// Check if on the Oracle DAO
 			isOnOdao, err := isOnOracleDAO(rp, nodeAccount.Address, latestBlock)
 			if err != nil {
-				errorLog.Println(err)
+				errorLog.Error(err)
 				time.Sleep(taskCooldown)
 				continue
 			}
 
 			// Run the manual rewards tree generation
 			if err := generateRewardsTree.run(); err != nil {
-				errorLog.Println(err)
+				errorLog.With(log.Task("generate-rewards-tree")).Error(err)
 			}
 			time.Sleep(taskCooldown)
 
 			if isOnOdao {
 				// Run the challenge check
 				if err := respondChallenges.run(); err != nil {
-					errorLog.Println(err)
+					errorLog.With(log.Task("respond-challenges")).Error(err)
 				}
 				time.Sleep(taskCooldown)
 
 				// Update the network state
 				state, err := updateNetworkState(m, &updateLog, latestBlock)
 				if err != nil {
-					errorLog.Println(err)
+					errorLog.Error(err)
 					time.Sleep(taskCooldown)
 					continue
 				}
 
 				// Run the network balance submission check
 				if err := submitNetworkBalances.run(state); err != nil {
-					errorLog.Println(err)
+					errorLog.With(log.Task("submit-network-balances")).Error(err)
 				}
 				time.Sleep(taskCooldown)
 
 				if !useRollingRecords {
 					// Run the rewards tree submission check
 					if err := submitRewardsTree_Stateless.Run(isOnOdao, state, latestBlock.Slot); err != nil {
-						errorLog.Println(err)
+						errorLog.With(log.Task("submit-rewards-tree")).Error(err)
 					}
 					time.Sleep(taskCooldown)
 				} else {
 					// Run the network balance and rewards tree submission check
 					if err := submitRewardsTree_Rolling.run(state); err != nil {
-						errorLog.Println(err)
+						errorLog.With(log.Task("submit-rewards-tree")).Error(err)
 					}
 					time.Sleep(taskCooldown)
 				}
 
 				// Run the price submission check
 				if err := submitRplPrice.run(state); err != nil {
-					errorLog.Println(err)
+					errorLog.With(log.Task("submit-rpl-price")).Error(err)
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the minipool dissolve check
 				if err := dissolveTimedOutMinipools.run(state); err != nil {
-					errorLog.Println(err)
+					errorLog.With(log.Task("dissolve-timed-out-minipools")).Error(err)
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the minipool scrub check
 				if err := submitScrubMinipools.run(state); err != nil {
-					errorLog.Println(err)
+					errorLog.With(log.Task("submit-scrub-minipools")).Error(err)
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the bond cancel check
 				if err := cancelBondReductions.run(state); err != nil {
-					errorLog.Println(err)
+					errorLog.With(log.Task("cancel-bond-reductions")).Error(err)
 				}
 				time.Sleep(taskCooldown)
 
 				// Run the solo migration check
 				if err := checkSoloMigrations.run(state); err != nil {
-					errorLog.Println(err)
+					errorLog.With(log.Task("check-solo-migrations")).Error(err)
 				}
 				/*time.Sleep(taskCooldown)
 
 				// Run the fee recipient penalty check
 				if err := processPenalties.run(); err != nil {
-					errorLog.Println(err)
+					errorLog.With(log.Task("process-penalties")).Error(err)
 				}*/
 				// DISABLED until MEV-Boost can support it
 			} else {
@@ -294,12 +311,12 @@ func run(c *cli.Context) error {
 				if !useRollingRecords {
 					// Run the rewards tree submission check
 					if err := submitRewardsTree_Stateless.Run(isOnOdao, nil, latestBlock.Slot); err != nil {
-						errorLog.Println(err)
+						errorLog.With(log.Task("submit-rewards-tree")).Error(err)
 					}
 				} else {
 					// Run the network balance and rewards tree submission check
 					if err := submitRewardsTree_Rolling.run(nil); err != nil {
-						errorLog.Println(err)
+						errorLog.With(log.Task("submit-rewards-tree")).Error(err)
 					}
 				}
 			}
@@ -311,9 +328,9 @@ func run(c *cli.Context) error {
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector, quorumCollector)
 		if err != nil {
-			errorLog.Println(err)
+			errorLog.Error(err)
 		}
 		wg.Done()
 	}()
@@ -323,6 +340,11 @@ func run(c *cli.Context) error {
 	return nil
 }
 
+// Create the logger for one of the watchtower's tasks
+func newTaskLogger(colorAttr color.Attribute, task string, nodeAddress common.Address) log.ColorLogger {
+	return log.NewColorLogger(colorAttr).With(log.Task(task), log.Node(nodeAddress))
+}
+
 // Configure HTTP transport settings
 func configureHTTP() {
 
@@ -0,0 +1,474 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	ManifestVersion  int    = 1
+	ManifestFilename string = "manifest.json"
+	DataRoot         string = "data"
+	ConfigRoot       string = "config"
+)
+
+// The scrypt work factor used to encrypt backups
+var workFactor int = 18
+
+// Validator keystores are stored in files or folders named after their pubkey by every client
+var pubkeyNameRegex = regexp.MustCompile(`^0x([0-9a-fA-F]{96})(\.json)?$`)
+
+// The slashing protection databases of every client. These are live databases that can't be copied safely while the
+// Validator Client is running, so backups have a slashing protection interchange file instead.
+var slashingProtectionDatabaseRegex = regexp.MustCompile(`^(slashing_protection\.sqlite.*|validator\.db|slashprotection|validator-db)$`)
+
+// The manifest that describes the contents of a backup
+type Manifest struct {
+	Version          int                     `json:"version"`
+	SmartnodeVersion string                  `json:"smartnodeVersion"`
+	Network          string                  `json:"network"`
+	Created          time.Time               `json:"created"`
+	ValidatorPubkeys []types.ValidatorPubkey `json:"validatorPubkeys"`
+	Files            []File                  `json:"files"`
+}
+
+// A file in a backup
+type File struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Mode   uint32 `json:"mode"`
+	Sha256 string `json:"sha256"`
+}
+
+// Writes files into an encrypted backup: a gzipped tarball encrypted with a passphrase using the age format.
+// The manifest is written as the last entry of the tarball when the writer is closed.
+type Writer struct {
+	encrypter io.WriteCloser
+	gzip      *gzip.Writer
+	tar       *tar.Writer
+	manifest  Manifest
+	pubkeys   map[types.ValidatorPubkey]bool
+	paths     map[string]bool
+}
+
+// Create a new backup writer that writes the encrypted backup to w
+func NewWriter(w io.Writer, passphrase string, smartnodeVersion string, network string) (*Writer, error) {
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error creating encrypter: %w", err)
+	}
+	recipient.SetWorkFactor(workFactor)
+	encrypter, err := age.Encrypt(w, recipient)
+	if err != nil {
+		return nil, fmt.Errorf("error creating encrypter: %w", err)
+	}
+	gzipWriter := gzip.NewWriter(encrypter)
+	return &Writer{
+		encrypter: encrypter,
+		gzip:      gzipWriter,
+		tar:       tar.NewWriter(gzipWriter),
+		manifest: Manifest{
+			Version:          ManifestVersion,
+			SmartnodeVersion: smartnodeVersion,
+			Network:          network,
+			Created:          time.Now().UTC(),
+			ValidatorPubkeys: []types.ValidatorPubkey{},
+			Files:            []File{},
+		},
+		pubkeys: map[types.ValidatorPubkey]bool{},
+		paths:   map[string]bool{},
+	}, nil
+}
+
+// Add a file to the backup under the given archive path. Returns false if the file doesn't exist.
+func (w *Writer) AddFile(sourcePath string, archivePath string) (bool, error) {
+	info, err := os.Stat(sourcePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reading %s: %w", sourcePath, err)
+	}
+	if !info.Mode().IsRegular() {
+		return false, fmt.Errorf("%s is not a regular file", sourcePath)
+	}
+	return true, w.addFile(sourcePath, archivePath, info)
+}
+
+// Add data to the backup as a file under the given archive path
+func (w *Writer) AddData(data []byte, archivePath string) error {
+	if w.paths[archivePath] {
+		return fmt.Errorf("backup already has %s", archivePath)
+	}
+	if err := checkArchivePath(archivePath); err != nil {
+		return err
+	}
+	err := w.tar.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     archivePath,
+		Size:     int64(len(data)),
+		Mode:     0600,
+		ModTime:  w.manifest.Created,
+	})
+	if err != nil {
+		return fmt.Errorf("error adding %s to the backup: %w", archivePath, err)
+	}
+	if _, err := w.tar.Write(data); err != nil {
+		return fmt.Errorf("error adding %s to the backup: %w", archivePath, err)
+	}
+	hash := sha256.Sum256(data)
+	w.record(archivePath, int64(len(data)), 0600, hex.EncodeToString(hash[:]))
+	return nil
+}
+
+// Add a folder and everything in it to the backup under the given archive path. Returns false if the folder doesn't exist.
+// Anything that isn't a regular file or folder (such as sockets and symlinks) is skipped, along with the slashing protection
+// databases of the Validator Clients and anything the skip function matches.
+func (w *Writer) AddFolder(sourcePath string, archivePath string, skip func(relPath string) bool) (bool, error) {
+	info, err := os.Stat(sourcePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reading %s: %w", sourcePath, err)
+	}
+	if !info.IsDir() {
+		return false, fmt.Errorf("%s is not a folder", sourcePath)
+	}
+
+	err = filepath.WalkDir(sourcePath, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", filePath, err)
+		}
+		relPath, err := filepath.Rel(sourcePath, filePath)
+		if err != nil {
+			return err
+		}
+		if slashingProtectionDatabaseRegex.MatchString(entry.Name()) || (skip != nil && skip(filepath.ToSlash(relPath))) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", filePath, err)
+		}
+		return w.addFile(filePath, path.Join(archivePath, filepath.ToSlash(relPath)), info)
+	})
+	return true, err
+}
+
+// Get the validator pubkeys of the keystores that have been added to the backup so far
+func (w *Writer) ValidatorPubkeys() []types.ValidatorPubkey {
+	pubkeys := make([]types.ValidatorPubkey, 0, len(w.pubkeys))
+	for pubkey := range w.pubkeys {
+		pubkeys = append(pubkeys, pubkey)
+	}
+	sort.Slice(pubkeys, func(i, j int) bool {
+		return pubkeys[i].Hex() < pubkeys[j].Hex()
+	})
+	return pubkeys
+}
+
+// Write the manifest and finish the backup. This doesn't close the underlying writer.
+func (w *Writer) Close() (*Manifest, error) {
+
+	// Sort the validator pubkeys so the manifest is stable
+	w.manifest.ValidatorPubkeys = w.ValidatorPubkeys()
+
+	// Write the manifest
+	manifestBytes, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error serializing manifest: %w", err)
+	}
+	err = w.tar.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     ManifestFilename,
+		Size:     int64(len(manifestBytes)),
+		Mode:     0600,
+		ModTime:  w.manifest.Created,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error writing manifest: %w", err)
+	}
+	if _, err := w.tar.Write(manifestBytes); err != nil {
+		return nil, fmt.Errorf("error writing manifest: %w", err)
+	}
+
+	// Flush everything
+	if err := w.tar.Close(); err != nil {
+		return nil, fmt.Errorf("error finishing tarball: %w", err)
+	}
+	if err := w.gzip.Close(); err != nil {
+		return nil, fmt.Errorf("error finishing compression: %w", err)
+	}
+	if err := w.encrypter.Close(); err != nil {
+		return nil, fmt.Errorf("error finishing encryption: %w", err)
+	}
+	return &w.manifest, nil
+
+}
+
+// Copy a file into the tarball, hashing it along the way
+func (w *Writer) addFile(sourcePath string, archivePath string, info fs.FileInfo) error {
+
+	if w.paths[archivePath] {
+		return nil
+	}
+	if err := checkArchivePath(archivePath); err != nil {
+		return err
+	}
+
+	file, err := os.Open(sourcePath)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", sourcePath, err)
+	}
+	defer file.Close()
+
+	// Write the file
+	mode := uint32(info.Mode().Perm())
+	err = w.tar.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     archivePath,
+		Size:     info.Size(),
+		Mode:     int64(mode),
+		ModTime:  info.ModTime(),
+	})
+	if err != nil {
+		return fmt.Errorf("error adding %s to the backup: %w", sourcePath, err)
+	}
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w.tar, hasher), file); err != nil {
+		return fmt.Errorf("error adding %s to the backup: %w", sourcePath, err)
+	}
+
+	w.record(archivePath, info.Size(), mode, hex.EncodeToString(hasher.Sum(nil)))
+	return nil
+
+}
+
+// Record a file that was written to the tarball in the manifest
+func (w *Writer) record(archivePath string, size int64, mode uint32, hash string) {
+	w.paths[archivePath] = true
+	w.manifest.Files = append(w.manifest.Files, File{
+		Path:   archivePath,
+		Size:   size,
+		Mode:   mode,
+		Sha256: hash,
+	})
+	for _, element := range strings.Split(archivePath, "/") {
+		if matches := pubkeyNameRegex.FindStringSubmatch(element); matches != nil {
+			pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(strings.ToLower(matches[1])))
+			if err == nil {
+				w.pubkeys[pubkey] = true
+			}
+		}
+	}
+}
+
+// Decrypt a backup and extract it into a staging folder, verifying every file against the manifest.
+// The staging folder must not exist yet; it's removed if the backup can't be extracted.
+func Extract(r io.Reader, passphrase string, stagingDir string) (*Manifest, error) {
+
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting backup: %w", err)
+	}
+	decrypter, err := age.Decrypt(r, identity)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting backup: %w", err)
+	}
+	gzipReader, err := gzip.NewReader(decrypter)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing backup: %w", err)
+	}
+	defer gzipReader.Close()
+
+	if err := os.Mkdir(stagingDir, 0700); err != nil {
+		return nil, fmt.Errorf("error creating staging folder %s: %w", stagingDir, err)
+	}
+	manifest, err := extract(tar.NewReader(gzipReader), stagingDir)
+	if err != nil {
+		os.RemoveAll(stagingDir)
+		return nil, err
+	}
+	return manifest, nil
+
+}
+
+// Move the files of an extracted backup from the staging folder to their destinations.
+// Roots maps the top-level folders in the backup (such as DataRoot and ConfigRoot) to where they should be restored.
+func Install(stagingDir string, manifest *Manifest, roots map[string]string) error {
+
+	// Make sure every file has a destination before touching anything
+	for _, file := range manifest.Files {
+		root, _, _ := strings.Cut(file.Path, "/")
+		if _, exists := roots[root]; !exists {
+			return fmt.Errorf("no destination for %s", file.Path)
+		}
+	}
+
+	for _, file := range manifest.Files {
+		root, relPath, _ := strings.Cut(file.Path, "/")
+		source := filepath.Join(stagingDir, filepath.FromSlash(file.Path))
+		destination := filepath.Join(roots[root], filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(destination), 0700); err != nil {
+			return fmt.Errorf("error creating folder for %s: %w", destination, err)
+		}
+		if err := os.Rename(source, destination); err == nil {
+			if err := os.Chmod(destination, fs.FileMode(file.Mode).Perm()); err != nil {
+				return fmt.Errorf("error setting permissions of %s: %w", destination, err)
+			}
+			continue
+		}
+
+		// The staging folder may be on a different filesystem, so fall back to copying
+		if err := copyFile(source, destination, fs.FileMode(file.Mode)); err != nil {
+			return fmt.Errorf("error restoring %s: %w", destination, err)
+		}
+	}
+	return nil
+
+}
+
+// Extract the tarball into the staging folder
+func extract(tarReader *tar.Reader, stagingDir string) (*Manifest, error) {
+
+	hashes := map[string]string{}
+	var manifest *Manifest
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading backup: %w", err)
+		}
+		if manifest != nil {
+			return nil, fmt.Errorf("backup has unexpected entry %s after the manifest", header.Name)
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("backup entry %s is not a regular file", header.Name)
+		}
+
+		// Read the manifest
+		if header.Name == ManifestFilename {
+			manifest = new(Manifest)
+			if err := json.NewDecoder(tarReader).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("error reading manifest: %w", err)
+			}
+			continue
+		}
+
+		// Extract the file
+		if err := checkArchivePath(header.Name); err != nil {
+			return nil, err
+		}
+		if _, exists := hashes[header.Name]; exists {
+			return nil, fmt.Errorf("backup has duplicate entry %s", header.Name)
+		}
+		hash, err := extractFile(tarReader, filepath.Join(stagingDir, filepath.FromSlash(header.Name)))
+		if err != nil {
+			return nil, fmt.Errorf("error extracting %s: %w", header.Name, err)
+		}
+		hashes[header.Name] = hash
+	}
+
+	// Verify the files against the manifest
+	if manifest == nil {
+		return nil, fmt.Errorf("backup doesn't have a manifest")
+	}
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("backup has manifest version %d but this version of the Smartnode only supports version %d", manifest.Version, ManifestVersion)
+	}
+	if len(manifest.Files) != len(hashes) {
+		return nil, fmt.Errorf("backup has %d files but its manifest lists %d", len(hashes), len(manifest.Files))
+	}
+	for _, file := range manifest.Files {
+		hash, exists := hashes[file.Path]
+		if !exists {
+			return nil, fmt.Errorf("%s is missing from the backup", file.Path)
+		}
+		if hash != file.Sha256 {
+			return nil, fmt.Errorf("checksum of %s doesn't match the manifest", file.Path)
+		}
+	}
+	return manifest, nil
+
+}
+
+// Write a file from the tarball and return its hash
+func extractFile(reader io.Reader, destination string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(destination), 0700); err != nil {
+		return "", err
+	}
+	file, err := os.OpenFile(destination, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, hasher), reader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// Copy a file with the given permissions
+func copyFile(source string, destination string, mode fs.FileMode) error {
+	sourceFile, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+	destinationFile, err := os.OpenFile(destination, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(destinationFile, sourceFile); err != nil {
+		destinationFile.Close()
+		return err
+	}
+	if err := destinationFile.Close(); err != nil {
+		return err
+	}
+	return os.Chmod(destination, mode.Perm())
+}
+
+// Make sure a path in the backup is relative, clean, and under a top-level folder
+func checkArchivePath(archivePath string) error {
+	if archivePath == "" ||
+		path.IsAbs(archivePath) ||
+		path.Clean(archivePath) != archivePath ||
+		archivePath == ".." ||
+		strings.HasPrefix(archivePath, "../") ||
+		strings.Contains(archivePath, "\\") ||
+		!strings.Contains(archivePath, "/") {
+		return fmt.Errorf("invalid path %s in backup", archivePath)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/goccy/go-json"
+)
+
+const (
+	testPassphrase = "correct horse battery staple"
+	testPubkey     = "0xa1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1"
+)
+
+func TestMain(m *testing.M) {
+	// Keep scrypt fast for the tests
+	workFactor = 10
+	os.Exit(m.Run())
+}
+
+// A file to put into a hand-built backup
+type testEntry struct {
+	name string
+	data []byte
+}
+
+// Write files into a folder
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	for name, contents := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Build an encrypted backup by hand, with a manifest listing the given files
+func buildBackup(t *testing.T, entries []testEntry, files []File) []byte {
+	var buffer bytes.Buffer
+	recipient, err := age.NewScryptRecipient(testPassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipient.SetWorkFactor(workFactor)
+	encrypter, err := age.Encrypt(&buffer, recipient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gzipWriter := gzip.NewWriter(encrypter)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifest, err := json.Marshal(Manifest{Version: ManifestVersion, Files: files})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries = append(entries, testEntry{name: ManifestFilename, data: manifest})
+	for _, entry := range entries {
+		err := tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: entry.name, Size: int64(len(entry.data)), Mode: 0600})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write(entry.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, closer := range []interface{ Close() error }{tarWriter, gzipWriter, encrypter} {
+		if err := closer.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buffer.Bytes()
+}
+
+// Get the manifest entry for a file
+func fileFor(name string, data []byte) File {
+	hash := sha256.Sum256(data)
+	return File{Path: name, Size: int64(len(data)), Mode: 0600, Sha256: hex.EncodeToString(hash[:])}
+}
+
+func TestRoundTrip(t *testing.T) {
+	source := t.TempDir()
+	writeTestFiles(t, source, map[string]string{
+		"wallet": "wallet",
+		"validators/lighthouse/validators/" + testPubkey + "/voting-keystore.json": "keystore",
+		"validators/lighthouse/validators/slashing_protection.sqlite":              "live database",
+		"validators/teku/slashprotection/" + testPubkey[2:] + ".yml":               "live database",
+		"validators/" + SlashingProtectionFilename:                                 "old interchange",
+	})
+
+	// Write the backup
+	var buffer bytes.Buffer
+	writer, err := NewWriter(&buffer, testPassphrase, "1.0.0", "mainnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := writer.AddFile(filepath.Join(source, "wallet"), DataRoot+"/wallet"); err != nil || !exists {
+		t.Fatalf("error adding wallet (exists %t): %v", exists, err)
+	}
+	if exists, err := writer.AddFile(filepath.Join(source, "password"), DataRoot+"/password"); err != nil || exists {
+		t.Fatalf("a missing file should be skipped (exists %t): %v", exists, err)
+	}
+	_, err = writer.AddFolder(filepath.Join(source, "validators"), DataRoot+"/validators", func(relPath string) bool {
+		return relPath == SlashingProtectionFilename
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(writer.ValidatorPubkeys()) != 1 {
+		t.Fatalf("expected 1 validator pubkey, got %v", writer.ValidatorPubkeys())
+	}
+	if err := writer.AddData([]byte("new interchange"), DataRoot+"/validators/"+SlashingProtectionFilename); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Files) != 3 {
+		t.Fatalf("expected the wallet, keystore, and interchange in the backup but got %v", manifest.Files)
+	}
+
+	// The wrong passphrase can't decrypt it
+	if _, err := Extract(bytes.NewReader(buffer.Bytes()), "wrong passphrase", filepath.Join(t.TempDir(), "staging")); err == nil {
+		t.Fatal("the backup was decrypted with the wrong passphrase")
+	}
+
+	// Restore it
+	stagingDir := filepath.Join(t.TempDir(), "staging")
+	extracted, err := Extract(bytes.NewReader(buffer.Bytes()), testPassphrase, stagingDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extracted.Network != "mainnet" || len(extracted.ValidatorPubkeys) != 1 || "0x"+extracted.ValidatorPubkeys[0].Hex() != testPubkey {
+		t.Fatalf("unexpected manifest %+v", extracted)
+	}
+	destination := t.TempDir()
+	if err := Install(stagingDir, extracted, map[string]string{DataRoot: destination}); err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string]string{
+		"wallet": "wallet",
+		"validators/lighthouse/validators/" + testPubkey + "/voting-keystore.json": "keystore",
+		"validators/" + SlashingProtectionFilename:                                 "new interchange",
+	} {
+		contents, err := os.ReadFile(filepath.Join(destination, filepath.FromSlash(name)))
+		if err != nil || string(contents) != expected {
+			t.Fatalf("unexpected contents of %s: [%s] (%v)", name, contents, err)
+		}
+	}
+	for _, name := range []string{"validators/lighthouse/validators/slashing_protection.sqlite", "validators/teku/slashprotection"} {
+		if _, err := os.Stat(filepath.Join(destination, filepath.FromSlash(name))); !os.IsNotExist(err) {
+			t.Fatalf("slashing protection database %s was restored", name)
+		}
+	}
+}
+
+func TestExtractTamperedChecksum(t *testing.T) {
+	data := []byte("wallet")
+	file := fileFor(DataRoot+"/wallet", data)
+	file.Sha256 = strings.Repeat("0", 64)
+	backup := buildBackup(t, []testEntry{{name: DataRoot + "/wallet", data: data}}, []File{file})
+
+	stagingDir := filepath.Join(t.TempDir(), "staging")
+	_, err := Extract(bytes.NewReader(backup), testPassphrase, stagingDir)
+	if err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Fatalf("expected a checksum error, got %v", err)
+	}
+	if _, err := os.Stat(stagingDir); !os.IsNotExist(err) {
+		t.Fatal("the staging folder should be removed when extraction fails")
+	}
+}
+
+func TestExtractDuplicateEntry(t *testing.T) {
+	data := []byte("wallet")
+	entry := testEntry{name: DataRoot + "/wallet", data: data}
+	backup := buildBackup(t, []testEntry{entry, entry}, []File{fileFor(entry.name, data)})
+
+	_, err := Extract(bytes.NewReader(backup), testPassphrase, filepath.Join(t.TempDir(), "staging"))
+	if err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Fatalf("expected a duplicate entry error, got %v", err)
+	}
+}
+
+func TestExtractPathTraversal(t *testing.T) {
+	data := []byte("escaped")
+	for _, name := range []string{"../escaped", DataRoot + "/../../escaped", "/" + DataRoot + "/escaped", "escaped"} {
+		backup := buildBackup(t, []testEntry{{name: name, data: data}}, []File{fileFor(name, data)})
+
+		root := t.TempDir()
+		_, err := Extract(bytes.NewReader(backup), testPassphrase, filepath.Join(root, "staging"))
+		if err == nil || !strings.Contains(err.Error(), "invalid path") {
+			t.Fatalf("expected an invalid path error for %s, got %v", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(root, "escaped")); !os.IsNotExist(err) {
+			t.Fatalf("%s was written outside of the staging folder", name)
+		}
+	}
+}
+
+func TestInstallRequiresDestination(t *testing.T) {
+	manifest := &Manifest{Files: []File{{Path: ConfigRoot + "/user-settings.yml"}}}
+	if err := Install(t.TempDir(), manifest, map[string]string{DataRoot: t.TempDir()}); err == nil {
+		t.Fatal("files without a destination should not be installed")
+	}
+}
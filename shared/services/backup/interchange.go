@@ -0,0 +1,85 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	InterchangeFormatVersion   string = "5"
+	SlashingProtectionFilename string = "slashing-protection.json"
+)
+
+// A slashing protection interchange file (EIP-3076)
+type Interchange struct {
+	Metadata struct {
+		InterchangeFormatVersion string `json:"interchange_format_version"`
+		GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+	} `json:"metadata"`
+	Data []InterchangeValidator `json:"data"`
+}
+type InterchangeValidator struct {
+	Pubkey             string                   `json:"pubkey"`
+	SignedBlocks       []InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []InterchangeAttestation `json:"signed_attestations"`
+}
+type InterchangeBlock struct {
+	Slot string `json:"slot"`
+}
+type InterchangeAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+}
+
+// Create an interchange in the minimal format that marks every block up to the given slot and every attestation up to the
+// given source and target epochs as signed, so the Validator Client refuses to sign anything the validators may already have.
+func NewMinimalInterchange(genesisValidatorsRoot []byte, pubkeys []types.ValidatorPubkey, slot uint64, sourceEpoch uint64, targetEpoch uint64) *Interchange {
+	interchange := &Interchange{
+		Data: make([]InterchangeValidator, len(pubkeys)),
+	}
+	interchange.Metadata.InterchangeFormatVersion = InterchangeFormatVersion
+	interchange.Metadata.GenesisValidatorsRoot = hexutil.AddPrefix(common.Bytes2Hex(genesisValidatorsRoot))
+	for i, pubkey := range pubkeys {
+		interchange.Data[i] = InterchangeValidator{
+			Pubkey:       hexutil.AddPrefix(pubkey.Hex()),
+			SignedBlocks: []InterchangeBlock{{Slot: fmt.Sprint(slot)}},
+			SignedAttestations: []InterchangeAttestation{{
+				SourceEpoch: fmt.Sprint(sourceEpoch),
+				TargetEpoch: fmt.Sprint(targetEpoch),
+			}},
+		}
+	}
+	return interchange
+}
+
+// Get a serialized minimal interchange for the validators that covers everything up to the current epoch
+func GetSlashingProtection(bc beacon.Client, pubkeys []types.ValidatorPubkey) ([]byte, error) {
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	if eth2Config.SlotsPerEpoch == 0 {
+		return nil, errors.New("Beacon config has no slots per epoch")
+	}
+
+	// Cover every slot of the current epoch, since blocks may have been proposed in it already
+	lastSlot := (head.Epoch+1)*eth2Config.SlotsPerEpoch - 1
+	interchange := NewMinimalInterchange(eth2Config.GenesisValidatorsRoot, pubkeys, lastSlot, head.JustifiedEpoch, head.Epoch)
+	bytes, err := json.MarshalIndent(interchange, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error serializing slashing protection: %w", err)
+	}
+	return bytes, nil
+}
@@ -0,0 +1,28 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+func TestNewMinimalInterchange(t *testing.T) {
+	pubkey, err := types.HexToValidatorPubkey(testPubkey[2:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := make([]byte, 32)
+	root[31] = 1
+	interchange := NewMinimalInterchange(root, []types.ValidatorPubkey{pubkey}, 6431, 199, 200)
+
+	bytes, err := json.Marshal(interchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0000000000000000000000000000000000000000000000000000000000000001"},` +
+		`"data":[{"pubkey":"` + testPubkey + `","signed_blocks":[{"slot":"6431"}],"signed_attestations":[{"source_epoch":"199","target_epoch":"200"}]}]}`
+	if string(bytes) != expected {
+		t.Fatalf("unexpected interchange:\n%s\nexpected:\n%s", bytes, expected)
+	}
+}
@@ -1,8 +1,9 @@
 package services
 
 import (
+	"context"
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
@@ -15,14 +16,14 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
+// Settings
+const maxBcHeadLag uint64 = 2
+
 // This is a proxy for multiple Beacon clients, providing natural fallback support if one of them fails.
+// The clients are used in order of preference; requests go to the first healthy one.
 type BeaconClientManager struct {
-	primaryBc       beacon.Client
-	fallbackBc      beacon.Client
-	logger          log.ColorLogger
-	primaryReady    bool
-	fallbackReady   bool
-	ignoreSyncCheck bool
+	bcs  []beacon.Client
+	pool *clientPool
 }
 
 // This is a signature for a wrapped Beacon client function that only returns an error
@@ -57,35 +58,33 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 		return nil, fmt.Errorf("Unknown Consensus client mode '%v'", cfg.ConsensusClientMode.Value)
 	}
 
-	// Fallback CC
-	var fallbackProvider string
+	// Fallback CCs
+	var fallbackProviders []string
 	if cfg.UseFallbackClients.Value == true {
 		if cfg.IsNativeMode {
-			fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
+			fallbackProviders = cfg.FallbackNormal.GetCcHttpUrls()
 		} else {
 			switch selectedCC {
 			case cfgtypes.ConsensusClient_Prysm:
-				fallbackProvider = cfg.FallbackPrysm.CcHttpUrl.Value.(string)
+				fallbackProviders = cfg.FallbackPrysm.GetCcHttpUrls()
 			default:
-				fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
+				fallbackProviders = cfg.FallbackNormal.GetCcHttpUrls()
 			}
 		}
 	}
 
-	var primaryBc beacon.Client
-	var fallbackBc beacon.Client
-	primaryBc = client.NewStandardHttpClient(primaryProvider)
-	if fallbackProvider != "" {
-		fallbackBc = client.NewStandardHttpClient(fallbackProvider)
+	concurrency := int(cfg.Smartnode.BeaconRequestConcurrency.Value.(uint64))
+	bcs := []beacon.Client{client.NewStandardHttpClient(primaryProvider, concurrency)}
+	for _, fallbackProvider := range fallbackProviders {
+		bcs = append(bcs, client.NewStandardHttpClient(fallbackProvider, concurrency))
 	}
 
-	return &BeaconClientManager{
-		primaryBc:     primaryBc,
-		fallbackBc:    fallbackBc,
-		logger:        log.NewColorLogger(color.FgHiBlue),
-		primaryReady:  true,
-		fallbackReady: fallbackBc != nil,
-	}, nil
+	manager := &BeaconClientManager{
+		bcs:  bcs,
+		pool: newClientPool("Beacon", len(bcs), maxBcHeadLag, log.NewColorLogger(color.FgHiBlue)),
+	}
+	manager.pool.probe = manager.probe
+	return manager, nil
 
 }
 
@@ -95,7 +94,7 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 
 // Get the client's process mode
 func (m *BeaconClientManager) GetClientType() (beacon.BeaconClientType, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetClientType()
 	})
 	if err != nil {
@@ -106,7 +105,7 @@ func (m *BeaconClientManager) GetClientType() (beacon.BeaconClientType, error) {
 
 // Get the client's sync status
 func (m *BeaconClientManager) GetSyncStatus() (beacon.SyncStatus, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetSyncStatus()
 	})
 	if err != nil {
@@ -117,7 +116,7 @@ func (m *BeaconClientManager) GetSyncStatus() (beacon.SyncStatus, error) {
 
 // Get the Beacon configuration
 func (m *BeaconClientManager) GetEth2Config() (beacon.Eth2Config, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetEth2Config()
 	})
 	if err != nil {
@@ -128,7 +127,7 @@ func (m *BeaconClientManager) GetEth2Config() (beacon.Eth2Config, error) {
 
 // Get the Beacon configuration
 func (m *BeaconClientManager) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetEth2DepositContract()
 	})
 	if err != nil {
@@ -139,7 +138,7 @@ func (m *BeaconClientManager) GetEth2DepositContract() (beacon.Eth2DepositContra
 
 // Get the attestations in a Beacon chain block
 func (m *BeaconClientManager) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
-	result1, result2, err := m.runFunction2(func(client beacon.Client) (interface{}, interface{}, error) {
+	result1, result2, err := m.runFunction2(true, func(client beacon.Client) (interface{}, interface{}, error) {
 		return client.GetAttestations(blockId)
 	})
 	if err != nil {
@@ -150,7 +149,7 @@ func (m *BeaconClientManager) GetAttestations(blockId string) ([]beacon.Attestat
 
 // Get a Beacon chain block
 func (m *BeaconClientManager) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
-	result1, result2, err := m.runFunction2(func(client beacon.Client) (interface{}, interface{}, error) {
+	result1, result2, err := m.runFunction2(true, func(client beacon.Client) (interface{}, interface{}, error) {
 		return client.GetBeaconBlock(blockId)
 	})
 	if err != nil {
@@ -161,7 +160,7 @@ func (m *BeaconClientManager) GetBeaconBlock(blockId string) (beacon.BeaconBlock
 
 // Get the Beacon chain's head information
 func (m *BeaconClientManager) GetBeaconHead() (beacon.BeaconHead, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetBeaconHead()
 	})
 	if err != nil {
@@ -172,7 +171,7 @@ func (m *BeaconClientManager) GetBeaconHead() (beacon.BeaconHead, error) {
 
 // Get a validator's status by its index
 func (m *BeaconClientManager) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorStatusByIndex(index, opts)
 	})
 	if err != nil {
@@ -183,7 +182,7 @@ func (m *BeaconClientManager) GetValidatorStatusByIndex(index string, opts *beac
 
 // Get a validator's status by its pubkey
 func (m *BeaconClientManager) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorStatus(pubkey, opts)
 	})
 	if err != nil {
@@ -194,7 +193,7 @@ func (m *BeaconClientManager) GetValidatorStatus(pubkey types.ValidatorPubkey, o
 
 // Get the statuses of multiple validators by their pubkeys
 func (m *BeaconClientManager) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorStatuses(pubkeys, opts)
 	})
 	if err != nil {
@@ -205,7 +204,7 @@ func (m *BeaconClientManager) GetValidatorStatuses(pubkeys []types.ValidatorPubk
 
 // Get a validator's index
 func (m *BeaconClientManager) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorIndex(pubkey)
 	})
 	if err != nil {
@@ -216,7 +215,7 @@ func (m *BeaconClientManager) GetValidatorIndex(pubkey types.ValidatorPubkey) (s
 
 // Get a validator's sync duties
 func (m *BeaconClientManager) GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorSyncDuties(indices, epoch)
 	})
 	if err != nil {
@@ -225,9 +224,31 @@ func (m *BeaconClientManager) GetValidatorSyncDuties(indices []string, epoch uin
 	return result.(map[string]bool), nil
 }
 
+// Check which validators were seen performing their duties in the given epoch
+func (m *BeaconClientManager) GetValidatorLiveness(indices []string, epoch uint64) (map[string]bool, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorLiveness(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]bool), nil
+}
+
+// Get the positions of validators in the sync committee
+func (m *BeaconClientManager) GetValidatorSyncCommitteeIndices(indices []string, epoch uint64) (map[string][]uint64, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorSyncCommitteeIndices(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string][]uint64), nil
+}
+
 // Get a validator's proposer duties
 func (m *BeaconClientManager) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorProposerDuties(indices, epoch)
 	})
 	if err != nil {
@@ -238,7 +259,7 @@ func (m *BeaconClientManager) GetValidatorProposerDuties(indices []string, epoch
 
 // Get the Beacon chain's domain data
 func (m *BeaconClientManager) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetDomainData(domainType, epoch, useGenesisFork)
 	})
 	if err != nil {
@@ -247,9 +268,20 @@ func (m *BeaconClientManager) GetDomainData(domainType []byte, epoch uint64, use
 	return result.([]byte), nil
 }
 
+// Get the fork at the head of the chain
+func (m *BeaconClientManager) GetFork() (beacon.Fork, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
+		return client.GetFork()
+	})
+	if err != nil {
+		return beacon.Fork{}, err
+	}
+	return result.(beacon.Fork), nil
+}
+
 // Voluntarily exit a validator
 func (m *BeaconClientManager) ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error {
-	err := m.runFunction0(func(client beacon.Client) error {
+	err := m.runFunction0(false, func(client beacon.Client) error {
 		return client.ExitValidator(validatorIndex, epoch, signature)
 	})
 	return err
@@ -257,7 +289,7 @@ func (m *BeaconClientManager) ExitValidator(validatorIndex string, epoch uint64,
 
 // Close the connection to the Beacon client
 func (m *BeaconClientManager) Close() error {
-	err := m.runFunction0(func(client beacon.Client) error {
+	err := m.runFunction0(false, func(client beacon.Client) error {
 		return client.Close()
 	})
 	return err
@@ -265,7 +297,7 @@ func (m *BeaconClientManager) Close() error {
 
 // Get the EL data for a CL block
 func (m *BeaconClientManager) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
-	result1, result2, err := m.runFunction2(func(client beacon.Client) (interface{}, interface{}, error) {
+	result1, result2, err := m.runFunction2(true, func(client beacon.Client) (interface{}, interface{}, error) {
 		return client.GetEth1DataForEth2Block(blockId)
 	})
 	if err != nil {
@@ -276,7 +308,7 @@ func (m *BeaconClientManager) GetEth1DataForEth2Block(blockId string) (beacon.Et
 
 // Get the attestation committees for an epoch
 func (m *BeaconClientManager) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
-	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+	result, err := m.runFunction1(true, func(client beacon.Client) (interface{}, error) {
 		return client.GetCommitteesForEpoch(epoch)
 	})
 	if err != nil {
@@ -287,7 +319,7 @@ func (m *BeaconClientManager) GetCommitteesForEpoch(epoch *uint64) (beacon.Commi
 
 // Change the withdrawal credentials for a validator
 func (m *BeaconClientManager) ChangeWithdrawalCredentials(validatorIndex string, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error {
-	err := m.runFunction0(func(client beacon.Client) error {
+	err := m.runFunction0(false, func(client beacon.Client) error {
 		return client.ChangeWithdrawalCredentials(validatorIndex, fromBlsPubkey, toExecutionAddress, signature)
 	})
 	if err != nil {
@@ -296,45 +328,53 @@ func (m *BeaconClientManager) ChangeWithdrawalCredentials(validatorIndex string,
 	return nil
 }
 
+// Subscribe to the Beacon node's event stream; this blocks until the context is cancelled or the stream fails
+func (m *BeaconClientManager) StreamEvents(ctx context.Context, topics []beacon.EventTopic, events chan<- beacon.BeaconEvent) error {
+	err := m.runFunction0(false, func(client beacon.Client) error {
+		return client.StreamEvents(ctx, topics, events)
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 /// ==================
 /// Internal Functions
 /// ==================
 
 func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 
-	status := &api.ClientManagerStatus{
-		FallbackEnabled: m.fallbackBc != nil,
-	}
-
 	// Ignore the sync check and just use the predefined settings if requested
-	if m.ignoreSyncCheck {
-		status.PrimaryClientStatus.IsWorking = m.primaryReady
-		status.PrimaryClientStatus.IsSynced = m.primaryReady
-		if status.FallbackEnabled {
-			status.FallbackClientStatus.IsWorking = m.fallbackReady
-			status.FallbackClientStatus.IsSynced = m.fallbackReady
-		}
-		return status
+	if !m.pool.isIgnoringSyncCheck() {
+		m.probe()
 	}
+	return m.pool.getManagerStatus()
 
-	// Get the primary BC status
-	status.PrimaryClientStatus = checkBcStatus(m.primaryBc)
-
-	// Get the fallback BC status if applicable
-	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkBcStatus(m.fallbackBc)
-	}
+}
 
-	// Flag the ready clients
-	m.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
-	m.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
+// Get the status of each client from the last time they were checked, without checking them again
+func (m *BeaconClientManager) GetLastStatus() *api.ClientManagerStatus {
+	return m.pool.getManagerStatus()
+}
 
-	return status
+// Get the health of each client, in order of preference
+func (m *BeaconClientManager) GetEndpointStats() []ClientEndpointStats {
+	return m.pool.getStats()
+}
 
+// Check the status of every client and update their health
+func (m *BeaconClientManager) probe() {
+	for i, bc := range m.bcs {
+		start := time.Now()
+		status, head := checkBcStatus(bc)
+		m.pool.healths[i].setProbeResult(status, head, time.Since(start))
+	}
+	m.pool.finishProbe()
 }
 
 // Check the client status
-func checkBcStatus(client beacon.Client) api.ClientStatus {
+func checkBcStatus(client beacon.Client) (api.ClientStatus, uint64) {
 
 	status := api.ClientStatus{}
 
@@ -344,7 +384,7 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 		status.Error = fmt.Sprintf("Sync progress check failed with [%s]", err.Error())
 		status.IsSynced = false
 		status.IsWorking = false
-		return status
+		return status, 0
 	}
 
 	// Return the sync status
@@ -357,137 +397,42 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 		status.IsSynced = false
 		status.SyncProgress = syncStatus.Progress
 	}
-	return status
+	return status, syncStatus.HeadSlot
 
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
-
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction0(function)
-			}
-			// If it's a different error, just return it
-			return err
-		}
-		// If there's no error, return the result
-		return nil
-	}
-
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return fmt.Errorf("all Beacon clients failed")
-			}
-
-			// If it's a different error, just return it
-			return err
-		}
-		// If there's no error, return the result
-		return nil
-	}
-
-	return fmt.Errorf("no Beacon clients were ready")
+func (m *BeaconClientManager) runFunction0(retry bool, function bcFunction0) error {
+	return m.pool.run(context.Background(), retry, func(index int) error {
+		return function(m.bcs[index])
+	})
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, error) {
-
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		result, err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction1(function)
-			}
-			// If it's a different error, just return it
-			return nil, err
-		}
-		// If there's no error, return the result
-		return result, nil
-	}
-
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		result, err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return nil, fmt.Errorf("all Beacon clients failed")
-			}
-			// If it's a different error, just return it
-			return nil, err
-		}
-		// If there's no error, return the result
-		return result, nil
+func (m *BeaconClientManager) runFunction1(retry bool, function bcFunction1) (interface{}, error) {
+	var result interface{}
+	err := m.pool.run(context.Background(), retry, func(index int) error {
+		var err error
+		result, err = function(m.bcs[index])
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return nil, fmt.Errorf("no Beacon clients were ready")
-
+	return result, nil
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, interface{}, error) {
-
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		result1, result2, err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction2(function)
-			}
-			// If it's a different error, just return it
-			return nil, nil, err
-		}
-		// If there's no error, return the result
-		return result1, result2, nil
-	}
-
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		result1, result2, err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return nil, nil, fmt.Errorf("all Beacon clients failed")
-			}
-			// If it's a different error, just return it
-			return nil, nil, err
-		}
-		// If there's no error, return the result
-		return result1, result2, nil
+func (m *BeaconClientManager) runFunction2(retry bool, function bcFunction2) (interface{}, interface{}, error) {
+	var result1 interface{}
+	var result2 interface{}
+	err := m.pool.run(context.Background(), retry, func(index int) error {
+		var err error
+		result1, result2, err = function(m.bcs[index])
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
-
-	return nil, nil, fmt.Errorf("no Beacon clients were ready")
-
-}
-
-// Returns true if the error was a connection failure and a backup client is available
-func (m *BeaconClientManager) isDisconnected(err error) bool {
-	return strings.Contains(err.Error(), "dial tcp")
+	return result1, result2, nil
 }
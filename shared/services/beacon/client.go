@@ -1,6 +1,8 @@
 package beacon
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -16,6 +18,7 @@ type ValidatorStatusOptions struct {
 type SyncStatus struct {
 	Syncing  bool
 	Progress float64
+	HeadSlot uint64
 }
 type Eth2Config struct {
 	GenesisForkVersion           []byte
@@ -27,6 +30,11 @@ type Eth2Config struct {
 	SecondsPerEpoch              uint64
 	EpochsPerSyncCommitteePeriod uint64
 }
+type Fork struct {
+	PreviousVersion []byte
+	CurrentVersion  []byte
+	Epoch           uint64
+}
 type Eth2DepositContract struct {
 	ChainID uint64
 	Address common.Address
@@ -63,6 +71,7 @@ type BeaconBlock struct {
 	Attestations         []AttestationInfo
 	FeeRecipient         common.Address
 	ExecutionBlockNumber uint64
+	SyncCommitteeBits    bitfield.Bitvector512
 }
 
 // Committees is an interface as an optimization- since committees responses
@@ -88,6 +97,26 @@ type Committees interface {
 	Release()
 }
 
+// Topics that can be subscribed to on the Beacon node's event stream
+type EventTopic string
+
+const (
+	EventTopic_Head                EventTopic = "head"
+	EventTopic_FinalizedCheckpoint EventTopic = "finalized_checkpoint"
+	EventTopic_ChainReorg          EventTopic = "chain_reorg"
+)
+
+// An event from the Beacon node's event stream.
+// Slot is set for head and chain_reorg events, Epoch for finalized_checkpoint
+// and chain_reorg events, and Depth for chain_reorg events only.
+type BeaconEvent struct {
+	Topic EventTopic
+	Slot  uint64
+	Epoch uint64
+	Block common.Hash
+	Depth uint64
+}
+
 type AttestationInfo struct {
 	AggregationBits bitfield.Bitlist
 	SlotIndex       uint64
@@ -140,11 +169,15 @@ type Client interface {
 	GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error)
 	GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error)
 	GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error)
+	GetValidatorSyncCommitteeIndices(indices []string, epoch uint64) (map[string][]uint64, error)
 	GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error)
+	GetValidatorLiveness(indices []string, epoch uint64) (map[string]bool, error)
 	GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error)
+	GetFork() (Fork, error)
 	ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error
 	Close() error
 	GetEth1DataForEth2Block(blockId string) (Eth1Data, bool, error)
 	GetCommitteesForEpoch(epoch *uint64) (Committees, error)
 	ChangeWithdrawalCredentials(validatorIndex string, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error
+	StreamEvents(ctx context.Context, topics []EventTopic, events chan<- BeaconEvent) error
 }
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Config
+const (
+	RequestEventsPath       = "/eth/v1/events?topics=%s"
+	EventStreamContentType  = "text/event-stream"
+	eventStreamMaxLineBytes = 1024 * 1024
+)
+
+// Event stream payloads
+type HeadEventData struct {
+	Slot  uinteger `json:"slot"`
+	Block string   `json:"block"`
+}
+type FinalizedCheckpointEventData struct {
+	Block string   `json:"block"`
+	Epoch uinteger `json:"epoch"`
+}
+type ChainReorgEventData struct {
+	Slot         uinteger `json:"slot"`
+	Depth        uinteger `json:"depth"`
+	NewHeadBlock string   `json:"new_head_block"`
+	Epoch        uinteger `json:"epoch"`
+}
+
+// Subscribe to the Beacon node's server-sent event stream for the provided topics.
+// This blocks until the context is cancelled or the stream fails; it never returns nil unless the context was cancelled.
+func (c *StandardHttpClient) StreamEvents(ctx context.Context, topics []beacon.EventTopic, events chan<- beacon.BeaconEvent) error {
+
+	// Build the request
+	topicStrings := make([]string, len(topics))
+	for i, topic := range topics {
+		topicStrings[i] = string(topic)
+	}
+	requestPath := fmt.Sprintf(RequestEventsPath, strings.Join(topicStrings, ","))
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), nil)
+	if err != nil {
+		return fmt.Errorf("Could not create event stream request: %w", err)
+	}
+	request.Header.Set("Accept", EventStreamContentType)
+
+	// Open the stream
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("Could not subscribe to event stream: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("Could not subscribe to event stream: HTTP status %d; response body: '%s'", response.StatusCode, string(body))
+	}
+
+	// Read events until the stream closes; each event is a block of "field: value" lines terminated by an empty line
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 4096), eventStreamMaxLineBytes)
+	var eventType string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if eventType != "" {
+				event, err := parseEvent(beacon.EventTopic(eventType), []byte(data.String()))
+				if err != nil {
+					return err
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+			eventType = ""
+			data.Reset()
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("Error reading event stream: %w", err)
+	}
+	return fmt.Errorf("Event stream was closed by the Beacon node")
+
+}
+
+// Convert a raw event stream payload into a Beacon event
+func parseEvent(topic beacon.EventTopic, data []byte) (beacon.BeaconEvent, error) {
+	event := beacon.BeaconEvent{
+		Topic: topic,
+	}
+	switch topic {
+	case beacon.EventTopic_Head:
+		var head HeadEventData
+		if err := json.Unmarshal(data, &head); err != nil {
+			return beacon.BeaconEvent{}, fmt.Errorf("Could not decode head event: %w", err)
+		}
+		event.Slot = uint64(head.Slot)
+		event.Block = common.HexToHash(head.Block)
+
+	case beacon.EventTopic_FinalizedCheckpoint:
+		var checkpoint FinalizedCheckpointEventData
+		if err := json.Unmarshal(data, &checkpoint); err != nil {
+			return beacon.BeaconEvent{}, fmt.Errorf("Could not decode finalized checkpoint event: %w", err)
+		}
+		event.Epoch = uint64(checkpoint.Epoch)
+		event.Block = common.HexToHash(checkpoint.Block)
+
+	case beacon.EventTopic_ChainReorg:
+		var reorg ChainReorgEventData
+		if err := json.Unmarshal(data, &reorg); err != nil {
+			return beacon.BeaconEvent{}, fmt.Errorf("Could not decode chain reorg event: %w", err)
+		}
+		event.Slot = uint64(reorg.Slot)
+		event.Epoch = uint64(reorg.Epoch)
+		event.Depth = uint64(reorg.Depth)
+		event.Block = common.HexToHash(reorg.NewHeadBlock)
+	}
+	return event, nil
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	RequestSSZContentType  = "application/octet-stream"
+	RequestSSZAcceptHeader = RequestSSZContentType + ";q=1.0," + RequestContentType + ";q=0.9"
+	ConsensusVersionHeader = "Eth-Consensus-Version"
+
+	consensusVersionPhase0    = "phase0"
+	consensusVersionAltair    = "altair"
+	consensusVersionBellatrix = "bellatrix"
+	consensusVersionCapella   = "capella"
+)
+
+// Make a GET request that prefers an SSZ response, falling back to JSON if the Beacon node doesn't support SSZ for the route.
+// Returns the body, the status code, the consensus version of the response if it was SSZ-encoded (or an empty string if it was JSON), and any error.
+func (c *StandardHttpClient) getSSZRequest(requestPath string) ([]byte, int, string, error) {
+
+	// Skip straight to JSON if the Beacon node has already refused an SSZ request
+	if c.sszDisabled.Load() {
+		body, status, err := c.getRequest(requestPath)
+		return body, status, "", err
+	}
+
+	// Send request
+	response, err := c.sendRequest(http.MethodGet, requestPath, nil, map[string]string{"Accept": RequestSSZAcceptHeader})
+	if err != nil {
+		return []byte{}, 0, "", err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Some Beacon nodes reject content negotiation outright; use JSON with them from now on
+	if response.StatusCode == http.StatusNotAcceptable || response.StatusCode == http.StatusUnsupportedMediaType {
+		c.sszDisabled.Store(true)
+		body, status, err := c.getRequest(requestPath)
+		return body, status, "", err
+	}
+
+	// Get response
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, 0, "", err
+	}
+	if response.StatusCode != http.StatusOK || !strings.HasPrefix(response.Header.Get("Content-Type"), RequestSSZContentType) {
+		return body, response.StatusCode, "", nil
+	}
+
+	// The consensus version is required to know which fork's schema the response uses
+	version := strings.ToLower(response.Header.Get(ConsensusVersionHeader))
+	if version == "" {
+		return []byte{}, 0, "", fmt.Errorf("SSZ response for %s didn't include the %s header", requestPath, ConsensusVersionHeader)
+	}
+	return body, response.StatusCode, version, nil
+
+}
+
+// Decode an SSZ-encoded signed beacon block into the same response the JSON route produces.
+// Returns false if the consensus version isn't one this client knows how to decode; that includes Deneb and later forks,
+// since the Prysm types this is built on stop at Capella, so getBeaconBlock requests those blocks again as JSON.
+func decodeBeaconBlockSSZ(version string, body []byte) (BeaconBlockResponse, bool, error) {
+
+	var response BeaconBlockResponse
+	message := &response.Data.Message
+	var eth1Data *ethpb.Eth1Data
+	var attestations []*ethpb.Attestation
+
+	switch version {
+	case consensusVersionPhase0:
+		var block ethpb.SignedBeaconBlock
+		if err := block.UnmarshalSSZ(body); err != nil {
+			return BeaconBlockResponse{}, false, err
+		}
+		message.Slot = uinteger(block.Block.Slot)
+		message.ProposerIndex = strconv.FormatUint(uint64(block.Block.ProposerIndex), 10)
+		eth1Data = block.Block.Body.Eth1Data
+		attestations = block.Block.Body.Attestations
+
+	case consensusVersionAltair:
+		var block ethpb.SignedBeaconBlockAltair
+		if err := block.UnmarshalSSZ(body); err != nil {
+			return BeaconBlockResponse{}, false, err
+		}
+		message.Slot = uinteger(block.Block.Slot)
+		message.ProposerIndex = strconv.FormatUint(uint64(block.Block.ProposerIndex), 10)
+		eth1Data = block.Block.Body.Eth1Data
+		attestations = block.Block.Body.Attestations
+		setSyncAggregate(&response, block.Block.Body.SyncAggregate.SyncCommitteeBits)
+
+	case consensusVersionBellatrix:
+		var block ethpb.SignedBeaconBlockBellatrix
+		if err := block.UnmarshalSSZ(body); err != nil {
+			return BeaconBlockResponse{}, false, err
+		}
+		message.Slot = uinteger(block.Block.Slot)
+		message.ProposerIndex = strconv.FormatUint(uint64(block.Block.ProposerIndex), 10)
+		eth1Data = block.Block.Body.Eth1Data
+		attestations = block.Block.Body.Attestations
+		setSyncAggregate(&response, block.Block.Body.SyncAggregate.SyncCommitteeBits)
+		payload := block.Block.Body.ExecutionPayload
+		setExecutionPayload(&response, payload.FeeRecipient, payload.BlockNumber)
+
+	case consensusVersionCapella:
+		var block ethpb.SignedBeaconBlockCapella
+		if err := block.UnmarshalSSZ(body); err != nil {
+			return BeaconBlockResponse{}, false, err
+		}
+		message.Slot = uinteger(block.Block.Slot)
+		message.ProposerIndex = strconv.FormatUint(uint64(block.Block.ProposerIndex), 10)
+		eth1Data = block.Block.Body.Eth1Data
+		attestations = block.Block.Body.Attestations
+		setSyncAggregate(&response, block.Block.Body.SyncAggregate.SyncCommitteeBits)
+		payload := block.Block.Body.ExecutionPayload
+		setExecutionPayload(&response, payload.FeeRecipient, payload.BlockNumber)
+
+	default:
+		return BeaconBlockResponse{}, false, nil
+	}
+
+	// Eth1 data
+	message.Body.Eth1Data.DepositRoot = eth1Data.DepositRoot
+	message.Body.Eth1Data.DepositCount = uinteger(eth1Data.DepositCount)
+	message.Body.Eth1Data.BlockHash = eth1Data.BlockHash
+
+	// Attestations
+	message.Body.Attestations = make([]Attestation, len(attestations))
+	for i, attestation := range attestations {
+		message.Body.Attestations[i].AggregationBits = hexutil.AddPrefix(hex.EncodeToString(attestation.AggregationBits))
+		message.Body.Attestations[i].Data.Slot = uinteger(attestation.Data.Slot)
+		message.Body.Attestations[i].Data.Index = uinteger(attestation.Data.CommitteeIndex)
+	}
+
+	return response, true, nil
+
+}
+
+// Set the execution payload details of a block response
+func setExecutionPayload(response *BeaconBlockResponse, feeRecipient []byte, blockNumber uint64) {
+	response.Data.Message.Body.ExecutionPayload = &struct {
+		FeeRecipient byteArray `json:"fee_recipient"`
+		BlockNumber  uinteger  `json:"block_number"`
+	}{
+		FeeRecipient: feeRecipient,
+		BlockNumber:  uinteger(blockNumber),
+	}
+}
+
+// Set the sync aggregate details of a block response
+func setSyncAggregate(response *BeaconBlockResponse, syncCommitteeBits []byte) {
+	response.Data.Message.Body.SyncAggregate = &struct {
+		SyncCommitteeBits string `json:"sync_committee_bits"`
+	}{
+		SyncCommitteeBits: hexutil.AddPrefix(hex.EncodeToString(syncCommitteeBits)),
+	}
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/prysmaticlabs/go-bitfield"
+	primitives "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+// The Beacon API's JSON encoding of the block built by getTestCapellaBlock, trimmed to the fields this client reads
+var testCapellaBlockJson = `{
+	"version": "capella",
+	"data": {
+		"message": {
+			"slot": "6543210",
+			"proposer_index": "123456",
+			"parent_root": "0x0000000000000000000000000000000000000000000000000000000000000000",
+			"body": {
+				"eth1_data": {
+					"deposit_root": "0x1111111111111111111111111111111111111111111111111111111111111111",
+					"deposit_count": "987654",
+					"block_hash": "0x2222222222222222222222222222222222222222222222222222222222222222"
+				},
+				"attestations": [
+					{"aggregation_bits": "0x0502", "data": {"slot": "6543209", "index": "3"}},
+					{"aggregation_bits": "0x80", "data": {"slot": "6543180", "index": "17"}}
+				],
+				"sync_aggregate": {
+					"sync_committee_bits": "0xff01` + strings.Repeat("00", 62) + `"
+				},
+				"execution_payload": {
+					"fee_recipient": "0x3333333333333333333333333333333333333333",
+					"block_number": "17000000"
+				}
+			}
+		}
+	}
+}`
+
+// Build a Capella block with the same contents as testCapellaBlockJson.
+// There's no network access where these tests run, so this stands in for a block downloaded from mainnet.
+func getTestCapellaBlock() *ethpb.SignedBeaconBlockCapella {
+	syncBits := bitfield.NewBitvector512()
+	for i := uint64(0); i < 9; i++ {
+		syncBits.SetBitAt(i, true)
+	}
+	newAttestation := func(bits bitfield.Bitlist, slot uint64, committeeIndex uint64) *ethpb.Attestation {
+		return &ethpb.Attestation{
+			AggregationBits: bits,
+			Data: &ethpb.AttestationData{
+				Slot:            primitives.Slot(slot),
+				CommitteeIndex:  primitives.CommitteeIndex(committeeIndex),
+				BeaconBlockRoot: make([]byte, 32),
+				Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+				Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			},
+			Signature: make([]byte, 96),
+		}
+	}
+
+	return &ethpb.SignedBeaconBlockCapella{
+		Block: &ethpb.BeaconBlockCapella{
+			Slot:          6543210,
+			ProposerIndex: 123456,
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     make([]byte, 32),
+			Body: &ethpb.BeaconBlockBodyCapella{
+				RandaoReveal: make([]byte, 96),
+				Eth1Data: &ethpb.Eth1Data{
+					DepositRoot:  bytes.Repeat([]byte{0x11}, 32),
+					DepositCount: 987654,
+					BlockHash:    bytes.Repeat([]byte{0x22}, 32),
+				},
+				Graffiti: make([]byte, 32),
+				Attestations: []*ethpb.Attestation{
+					newAttestation(bitfield.Bitlist{0x05, 0x02}, 6543209, 3),
+					newAttestation(bitfield.Bitlist{0x80}, 6543180, 17),
+				},
+				SyncAggregate: &ethpb.SyncAggregate{
+					SyncCommitteeBits:      syncBits,
+					SyncCommitteeSignature: make([]byte, 96),
+				},
+				ExecutionPayload: &enginev1.ExecutionPayloadCapella{
+					ParentHash:    make([]byte, 32),
+					FeeRecipient:  bytes.Repeat([]byte{0x33}, 20),
+					StateRoot:     make([]byte, 32),
+					ReceiptsRoot:  make([]byte, 32),
+					LogsBloom:     make([]byte, 256),
+					PrevRandao:    make([]byte, 32),
+					BlockNumber:   17000000,
+					BaseFeePerGas: make([]byte, 32),
+					BlockHash:     make([]byte, 32),
+				},
+			},
+		},
+		Signature: make([]byte, 96),
+	}
+}
+
+func TestDecodeCapellaBlockSSZ(t *testing.T) {
+	body, err := getTestCapellaBlock().MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sszBlock, supported, err := decodeBeaconBlockSSZ(consensusVersionCapella, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !supported {
+		t.Fatalf("capella blocks should be supported")
+	}
+
+	var jsonBlock BeaconBlockResponse
+	if err := json.Unmarshal([]byte(testCapellaBlockJson), &jsonBlock); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sszBlock, jsonBlock) {
+		t.Fatalf("SSZ and JSON blocks differ:\nSSZ:  %+v\nJSON: %+v", sszBlock, jsonBlock)
+	}
+}
+
+func TestDecodeUnknownForkSSZ(t *testing.T) {
+	_, supported, err := decodeBeaconBlockSSZ("deneb", []byte{0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if supported {
+		t.Fatalf("deneb blocks shouldn't be decoded as SSZ")
+	}
+}
+
+func TestGetBeaconBlockFallsBackToJson(t *testing.T) {
+	// A Beacon node that serves a fork the SSZ decoder doesn't know about
+	sszRequests := 0
+	jsonRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Accept"), RequestSSZContentType) {
+			sszRequests++
+			w.Header().Set("Content-Type", RequestSSZContentType)
+			w.Header().Set(ConsensusVersionHeader, "deneb")
+			_, _ = w.Write([]byte{0x01, 0x02})
+			return
+		}
+		jsonRequests++
+		w.Header().Set("Content-Type", RequestContentType)
+		_, _ = w.Write([]byte(testCapellaBlockJson))
+	}))
+	defer server.Close()
+
+	client := NewStandardHttpClient(server.URL, 1)
+	block, found, err := client.getBeaconBlock("6543210")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || block.Data.Message.Slot != 6543210 || len(block.Data.Message.Body.Attestations) != 2 {
+		t.Fatalf("unexpected block: %+v", block)
+	}
+	if sszRequests != 1 || jsonRequests != 1 {
+		t.Fatalf("expected one SSZ request and one JSON request, got %d and %d", sszRequests, jsonRequests)
+	}
+}
+
+func TestGetBeaconBlockSSZ(t *testing.T) {
+	body, err := getTestCapellaBlock().MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Accept"), RequestSSZContentType) {
+			t.Errorf("expected an SSZ request, got Accept: %s", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", RequestSSZContentType)
+		w.Header().Set(ConsensusVersionHeader, "capella")
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewStandardHttpClient(server.URL, 1)
+	block, found, err := client.getBeaconBlock("6543210")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || block.Data.Message.ProposerIndex != "123456" || block.Data.Message.Body.ExecutionPayload == nil || block.Data.Message.Body.ExecutionPayload.BlockNumber != 17000000 {
+		t.Fatalf("unexpected block: %+v", block)
+	}
+}
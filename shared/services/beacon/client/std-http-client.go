@@ -2,13 +2,16 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -41,21 +44,45 @@ const (
 	RequestBeaconBlockPath                 = "/eth/v2/beacon/blocks/%s"
 	RequestValidatorSyncDuties             = "/eth/v1/validator/duties/sync/%s"
 	RequestValidatorProposerDuties         = "/eth/v1/validator/duties/proposer/%s"
+	RequestValidatorLiveness               = "/eth/v1/validator/liveness/%s"
 	RequestWithdrawalCredentialsChangePath = "/eth/v1/beacon/pool/bls_to_execution_changes"
 
 	MaxRequestValidatorsCount     = 600
-	threadLimit               int = 12
+	MaxPostRequestValidatorsCount = 10000
+	DefaultRequestConcurrency     = 12
+
+	// The most of a server error's response body to include in the error
+	maxErrorBodyLength = 1024
+
+	RequestTimeout      = 30 * time.Second
+	StateRequestTimeout = 5 * time.Minute
 )
 
+// Returned when the Beacon node doesn't support the POST form of the validators route
+var errPostValidatorsUnsupported = errors.New("POST validators route is not supported")
+
+// Returned when the Beacon node rejects a request because too many have been sent to it
+var ErrRateLimited = errors.New("the Beacon node is rate limiting requests")
+
+// Returned when the Beacon node fails with a server error, such as a 503 while it's syncing
+var ErrUnavailable = errors.New("the Beacon node is unavailable")
+
 // Beacon client using the standard Beacon HTTP REST API (https://ethereum.github.io/beacon-APIs/)
 type StandardHttpClient struct {
-	providerAddress string
+	providerAddress           string
+	sszDisabled               atomic.Bool
+	postValidatorsUnsupported atomic.Bool
+	requestLimiter            chan struct{}
 }
 
-// Create a new client instance
-func NewStandardHttpClient(providerAddress string) *StandardHttpClient {
+// Create a new client instance; concurrency is the maximum number of validator and duty requests that can be in flight at once
+func NewStandardHttpClient(providerAddress string, concurrency int) *StandardHttpClient {
+	if concurrency <= 0 {
+		concurrency = DefaultRequestConcurrency
+	}
 	return &StandardHttpClient{
 		providerAddress: providerAddress,
+		requestLimiter:  make(chan struct{}, concurrency),
 	}
 }
 
@@ -85,6 +112,7 @@ func (c *StandardHttpClient) GetSyncStatus() (beacon.SyncStatus, error) {
 	return beacon.SyncStatus{
 		Syncing:  syncStatus.Data.IsSyncing,
 		Progress: progress,
+		HeadSlot: uint64(syncStatus.Data.HeadSlot),
 	}, nil
 
 }
@@ -303,7 +331,9 @@ func (c *StandardHttpClient) GetValidatorStatuses(pubkeys []types.ValidatorPubke
 func (c *StandardHttpClient) GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error) {
 
 	// Perform the post request
+	c.acquireRequestSlot()
 	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorSyncDuties, strconv.FormatUint(epoch, 10)), indices)
+	c.releaseRequestSlot()
 
 	if err != nil {
 		return nil, fmt.Errorf("Could not get validator sync duties: %w", err)
@@ -333,11 +363,46 @@ func (c *StandardHttpClient) GetValidatorSyncDuties(indices []string, epoch uint
 	return validatorMap, nil
 }
 
+// Get the positions of validators in the sync committee for the given epoch; validators that aren't on it are omitted
+func (c *StandardHttpClient) GetValidatorSyncCommitteeIndices(indices []string, epoch uint64) (map[string][]uint64, error) {
+
+	// Perform the post request
+	c.acquireRequestSlot()
+	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorSyncDuties, strconv.FormatUint(epoch, 10)), indices)
+	c.releaseRequestSlot()
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator sync duties: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator sync duties: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response SyncDutiesResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator sync duties data: %w", err)
+	}
+
+	// Map the results
+	validatorMap := make(map[string][]uint64)
+	for _, duty := range response.Data {
+		positions := make([]uint64, len(duty.SyncCommitteeIndices))
+		for i, position := range duty.SyncCommitteeIndices {
+			positions[i] = uint64(position)
+		}
+		validatorMap[duty.ValidatorIndex] = positions
+	}
+
+	return validatorMap, nil
+}
+
 // Sums proposer duties per validators for a given epoch
 func (c *StandardHttpClient) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
 
-	// Perform the post request
+	// Perform the request
+	c.acquireRequestSlot()
 	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorProposerDuties, strconv.FormatUint(epoch, 10)))
+	c.releaseRequestSlot()
 
 	if err != nil {
 		return nil, fmt.Errorf("Could not get validator proposer duties: %w", err)
@@ -367,6 +432,40 @@ func (c *StandardHttpClient) GetValidatorProposerDuties(indices []string, epoch
 	return proposerMap, nil
 }
 
+// Check which validators were seen performing their duties (e.g. attesting) in the given epoch
+func (c *StandardHttpClient) GetValidatorLiveness(indices []string, epoch uint64) (map[string]bool, error) {
+
+	// Perform the post request
+	c.acquireRequestSlot()
+	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorLiveness, strconv.FormatUint(epoch, 10)), indices)
+	c.releaseRequestSlot()
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator liveness: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator liveness: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response LivenessResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator liveness data: %w", err)
+	}
+
+	// Map the results
+	livenessMap := make(map[string]bool)
+	for _, index := range indices {
+		livenessMap[index] = false
+	}
+	for _, liveness := range response.Data {
+		if _, exists := livenessMap[liveness.Index]; exists {
+			livenessMap[liveness.Index] = liveness.IsLive
+		}
+	}
+
+	return livenessMap, nil
+}
+
 // Get a validator's index
 func (c *StandardHttpClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
 
@@ -430,6 +529,19 @@ func (c *StandardHttpClient) GetDomainData(domainType []byte, epoch uint64, useG
 
 }
 
+// Get the fork at the head of the chain
+func (c *StandardHttpClient) GetFork() (beacon.Fork, error) {
+	fork, err := c.getFork("head")
+	if err != nil {
+		return beacon.Fork{}, err
+	}
+	return beacon.Fork{
+		PreviousVersion: fork.Data.PreviousVersion,
+		CurrentVersion:  fork.Data.CurrentVersion,
+		Epoch:           uint64(fork.Data.Epoch),
+	}, nil
+}
+
 // Perform a voluntary exit on a validator
 func (c *StandardHttpClient) ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error {
 	return c.postVoluntaryExit(VoluntaryExitRequest{
@@ -509,6 +621,15 @@ func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock,
 		beaconBlock.ExecutionBlockNumber = uint64(block.Data.Message.Body.ExecutionPayload.BlockNumber)
 	}
 
+	// Sync aggregates only exist after Altair
+	if block.Data.Message.Body.SyncAggregate != nil {
+		bitString := hexutil.RemovePrefix(block.Data.Message.Body.SyncAggregate.SyncCommitteeBits)
+		beaconBlock.SyncCommitteeBits, err = hex.DecodeString(bitString)
+		if err != nil {
+			return beacon.BeaconBlock{}, false, fmt.Errorf("Error decoding sync committee bits of block %s: %w", blockId, err)
+		}
+	}
+
 	// Add attestation info
 	for i, attestation := range block.Data.Message.Body.Attestations {
 		bitString := hexutil.RemovePrefix(attestation.AggregationBits)
@@ -645,12 +766,15 @@ func (c *StandardHttpClient) getFork(stateId string) (ForkResponse, error) {
 }
 
 // Get validators
+// The Beacon API only defines a JSON encoding for this route, so unlike blocks it can't be requested as SSZ
 func (c *StandardHttpClient) getValidators(stateId string, pubkeys []string) (ValidatorsResponse, error) {
 	var query string
 	if len(pubkeys) > 0 {
 		query = fmt.Sprintf("?id=%s", strings.Join(pubkeys, ","))
 	}
+	c.acquireRequestSlot()
 	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorsPath, stateId) + query)
+	c.releaseRequestSlot()
 	if err != nil {
 		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: %w", err)
 	}
@@ -689,14 +813,33 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 		return ValidatorsResponse{}, fmt.Errorf("must specify a slot or epoch when calling getValidatorsByOpts")
 	}
 
+	// Prefer the POST form of the route, which can take far more validators per request
+	if !c.postValidatorsUnsupported.Load() {
+		validators, err := c.getValidatorsInBatches(stateId, pubkeysOrIndices, MaxPostRequestValidatorsCount, c.postValidators)
+		if !errors.Is(err, errPostValidatorsUnsupported) {
+			return validators, err
+		}
+
+		// Only stop trying POST once GET works, in case the failure was caused by the state rather than the route
+		validators, err = c.getValidatorsInBatches(stateId, pubkeysOrIndices, MaxRequestValidatorsCount, c.getValidators)
+		if err == nil {
+			c.postValidatorsUnsupported.Store(true)
+		}
+		return validators, err
+	}
+
+	return c.getValidatorsInBatches(stateId, pubkeysOrIndices, MaxRequestValidatorsCount, c.getValidators)
+}
+
+// Get validators in concurrent batches of the provided size
+func (c *StandardHttpClient) getValidatorsInBatches(stateId string, pubkeysOrIndices []string, batchSize int, getBatch func(string, []string) (ValidatorsResponse, error)) (ValidatorsResponse, error) {
 	count := len(pubkeysOrIndices)
 	data := make([]Validator, count)
 	validFlags := make([]bool, count)
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
-	for i := 0; i < count; i += MaxRequestValidatorsCount {
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + MaxRequestValidatorsCount
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
@@ -704,7 +847,7 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 		wg.Go(func() error {
 			// Get & add validators
 			batch := pubkeysOrIndices[i:max]
-			validators, err := c.getValidators(stateId, batch)
+			validators, err := getBatch(stateId, batch)
 			if err != nil {
 				return fmt.Errorf("error getting validator statuses: %w", err)
 			}
@@ -731,6 +874,41 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 	return ValidatorsResponse{Data: trueData}, nil
 }
 
+// Get validators with the POST form of the route, which takes the IDs in the request body instead of the query string
+func (c *StandardHttpClient) postValidators(stateId string, pubkeysOrIndices []string) (ValidatorsResponse, error) {
+	request := ValidatorsRequest{
+		IDs: pubkeysOrIndices,
+	}
+	c.acquireRequestSlot()
+	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorsPath, stateId), request)
+	c.releaseRequestSlot()
+	if err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: %w", err)
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusUnsupportedMediaType, http.StatusNotImplemented:
+		return ValidatorsResponse{}, fmt.Errorf("%w: HTTP status %d; response body: '%s'", errPostValidatorsUnsupported, status, string(responseBody))
+	default:
+		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var validators ValidatorsResponse
+	if err := json.Unmarshal(responseBody, &validators); err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("Could not decode validators: %w", err)
+	}
+	return validators, nil
+}
+
+// Wait until another validator or duty request can be sent to the Beacon node
+func (c *StandardHttpClient) acquireRequestSlot() {
+	c.requestLimiter <- struct{}{}
+}
+
+// Let the next waiting validator or duty request through
+func (c *StandardHttpClient) releaseRequestSlot() {
+	<-c.requestLimiter
+}
+
 // Send voluntary exit request
 func (c *StandardHttpClient) postVoluntaryExit(request VoluntaryExitRequest) error {
 	responseBody, status, err := c.postRequest(RequestVoluntaryExitPath, request)
@@ -764,7 +942,9 @@ func (c *StandardHttpClient) getAttestations(blockId string) (AttestationsRespon
 
 // Get the target beacon block
 func (c *StandardHttpClient) getBeaconBlock(blockId string) (BeaconBlockResponse, bool, error) {
-	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestBeaconBlockPath, blockId))
+	// Blocks are requested as SSZ where possible, since it's much cheaper to decode than JSON
+	requestPath := fmt.Sprintf(RequestBeaconBlockPath, blockId)
+	responseBody, status, version, err := c.getSSZRequest(requestPath)
 	if err != nil {
 		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: %w", err)
 	}
@@ -774,6 +954,24 @@ func (c *StandardHttpClient) getBeaconBlock(blockId string) (BeaconBlockResponse
 	if status != http.StatusOK {
 		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: HTTP status %d; response body: '%s'", status, string(responseBody))
 	}
+	if version != "" {
+		beaconBlock, supported, err := decodeBeaconBlockSSZ(version, responseBody)
+		if err != nil {
+			return BeaconBlockResponse{}, false, fmt.Errorf("Could not decode SSZ beacon block data: %w", err)
+		}
+		if supported {
+			return beaconBlock, true, nil
+		}
+
+		// This is a fork the SSZ decoder doesn't know about yet (such as Deneb), so get it as JSON instead
+		responseBody, status, err = c.getRequest(requestPath)
+		if err != nil {
+			return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: %w", err)
+		}
+		if status != http.StatusOK {
+			return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: HTTP status %d; response body: '%s'", status, string(responseBody))
+		}
+	}
 	var beaconBlock BeaconBlockResponse
 	if err := json.Unmarshal(responseBody, &beaconBlock); err != nil {
 		return BeaconBlockResponse{}, false, fmt.Errorf("Could not decode beacon block data: %w", err)
@@ -821,7 +1019,7 @@ func (c *StandardHttpClient) getCommittees(stateId string, epoch *uint64) (Commi
 		query = fmt.Sprintf("?epoch=%d", *epoch)
 	}
 
-	// Committees responses are large, so let the json decoder read it in a buffered fashion
+	// Committees responses are large, so let the json decoder read it in a buffered fashion.
 	reader, status, err := c.getRequestReader(fmt.Sprintf(RequestCommitteePath, stateId) + query)
 	if err != nil {
 		return CommitteesResponse{}, fmt.Errorf("Could not get committees: %w", err)
@@ -868,7 +1066,7 @@ func (c *StandardHttpClient) postWithdrawalCredentialsChange(request BLSToExecut
 func (c *StandardHttpClient) getRequestReader(requestPath string) (io.ReadCloser, int, error) {
 
 	// Send request
-	response, err := http.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
+	response, err := c.sendRequest(http.MethodGet, requestPath, nil, nil)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -909,7 +1107,7 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	requestBodyReader := bytes.NewReader(requestBodyBytes)
 
 	// Send request
-	response, err := http.Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
+	response, err := c.sendRequest(http.MethodPost, requestPath, requestBodyReader, map[string]string{"Content-Type": RequestContentType})
 	if err != nil {
 		return []byte{}, 0, err
 	}
@@ -927,3 +1125,64 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	return body, response.StatusCode, nil
 
 }
+
+// Send a request to the Beacon node with a deadline.
+// The deadline covers reading the response body too, and is released when the body is closed.
+func (c *StandardHttpClient) sendRequest(method string, requestPath string, body io.Reader, headers map[string]string) (*http.Response, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), getRequestTimeout(requestPath))
+	request, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), body)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if response.StatusCode == http.StatusTooManyRequests {
+		_ = response.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("%w (%s)", ErrRateLimited, requestPath)
+	}
+	// 501 means the route isn't supported, which callers handle themselves
+	if response.StatusCode >= http.StatusInternalServerError && response.StatusCode != http.StatusNotImplemented {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodyLength))
+		_ = response.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("%w (%s): HTTP status %d; response body: '%s'", ErrUnavailable, requestPath, response.StatusCode, string(responseBody))
+	}
+
+	response.Body = &cancelOnClose{
+		ReadCloser: response.Body,
+		cancel:     cancel,
+	}
+	return response, nil
+
+}
+
+// Get the deadline for a request; routes that return data about every validator get longer
+func getRequestTimeout(requestPath string) time.Duration {
+	if strings.HasPrefix(requestPath, "/eth/v1/beacon/states/") &&
+		(strings.Contains(requestPath, "/validators") || strings.Contains(requestPath, "/committees")) {
+		return StateRequestTimeout
+	}
+	return RequestTimeout
+}
+
+// A response body that releases its request's deadline when it's closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close the body and release the deadline
+func (r *cancelOnClose) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
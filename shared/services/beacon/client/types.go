@@ -19,6 +19,9 @@ type VoluntaryExitRequest struct {
 	Message   VoluntaryExitMessage `json:"message"`
 	Signature byteArray            `json:"signature"`
 }
+type ValidatorsRequest struct {
+	IDs []string `json:"ids"`
+}
 type BLSToExecutionChangeMessage struct {
 	ValidatorIndex     string    `json:"validator_index"`
 	FromBLSPubkey      byteArray `json:"from_bls_pubkey"`
@@ -96,6 +99,9 @@ type BeaconBlockResponse struct {
 					FeeRecipient byteArray `json:"fee_recipient"`
 					BlockNumber  uinteger  `json:"block_number"`
 				} `json:"execution_payload"`
+				SyncAggregate *struct {
+					SyncCommitteeBits string `json:"sync_committee_bits"`
+				} `json:"sync_aggregate"`
 			} `json:"body"`
 		} `json:"message"`
 	} `json:"data"`
@@ -126,6 +132,13 @@ type SyncDuty struct {
 	ValidatorIndex       string     `json:"validator_index"`
 	SyncCommitteeIndices []uinteger `json:"validator_sync_committee_indices"`
 }
+type LivenessResponse struct {
+	Data []ValidatorLiveness `json:"data"`
+}
+type ValidatorLiveness struct {
+	Index  string `json:"index"`
+	IsLive bool   `json:"is_live"`
+}
 type ProposerDutiesResponse struct {
 	Data []ProposerDuty `json:"data"`
 }
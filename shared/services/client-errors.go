@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+)
+
+// The JSON-RPC error code hosted providers use when a request exceeds their rate limit
+const rpcLimitExceededCode int = -32005
+
+// The kind of failure returned by a client request
+type clientErrorType int
+
+const (
+	// The request succeeded
+	clientError_None clientErrorType = iota
+
+	// The client couldn't be reached
+	clientError_Connection
+
+	// The client didn't respond in time
+	clientError_Timeout
+
+	// The client rejected the request because too many have been sent to it
+	clientError_RateLimit
+
+	// The client handled the request but returned an error, such as a contract revert or a missing block
+	clientError_Rpc
+)
+
+// Get a description of an error type for logging
+func (t clientErrorType) String() string {
+	switch t {
+	case clientError_None:
+		return "none"
+	case clientError_Connection:
+		return "connection failure"
+	case clientError_Timeout:
+		return "timeout"
+	case clientError_RateLimit:
+		return "rate limit"
+	default:
+		return "request error"
+	}
+}
+
+// Work out what kind of failure an error from a client represents.
+// Connection failures, timeouts and rate limits are problems with the client itself, so the request can be sent to another client;
+// anything else came from the client handling the request and would be the same on any other client.
+func classifyClientError(err error) clientErrorType {
+	if err == nil {
+		return clientError_None
+	}
+
+	// Timeouts
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return clientError_Timeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return clientError_Timeout
+	}
+
+	// Rate limits and HTTP-level failures
+	if errors.Is(err, client.ErrRateLimited) {
+		return clientError_RateLimit
+	}
+	if errors.Is(err, client.ErrUnavailable) {
+		return clientError_Connection
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusTooManyRequests:
+			return clientError_RateLimit
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return clientError_Connection
+		}
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == rpcLimitExceededCode {
+		return clientError_RateLimit
+	}
+
+	// Connection failures
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &opErr) ||
+		errors.As(err, &dnsErr) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return clientError_Connection
+	}
+
+	return clientError_Rpc
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+)
+
+// A network error that timed out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// A JSON-RPC error with a code
+type codedRpcError struct {
+	code int
+}
+
+func (e codedRpcError) Error() string  { return fmt.Sprintf("rpc error %d", e.code) }
+func (e codedRpcError) ErrorCode() int { return e.code }
+
+func TestClassifyClientError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected clientErrorType
+	}{
+		{"no error", nil, clientError_None},
+		{"context deadline", fmt.Errorf("error getting block: %w", context.DeadlineExceeded), clientError_Timeout},
+		{"os deadline", os.ErrDeadlineExceeded, clientError_Timeout},
+		{"network timeout", &net.OpError{Op: "read", Err: timeoutError{}}, clientError_Timeout},
+		{"beacon rate limit", fmt.Errorf("%w (/eth/v1/node/syncing)", client.ErrRateLimited), clientError_RateLimit},
+		{"http rate limit", rpc.HTTPError{StatusCode: http.StatusTooManyRequests}, clientError_RateLimit},
+		{"provider rate limit", codedRpcError{code: rpcLimitExceededCode}, clientError_RateLimit},
+		{"beacon server error", fmt.Errorf("Could not get beacon head: %w", client.ErrUnavailable), clientError_Connection},
+		{"http bad gateway", rpc.HTTPError{StatusCode: http.StatusBadGateway}, clientError_Connection},
+		{"http unavailable", rpc.HTTPError{StatusCode: http.StatusServiceUnavailable}, clientError_Connection},
+		{"connection refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, clientError_Connection},
+		{"dns failure", &net.DNSError{Err: "no such host", Name: "eth1"}, clientError_Connection},
+		{"connection reset", fmt.Errorf("error reading response: %w", syscall.ECONNRESET), clientError_Connection},
+		{"closed connection", io.ErrUnexpectedEOF, clientError_Connection},
+		{"http bad request", rpc.HTTPError{StatusCode: http.StatusBadRequest}, clientError_Rpc},
+		{"other rpc error", codedRpcError{code: -32000}, clientError_Rpc},
+		{"revert", errors.New("execution reverted"), clientError_Rpc},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if errorType := classifyClientError(test.err); errorType != test.expected {
+				t.Fatalf("expected %s, got %s", test.expected, errorType)
+			}
+		})
+	}
+}
+
+func TestClassifyBeaconStatusErrors(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected clientErrorType
+	}{
+		{http.StatusServiceUnavailable, clientError_Connection},
+		{http.StatusInternalServerError, clientError_Connection},
+		{http.StatusTooManyRequests, clientError_RateLimit},
+		{http.StatusNotImplemented, clientError_Rpc},
+		{http.StatusBadRequest, clientError_Rpc},
+	}
+	for _, test := range tests {
+		t.Run(http.StatusText(test.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(`{"code":1,"message":"node is syncing"}`))
+			}))
+			defer server.Close()
+
+			_, err := client.NewStandardHttpClient(server.URL, 1).GetBeaconHead()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if errorType := classifyClientError(err); errorType != test.expected {
+				t.Fatalf("expected %s, got %s (%v)", test.expected, errorType, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	clientHealthCheckInterval time.Duration = 15 * time.Second
+	clientErrorRateWeight     float64       = 0.1
+	clientLatencyWeight       float64       = 0.2
+	maxClientErrorRate        float64       = 0.5
+	maxClientRequestRetries   int           = 2
+	clientRetryBaseDelay      time.Duration = time.Second
+)
+
+// The health of a single client endpoint, updated by periodic probes and by the requests sent to it
+type clientHealth struct {
+	lock      sync.Mutex
+	status    api.ClientStatus
+	head      uint64
+	headLag   uint64
+	latency   time.Duration
+	errorRate float64
+	requests  uint64
+	failures  uint64
+}
+
+// A snapshot of a client endpoint's health, used for metrics
+type ClientEndpointStats struct {
+	// The endpoint's name, e.g. "primary" or "fallback2"
+	Name string
+
+	// True if this is the endpoint requests are currently sent to
+	Active bool
+
+	// True if the endpoint is usable
+	Healthy bool
+
+	// True if the endpoint reported that it's synced during the last probe
+	Synced bool
+
+	// The number of blocks (or slots) this endpoint is behind the best endpoint
+	HeadLag uint64
+
+	// The moving average of the endpoint's probe latency
+	Latency time.Duration
+
+	// The moving average of the fraction of requests that failed to reach the endpoint
+	ErrorRate float64
+
+	// A score from 0 (unusable) to 1 (perfect) summarising the endpoint's health
+	Score float64
+
+	// The total number of requests sent to the endpoint
+	Requests uint64
+
+	// The total number of requests that failed to reach the endpoint
+	Failures uint64
+}
+
+// An ordered list of equivalent client endpoints; requests go to the first healthy one.
+// The endpoints are probed periodically, so a preferred endpoint is used again as soon as it recovers.
+// It's safe for concurrent use.
+type clientPool struct {
+	clientType string
+	healths    []*clientHealth
+	maxHeadLag uint64
+	logger     log.ColorLogger
+	probe      func()
+
+	lock            sync.Mutex
+	ignoreSyncCheck bool
+	skipPrimary     bool
+	active          int
+	probing         bool
+	lastProbe       time.Time
+}
+
+// Create a new pool with the provided number of endpoints; they're all assumed to be healthy until they're probed
+func newClientPool(clientType string, count int, maxHeadLag uint64, logger log.ColorLogger) *clientPool {
+	healths := make([]*clientHealth, count)
+	for i := range healths {
+		healths[i] = &clientHealth{
+			status: api.ClientStatus{
+				IsWorking:    true,
+				IsSynced:     true,
+				SyncProgress: 1,
+			},
+		}
+	}
+	return &clientPool{
+		clientType: clientType,
+		healths:    healths,
+		maxHeadLag: maxHeadLag,
+		logger:     logger,
+		lastProbe:  time.Now(),
+	}
+}
+
+// Get the name of the endpoint with the provided index
+func (p *clientPool) getName(index int) string {
+	if index == 0 {
+		return "primary"
+	}
+	if len(p.healths) == 2 {
+		return "fallback"
+	}
+	return fmt.Sprintf("fallback%d", index)
+}
+
+// Set the flags the CLI uses to control which clients the API uses
+func (p *clientPool) setRequestSettings(ignoreSyncCheck bool, skipPrimary bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.ignoreSyncCheck = ignoreSyncCheck
+	p.skipPrimary = skipPrimary
+}
+
+// Check if sync checks should be skipped and the predefined client settings used instead
+func (p *clientPool) isIgnoringSyncCheck() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.ignoreSyncCheck
+}
+
+// Check if the primary endpoint should be skipped
+func (p *clientPool) isSkippingPrimary() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.skipPrimary
+}
+
+// Run a function on each usable endpoint in order until one succeeds or they all fail.
+// Connection failures, timeouts and rate limits move on to the next endpoint; any other error is returned as-is.
+// If retry is set, the request is idempotent, so timeouts and rate limits are retried on the same endpoint with a backoff first.
+// Requests that aren't idempotent aren't resent after a timeout, since they may have been processed.
+func (p *clientPool) run(ctx context.Context, retry bool, function func(index int) error) error {
+	p.checkProbe()
+	skipPrimary := p.isSkippingPrimary()
+
+	var lastErr error
+	for index, health := range p.healths {
+		if (index == 0 && skipPrimary) || !health.isUsable(p.maxHeadLag) {
+			continue
+		}
+
+		for attempt := 0; ; attempt++ {
+			err := function(index)
+			errType := classifyClientError(err)
+			if errType == clientError_None || errType == clientError_Rpc {
+				// The endpoint handled the request, even if it returned an error
+				health.recordRequest(false)
+				p.setActive(index)
+				return err
+			}
+
+			// Don't try again if the caller gave up
+			if ctx.Err() != nil {
+				return err
+			}
+			lastErr = err
+
+			if errType == clientError_Connection {
+				p.logger.Warnf("The %s %s client disconnected (%s), trying the next one...", p.getName(index), p.clientType, err.Error())
+				health.setDisconnected(err)
+				break
+			}
+
+			health.recordRequest(true)
+			if errType == clientError_Timeout && !retry {
+				return err
+			}
+			if retry && attempt < maxClientRequestRetries {
+				delay := clientRetryBaseDelay << attempt
+				p.logger.Warnf("Request to the %s %s client failed with a %s (%s), retrying in %s...", p.getName(index), p.clientType, errType, err.Error(), delay)
+				select {
+				case <-ctx.Done():
+					return err
+				case <-time.After(delay):
+				}
+				continue
+			}
+			p.logger.Warnf("Request to the %s %s client failed with a %s (%s), trying the next one...", p.getName(index), p.clientType, errType, err.Error())
+			break
+		}
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("all %s clients failed: %w", p.clientType, lastErr)
+	}
+	return fmt.Errorf("no %s clients were ready", p.clientType)
+}
+
+// Log when requests start going to a different endpoint
+func (p *clientPool) setActive(index int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.active == index {
+		return
+	}
+	if index < p.active {
+		p.logger.Printlnf("The %s %s client is healthy again, switching back to it.", p.getName(index), p.clientType)
+	} else {
+		p.logger.Printlnf("Using the %s %s client.", p.getName(index), p.clientType)
+	}
+	p.active = index
+}
+
+// Start probing the endpoints in the background if it's time to do so
+func (p *clientPool) checkProbe() {
+	if p.probe == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.ignoreSyncCheck || p.probing || time.Since(p.lastProbe) < clientHealthCheckInterval {
+		return
+	}
+	p.probing = true
+	go func() {
+		p.probe()
+		p.lock.Lock()
+		p.probing = false
+		p.lock.Unlock()
+	}()
+}
+
+// Mark the end of a probe and work out how far each endpoint is behind the best one
+func (p *clientPool) finishProbe() {
+	var bestHead uint64
+	for _, health := range p.healths {
+		health.lock.Lock()
+		if health.status.IsWorking && health.head > bestHead {
+			bestHead = health.head
+		}
+		health.lock.Unlock()
+	}
+	for _, health := range p.healths {
+		health.lock.Lock()
+		if health.head < bestHead {
+			health.headLag = bestHead - health.head
+		} else {
+			health.headLag = 0
+		}
+		health.lock.Unlock()
+	}
+
+	p.lock.Lock()
+	p.lastProbe = time.Now()
+	p.lock.Unlock()
+}
+
+// Get the index of the first usable endpoint
+func (p *clientPool) getFirstUsable() (int, bool) {
+	skipPrimary := p.isSkippingPrimary()
+	for index, health := range p.healths {
+		if (index == 0 && skipPrimary) || !health.isUsable(p.maxHeadLag) {
+			continue
+		}
+		return index, true
+	}
+	return 0, false
+}
+
+// Get the indices of every usable endpoint
+func (p *clientPool) getUsableIndices() []int {
+	p.checkProbe()
+	skipPrimary := p.isSkippingPrimary()
+	indices := []int{}
+	for index, health := range p.healths {
+		if (index == 0 && skipPrimary) || !health.isUsable(p.maxHeadLag) {
+			continue
+		}
+		indices = append(indices, index)
+	}
+	return indices
+}
+
+// Get the last reported status of each endpoint
+func (p *clientPool) getStatuses() []api.ClientStatus {
+	statuses := make([]api.ClientStatus, len(p.healths))
+	for i, health := range p.healths {
+		health.lock.Lock()
+		statuses[i] = health.status
+		health.lock.Unlock()
+	}
+	return statuses
+}
+
+// Get the status of the pool in the format used by the API
+func (p *clientPool) getManagerStatus() *api.ClientManagerStatus {
+	statuses := p.getStatuses()
+	status := &api.ClientManagerStatus{
+		PrimaryClientStatus: statuses[0],
+		FallbackEnabled:     len(statuses) > 1,
+	}
+	if !status.FallbackEnabled {
+		return status
+	}
+
+	// Report the first synced fallback (or the first fallback if none are) for callers that only care about one
+	status.FallbackClientStatuses = statuses[1:]
+	status.FallbackClientStatus = statuses[1]
+	for _, fallbackStatus := range statuses[1:] {
+		if fallbackStatus.IsSynced {
+			status.FallbackClientStatus = fallbackStatus
+			break
+		}
+	}
+	return status
+}
+
+// Get a snapshot of each endpoint's health
+func (p *clientPool) getStats() []ClientEndpointStats {
+	p.lock.Lock()
+	active := p.active
+	p.lock.Unlock()
+
+	stats := make([]ClientEndpointStats, len(p.healths))
+	for i, health := range p.healths {
+		healthy := health.isUsable(p.maxHeadLag)
+		health.lock.Lock()
+		stats[i] = ClientEndpointStats{
+			Name:      p.getName(i),
+			Active:    i == active,
+			Healthy:   healthy,
+			Synced:    health.status.IsSynced,
+			HeadLag:   health.headLag,
+			Latency:   health.latency,
+			ErrorRate: health.errorRate,
+			Requests:  health.requests,
+			Failures:  health.failures,
+		}
+		if healthy {
+			stats[i].Score = (1 - health.errorRate) * (1 - float64(health.headLag)/float64(p.maxHeadLag+1)) / (1 + health.latency.Seconds())
+		}
+		health.lock.Unlock()
+	}
+	return stats
+}
+
+// Check if the endpoint is working, synced, close to the head of the chain and not failing too many requests
+func (h *clientHealth) isUsable(maxHeadLag uint64) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.status.IsWorking && h.status.IsSynced && h.headLag <= maxHeadLag && h.errorRate <= maxClientErrorRate
+}
+
+// Save the results of a probe
+func (h *clientHealth) setProbeResult(status api.ClientStatus, head uint64, latency time.Duration) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.status = status
+	if !status.IsWorking {
+		h.recordResult(true)
+		return
+	}
+
+	// Probes count as requests, so endpoints that were avoided because of their error rate can recover
+	h.recordResult(false)
+	h.head = head
+	if h.latency == 0 {
+		h.latency = latency
+	} else {
+		h.latency = time.Duration(float64(h.latency)*(1-clientLatencyWeight) + float64(latency)*clientLatencyWeight)
+	}
+}
+
+// Mark the endpoint as unavailable until the next successful probe
+func (h *clientHealth) setDisconnected(err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.recordResult(true)
+	h.status.IsWorking = false
+	h.status.IsSynced = false
+	h.status.Error = fmt.Sprintf("Client disconnected (%s)", err.Error())
+}
+
+// Update the endpoint's error rate with the result of a request
+func (h *clientHealth) recordRequest(failed bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.recordResult(failed)
+}
+
+// Update the endpoint's error rate; the lock must be held
+func (h *clientHealth) recordResult(failed bool) {
+	h.requests++
+	result := 0.0
+	if failed {
+		h.failures++
+		result = 1
+	}
+	h.errorRate = h.errorRate*(1-clientErrorRateWeight) + result*clientErrorRateWeight
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func newTestClientPool(count int) *clientPool {
+	return newClientPool("Execution", count, maxEcHeadLag, log.NewColorLogger(0))
+}
+
+// Report a probe result for each endpoint and finish the probe
+func probeTestClientPool(pool *clientPool, heads []uint64, latencies []time.Duration) {
+	for i, health := range pool.healths {
+		health.setProbeResult(api.ClientStatus{IsWorking: true, IsSynced: true, SyncProgress: 1}, heads[i], latencies[i])
+	}
+	pool.finishProbe()
+}
+
+func TestClientPoolScores(t *testing.T) {
+	pool := newTestClientPool(3)
+	probeTestClientPool(pool, []uint64{100, 98, 100}, []time.Duration{0, 0, time.Second})
+
+	stats := pool.getStats()
+	if stats[0].Name != "primary" || stats[1].Name != "fallback1" || stats[2].Name != "fallback2" {
+		t.Fatalf("unexpected names %s, %s, %s", stats[0].Name, stats[1].Name, stats[2].Name)
+	}
+	if !stats[0].Active || stats[1].Active {
+		t.Fatal("the primary endpoint should be active")
+	}
+
+	// A perfect endpoint scores 1
+	if stats[0].Score != 1 {
+		t.Fatalf("expected a perfect score for the primary, got %f", stats[0].Score)
+	}
+
+	// Lagging behind the best head lowers the score
+	if stats[1].HeadLag != 2 {
+		t.Fatalf("expected a head lag of 2, got %d", stats[1].HeadLag)
+	}
+	expected := 1 - 2/float64(maxEcHeadLag+1)
+	if math.Abs(stats[1].Score-expected) > 1e-9 {
+		t.Fatalf("expected a score of %f for a lagging endpoint, got %f", expected, stats[1].Score)
+	}
+
+	// So does latency
+	if math.Abs(stats[2].Score-0.5) > 1e-9 {
+		t.Fatalf("expected a score of 0.5 for a slow endpoint, got %f", stats[2].Score)
+	}
+}
+
+func TestClientPoolUnusableEndpoints(t *testing.T) {
+	pool := newTestClientPool(3)
+	probeTestClientPool(pool, []uint64{100, 100 - maxEcHeadLag - 1, 100}, []time.Duration{0, 0, 0})
+	pool.healths[2].setProbeResult(api.ClientStatus{IsWorking: true, IsSynced: false}, 100, 0)
+
+	indices := pool.getUsableIndices()
+	if len(indices) != 1 || indices[0] != 0 {
+		t.Fatalf("only the primary should be usable, got %v", indices)
+	}
+	stats := pool.getStats()
+	if stats[1].Healthy || stats[1].Score != 0 || stats[2].Healthy || stats[2].Score != 0 {
+		t.Fatalf("endpoints that are too far behind or syncing should score 0: %+v", stats)
+	}
+
+	// Skipping the primary leaves nothing
+	pool.setRequestSettings(false, true)
+	if _, exists := pool.getFirstUsable(); exists {
+		t.Fatal("no endpoint should be usable without the primary")
+	}
+}
+
+func TestClientPoolErrorRate(t *testing.T) {
+	pool := newTestClientPool(1)
+	health := pool.healths[0]
+
+	// The error rate is a moving average, so a few failures aren't enough to stop using an endpoint
+	for i := 0; i < 5; i++ {
+		health.recordRequest(true)
+	}
+	if !health.isUsable(maxEcHeadLag) {
+		t.Fatalf("an endpoint with an error rate of %f should still be usable", health.errorRate)
+	}
+	for i := 0; i < 5; i++ {
+		health.recordRequest(true)
+	}
+	if health.isUsable(maxEcHeadLag) {
+		t.Fatalf("an endpoint with an error rate of %f should not be usable", health.errorRate)
+	}
+	if stats := pool.getStats()[0]; stats.Requests != 10 || stats.Failures != 10 {
+		t.Fatalf("unexpected request counts %+v", stats)
+	}
+
+	// Successful probes bring it back
+	for i := 0; i < 10 && !health.isUsable(maxEcHeadLag); i++ {
+		health.setProbeResult(api.ClientStatus{IsWorking: true, IsSynced: true}, 100, 0)
+	}
+	if !health.isUsable(maxEcHeadLag) {
+		t.Fatal("successful probes should make the endpoint usable again")
+	}
+}
+
+func TestClientPoolLatencyAverage(t *testing.T) {
+	health := newTestClientPool(1).healths[0]
+	status := api.ClientStatus{IsWorking: true, IsSynced: true}
+	health.setProbeResult(status, 100, time.Second)
+	if health.latency != time.Second {
+		t.Fatalf("the first probe should set the latency, got %s", health.latency)
+	}
+	health.setProbeResult(status, 100, 2*time.Second)
+	expected := time.Duration(float64(time.Second)*(1-clientLatencyWeight) + float64(2*time.Second)*clientLatencyWeight)
+	if health.latency != expected {
+		t.Fatalf("expected a latency of %s, got %s", expected, health.latency)
+	}
+}
+
+func TestClientPoolRunFallsBack(t *testing.T) {
+	pool := newTestClientPool(3)
+	calls := []int{}
+	rpcErr := errors.New("execution reverted")
+	err := pool.run(context.Background(), false, func(index int) error {
+		calls = append(calls, index)
+		switch index {
+		case 0:
+			return syscall.ECONNREFUSED
+		default:
+			return rpcErr
+		}
+	})
+
+	// The disconnected primary is skipped, and the fallback's RPC error is returned as-is
+	if !errors.Is(err, rpcErr) {
+		t.Fatalf("expected the RPC error, got %v", err)
+	}
+	if len(calls) != 2 || calls[0] != 0 || calls[1] != 1 {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+	if pool.healths[0].isUsable(maxEcHeadLag) {
+		t.Fatal("the disconnected primary should not be usable")
+	}
+	if stats := pool.getStats(); !stats[1].Active {
+		t.Fatal("the fallback should be active")
+	}
+
+	// Without the primary, requests go straight to the fallback
+	calls = []int{}
+	if err := pool.run(context.Background(), false, func(index int) error {
+		calls = append(calls, index)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 || calls[0] != 1 {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+}
+
+func TestClientPoolRunFallsBackFromSyncingBeacon(t *testing.T) {
+	// A primary Beacon node that's still syncing and answers everything with a 503
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	primary := client.NewStandardHttpClient(server.URL, 1)
+
+	pool := newClientPool("Beacon", 2, maxBcHeadLag, log.NewColorLogger(0))
+	calls := []int{}
+	err := pool.run(context.Background(), false, func(index int) error {
+		calls = append(calls, index)
+		if index == 0 {
+			_, err := primary.GetBeaconHead()
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 || calls[0] != 0 || calls[1] != 1 {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+	if pool.healths[0].isUsable(maxBcHeadLag) {
+		t.Fatal("the syncing primary should not be usable")
+	}
+}
+
+func TestClientPoolRunAllFailed(t *testing.T) {
+	pool := newTestClientPool(2)
+	err := pool.run(context.Background(), false, func(index int) error {
+		return syscall.ECONNREFUSED
+	})
+	if err == nil || !errors.Is(err, syscall.ECONNREFUSED) {
+		t.Fatalf("expected the last connection error, got %v", err)
+	}
+	err = pool.run(context.Background(), false, func(index int) error {
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected an error when no clients are ready")
+	}
+}
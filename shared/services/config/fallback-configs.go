@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/rocket-pool/smartnode/shared/types/config"
 )
 
@@ -13,6 +15,12 @@ type FallbackNormalConfig struct {
 
 	// The URL of the Beacon Node HTTP endpoint
 	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty"`
+
+	// The URLs of any additional Execution Client HTTP endpoints, in order of preference
+	AdditionalEcHttpUrls config.Parameter `yaml:"additionalEcHttpUrls,omitempty"`
+
+	// The URLs of any additional Beacon Node HTTP endpoints, in order of preference
+	AdditionalCcHttpUrls config.Parameter `yaml:"additionalCcHttpUrls,omitempty"`
 }
 
 // Configuration for fallback Prysm
@@ -25,6 +33,12 @@ type FallbackPrysmConfig struct {
 	// The URL of the Beacon Node HTTP endpoint
 	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty"`
 
+	// The URLs of any additional Execution Client HTTP endpoints, in order of preference
+	AdditionalEcHttpUrls config.Parameter `yaml:"additionalEcHttpUrls,omitempty"`
+
+	// The URLs of any additional Beacon Node HTTP endpoints, in order of preference
+	AdditionalCcHttpUrls config.Parameter `yaml:"additionalCcHttpUrls,omitempty"`
+
 	// The URL of the JSON-RPC endpoint for the Validator client
 	JsonRpcUrl config.Parameter `yaml:"jsonRpcUrl,omitempty"`
 }
@@ -57,6 +71,30 @@ func NewFallbackNormalConfig(cfg *RocketPoolConfig) *FallbackNormalConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		AdditionalEcHttpUrls: config.Parameter{
+			ID:                   "additionalEcHttpUrls",
+			Name:                 "Additional Execution Client URLs",
+			Description:          "A comma-separated list of the HTTP API endpoints for any other fallback Execution clients you want to use. The Smartnode will use the first healthy client, trying the primary client first, then the fallback above, then these in the order they're listed, and will switch back to a preferred client once it's healthy again.\n\nNOTE: Only the Smartnode uses these; your Validator client only uses the fallback above.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AdditionalCcHttpUrls: config.Parameter{
+			ID:                   "additionalCcHttpUrls",
+			Name:                 "Additional Beacon Node URLs",
+			Description:          "A comma-separated list of the HTTP Beacon API endpoints for any other fallback Consensus clients you want to use. The Smartnode will use the first healthy client, trying the primary client first, then the fallback above, then these in the order they're listed, and will switch back to a preferred client once it's healthy again.\n\nNOTE: Only the Smartnode uses these; your Validator client only uses the fallback above.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 }
 
@@ -100,6 +138,30 @@ func NewFallbackPrysmConfig(cfg *RocketPoolConfig) *FallbackPrysmConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		AdditionalEcHttpUrls: config.Parameter{
+			ID:                   "additionalEcHttpUrls",
+			Name:                 "Additional Execution Client URLs",
+			Description:          "A comma-separated list of the HTTP API endpoints for any other fallback Execution clients you want to use. The Smartnode will use the first healthy client, trying the primary client first, then the fallback above, then these in the order they're listed, and will switch back to a preferred client once it's healthy again.\n\nNOTE: Only the Smartnode uses these; your Validator client only uses the fallback above.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AdditionalCcHttpUrls: config.Parameter{
+			ID:                   "additionalCcHttpUrls",
+			Name:                 "Additional Beacon Node URLs",
+			Description:          "A comma-separated list of the HTTP Beacon API endpoints for any other fallback Prysm clients you want to use. The Smartnode will use the first healthy client, trying the primary client first, then the fallback above, then these in the order they're listed, and will switch back to a preferred client once it's healthy again.\n\nNOTE: Only the Smartnode uses these; your Validator client only uses the fallback above.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 }
 
@@ -108,6 +170,8 @@ func (cfg *FallbackNormalConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.EcHttpUrl,
 		&cfg.CcHttpUrl,
+		&cfg.AdditionalEcHttpUrls,
+		&cfg.AdditionalCcHttpUrls,
 	}
 }
 
@@ -117,6 +181,8 @@ func (cfg *FallbackPrysmConfig) GetParameters() []*config.Parameter {
 		&cfg.EcHttpUrl,
 		&cfg.CcHttpUrl,
 		&cfg.JsonRpcUrl,
+		&cfg.AdditionalEcHttpUrls,
+		&cfg.AdditionalCcHttpUrls,
 	}
 }
 
@@ -129,3 +195,38 @@ func (config *FallbackNormalConfig) GetConfigTitle() string {
 func (config *FallbackPrysmConfig) GetConfigTitle() string {
 	return config.Title
 }
+
+// Get the URLs of the fallback Execution clients, in order of preference
+func (cfg *FallbackNormalConfig) GetEcHttpUrls() []string {
+	return getFallbackUrls(cfg.EcHttpUrl.Value.(string), cfg.AdditionalEcHttpUrls.Value.(string))
+}
+
+// Get the URLs of the fallback Beacon Nodes, in order of preference
+func (cfg *FallbackNormalConfig) GetCcHttpUrls() []string {
+	return getFallbackUrls(cfg.CcHttpUrl.Value.(string), cfg.AdditionalCcHttpUrls.Value.(string))
+}
+
+// Get the URLs of the fallback Execution clients, in order of preference
+func (cfg *FallbackPrysmConfig) GetEcHttpUrls() []string {
+	return getFallbackUrls(cfg.EcHttpUrl.Value.(string), cfg.AdditionalEcHttpUrls.Value.(string))
+}
+
+// Get the URLs of the fallback Beacon Nodes, in order of preference
+func (cfg *FallbackPrysmConfig) GetCcHttpUrls() []string {
+	return getFallbackUrls(cfg.CcHttpUrl.Value.(string), cfg.AdditionalCcHttpUrls.Value.(string))
+}
+
+// Combine the main fallback URL with the comma-separated list of additional ones, skipping blanks and duplicates
+func getFallbackUrls(url string, additionalUrls string) []string {
+	urls := []string{}
+	seen := map[string]bool{}
+	for _, url := range append([]string{url}, strings.Split(additionalUrls, ",")...) {
+		url = strings.TrimSpace(url)
+		if url == "" || seen[url] {
+			continue
+		}
+		seen[url] = true
+		urls = append(urls, url)
+	}
+	return urls
+}
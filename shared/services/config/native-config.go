@@ -24,6 +24,12 @@ type NativeConfig struct {
 
 	// The command for stopping the validator container in native mode
 	ValidatorStopCommand config.Parameter `yaml:"validatorStopCommand,omitempty"`
+
+	// The URL of the VC's keymanager API
+	KeymanagerApiUrl config.Parameter `yaml:"keymanagerApiUrl,omitempty"`
+
+	// The path of the VC's keymanager API token file
+	KeymanagerApiTokenPath config.Parameter `yaml:"keymanagerApiTokenPath,omitempty"`
 }
 
 // Generates a new Smartnode configuration
@@ -112,6 +118,30 @@ func NewNativeConfig(cfg *RocketPoolConfig) *NativeConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		KeymanagerApiUrl: config.Parameter{
+			ID:                   "keymanagerApiUrl",
+			Name:                 "Keymanager API URL",
+			Description:          "The URL of your Validator Client's keymanager API (e.g. http://localhost:5062), used to load new validator keys and update your fee recipient without restarting it. Leave it blank to always restart your Validator Client with the VC Restart Script instead.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiTokenPath: config.Parameter{
+			ID:                   "keymanagerApiTokenPath",
+			Name:                 "Keymanager API Token File",
+			Description:          "The absolute path to the file holding the bearer token for your Validator Client's keymanager API. The Smartnode's user must be able to read it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 
 }
@@ -124,6 +154,8 @@ func (cfg *NativeConfig) GetParameters() []*config.Parameter {
 		&cfg.CcHttpUrl,
 		&cfg.ValidatorRestartCommand,
 		&cfg.ValidatorStopCommand,
+		&cfg.KeymanagerApiUrl,
+		&cfg.KeymanagerApiTokenPath,
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/alessio/shellescape"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pbnjay/memory"
 	"github.com/rocket-pool/smartnode/addons"
 	"github.com/rocket-pool/smartnode/shared"
@@ -37,6 +38,9 @@ const (
 
 	FeeRecipientFileEnvVar string = "FEE_RECIPIENT_FILE"
 	FeeRecipientEnvVar     string = "FEE_RECIPIENT"
+
+	// Where the validators folder is mounted in the Validator Client's container
+	ValidatorsContainerPath string = "/validators"
 )
 
 // Defaults
@@ -731,6 +735,20 @@ func (cfg *RocketPoolConfig) IsDoppelgangerEnabled() (bool, error) {
 	}
 }
 
+// Get the URLs of the fallback Execution clients, in order of preference; empty if fallback clients are disabled
+func (cfg *RocketPoolConfig) GetFallbackEcHttpUrls() []string {
+	if cfg.UseFallbackClients.Value != true {
+		return []string{}
+	}
+	if !cfg.IsNativeMode {
+		cc, _ := cfg.GetSelectedConsensusClient()
+		if cc == config.ConsensusClient_Prysm {
+			return cfg.FallbackPrysm.GetEcHttpUrls()
+		}
+	}
+	return cfg.FallbackNormal.GetEcHttpUrls()
+}
+
 // Serializes the configuration into a map of maps, compatible with a settings file
 func (cfg *RocketPoolConfig) Serialize() map[string]map[string]string {
 
@@ -926,6 +944,16 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 	}
 	envVars["CC_CLIENT"] = fmt.Sprint(consensusClient)
 
+	// Remote signer
+	if cfg.Smartnode.UseRemoteSigner.Value == true {
+		addValidatorClientFlags(envVars, getRemoteSignerFlags(consensusClient, cfg.Smartnode.RemoteSignerUrl.Value.(string)))
+	}
+
+	// Keymanager API
+	if cfg.Smartnode.UseKeymanagerApi.Value == true {
+		addValidatorClientFlags(envVars, getKeymanagerApiFlags(consensusClient, cfg.Smartnode.KeymanagerApiPort.Value))
+	}
+
 	// Graffiti
 	identifier := ""
 	versionString := fmt.Sprintf("v%s", shared.RocketPoolVersion)
@@ -1140,9 +1168,93 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		}
 	}
 
+	// Ensure the external node account signer can be used
+	nodeSigner := cfg.Smartnode.NodeSigner.Value.(config.NodeSigner)
+	if nodeSigner == config.NodeSigner_Clef || nodeSigner == config.NodeSigner_Rpc {
+		if cfg.Smartnode.NodeSignerUrl.Value.(string) == "" {
+			errors = append(errors, "You have an external node account signer selected but don't have its URL set. Please enter the signer URL to use it.")
+		}
+		if !common.IsHexAddress(cfg.Smartnode.NodeSignerAddress.Value.(string)) {
+			errors = append(errors, "You have an external node account signer selected but don't have a valid node account address set. Please enter the address of the account in the signer to use it.")
+		}
+	}
+
+	// Docker containers don't inherit the environment or file descriptors of the process that starts them
+	if !cfg.IsNativeMode && cfg.Smartnode.PasswordBackend.Value.(config.PasswordBackend) == config.PasswordBackend_Env {
+		errors = append(errors, "You have the Environment password backend selected, but it can only be used in Native mode because the Smartnode's containers can't read environment variables or file descriptors from your shell. Please select a different password backend.")
+	}
+
+	// Docker's default seccomp profile blocks the kernel keyring syscalls, and containers don't share the host user's keyring
+	if !cfg.IsNativeMode && cfg.Smartnode.PasswordBackend.Value.(config.PasswordBackend) == config.PasswordBackend_Keyring {
+		errors = append(errors, "You have the Kernel Keyring password backend selected, but it can only be used in Native mode because the Smartnode's containers can't reach your user's kernel keyring. Please select a different password backend.")
+	}
+
+	// Ensure quorum reads have more than one Execution client to compare
+	if cfg.Smartnode.UseQuorumReads.Value == true && len(cfg.GetFallbackEcHttpUrls()) == 0 {
+		errors = append(errors, "You have quorum reads enabled but don't have any fallback Execution clients set. Please enable fallback clients and enter at least one fallback Execution client URL, or disable quorum reads.")
+	}
+
+	// Ensure the remote signer can be used
+	if cfg.Smartnode.UseRemoteSigner.Value == true {
+		if cfg.Smartnode.RemoteSignerUrl.Value.(string) == "" {
+			errors = append(errors, "You have the remote signer enabled but don't have a URL set. Please enter the remote signer URL to use it.")
+		}
+		consensusClient, _ := cfg.GetSelectedConsensusClient()
+		if consensusClient == config.ConsensusClient_Lighthouse {
+			errors = append(errors, "You have the remote signer enabled, but Lighthouse's Validator Client can't discover keys from a remote signer on its own. Please select a different Consensus client or disable the remote signer.")
+		}
+	}
+
 	return errors
 }
 
+// Append flags to the Validator Client's additional flags, which every VC start script passes through
+func addValidatorClientFlags(envVars map[string]string, flags string) {
+	if flags == "" {
+		return
+	}
+	if envVars["VC_ADDITIONAL_FLAGS"] == "" {
+		envVars["VC_ADDITIONAL_FLAGS"] = flags
+	} else {
+		envVars["VC_ADDITIONAL_FLAGS"] = fmt.Sprintf("%s %s", envVars["VC_ADDITIONAL_FLAGS"], flags)
+	}
+}
+
+// Get the Validator Client flags that make it sign with the remote signer and load its keys from it
+func getRemoteSignerFlags(consensusClient config.ConsensusClient, url string) string {
+	switch consensusClient {
+	case config.ConsensusClient_Lodestar:
+		return fmt.Sprintf("--externalSigner.url=%s --externalSigner.fetch=true", url)
+	case config.ConsensusClient_Nimbus:
+		return fmt.Sprintf("--web3-signer-url=%s", url)
+	case config.ConsensusClient_Prysm:
+		return fmt.Sprintf("--validators-external-signer-url=%s --validators-external-signer-public-keys=%s/api/v1/eth2/publicKeys", url, strings.TrimSuffix(url, "/"))
+	case config.ConsensusClient_Teku:
+		return fmt.Sprintf("--validators-external-signer-url=%s --validators-external-signer-public-keys=external-signer", url)
+	default:
+		return ""
+	}
+}
+
+// Get the Validator Client flags that enable its keymanager API with the Smartnode's token file
+func getKeymanagerApiFlags(consensusClient config.ConsensusClient, port interface{}) string {
+	tokenPath := filepath.Join(ValidatorsContainerPath, KeymanagerApiTokenFilename)
+	switch consensusClient {
+	case config.ConsensusClient_Lighthouse:
+		return fmt.Sprintf("--http --http-address=0.0.0.0 --http-port=%v --unencrypted-http-transport --http-token-path=%s", port, tokenPath)
+	case config.ConsensusClient_Lodestar:
+		return fmt.Sprintf("--keymanager --keymanager.address=0.0.0.0 --keymanager.port=%v --keymanager.tokenFile=%s", port, tokenPath)
+	case config.ConsensusClient_Nimbus:
+		return fmt.Sprintf("--keymanager --keymanager-address=0.0.0.0 --keymanager-port=%v --keymanager-token-file=%s", port, tokenPath)
+	case config.ConsensusClient_Prysm:
+		return fmt.Sprintf("--rpc --http-host=0.0.0.0 --http-port=%v --keymanager-token-file=%s", port, tokenPath)
+	case config.ConsensusClient_Teku:
+		return fmt.Sprintf("--validator-api-enabled=true --validator-api-interface=0.0.0.0 --validator-api-port=%v --validator-api-host-allowlist=* --validator-api-ssl-enabled=false --validator-api-bearer-file=%s", port, tokenPath)
+	default:
+		return ""
+	}
+}
+
 // Applies all of the defaults to all of the settings that have them defined
 func (cfg *RocketPoolConfig) applyAllDefaults() error {
 	for _, param := range cfg.GetParameters() {
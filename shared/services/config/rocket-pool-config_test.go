@@ -0,0 +1,182 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const testRemoteSignerUrl = "http://web3signer:9000"
+
+// Render the environment variables for every Consensus client in both local and external mode
+func TestRemoteSignerFlagsReachValidatorClient(t *testing.T) {
+	clients := []config.ConsensusClient{
+		config.ConsensusClient_Lighthouse,
+		config.ConsensusClient_Lodestar,
+		config.ConsensusClient_Nimbus,
+		config.ConsensusClient_Prysm,
+		config.ConsensusClient_Teku,
+	}
+	modes := []config.Mode{config.Mode_Local, config.Mode_External}
+
+	for _, mode := range modes {
+		for _, client := range clients {
+			cfg := newTestConfig(t)
+			cfg.ConsensusClientMode.Value = mode
+			cfg.ConsensusClient.Value = client
+			cfg.ExternalConsensusClient.Value = client
+			cfg.Smartnode.UseRemoteSigner.Value = true
+			cfg.Smartnode.RemoteSignerUrl.Value = testRemoteSignerUrl
+
+			// Include a user flag to make sure it's kept
+			if mode == config.Mode_Local {
+				setValidatorClientAdditionalFlags(t, cfg, client, "--user-flag")
+			}
+
+			envVars := cfg.GenerateEnvironmentVariables()
+			flags := envVars["VC_ADDITIONAL_FLAGS"]
+			expected := getRemoteSignerFlags(client, testRemoteSignerUrl)
+			if !strings.Contains(flags, expected) {
+				t.Errorf("%s (%s): VC additional flags [%s] don't include the remote signer flags [%s]", client, mode, flags, expected)
+			}
+			if mode == config.Mode_Local && !strings.HasPrefix(flags, "--user-flag") {
+				t.Errorf("%s (%s): VC additional flags [%s] lost the user's flags", client, mode, flags)
+			}
+			if client != config.ConsensusClient_Lighthouse && !strings.Contains(flags, testRemoteSignerUrl) {
+				t.Errorf("%s (%s): VC additional flags [%s] don't include the signer URL", client, mode, flags)
+			}
+		}
+	}
+}
+
+func TestRemoteSignerFlagsOffByDefault(t *testing.T) {
+	cfg := newTestConfig(t)
+	envVars := cfg.GenerateEnvironmentVariables()
+	if strings.Contains(envVars["VC_ADDITIONAL_FLAGS"], "signer") {
+		t.Fatalf("remote signer flags were added without the remote signer enabled: [%s]", envVars["VC_ADDITIONAL_FLAGS"])
+	}
+}
+
+func TestKeymanagerApiFlagsReachValidatorClient(t *testing.T) {
+	for _, client := range []config.ConsensusClient{
+		config.ConsensusClient_Lighthouse,
+		config.ConsensusClient_Lodestar,
+		config.ConsensusClient_Nimbus,
+		config.ConsensusClient_Prysm,
+		config.ConsensusClient_Teku,
+	} {
+		cfg := newTestConfig(t)
+		cfg.ConsensusClient.Value = client
+		cfg.Smartnode.UseKeymanagerApi.Value = true
+		setValidatorClientAdditionalFlags(t, cfg, client, "--user-flag")
+
+		envVars := cfg.GenerateEnvironmentVariables()
+		flags := envVars["VC_ADDITIONAL_FLAGS"]
+		expected := getKeymanagerApiFlags(client, cfg.Smartnode.KeymanagerApiPort.Value)
+		if expected == "" || !strings.Contains(flags, expected) {
+			t.Fatalf("%s: keymanager API flags [%s] missing from the VC additional flags [%s]", client, expected, flags)
+		}
+		if !strings.Contains(flags, "--user-flag") {
+			t.Fatalf("%s: user flags were dropped: [%s]", client, flags)
+		}
+		if _, exists := envVars["VC_KEYMANAGER_FLAGS"]; exists {
+			t.Fatalf("%s: the keymanager API flags should only be in the VC additional flags", client)
+		}
+	}
+}
+
+func TestQuorumReadsRequireFallbackClient(t *testing.T) {
+	for _, client := range []config.ConsensusClient{config.ConsensusClient_Lighthouse, config.ConsensusClient_Prysm} {
+		cfg := newTestConfig(t)
+		cfg.ConsensusClient.Value = client
+		cfg.Smartnode.UseQuorumReads.Value = true
+		if !hasValidationError(cfg, "quorum reads") {
+			t.Fatalf("%s: quorum reads without fallback clients should fail validation", client)
+		}
+
+		// Enabling fallback clients isn't enough without a URL
+		cfg.UseFallbackClients.Value = true
+		if !hasValidationError(cfg, "quorum reads") {
+			t.Fatalf("%s: quorum reads without a fallback Execution client URL should fail validation", client)
+		}
+
+		// The URL has to be on the fallback config for the selected client
+		if client == config.ConsensusClient_Prysm {
+			cfg.FallbackPrysm.EcHttpUrl.Value = "http://fallback:8545"
+		} else {
+			cfg.FallbackNormal.EcHttpUrl.Value = "http://fallback:8545"
+		}
+		if hasValidationError(cfg, "quorum reads") {
+			t.Fatalf("%s: quorum reads with a fallback Execution client should pass validation", client)
+		}
+	}
+}
+
+func TestNativeOnlyPasswordBackends(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Smartnode.PasswordBackend.Value = config.PasswordBackend_Env
+	if !hasValidationError(cfg, "Environment password backend") {
+		t.Fatalf("the environment password backend should fail validation in Docker mode")
+	}
+	cfg.Smartnode.PasswordBackend.Value = config.PasswordBackend_Keyring
+	if !hasValidationError(cfg, "Kernel Keyring password backend") {
+		t.Fatalf("the kernel keyring password backend should fail validation in Docker mode")
+	}
+	for _, backend := range []config.PasswordBackend{config.PasswordBackend_File, config.PasswordBackend_Exec} {
+		cfg.Smartnode.PasswordBackend.Value = backend
+		if hasValidationError(cfg, "password backend") {
+			t.Fatalf("the %s password backend should pass validation in Docker mode", backend)
+		}
+	}
+
+	nativeCfg := NewRocketPoolConfig("/tmp/rocketpool", true)
+	err := nativeCfg.Deserialize(nativeCfg.Serialize())
+	if err != nil {
+		t.Fatalf("error loading default native config: %s", err)
+	}
+	for _, backend := range []config.PasswordBackend{config.PasswordBackend_Env, config.PasswordBackend_Keyring} {
+		nativeCfg.Smartnode.PasswordBackend.Value = backend
+		if hasValidationError(nativeCfg, "password backend") {
+			t.Fatalf("the %s password backend should pass validation in Native mode", backend)
+		}
+	}
+}
+
+// Check if the config has a validation error that mentions the provided text
+func hasValidationError(cfg *RocketPoolConfig, text string) bool {
+	for _, err := range cfg.Validate() {
+		if strings.Contains(err, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// Create a default config the way it's loaded from disk, so every parameter has its proper type
+func newTestConfig(t *testing.T) *RocketPoolConfig {
+	cfg := NewRocketPoolConfig("/tmp/rocketpool", false)
+	err := cfg.Deserialize(cfg.Serialize())
+	if err != nil {
+		t.Fatalf("error loading default config: %s", err)
+	}
+	return cfg
+}
+
+// Set the additional VC flags on the local config for a client
+func setValidatorClientAdditionalFlags(t *testing.T, cfg *RocketPoolConfig, client config.ConsensusClient, flags string) {
+	switch client {
+	case config.ConsensusClient_Lighthouse:
+		cfg.Lighthouse.AdditionalVcFlags.Value = flags
+	case config.ConsensusClient_Lodestar:
+		cfg.Lodestar.AdditionalVcFlags.Value = flags
+	case config.ConsensusClient_Nimbus:
+		cfg.Nimbus.AdditionalVcFlags.Value = flags
+	case config.ConsensusClient_Prysm:
+		cfg.Prysm.AdditionalVcFlags.Value = flags
+	case config.ConsensusClient_Teku:
+		cfg.Teku.AdditionalVcFlags.Value = flags
+	default:
+		t.Fatalf("unknown client %s", client)
+	}
+}
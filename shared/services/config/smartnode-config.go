@@ -3,11 +3,13 @@ package config
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 // Constants
@@ -27,18 +29,39 @@ const (
 	WatchtowerStateFile                string = "state.yml"
 	RegenerateRewardsTreeRequestSuffix string = ".request"
 	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
+	RecordRewardsTreeFixtureRequest    string = "record-fixture"
+	RewardsTreeFixturesFolder          string = "fixtures"
+	RewardsTreeFixtureFolderFormat     string = "rp-rewards-fixture-%s-%d"
 	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
 	SecondaryRewardsFileUrl            string = "https://ipfs.io/ipfs/%s/%s"
 	GithubRewardsFileUrl               string = "https://github.com/rocket-pool/rewards-trees/raw/main/%s/%s"
 	FeeRecipientFilename               string = "rp-fee-recipient.txt"
 	NativeFeeRecipientFilename         string = "rp-fee-recipient-env.txt"
+	NodeTaskStatusFilename             string = "node-task-status.json"
+	ApiSocketFilename                  string = "api.sock"
+	ApiTokenFilename                   string = "api.token"
+	StateCacheFolder                   string = "state-cache"
+	TxJournalFilename                  string = "tx-journal.json"
+	NotificationStateFilename          string = "notification-state.json"
+	KeymanagerApiTokenFilename         string = "keymanager-api-token.txt"
+	ValidatorPerformanceFilenameFormat string = "validator-performance-%s.json"
 )
 
 // Defaults
 const (
-	defaultProjectName       string = "rocketpool"
-	WatchtowerMaxFeeDefault  uint64 = 200
-	WatchtowerPrioFeeDefault uint64 = 3
+	defaultProjectName        string  = "rocketpool"
+	WatchtowerMaxFeeDefault   uint64  = 200
+	WatchtowerPrioFeeDefault  uint64  = 3
+	defaultNodeTaskInterval   uint64  = 5
+	defaultBeaconConcurrency  uint64  = 12
+	defaultStateCacheSize     uint64  = 2048
+	defaultTxBumpInterval     uint64  = 0
+	defaultTxBumpPercent      uint64  = 15
+	defaultTxBumpMaxFee       float64 = 150
+	defaultTaskFailureAlerts  uint64  = 3
+	defaultPasswordEnvSource  string  = "ROCKETPOOL_NODE_PASSWORD"
+	defaultPasswordKeyringKey string  = "rocketpool-node-password"
+	defaultKeymanagerApiPort  uint16  = 5062
 )
 
 // Configuration for the Smartnode
@@ -76,6 +99,12 @@ type SmartnodeConfig struct {
 	// The amount of ETH in a minipool's balance before auto-distribute kicks in
 	DistributeThreshold config.Parameter `yaml:"distributeThreshold,omitempty"`
 
+	// The format of the node and watchtower logs
+	LogFormat config.Parameter `yaml:"logFormat,omitempty"`
+
+	// The minimum level of the node and watchtower logs
+	LogLevel config.Parameter `yaml:"logLevel,omitempty"`
+
 	// Mode for acquiring Merkle rewards trees
 	RewardsTreeMode config.Parameter `yaml:"rewardsTreeMode,omitempty"`
 
@@ -94,6 +123,9 @@ type SmartnodeConfig struct {
 	// The toggle for rolling records
 	UseRollingRecords config.Parameter `yaml:"useRollingRecords,omitempty"`
 
+	// Toggle for cross-checking the watchtower's Execution client reads against every configured Execution client
+	UseQuorumReads config.Parameter `yaml:"useQuorumReads,omitempty"`
+
 	// The rolling record checkpoint interval
 	RecordCheckpointInterval config.Parameter `yaml:"recordCheckpointInterval,omitempty"`
 
@@ -103,6 +135,135 @@ type SmartnodeConfig struct {
 	// The path of the records folder where snapshots of rolling record info is stored during a rewards interval
 	RecordsPath config.Parameter `yaml:"recordsPath,omitempty"`
 
+	// Toggle for the node daemon's fee recipient management task
+	ManageFeeRecipientEnabled config.Parameter `yaml:"manageFeeRecipientEnabled,omitempty"`
+
+	// The longest the node daemon will wait between runs of the fee recipient management task, in minutes
+	ManageFeeRecipientInterval config.Parameter `yaml:"manageFeeRecipientInterval,omitempty"`
+
+	// Toggle for the node daemon's rewards tree download task
+	DownloadRewardsTreesEnabled config.Parameter `yaml:"downloadRewardsTreesEnabled,omitempty"`
+
+	// The longest the node daemon will wait between runs of the rewards tree download task, in minutes
+	DownloadRewardsTreesInterval config.Parameter `yaml:"downloadRewardsTreesInterval,omitempty"`
+
+	// Toggle for the node daemon's prelaunch minipool staking task
+	StakePrelaunchMinipoolsEnabled config.Parameter `yaml:"stakePrelaunchMinipoolsEnabled,omitempty"`
+
+	// The longest the node daemon will wait between runs of the prelaunch minipool staking task, in minutes
+	StakePrelaunchMinipoolsInterval config.Parameter `yaml:"stakePrelaunchMinipoolsInterval,omitempty"`
+
+	// Toggle for the node daemon's minipool distribution task
+	DistributeMinipoolsEnabled config.Parameter `yaml:"distributeMinipoolsEnabled,omitempty"`
+
+	// The longest the node daemon will wait between runs of the minipool distribution task, in minutes
+	DistributeMinipoolsInterval config.Parameter `yaml:"distributeMinipoolsInterval,omitempty"`
+
+	// Toggle for the node daemon's bond reduction task
+	ReduceBondsEnabled config.Parameter `yaml:"reduceBondsEnabled,omitempty"`
+
+	// The longest the node daemon will wait between runs of the bond reduction task, in minutes
+	ReduceBondsInterval config.Parameter `yaml:"reduceBondsInterval,omitempty"`
+
+	// Toggle for the node daemon's vacant minipool promotion task
+	PromoteMinipoolsEnabled config.Parameter `yaml:"promoteMinipoolsEnabled,omitempty"`
+
+	// The longest the node daemon will wait between runs of the vacant minipool promotion task, in minutes
+	PromoteMinipoolsInterval config.Parameter `yaml:"promoteMinipoolsInterval,omitempty"`
+
+	// Toggle for the node daemon's validator performance tracking task
+	TrackPerformanceEnabled config.Parameter `yaml:"trackPerformanceEnabled,omitempty"`
+
+	// The longest the node daemon will wait between runs of the validator performance tracking task, in minutes
+	TrackPerformanceInterval config.Parameter `yaml:"trackPerformanceInterval,omitempty"`
+
+	// The maximum number of validator and duty requests that can be sent to the Beacon node at once
+	BeaconRequestConcurrency config.Parameter `yaml:"beaconRequestConcurrency,omitempty"`
+
+	// Toggle for saving finalized network states to disk so they don't need to be rebuilt
+	UseStateCache config.Parameter `yaml:"useStateCache,omitempty"`
+
+	// The maximum size of the network state cache, in MB
+	StateCacheSize config.Parameter `yaml:"stateCacheSize,omitempty"`
+
+	// How long the daemons wait for a transaction to be included before resubmitting it with higher fees, in minutes
+	TxBumpInterval config.Parameter `yaml:"txBumpInterval,omitempty"`
+
+	// The percentage that resubmitted transactions raise their fees by
+	TxBumpPercent config.Parameter `yaml:"txBumpPercent,omitempty"`
+
+	// The highest max fee that resubmitted transactions can use, in gwei
+	TxBumpMaxFee config.Parameter `yaml:"txBumpMaxFee,omitempty"`
+
+	// The wallet indices of additional node accounts, derived from the node wallet, that the node daemon manages
+	AdditionalNodeIndices config.Parameter `yaml:"additionalNodeIndices,omitempty"`
+
+	// Folders in the data directory holding the wallets of additional nodes the node daemon manages
+	AdditionalWalletFolders config.Parameter `yaml:"additionalWalletFolders,omitempty"`
+
+	// The URL of a webhook that receives node daemon notifications as generic JSON
+	NotificationWebhookUrl config.Parameter `yaml:"notificationWebhookUrl,omitempty"`
+
+	// The URL of a Discord webhook that receives node daemon notifications
+	NotificationDiscordUrl config.Parameter `yaml:"notificationDiscordUrl,omitempty"`
+
+	// The URL of a Slack incoming webhook that receives node daemon notifications
+	NotificationSlackUrl config.Parameter `yaml:"notificationSlackUrl,omitempty"`
+
+	// The Telegram Bot API sendMessage URL for node daemon notifications
+	NotificationTelegramUrl config.Parameter `yaml:"notificationTelegramUrl,omitempty"`
+
+	// The Telegram chat to send node daemon notifications to
+	NotificationTelegramChatID config.Parameter `yaml:"notificationTelegramChatId,omitempty"`
+
+	// The host:port of an SMTP relay for node daemon notifications
+	NotificationSmtpServer config.Parameter `yaml:"notificationSmtpServer,omitempty"`
+
+	// The sender address of notification emails
+	NotificationSmtpFrom config.Parameter `yaml:"notificationSmtpFrom,omitempty"`
+
+	// The recipients of notification emails
+	NotificationSmtpTo config.Parameter `yaml:"notificationSmtpTo,omitempty"`
+
+	// The types of events to send notifications for
+	NotificationEvents config.Parameter `yaml:"notificationEvents,omitempty"`
+
+	// The number of consecutive failures of a task before a notification is sent
+	NotificationTaskFailures config.Parameter `yaml:"notificationTaskFailures,omitempty"`
+
+	// Where the node wallet password is stored
+	PasswordBackend config.Parameter `yaml:"passwordBackend,omitempty"`
+
+	// The environment variable or file descriptor the node wallet password is passed in
+	PasswordEnvSource config.Parameter `yaml:"passwordEnvSource,omitempty"`
+
+	// The description of the node wallet password's key in the kernel keyring
+	PasswordKeyringKey config.Parameter `yaml:"passwordKeyringKey,omitempty"`
+
+	// The helper command that stores and retrieves the node wallet password
+	PasswordHelperCommand config.Parameter `yaml:"passwordHelperCommand,omitempty"`
+
+	// Toggle for keeping validator keys in a Web3Signer-compatible remote signer instead of on disk
+	UseRemoteSigner config.Parameter `yaml:"useRemoteSigner,omitempty"`
+
+	// The URL of the remote signer
+	RemoteSignerUrl config.Parameter `yaml:"remoteSignerUrl,omitempty"`
+
+	// What signs transactions and messages for the node account
+	NodeSigner config.Parameter `yaml:"nodeSigner,omitempty"`
+
+	// The JSON-RPC URL of the external node account signer
+	NodeSignerUrl config.Parameter `yaml:"nodeSignerUrl,omitempty"`
+
+	// The address of the node account held by the external signer
+	NodeSignerAddress config.Parameter `yaml:"nodeSignerAddress,omitempty"`
+
+	// Toggle for managing validator keys and fee recipients through the Validator Client's keymanager API
+	UseKeymanagerApi config.Parameter `yaml:"useKeymanagerApi,omitempty"`
+
+	// The port the Validator Client's keymanager API listens on
+	KeymanagerApiPort config.Parameter `yaml:"keymanagerApiPort,omitempty"`
+
 	///////////////////////////
 	// Non-editable settings //
 	///////////////////////////
@@ -167,6 +328,9 @@ type SmartnodeConfig struct {
 	// Addresses for RocketRewardsPool that have been upgraded during development
 	previousRewardsPoolAddresses map[config.Network][]common.Address `yaml:"-"`
 
+	// Addresses for RocketMerkleDistributorMainnet that were replaced by upgrade contracts instead of Oracle DAO upgrades
+	previousMerkleDistributorAddresses map[config.Network][]common.Address `yaml:"-"`
+
 	// The RocketOvmPriceMessenger Optimism address for each network
 	optimismPriceMessengerAddress map[config.Network]string `yaml:"-"`
 
@@ -298,6 +462,56 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		LogFormat: config.Parameter{
+			ID:                   "logFormat",
+			Name:                 "Log Format",
+			Description:          "Select the format of the node and watchtower daemon logs.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.LogFormat_Color},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Color",
+				Description: "Human-readable lines, colored by task.",
+				Value:       config.LogFormat_Color,
+			}, {
+				Name:        "JSON",
+				Description: "One JSON object per line with the level, message, task, node address and (where relevant) minipool address, transaction hash and slot as separate keys. Use this if you collect your logs with a tool such as Loki or ELK.",
+				Value:       config.LogFormat_Json,
+			}},
+		},
+
+		LogLevel: config.Parameter{
+			ID:                   "logLevel",
+			Name:                 "Log Level",
+			Description:          "Select the least severe kind of message the node and watchtower daemons will log.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.LogLevel_Info},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Debug",
+				Description: "Log everything, including detailed progress messages that are useful for troubleshooting.",
+				Value:       config.LogLevel_Debug,
+			}, {
+				Name:        "Info",
+				Description: "Log normal progress messages, warnings and errors.",
+				Value:       config.LogLevel_Info,
+			}, {
+				Name:        "Warning",
+				Description: "Only log warnings and errors.",
+				Value:       config.LogLevel_Warn,
+			}, {
+				Name:        "Error",
+				Description: "Only log errors.",
+				Value:       config.LogLevel_Error,
+			}},
+		},
+
 		RewardsTreeMode: config.Parameter{
 			ID:                   "rewardsTreeMode",
 			Name:                 "Rewards Tree Mode",
@@ -379,6 +593,18 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		UseQuorumReads: config.Parameter{
+			ID:                   "useQuorumReads",
+			Name:                 "Use Quorum Reads",
+			Description:          "Enable this to have the watchtower send its contract calls and block header lookups for past blocks to your primary and all of your fallback Execution clients, and refuse to submit anything if they don't all agree. This protects Oracle DAO submissions from a single faulty Execution client, but multiplies the load on your clients.\n\nRequires at least one fallback Execution client. Only useful for the Oracle DAO.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		RecordCheckpointInterval: config.Parameter{
 			ID:                   "recordCheckpointInterval",
 			Name:                 "Record Checkpoint Interval",
@@ -415,6 +641,552 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		ManageFeeRecipientEnabled: config.Parameter{
+			ID:                   "manageFeeRecipientEnabled",
+			Name:                 "Enable Fee Recipient Management",
+			Description:          "Enable this to let the node daemon run the task that checks that your Validator Client's fee recipient matches your Smoothing Pool status and corrects it if necessary.\n\n[orange]WARNING: if this is disabled, you are responsible for keeping your fee recipient correct yourself. Using the wrong fee recipient will get your node penalized.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ManageFeeRecipientInterval: config.Parameter{
+			ID:                   "manageFeeRecipientInterval",
+			Name:                 "Fee Recipient Management Interval",
+			Description:          "The longest time, in minutes, that the node daemon will wait between runs of the fee recipient management task. Relevant chain events (such as new blocks) can cause it to run sooner.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultNodeTaskInterval},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DownloadRewardsTreesEnabled: config.Parameter{
+			ID:                   "downloadRewardsTreesEnabled",
+			Name:                 "Enable Rewards Tree Download",
+			Description:          "Enable this to let the node daemon run the task that downloads the Merkle rewards tree for each finished rewards interval if it isn't on disk yet. The trees are needed to claim rewards.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DownloadRewardsTreesInterval: config.Parameter{
+			ID:                   "downloadRewardsTreesInterval",
+			Name:                 "Rewards Tree Download Interval",
+			Description:          "The longest time, in minutes, that the node daemon will wait between runs of the rewards tree download task. Relevant chain events (such as new blocks) can cause it to run sooner.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultNodeTaskInterval},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		StakePrelaunchMinipoolsEnabled: config.Parameter{
+			ID:                   "stakePrelaunchMinipoolsEnabled",
+			Name:                 "Enable Prelaunch Minipool Staking",
+			Description:          "Enable this to let the node daemon run the task that stakes minipools that have finished the scrub check and are ready to move from prelaunch to staking.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		StakePrelaunchMinipoolsInterval: config.Parameter{
+			ID:                   "stakePrelaunchMinipoolsInterval",
+			Name:                 "Prelaunch Minipool Staking Interval",
+			Description:          "The longest time, in minutes, that the node daemon will wait between runs of the prelaunch minipool staking task. Relevant chain events (such as new blocks) can cause it to run sooner.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultNodeTaskInterval},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DistributeMinipoolsEnabled: config.Parameter{
+			ID:                   "distributeMinipoolsEnabled",
+			Name:                 "Enable Minipool Distribution",
+			Description:          "Enable this to let the node daemon run the task that distributes the balances of your minipools once they are above the Auto Distribute Threshold.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DistributeMinipoolsInterval: config.Parameter{
+			ID:                   "distributeMinipoolsInterval",
+			Name:                 "Minipool Distribution Interval",
+			Description:          "The longest time, in minutes, that the node daemon will wait between runs of the minipool distribution task. Relevant chain events (such as new blocks) can cause it to run sooner.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultNodeTaskInterval},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ReduceBondsEnabled: config.Parameter{
+			ID:                   "reduceBondsEnabled",
+			Name:                 "Enable Bond Reduction",
+			Description:          "Enable this to let the node daemon run the task that completes bond reductions that you have started once the scrub window has passed.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ReduceBondsInterval: config.Parameter{
+			ID:                   "reduceBondsInterval",
+			Name:                 "Bond Reduction Interval",
+			Description:          "The longest time, in minutes, that the node daemon will wait between runs of the bond reduction task. Relevant chain events (such as new blocks) can cause it to run sooner.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultNodeTaskInterval},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PromoteMinipoolsEnabled: config.Parameter{
+			ID:                   "promoteMinipoolsEnabled",
+			Name:                 "Enable Vacant Minipool Promotion",
+			Description:          "Enable this to let the node daemon run the task that promotes vacant minipools created by solo staker migrations once the scrub window has passed.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PromoteMinipoolsInterval: config.Parameter{
+			ID:                   "promoteMinipoolsInterval",
+			Name:                 "Vacant Minipool Promotion Interval",
+			Description:          "The longest time, in minutes, that the node daemon will wait between runs of the vacant minipool promotion task. Relevant chain events (such as new blocks) can cause it to run sooner.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultNodeTaskInterval},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TrackPerformanceEnabled: config.Parameter{
+			ID:                   "trackPerformanceEnabled",
+			Name:                 "Enable Validator Performance Tracking",
+			Description:          "Enable this to let the node daemon track the attestations, proposals and sync committee duties of your minipools' validators for the current rewards interval and report them in its metrics.\n\nThis requires the node daemon to download every finalized Beacon block, so you may want to disable it if your Beacon Node is on a limited connection.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TrackPerformanceInterval: config.Parameter{
+			ID:                   "trackPerformanceInterval",
+			Name:                 "Validator Performance Tracking Interval",
+			Description:          "The longest time, in minutes, that the node daemon will wait between runs of the validator performance tracking task.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultNodeTaskInterval},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BeaconRequestConcurrency: config.Parameter{
+			ID:                   "beaconRequestConcurrency",
+			Name:                 "Beacon Request Concurrency",
+			Description:          "The maximum number of validator and duty requests the Smartnode will send to your Beacon Node at the same time. Lower this if your Beacon Node struggles when the Smartnode looks up a large number of validators.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultBeaconConcurrency},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UseStateCache: config.Parameter{
+			ID:                   "useStateCache",
+			Name:                 "Use Network State Cache",
+			Description:          "Enable this to save snapshots of the Rocket Pool network at finalized slots to disk, so the Smartnode can load them instead of rebuilding them from your clients every time it needs them (for example, when generating rewards trees). This uses more disk space, but can save a lot of time and load on your clients.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		StateCacheSize: config.Parameter{
+			ID:                   "stateCacheSize",
+			Name:                 "Network State Cache Size",
+			Description:          "The maximum amount of disk space, in MB, that the network state cache can use. The least recently used snapshots will be removed when it grows beyond this size.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultStateCacheSize},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TxBumpInterval: config.Parameter{
+			ID:                   "txBumpInterval",
+			Name:                 "Stuck Transaction Interval",
+			Description:          "How long, in minutes, the node and watchtower daemons wait for one of their transactions to be included in a block before they resubmit it with higher fees. Leave this at 0 to never resubmit stuck transactions automatically.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultTxBumpInterval},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TxBumpPercent: config.Parameter{
+			ID:                   "txBumpPercent",
+			Name:                 "Stuck Transaction Fee Increase",
+			Description:          "The percentage that the max fee and priority fee of a stuck transaction are raised by each time it's resubmitted. Execution clients require an increase of at least 10%, so lower values will be treated as 10%.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultTxBumpPercent},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TxBumpMaxFee: config.Parameter{
+			ID:                   "txBumpMaxFee",
+			Name:                 "Stuck Transaction Max Fee",
+			Description:          "The highest max fee, in gwei, that a resubmitted transaction can use. Stuck transactions won't be resubmitted again once their fees would go over this limit.\n\nResubmitted transactions also never go over the Automatic TX Gas Threshold or the Manual Max Fee, if you've set them.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultTxBumpMaxFee},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AdditionalNodeIndices: config.Parameter{
+			ID:                   "additionalNodeIndices",
+			Name:                 "Additional Node Indices",
+			Description:          "A comma-separated list of wallet indices (for example, `1,2`) for additional node accounts that the node daemon should manage alongside your primary node. Their keys are derived from the same mnemonic as your node wallet.\n\nEvery node shares your Validator Client, so they must all use the same fee recipient (in practice, they should all be in the Smoothing Pool).",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AdditionalWalletFolders: config.Parameter{
+			ID:                   "additionalWalletFolders",
+			Name:                 "Additional Wallet Folders",
+			Description:          "A comma-separated list of folders inside your data directory, each holding a `wallet` and `password` file for an additional node that the node daemon should manage alongside your primary node.\n\nEvery node shares your Validator Client, so they must all use the same fee recipient (in practice, they should all be in the Smoothing Pool).",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationWebhookUrl: config.Parameter{
+			ID:                   "notificationWebhookUrl",
+			Name:                 "Notification Webhook URL",
+			Description:          "The URL of a webhook that should receive node daemon notifications. Each notification is sent as a JSON object with its type, time, node, minipool, transaction hash, title and message.\n\nLeave this blank to disable it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationDiscordUrl: config.Parameter{
+			ID:                   "notificationDiscordUrl",
+			Name:                 "Discord Webhook URL",
+			Description:          "The URL of a Discord channel webhook that should receive node daemon notifications.\n\nLeave this blank to disable it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationSlackUrl: config.Parameter{
+			ID:                   "notificationSlackUrl",
+			Name:                 "Slack Webhook URL",
+			Description:          "The URL of a Slack incoming webhook that should receive node daemon notifications.\n\nLeave this blank to disable it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationTelegramUrl: config.Parameter{
+			ID:                   "notificationTelegramUrl",
+			Name:                 "Telegram Bot URL",
+			Description:          "The Telegram Bot API URL that node daemon notifications should be sent to, in the form `https://api.telegram.org/bot<token>/sendMessage`.\n\nLeave this blank to disable it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationTelegramChatID: config.Parameter{
+			ID:                   "notificationTelegramChatId",
+			Name:                 "Telegram Chat ID",
+			Description:          "The ID of the Telegram chat your bot should send node daemon notifications to.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationSmtpServer: config.Parameter{
+			ID:                   "notificationSmtpServer",
+			Name:                 "SMTP Relay",
+			Description:          "The host and port (e.g. `localhost:25`) of an SMTP relay that should email node daemon notifications. The relay must accept mail without authentication, so it should only be a local one.\n\nLeave this blank to disable it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationSmtpFrom: config.Parameter{
+			ID:                   "notificationSmtpFrom",
+			Name:                 "Email Sender",
+			Description:          "The address notification emails should be sent from.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationSmtpTo: config.Parameter{
+			ID:                   "notificationSmtpTo",
+			Name:                 "Email Recipients",
+			Description:          "A comma-separated list of addresses notification emails should be sent to.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationEvents: config.Parameter{
+			ID:                   "notificationEvents",
+			Name:                 "Notification Events",
+			Description:          "A comma-separated list of the events you want to be notified about; leave it blank to be notified about all of them.\n\nThe events are `minipool-staked`, `minipool-promoted`, `minipool-distributed`, `bond-reduced`, `bond-reduction-cancelled`, `fee-recipient-error`, `task-failing`, `task-recovered`, `client-unavailable` and `client-recovered`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationTaskFailures: config.Parameter{
+			ID:                   "notificationTaskFailures",
+			Name:                 "Task Failure Notification Threshold",
+			Description:          "The number of times in a row a node daemon task has to fail before you're notified about it.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultTaskFailureAlerts},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordBackend: config.Parameter{
+			ID:                   "passwordBackend",
+			Name:                 "Password Backend",
+			Description:          "Select where the password for your node wallet is stored. By default it's kept in a plaintext file next to your wallet; the other backends let you keep it off the disk.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.PasswordBackend_File},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "File",
+				Description: "Store the password in a plaintext file in your data directory.",
+				Value:       config.PasswordBackend_File,
+			}, {
+				Name:        "Environment",
+				Description: "Read the password from an environment variable or file descriptor that is passed to the Smartnode when it starts. The Smartnode can't set the password itself with this backend, so you must provide it before initializing or recovering your wallet.\n\n[orange]NOTE: This is only usable in Native mode, since the Smartnode's Docker containers don't inherit your shell's environment or file descriptors.",
+				Value:       config.PasswordBackend_Env,
+			}, {
+				Name:        "Kernel Keyring",
+				Description: "Store the password in your user's Linux kernel keyring, so it only ever lives in memory. Keys don't survive a reboot, so you'll need to load the password again (e.g. with `keyctl padd user rocketpool-node-password @u`) after each restart.\n\n[orange]NOTE: This is only usable in Native mode, since the Smartnode's Docker containers can't reach your user's kernel keyring.",
+				Value:       config.PasswordBackend_Keyring,
+			}, {
+				Name:        "Helper Command",
+				Description: "Run a helper command to store and retrieve the password, so it can be kept in an external secret store such as HashiCorp Vault, pass or systemd-creds.",
+				Value:       config.PasswordBackend_Exec,
+			}},
+		},
+
+		PasswordEnvSource: config.Parameter{
+			ID:                   "passwordEnvSource",
+			Name:                 "Password Environment Variable",
+			Description:          "[orange]**For the Environment password backend in Native mode only.**\n\n[white]The name of the environment variable that holds your node wallet password, or `fd:N` to read it from file descriptor N instead. The variable is cleared once it has been read.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultPasswordEnvSource},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordKeyringKey: config.Parameter{
+			ID:                   "passwordKeyringKey",
+			Name:                 "Password Keyring Key",
+			Description:          "[orange]**For the Kernel Keyring password backend only.**\n\n[white]The description of the `user` key in your user keyring that holds your node wallet password.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultPasswordKeyringKey},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordHelperCommand: config.Parameter{
+			ID:                   "passwordHelperCommand",
+			Name:                 "Password Helper Command",
+			Description:          "[orange]**For the Helper Command password backend only.**\n\n[white]The command that stores and retrieves your node wallet password. It's run through the shell with `get`, `set` or `delete` appended as its last argument: `get` must print the password to stdout, `set` receives the password on stdin, and the command must exit with a non-zero code if it fails.\n\nFor example, `pass-helper.sh` could call `pass show rocketpool/node` for `get` and `pass insert -m rocketpool/node` for `set`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UseRemoteSigner: config.Parameter{
+			ID:                   "useRemoteSigner",
+			Name:                 "Use Remote Signer",
+			Description:          "Enable this to keep your validator keys in a Web3Signer-compatible remote signer instead of on disk. New validator keys will be imported into the signer through its keymanager API, your Validator Client will be configured to sign with it, and exits and withdrawal credential changes will be signed by it.\n\nThe signer must have its keymanager API enabled. Keys that were created before you enabled this stay on disk; import them into the signer yourself.\n\n[orange]NOTE: Lighthouse's Validator Client can't discover keys from a remote signer on its own, so it isn't supported.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"USE_REMOTE_SIGNER"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RemoteSignerUrl: config.Parameter{
+			ID:                   "remoteSignerUrl",
+			Name:                 "Remote Signer URL",
+			Description:          "The URL of your Web3Signer-compatible remote signer, e.g. `http://web3signer:9000`. It must be reachable from both the Smartnode and your Validator Client.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"REMOTE_SIGNER_URL"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeSigner: config.Parameter{
+			ID:                   "nodeSigner",
+			Name:                 "Node Account Signer",
+			Description:          "Select what signs transactions and messages for your node account. By default the Smartnode signs them with the key derived from your node wallet; an external signer lets you keep that key out of the Smartnode entirely.\n\nAdditional node accounts derived from your node wallet always sign with their derived keys.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.NodeSigner_Local},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Local",
+				Description: "Sign with the node account key derived from your node wallet.",
+				Value:       config.NodeSigner_Local,
+			}, {
+				Name:        "Clef",
+				Description: "Send everything to Clef (or a hardware wallet or HSM behind it) through its external API to be signed.",
+				Value:       config.NodeSigner_Clef,
+			}, {
+				Name:        "JSON-RPC",
+				Description: "Send everything to a signer that supports the standard `eth_signTransaction` and `personal_sign` JSON-RPC methods, such as an EIP-1193 wallet bridge or Web3Signer's eth1 mode.",
+				Value:       config.NodeSigner_Rpc,
+			}},
+		},
+
+		NodeSignerUrl: config.Parameter{
+			ID:                   "nodeSignerUrl",
+			Name:                 "Node Account Signer URL",
+			Description:          "[orange]**For external node account signers only.**\n\n[white]The JSON-RPC URL of the signer, e.g. `http://clef:8550`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeSignerAddress: config.Parameter{
+			ID:                   "nodeSignerAddress",
+			Name:                 "Node Account Address",
+			Description:          "[orange]**For external node account signers only.**\n\n[white]The address of the account in the signer that should be used as your node account.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UseKeymanagerApi: config.Parameter{
+			ID:                   "useKeymanagerApi",
+			Name:                 "Use Keymanager API",
+			Description:          "Enable this to have the Smartnode load new validator keys and update your fee recipient through your Validator Client's keymanager API, so it doesn't have to be restarted and miss duties. If the API can't be reached, the Smartnode restarts the Validator Client like it normally would.\n\nIn Native mode, set the keymanager API's URL and token file in the Native settings.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"USE_KEYMANAGER_API"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiPort: config.Parameter{
+			ID:                   "keymanagerApiPort",
+			Name:                 "Keymanager API Port",
+			Description:          "The port your Validator Client should run its keymanager API on. It's only reachable from the Smartnode's containers.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultKeymanagerApiPort},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"KEYMANAGER_API_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		txWatchUrl: map[config.Network]string{
 			config.Network_Mainnet: "https://etherscan.io/tx",
 			config.Network_Prater:  "https://goerli.etherscan.io/tx",
@@ -540,6 +1312,12 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			config.Network_Devnet: {},
 		},
 
+		previousMerkleDistributorAddresses: map[config.Network][]common.Address{
+			config.Network_Mainnet: {},
+			config.Network_Prater:  {},
+			config.Network_Devnet:  {},
+		},
+
 		optimismPriceMessengerAddress: map[config.Network]string{
 			config.Network_Mainnet: "0xdddcf2c25d50ec22e67218e873d46938650d03a7",
 			config.Network_Prater:  "0x87E2deCE7d0A080D579f63cbcD7e1629BEcd7E7d",
@@ -601,20 +1379,78 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.PriorityFee,
 		&cfg.AutoTxGasThreshold,
 		&cfg.DistributeThreshold,
+		&cfg.LogFormat,
+		&cfg.LogLevel,
 		&cfg.RewardsTreeMode,
 		&cfg.ArchiveECUrl,
 		&cfg.Web3StorageApiToken,
 		&cfg.WatchtowerMaxFeeOverride,
 		&cfg.WatchtowerPrioFeeOverride,
 		&cfg.UseRollingRecords,
+		&cfg.UseQuorumReads,
 		&cfg.RecordCheckpointInterval,
 		&cfg.CheckpointRetentionLimit,
 		&cfg.RecordsPath,
+		&cfg.ManageFeeRecipientEnabled,
+		&cfg.ManageFeeRecipientInterval,
+		&cfg.DownloadRewardsTreesEnabled,
+		&cfg.DownloadRewardsTreesInterval,
+		&cfg.StakePrelaunchMinipoolsEnabled,
+		&cfg.StakePrelaunchMinipoolsInterval,
+		&cfg.DistributeMinipoolsEnabled,
+		&cfg.DistributeMinipoolsInterval,
+		&cfg.ReduceBondsEnabled,
+		&cfg.ReduceBondsInterval,
+		&cfg.PromoteMinipoolsEnabled,
+		&cfg.PromoteMinipoolsInterval,
+		&cfg.TrackPerformanceEnabled,
+		&cfg.TrackPerformanceInterval,
+		&cfg.BeaconRequestConcurrency,
+		&cfg.UseStateCache,
+		&cfg.StateCacheSize,
+		&cfg.TxBumpInterval,
+		&cfg.TxBumpPercent,
+		&cfg.TxBumpMaxFee,
+		&cfg.AdditionalNodeIndices,
+		&cfg.AdditionalWalletFolders,
+		&cfg.NotificationWebhookUrl,
+		&cfg.NotificationDiscordUrl,
+		&cfg.NotificationSlackUrl,
+		&cfg.NotificationTelegramUrl,
+		&cfg.NotificationTelegramChatID,
+		&cfg.NotificationSmtpServer,
+		&cfg.NotificationSmtpFrom,
+		&cfg.NotificationSmtpTo,
+		&cfg.NotificationEvents,
+		&cfg.NotificationTaskFailures,
+		&cfg.PasswordBackend,
+		&cfg.PasswordEnvSource,
+		&cfg.PasswordKeyringKey,
+		&cfg.PasswordHelperCommand,
+		&cfg.UseRemoteSigner,
+		&cfg.RemoteSignerUrl,
+		&cfg.NodeSigner,
+		&cfg.NodeSignerUrl,
+		&cfg.NodeSignerAddress,
+		&cfg.UseKeymanagerApi,
+		&cfg.KeymanagerApiPort,
 	}
 }
 
 // Getters for the non-editable parameters
 
+func (cfg *SmartnodeConfig) GetLogSettings() (log.Format, log.Level) {
+	format := log.Format_Color
+	if cfg.LogFormat.Value.(config.LogFormat) == config.LogFormat_Json {
+		format = log.Format_Json
+	}
+	level, err := log.ParseLevel(string(cfg.LogLevel.Value.(config.LogLevel)))
+	if err != nil {
+		level = log.Level_Info
+	}
+	return format, level
+}
+
 func (cfg *SmartnodeConfig) GetTxWatchUrl() string {
 	return cfg.txWatchUrl[cfg.Network.Value.(config.Network)]
 }
@@ -784,6 +1620,10 @@ func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64,
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
 }
 
+func (cfg *SmartnodeConfig) GetRewardsTreeFixturePath(interval uint64, daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), RewardsTreeFixturesFolder, fmt.Sprintf(RewardsTreeFixtureFolderFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
 func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder)
@@ -792,6 +1632,98 @@ func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder)
 }
 
+func (cfg *SmartnodeConfig) GetNodeTaskStatusPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, NodeTaskStatusFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), NodeTaskStatusFilename)
+}
+
+func (cfg *SmartnodeConfig) GetTxJournalPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, TxJournalFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), TxJournalFilename)
+}
+
+func (cfg *SmartnodeConfig) GetNotificationStatePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, NotificationStateFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), NotificationStateFilename)
+}
+
+func (cfg *SmartnodeConfig) GetApiSocketPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ApiSocketFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ApiSocketFilename)
+}
+
+func (cfg *SmartnodeConfig) GetApiTokenPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ApiTokenFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ApiTokenFilename)
+}
+
+func (cfg *SmartnodeConfig) GetValidatorPerformancePath(nodeAddress common.Address, daemon bool) string {
+	filename := fmt.Sprintf(ValidatorPerformanceFilenameFormat, nodeAddress.Hex())
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, filename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), filename)
+}
+
+func (cfg *SmartnodeConfig) GetStateCachePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, StateCacheFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), StateCacheFolder)
+}
+
+// Get the wallet indices of the additional node accounts derived from the node wallet
+func (cfg *SmartnodeConfig) GetAdditionalNodeIndices() ([]uint, error) {
+	indices := []uint{}
+	for _, element := range strings.Split(cfg.AdditionalNodeIndices.Value.(string), ",") {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+		index, err := strconv.ParseUint(element, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid additional node index [%s]: %w", element, err)
+		}
+		indices = append(indices, uint(index))
+	}
+	return indices, nil
+}
+
+// Get the paths of the folders holding the wallets of additional nodes
+func (cfg *SmartnodeConfig) GetAdditionalWalletFolders(daemon bool) []string {
+	dataPath := cfg.DataPath.Value.(string)
+	if daemon && !cfg.parent.IsNativeMode {
+		dataPath = DaemonDataPath
+	}
+
+	folders := []string{}
+	for _, element := range strings.Split(cfg.AdditionalWalletFolders.Value.(string), ",") {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+		folders = append(folders, filepath.Join(dataPath, element))
+	}
+	return folders
+}
+
 func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	if !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, "validators", FeeRecipientFilename)
@@ -800,6 +1732,22 @@ func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "validators", NativeFeeRecipientFilename)
 }
 
+func (cfg *SmartnodeConfig) GetKeymanagerApiUrl() string {
+	if !cfg.parent.IsNativeMode {
+		return fmt.Sprintf("http://%s:%d", ValidatorContainerName, cfg.KeymanagerApiPort.Value)
+	}
+
+	return cfg.parent.Native.KeymanagerApiUrl.Value.(string)
+}
+
+func (cfg *SmartnodeConfig) GetKeymanagerApiTokenPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, "validators", KeymanagerApiTokenFilename)
+	}
+
+	return cfg.parent.Native.KeymanagerApiTokenPath.Value.(string)
+}
+
 func (cfg *SmartnodeConfig) GetV100RewardsPoolAddress() common.Address {
 	return common.HexToAddress(cfg.v1_0_0_RewardsPoolAddress[cfg.Network.Value.(config.Network)])
 }
@@ -840,6 +1788,10 @@ func (cfg *SmartnodeConfig) GetPreviousRewardsPoolAddresses() []common.Address {
 	return cfg.previousRewardsPoolAddresses[cfg.Network.Value.(config.Network)]
 }
 
+func (cfg *SmartnodeConfig) GetPreviousMerkleDistributorAddresses() []common.Address {
+	return cfg.previousMerkleDistributorAddresses[cfg.Network.Value.(config.Network)]
+}
+
 func (cfg *SmartnodeConfig) GetOptimismMessengerAddress() string {
 	return cfg.optimismPriceMessengerAddress[cfg.Network.Value.(config.Network)]
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math"
 	"math/big"
-	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -19,26 +18,29 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
+// Settings
+const (
+	maxEcHeadLag         uint64        = 3
+	ecRequestTimeout     time.Duration = 30 * time.Second
+	ecLogsRequestTimeout time.Duration = 2 * time.Minute
+)
+
 // This is a proxy for multiple ETH clients, providing natural fallback support if one of them fails.
+// The clients are used in order of preference; requests go to the first healthy one.
 type ExecutionClientManager struct {
-	primaryEcUrl    string
-	fallbackEcUrl   string
-	primaryEc       *ethclient.Client
-	fallbackEc      *ethclient.Client
-	logger          log.ColorLogger
-	primaryReady    bool
-	fallbackReady   bool
-	ignoreSyncCheck bool
+	ecs             []*ethclient.Client
+	pool            *clientPool
+	expectedChainID uint
+	quorum          ecQuorum
 }
 
 // This is a signature for a wrapped ethclient.Client function
-type ecFunction func(*ethclient.Client) (interface{}, error)
+type ecFunction func(context.Context, *ethclient.Client) (interface{}, error)
 
 // Creates a new ExecutionClientManager instance based on the Rocket Pool config
 func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
 
 	var primaryEcUrl string
-	var fallbackEcUrl string
 
 	// Get the primary EC url
 	if cfg.IsNativeMode {
@@ -49,43 +51,30 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		primaryEcUrl = cfg.ExternalExecution.HttpUrl.Value.(string)
 	}
 
-	// Get the fallback EC url, if applicable
-	if cfg.UseFallbackClients.Value == true {
-		if cfg.IsNativeMode {
-			fallbackEcUrl = cfg.FallbackNormal.EcHttpUrl.Value.(string)
-		} else {
-			cc, _ := cfg.GetSelectedConsensusClient()
-			switch cc {
-			case cfgtypes.ConsensusClient_Prysm:
-				fallbackEcUrl = cfg.FallbackPrysm.EcHttpUrl.Value.(string)
-			default:
-				fallbackEcUrl = cfg.FallbackNormal.EcHttpUrl.Value.(string)
-			}
-		}
-	}
+	// Get the fallback EC urls, if applicable
+	fallbackEcUrls := cfg.GetFallbackEcHttpUrls()
 
 	primaryEc, err := ethclient.Dial(primaryEcUrl)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
 	}
+	ecs := []*ethclient.Client{primaryEc}
 
-	var fallbackEc *ethclient.Client
-	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+	for _, fallbackEcUrl := range fallbackEcUrls {
+		fallbackEc, err := ethclient.Dial(fallbackEcUrl)
 		if err != nil {
 			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
 		}
+		ecs = append(ecs, fallbackEc)
 	}
 
-	return &ExecutionClientManager{
-		primaryEcUrl:  primaryEcUrl,
-		fallbackEcUrl: fallbackEcUrl,
-		primaryEc:     primaryEc,
-		fallbackEc:    fallbackEc,
-		logger:        log.NewColorLogger(color.FgYellow),
-		primaryReady:  true,
-		fallbackReady: fallbackEc != nil,
-	}, nil
+	manager := &ExecutionClientManager{
+		ecs:             ecs,
+		pool:            newClientPool("Execution", len(ecs), maxEcHeadLag, log.NewColorLogger(color.FgYellow)),
+		expectedChainID: cfg.Smartnode.GetChainID(),
+	}
+	manager.pool.probe = manager.probe
+	return manager, nil
 
 }
 
@@ -96,7 +85,7 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 // CodeAt returns the code of the given account. This is needed to differentiate
 // between contract internal errors and the local chain being out of sync.
 func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.CodeAt(ctx, contract, blockNumber)
 	})
 	if err != nil {
@@ -108,9 +97,16 @@ func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Add
 // CallContract executes an Ethereum contract call with the specified data as the
 // input.
 func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	function := func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.CallContract(ctx, call, blockNumber)
-	})
+	}
+	var result interface{}
+	var err error
+	if p.useQuorum(blockNumber) {
+		result, err = p.runQuorumFunction(ctx, "CallContract", blockNumber, function, callResultsEqual)
+	} else {
+		result, err = p.runFunction(ctx, ecRequestTimeout, true, function)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +119,7 @@ func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum
 
 // HeaderByHash returns the block header with the given hash.
 func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByHash(ctx, hash)
 	})
 	if err != nil {
@@ -135,9 +131,16 @@ func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.H
 // HeaderByNumber returns a block header from the current canonical chain. If number is
 // nil, the latest known header is returned.
 func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	function := func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByNumber(ctx, number)
-	})
+	}
+	var result interface{}
+	var err error
+	if p.useQuorum(number) {
+		result, err = p.runQuorumFunction(ctx, "HeaderByNumber", number, function, headersEqual)
+	} else {
+		result, err = p.runFunction(ctx, ecRequestTimeout, true, function)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -146,7 +149,7 @@ func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big
 
 // PendingCodeAt returns the code of the given account in the pending state.
 func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.PendingCodeAt(ctx, account)
 	})
 	if err != nil {
@@ -157,7 +160,7 @@ func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account comm
 
 // PendingNonceAt retrieves the current pending nonce associated with an account.
 func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.PendingNonceAt(ctx, account)
 	})
 	if err != nil {
@@ -169,7 +172,7 @@ func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account com
 // SuggestGasPrice retrieves the currently suggested gas price to allow a timely
 // execution of a transaction.
 func (p *ExecutionClientManager) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.SuggestGasPrice(ctx)
 	})
 	if err != nil {
@@ -181,7 +184,7 @@ func (p *ExecutionClientManager) SuggestGasPrice(ctx context.Context) (*big.Int,
 // SuggestGasTipCap retrieves the currently suggested 1559 priority fee to allow
 // a timely execution of a transaction.
 func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.SuggestGasTipCap(ctx)
 	})
 	if err != nil {
@@ -196,7 +199,7 @@ func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int
 // transactions may be added or removed by miners, but it should provide a basis
 // for setting a reasonable default.
 func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.EstimateGas(ctx, call)
 	})
 	if err != nil {
@@ -207,7 +210,7 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 
 // SendTransaction injects the transaction into the pending pool for execution.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	_, err := p.runFunction(ctx, ecRequestTimeout, false, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
 	return err
@@ -222,7 +225,7 @@ func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.
 //
 // TODO(karalabe): Deprecate when the subscription one can return past data too.
 func (p *ExecutionClientManager) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecLogsRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.FilterLogs(ctx, query)
 	})
 	if err != nil {
@@ -234,7 +237,7 @@ func (p *ExecutionClientManager) FilterLogs(ctx context.Context, query ethereum.
 // SubscribeFilterLogs creates a background log filtering operation, returning
 // a subscription immediately, which can be used to stream the found events.
 func (p *ExecutionClientManager) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, 0, false, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.SubscribeFilterLogs(ctx, query, ch)
 	})
 	if err != nil {
@@ -243,6 +246,18 @@ func (p *ExecutionClientManager) SubscribeFilterLogs(ctx context.Context, query
 	return result.(ethereum.Subscription), err
 }
 
+// SubscribeNewHead subscribes to notifications about the current blockchain head.
+// This is only supported when the client is connected over a websocket or IPC.
+func (p *ExecutionClientManager) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	result, err := p.runFunction(ctx, 0, false, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
+		return client.SubscribeNewHead(ctx, ch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(ethereum.Subscription), err
+}
+
 /// =======================
 /// DeployBackend Functions
 /// =======================
@@ -250,7 +265,7 @@ func (p *ExecutionClientManager) SubscribeFilterLogs(ctx context.Context, query
 // TransactionReceipt returns the receipt of a transaction by transaction hash.
 // Note that the receipt is not available for pending transactions.
 func (p *ExecutionClientManager) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.TransactionReceipt(ctx, txHash)
 	})
 	if err != nil {
@@ -265,7 +280,7 @@ func (p *ExecutionClientManager) TransactionReceipt(ctx context.Context, txHash
 
 // BlockNumber returns the most recent block number
 func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.BlockNumber(ctx)
 	})
 	if err != nil {
@@ -277,7 +292,7 @@ func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error
 // BalanceAt returns the wei balance of the given account.
 // The block number can be nil, in which case the balance is taken from the latest known block.
 func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.BalanceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -288,7 +303,7 @@ func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.A
 
 // TransactionByHash returns the transaction with the given hash.
 func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		tx, isPending, err := client.TransactionByHash(ctx, hash)
 		result := []interface{}{tx, isPending}
 		return result, err
@@ -307,7 +322,7 @@ func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash com
 // NonceAt returns the account nonce of the given account.
 // The block number can be nil, in which case the nonce is taken from the latest known block.
 func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.NonceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -319,7 +334,7 @@ func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Add
 // SyncProgress retrieves the current progress of the sync algorithm. If there's
 // no sync currently running, it returns nil.
 func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runFunction(ctx, ecRequestTimeout, true, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.SyncProgress(ctx)
 	})
 	if err != nil {
@@ -334,44 +349,45 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 
 func (p *ExecutionClientManager) CheckStatus(cfg *config.RocketPoolConfig) *api.ClientManagerStatus {
 
-	status := &api.ClientManagerStatus{
-		FallbackEnabled: p.fallbackEc != nil,
-	}
-
 	// Ignore the sync check and just use the predefined settings if requested
-	if p.ignoreSyncCheck {
-		status.PrimaryClientStatus.IsWorking = p.primaryReady
-		status.PrimaryClientStatus.IsSynced = p.primaryReady
-		if status.FallbackEnabled {
-			status.FallbackClientStatus.IsWorking = p.fallbackReady
-			status.FallbackClientStatus.IsSynced = p.fallbackReady
-		}
-		return status
+	if !p.pool.isIgnoringSyncCheck() {
+		p.probe()
 	}
+	return p.pool.getManagerStatus()
 
-	// Get the primary EC status
-	status.PrimaryClientStatus = checkEcStatus(p.primaryEc)
+}
+
+// Get the status of each client from the last time they were checked, without checking them again
+func (p *ExecutionClientManager) GetLastStatus() *api.ClientManagerStatus {
+	return p.pool.getManagerStatus()
+}
 
-	// Flag if primary client is ready
-	p.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
+// Get the health of each client, in order of preference
+func (p *ExecutionClientManager) GetEndpointStats() []ClientEndpointStats {
+	return p.pool.getStats()
+}
 
-	// Get the fallback EC status if applicable
-	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkEcStatus(p.fallbackEc)
-		// Check if fallback is using the expected network
-		expectedChainID := cfg.Smartnode.GetChainID()
-		if status.FallbackClientStatus.Error == "" && status.FallbackClientStatus.NetworkId != expectedChainID {
-			p.fallbackReady = false
+// Check the status of every client and update their health
+func (p *ExecutionClientManager) probe() {
+	for i, ec := range p.ecs {
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), ecRequestTimeout)
+		status, head := checkEcStatus(ctx, ec)
+		cancel()
+		latency := time.Since(start)
+
+		// Check if the fallbacks are using the expected network
+		if i > 0 && status.Error == "" && status.NetworkId != p.expectedChainID {
 			colorReset := "\033[0m"
 			colorYellow := "\033[33m"
-			status.FallbackClientStatus.Error = fmt.Sprintf("The fallback client is using a different chain [%s%s%s, Chain ID %d] than what your node is configured for [%s, Chain ID %d]", colorYellow, getNetworkNameFromId(status.FallbackClientStatus.NetworkId), colorReset, status.FallbackClientStatus.NetworkId, getNetworkNameFromId(expectedChainID), expectedChainID)
-			return status
+			status.IsWorking = false
+			status.IsSynced = false
+			status.Error = fmt.Sprintf("The %s client is using a different chain [%s%s%s, Chain ID %d] than what your node is configured for [%s, Chain ID %d]", p.pool.getName(i), colorYellow, getNetworkNameFromId(status.NetworkId), colorReset, status.NetworkId, getNetworkNameFromId(p.expectedChainID), p.expectedChainID)
 		}
-	}
-
-	p.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
 
-	return status
+		p.pool.healths[i].setProbeResult(status, head, latency)
+	}
+	p.pool.finishProbe()
 }
 
 func getNetworkNameFromId(networkId uint) string {
@@ -386,18 +402,18 @@ func getNetworkNameFromId(networkId uint) string {
 
 }
 
-// Check the client status
-func checkEcStatus(client *ethclient.Client) api.ClientStatus {
+// Check the client status; also returns the number of the latest block the client has
+func checkEcStatus(ctx context.Context, client *ethclient.Client) (api.ClientStatus, uint64) {
 
 	status := api.ClientStatus{}
 
 	// Get the NetworkId
-	networkId, err := client.NetworkID(context.Background())
+	networkId, err := client.NetworkID(ctx)
 	if err != nil {
 		status.Error = fmt.Sprintf("Sync progress check failed with [%s]", err.Error())
 		status.IsSynced = false
 		status.IsWorking = false
-		return status
+		return status, 0
 	}
 
 	if networkId != nil {
@@ -405,37 +421,39 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 	}
 
 	// Get the fallback's sync progress
-	progress, err := client.SyncProgress(context.Background())
+	progress, err := client.SyncProgress(ctx)
 	if err != nil {
 		status.Error = fmt.Sprintf("Sync progress check failed with [%s]", err.Error())
 		status.IsSynced = false
 		status.IsWorking = false
-		return status
+		return status, 0
 	}
 
 	// Make sure it's up to date
 	if progress == nil {
 
-		isUpToDate, blockTime, err := IsSyncWithinThreshold(client)
+		header, err := client.HeaderByNumber(ctx, nil)
 		if err != nil {
 			status.Error = fmt.Sprintf("Error checking if client's sync progress is up to date: [%s]", err.Error())
 			status.IsSynced = false
 			status.IsWorking = false
-			return status
+			return status, 0
 		}
+		head := header.Number.Uint64()
 
 		status.IsWorking = true
-		if !isUpToDate {
+		blockTime := time.Unix(int64(header.Time), 0)
+		if time.Since(blockTime) >= ethClientRecentBlockThreshold {
 			status.Error = fmt.Sprintf("Client claims to have finished syncing, but its last block was from %s ago. It likely doesn't have enough peers", time.Since(blockTime))
 			status.IsSynced = false
 			status.SyncProgress = 0
-			return status
+			return status, head
 		}
 
 		// It's synced and it works!
 		status.IsSynced = true
 		status.SyncProgress = 1
-		return status
+		return status, head
 
 	}
 
@@ -451,56 +469,31 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 		status.SyncProgress = 0
 	}
 
-	return status
+	return status, progress.CurrentBlock
 
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{}, error) {
-
-	// Check if we can use the primary
-	if p.primaryReady {
-		// Try to run the function on the primary
-		result, err := function(p.primaryEc)
-		if err != nil {
-			if p.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Primary Execution client disconnected (%s), using fallback...", err.Error())
-				p.primaryReady = false
-				return p.runFunction(function)
-			}
-
-			// If it's a different error, just return it
-			return nil, err
+// Each attempt gets its own deadline from the timeout, unless it's 0; if retry is set, the function is idempotent and can be retried.
+func (p *ExecutionClientManager) runFunction(ctx context.Context, timeout time.Duration, retry bool, function ecFunction) (interface{}, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var result interface{}
+	err := p.pool.run(ctx, retry, func(index int) error {
+		requestCtx := ctx
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			requestCtx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
 		}
 
-		// If there's no error, return the result
-		return result, nil
-	}
-
-	if p.fallbackReady {
-		// Try to run the function on the fallback
-		result, err := function(p.fallbackEc)
-		if err != nil {
-			if p.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Fallback Execution client disconnected (%s)", err.Error())
-				p.fallbackReady = false
-				return nil, fmt.Errorf("all Execution clients failed")
-			}
-
-			// If it's a different error, just return it
-			return nil, err
-		}
-
-		// If there's no error, return the result
-		return result, nil
+		var err error
+		result, err = function(requestCtx, p.ecs[index])
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return nil, fmt.Errorf("no Execution clients were ready")
-}
-
-// Returns true if the error was a connection failure and a backup client is available
-func (p *ExecutionClientManager) isDisconnected(err error) bool {
-	return strings.Contains(err.Error(), "dial tcp")
+	return result, nil
 }
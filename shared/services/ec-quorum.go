@@ -0,0 +1,240 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Settings
+const minQuorumResponses int = 2
+
+// Returned when quorum reads are enabled and the Execution clients don't agree on a result
+var ErrQuorumMismatch = errors.New("Execution clients returned different results")
+
+// The quorum read statistics for a single method
+type QuorumStats struct {
+	// The name of the method
+	Method string
+
+	// The number of reads that were cross-checked
+	Checks uint64
+
+	// The number of reads where the clients disagreed
+	Mismatches uint64
+
+	// The number of reads that couldn't be cross-checked because too few clients responded or had the block yet
+	Unavailable uint64
+
+	// The time of the latest mismatch
+	LastMismatchTime time.Time
+}
+
+// Quorum read settings and statistics for an ExecutionClientManager
+type ecQuorum struct {
+	lock    sync.Mutex
+	enabled bool
+	stats   map[string]*QuorumStats
+}
+
+// The response from a single client to a quorum read
+type quorumResponse struct {
+	index  int
+	result interface{}
+	err    error
+}
+
+// Enable quorum reads: calls and header lookups for specific blocks are sent to every usable client and fail if they don't all agree.
+// Reads of the latest block aren't cross-checked, since the clients may legitimately be a block apart.
+// For the same reason, clients that don't have a block yet and disagreements close to the head of the chain don't count as mismatches.
+func (p *ExecutionClientManager) EnableQuorumReads() {
+	p.quorum.lock.Lock()
+	defer p.quorum.lock.Unlock()
+	p.quorum.enabled = true
+}
+
+// Check if quorum reads are enabled
+func (p *ExecutionClientManager) IsQuorumReadsEnabled() bool {
+	p.quorum.lock.Lock()
+	defer p.quorum.lock.Unlock()
+	return p.quorum.enabled
+}
+
+// Get the quorum read statistics for each method that has been cross-checked
+func (p *ExecutionClientManager) GetQuorumStats() []QuorumStats {
+	p.quorum.lock.Lock()
+	defer p.quorum.lock.Unlock()
+	stats := make([]QuorumStats, 0, len(p.quorum.stats))
+	for _, methodStats := range p.quorum.stats {
+		stats = append(stats, *methodStats)
+	}
+	return stats
+}
+
+// Check if a read at the provided block should be cross-checked
+func (p *ExecutionClientManager) useQuorum(blockNumber *big.Int) bool {
+	return blockNumber != nil && blockNumber.Sign() >= 0 && p.IsQuorumReadsEnabled()
+}
+
+// Run a read on every usable client at the same time and make sure they all return the same result
+func (p *ExecutionClientManager) runQuorumFunction(ctx context.Context, method string, blockNumber *big.Int, function ecFunction, equal func(interface{}, interface{}) bool) (interface{}, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Send the request to every usable client
+	indices := p.pool.getUsableIndices()
+	responses := make([]quorumResponse, len(indices))
+	var wg sync.WaitGroup
+	for i, index := range indices {
+		wg.Add(1)
+		go func(i int, index int) {
+			defer wg.Done()
+			requestCtx, cancel := context.WithTimeout(ctx, ecRequestTimeout)
+			defer cancel()
+			result, err := function(requestCtx, p.ecs[index])
+			responses[i] = quorumResponse{
+				index:  index,
+				result: result,
+				err:    err,
+			}
+		}(i, index)
+	}
+	wg.Wait()
+
+	// Only count the clients that actually handled the request
+	answers := []quorumResponse{}
+	for _, response := range responses {
+		health := p.pool.healths[response.index]
+		switch classifyClientError(response.err) {
+		case clientError_None, clientError_Rpc:
+			health.recordRequest(false)
+			answers = append(answers, response)
+		case clientError_Connection:
+			p.pool.logger.Warnf("The %s %s client disconnected (%s)", p.pool.getName(response.index), p.pool.clientType, response.err.Error())
+			health.setDisconnected(response.err)
+		default:
+			health.recordRequest(true)
+		}
+	}
+	if len(answers) < minQuorumResponses {
+		p.recordQuorumResult(method, false, true)
+		return nil, fmt.Errorf("%s at block %s needs at least %d Execution clients to respond for a quorum read, but only %d did", method, blockNumber.String(), minQuorumResponses, len(answers))
+	}
+
+	// Clients that don't have the block yet are behind, not wrong, so leave them out
+	current := []quorumResponse{}
+	for _, answer := range answers {
+		if !isBlockNotFoundError(answer.err) {
+			current = append(current, answer)
+		}
+	}
+	if len(current) == 0 {
+		// None of them have it, so they agree that it doesn't exist yet
+		p.recordQuorumResult(method, false, false)
+		return nil, answers[0].err
+	}
+	if len(current) < minQuorumResponses {
+		p.recordQuorumResult(method, false, true)
+		return nil, fmt.Errorf("%s at block %s needs at least %d Execution clients to have the block for a quorum read, but only %d did", method, blockNumber.String(), minQuorumResponses, len(current))
+	}
+
+	// Make sure they all agree
+	reference := current[0]
+	disagreeing := []string{}
+	for _, answer := range current[1:] {
+		var matches bool
+		if reference.err != nil || answer.err != nil {
+			matches = reference.err != nil && answer.err != nil && reference.err.Error() == answer.err.Error()
+		} else {
+			matches = equal(reference.result, answer.result)
+		}
+		if !matches {
+			disagreeing = append(disagreeing, p.pool.getName(answer.index))
+		}
+	}
+	if len(disagreeing) > 0 {
+		// Clients can legitimately disagree about blocks at the head of the chain while a reorg settles
+		if p.isNearHead(ctx, blockNumber, current) {
+			p.recordQuorumResult(method, false, true)
+			return nil, fmt.Errorf("%s at block %s from the %s client didn't match the %s client(s), but the block is too close to the head of the chain to be sure it's final", method, blockNumber.String(), p.pool.getName(reference.index), strings.Join(disagreeing, ", "))
+		}
+		p.recordQuorumResult(method, true, false)
+		return nil, fmt.Errorf("%w: %s at block %s from the %s client didn't match the %s client(s)", ErrQuorumMismatch, method, blockNumber.String(), p.pool.getName(reference.index), strings.Join(disagreeing, ", "))
+	}
+
+	p.recordQuorumResult(method, false, false)
+	return reference.result, reference.err
+}
+
+// Check if a block is within the allowed head lag of the highest head reported by the clients that answered a quorum read
+func (p *ExecutionClientManager) isNearHead(ctx context.Context, blockNumber *big.Int, answers []quorumResponse) bool {
+	var head uint64
+	found := false
+	for _, answer := range answers {
+		requestCtx, cancel := context.WithTimeout(ctx, ecRequestTimeout)
+		clientHead, err := p.ecs[answer.index].BlockNumber(requestCtx)
+		cancel()
+		if err == nil && clientHead >= head {
+			head = clientHead
+			found = true
+		}
+	}
+	return found && blockNumber.Uint64()+maxEcHeadLag >= head
+}
+
+// Check if an error means the client doesn't have the requested block
+func isBlockNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "header not found") || strings.Contains(message, "block not found") || strings.Contains(message, "unknown block")
+}
+
+// Update the statistics for a quorum read
+func (p *ExecutionClientManager) recordQuorumResult(method string, mismatch bool, unavailable bool) {
+	p.quorum.lock.Lock()
+	defer p.quorum.lock.Unlock()
+	if p.quorum.stats == nil {
+		p.quorum.stats = map[string]*QuorumStats{}
+	}
+	stats, exists := p.quorum.stats[method]
+	if !exists {
+		stats = &QuorumStats{
+			Method: method,
+		}
+		p.quorum.stats[method] = stats
+	}
+
+	if unavailable {
+		stats.Unavailable++
+		return
+	}
+	stats.Checks++
+	if mismatch {
+		stats.Mismatches++
+		stats.LastMismatchTime = time.Now()
+	}
+}
+
+// Compare two contract call results
+func callResultsEqual(a interface{}, b interface{}) bool {
+	return bytes.Equal(a.([]byte), b.([]byte))
+}
+
+// Compare two block headers
+func headersEqual(a interface{}, b interface{}) bool {
+	return a.(*types.Header).Hash() == b.(*types.Header).Hash()
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// An Execution client that has every block up to its head, and returns the same result for every contract call
+type fakeExecutionClient struct {
+	head       uint64
+	callResult []byte
+	extraData  []byte
+}
+
+// Serve the JSON-RPC methods used by quorum reads
+func (c *fakeExecutionClient) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var request struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result interface{}
+	var rpcErr map[string]interface{}
+	switch request.Method {
+	case "eth_blockNumber":
+		result = hexutil.Uint64(c.head)
+	case "eth_call":
+		var block hexutil.Uint64
+		json.Unmarshal(request.Params[1], &block)
+		if uint64(block) > c.head {
+			rpcErr = map[string]interface{}{"code": -32000, "message": "header not found"}
+		} else {
+			result = hexutil.Bytes(c.callResult)
+		}
+	case "eth_getBlockByNumber":
+		var block hexutil.Uint64
+		json.Unmarshal(request.Params[0], &block)
+		if uint64(block) <= c.head {
+			result = &types.Header{
+				Number:     new(big.Int).SetUint64(uint64(block)),
+				Difficulty: big.NewInt(0),
+				Extra:      c.extraData,
+			}
+		}
+	default:
+		rpcErr = map[string]interface{}{"code": -32601, "message": "method not found"}
+	}
+
+	response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+	if rpcErr != nil {
+		response["error"] = rpcErr
+	} else {
+		response["result"] = result
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Create a manager with quorum reads enabled for the provided clients
+func newTestQuorumManager(t *testing.T, clients ...*fakeExecutionClient) *ExecutionClientManager {
+	ecs := []*ethclient.Client{}
+	for _, client := range clients {
+		server := httptest.NewServer(client)
+		t.Cleanup(server.Close)
+		ec, err := ethclient.Dial(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(ec.Close)
+		ecs = append(ecs, ec)
+	}
+	manager := &ExecutionClientManager{
+		ecs:  ecs,
+		pool: newClientPool("Execution", len(ecs), maxEcHeadLag, log.NewColorLogger(0)),
+	}
+	manager.EnableQuorumReads()
+	return manager
+}
+
+// Get the statistics for a method
+func getQuorumStats(manager *ExecutionClientManager, method string) QuorumStats {
+	for _, stats := range manager.GetQuorumStats() {
+		if stats.Method == method {
+			return stats
+		}
+	}
+	return QuorumStats{Method: method}
+}
+
+func TestQuorumAgreement(t *testing.T) {
+	manager := newTestQuorumManager(t,
+		&fakeExecutionClient{head: 100, callResult: []byte{1}},
+		&fakeExecutionClient{head: 100, callResult: []byte{1}},
+		&fakeExecutionClient{head: 100, callResult: []byte{1}},
+	)
+	result, err := manager.CallContract(context.Background(), ethereum.CallMsg{}, big.NewInt(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result[0] != 1 {
+		t.Fatalf("unexpected result %x", result)
+	}
+	if stats := getQuorumStats(manager, "CallContract"); stats.Checks != 1 || stats.Mismatches != 0 || stats.Unavailable != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestQuorumMismatch(t *testing.T) {
+	manager := newTestQuorumManager(t,
+		&fakeExecutionClient{head: 100, callResult: []byte{1}},
+		&fakeExecutionClient{head: 100, callResult: []byte{2}},
+	)
+	_, err := manager.CallContract(context.Background(), ethereum.CallMsg{}, big.NewInt(50))
+	if !errors.Is(err, ErrQuorumMismatch) {
+		t.Fatalf("expected a mismatch, got %v", err)
+	}
+	if stats := getQuorumStats(manager, "CallContract"); stats.Checks != 1 || stats.Mismatches != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	// Headers are compared by hash
+	manager = newTestQuorumManager(t,
+		&fakeExecutionClient{head: 100},
+		&fakeExecutionClient{head: 100, extraData: []byte("different")},
+	)
+	_, err = manager.HeaderByNumber(context.Background(), big.NewInt(50))
+	if !errors.Is(err, ErrQuorumMismatch) {
+		t.Fatalf("expected a mismatch, got %v", err)
+	}
+}
+
+func TestQuorumClientBehind(t *testing.T) {
+	// One client doesn't have the block yet; the other two can still be compared
+	manager := newTestQuorumManager(t,
+		&fakeExecutionClient{head: 100, callResult: []byte{1}},
+		&fakeExecutionClient{head: 99, callResult: []byte{2}},
+		&fakeExecutionClient{head: 100, callResult: []byte{1}},
+	)
+	if _, err := manager.CallContract(context.Background(), ethereum.CallMsg{}, big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.HeaderByNumber(context.Background(), big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Not enough of them have it to be compared
+	manager = newTestQuorumManager(t,
+		&fakeExecutionClient{head: 100, callResult: []byte{1}},
+		&fakeExecutionClient{head: 99, callResult: []byte{1}},
+	)
+	for _, method := range []string{"CallContract", "HeaderByNumber"} {
+		var err error
+		if method == "CallContract" {
+			_, err = manager.CallContract(context.Background(), ethereum.CallMsg{}, big.NewInt(100))
+		} else {
+			_, err = manager.HeaderByNumber(context.Background(), big.NewInt(100))
+		}
+		if err == nil || errors.Is(err, ErrQuorumMismatch) {
+			t.Fatalf("%s: expected an unavailable error, got %v", method, err)
+		}
+		if stats := getQuorumStats(manager, method); stats.Unavailable != 1 || stats.Mismatches != 0 {
+			t.Fatalf("%s: a client that's behind should count as unavailable: %+v", method, stats)
+		}
+	}
+
+	// None of them have it
+	_, err := manager.HeaderByNumber(context.Background(), big.NewInt(200))
+	if !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestQuorumMismatchNearHead(t *testing.T) {
+	manager := newTestQuorumManager(t,
+		&fakeExecutionClient{head: 100, callResult: []byte{1}},
+		&fakeExecutionClient{head: 100, callResult: []byte{2}},
+	)
+	_, err := manager.CallContract(context.Background(), ethereum.CallMsg{}, big.NewInt(100-int64(maxEcHeadLag)))
+	if err == nil || errors.Is(err, ErrQuorumMismatch) || !strings.Contains(err.Error(), "head of the chain") {
+		t.Fatalf("expected an unavailable error near the head, got %v", err)
+	}
+	if stats := getQuorumStats(manager, "CallContract"); stats.Unavailable != 1 || stats.Mismatches != 0 {
+		t.Fatalf("a disagreement near the head should count as unavailable: %+v", stats)
+	}
+}
+
+func TestQuorumOnlyForSpecificBlocks(t *testing.T) {
+	manager := newTestQuorumManager(t,
+		&fakeExecutionClient{head: 100, callResult: []byte{1}},
+		&fakeExecutionClient{head: 100, callResult: []byte{2}},
+	)
+	result, err := manager.CallContract(context.Background(), ethereum.CallMsg{To: &common.Address{}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result[0] != 1 {
+		t.Fatalf("reads of the latest block should go to the primary client, got %x", result)
+	}
+	if len(manager.GetQuorumStats()) != 0 {
+		t.Fatal("reads of the latest block should not be cross-checked")
+	}
+}
@@ -0,0 +1,337 @@
+package keymanager
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	KeystoresPath    string        = "/eth/v1/keystores"
+	RemoteKeysPath   string        = "/eth/v1/remotekeys"
+	FeeRecipientPath string        = "/eth/v1/validator/%s/feerecipient"
+	RequestTimeout   time.Duration = 30 * time.Second
+	importedStatus   string        = "imported"
+	duplicateStatus  string        = "duplicate"
+)
+
+// Client for the standard Ethereum keymanager API, which lets validator keys and fee recipients
+// be managed on a running Validator Client (or a remote signer) without restarting it
+type Client struct {
+	url       string
+	tokenPath string
+	client    http.Client
+	encryptor *eth2ks.Encryptor
+}
+
+// A validator key to import, along with its derivation path
+type ValidatorKey struct {
+	Key            *eth2types.BLSPrivateKey
+	DerivationPath string
+}
+
+// Encrypted validator key store
+type validatorKey struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Version uint                   `json:"version"`
+	UUID    uuid.UUID              `json:"uuid"`
+	Path    string                 `json:"path"`
+	Pubkey  types.ValidatorPubkey  `json:"pubkey"`
+}
+
+// Requests and responses
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+type remoteKey struct {
+	Pubkey string `json:"pubkey"`
+	Url    string `json:"url,omitempty"`
+}
+type importRemoteKeysRequest struct {
+	RemoteKeys []remoteKey `json:"remote_keys"`
+}
+type importResponse struct {
+	Data []struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"data"`
+}
+type listKeystoresResponse struct {
+	Data []struct {
+		ValidatingPubkey string `json:"validating_pubkey"`
+	} `json:"data"`
+}
+type listRemoteKeysResponse struct {
+	Data []remoteKey `json:"data"`
+}
+type feeRecipientMessage struct {
+	EthAddress string `json:"ethaddress"`
+}
+type getFeeRecipientResponse struct {
+	Data feeRecipientMessage `json:"data"`
+}
+
+// Create a new keymanager API client. The bearer token is read from the token file on every request, so the client
+// keeps working if the Validator Client regenerates it; if the token path is blank, requests are sent without one.
+func NewClient(url string, tokenPath string) *Client {
+	return &Client{
+		url:       strings.TrimSuffix(url, "/"),
+		tokenPath: tokenPath,
+		client: http.Client{
+			Timeout: RequestTimeout,
+		},
+		encryptor: eth2ks.New(eth2ks.WithCipher("scrypt")),
+	}
+}
+
+// Encrypt validator keys with new random passwords and import them, and return the ones the Validator Client already had.
+// If the keys have signed anything before, slashingProtection is their EIP-3076 interchange; otherwise it's blank.
+func (c *Client) ImportKeystores(keys []ValidatorKey, slashingProtection string) ([]types.ValidatorPubkey, error) {
+
+	// Encrypt the keys
+	request := importKeystoresRequest{
+		Keystores:          make([]string, len(keys)),
+		Passwords:          make([]string, len(keys)),
+		SlashingProtection: slashingProtection,
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(keys))
+	for i, key := range keys {
+		keystoreString, password, err := c.encryptKey(key)
+		if err != nil {
+			return nil, err
+		}
+		request.Keystores[i] = keystoreString
+		request.Passwords[i] = password
+		pubkeys[i] = types.BytesToValidatorPubkey(key.Key.PublicKey().Marshal())
+	}
+
+	// Import them
+	var response importResponse
+	if err := c.post(KeystoresPath, request, &response); err != nil {
+		return nil, fmt.Errorf("Could not import validator keys: %w", err)
+	}
+	return checkImportResponse(response, pubkeys)
+
+}
+
+// Import validator keys that are held by a remote signer, and return the ones the Validator Client already had
+func (c *Client) ImportRemoteKeys(pubkeys []types.ValidatorPubkey, signerUrl string) ([]types.ValidatorPubkey, error) {
+	request := importRemoteKeysRequest{
+		RemoteKeys: make([]remoteKey, len(pubkeys)),
+	}
+	for i, pubkey := range pubkeys {
+		request.RemoteKeys[i] = remoteKey{
+			Pubkey: hexutil.AddPrefix(pubkey.Hex()),
+			Url:    signerUrl,
+		}
+	}
+	var response importResponse
+	if err := c.post(RemoteKeysPath, request, &response); err != nil {
+		return nil, fmt.Errorf("Could not import remote validator keys: %w", err)
+	}
+	return checkImportResponse(response, pubkeys)
+}
+
+// Get the public keys of the validator keys stored in the Validator Client
+func (c *Client) ListKeystores() ([]types.ValidatorPubkey, error) {
+	var response listKeystoresResponse
+	if err := c.get(KeystoresPath, &response); err != nil {
+		return nil, fmt.Errorf("Could not list validator keys: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, 0, len(response.Data))
+	for _, keystore := range response.Data {
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(keystore.ValidatingPubkey))
+		if err != nil {
+			return nil, fmt.Errorf("Keymanager API returned an invalid public key '%s': %w", keystore.ValidatingPubkey, err)
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+// Get the public keys of the remote validator keys the Validator Client uses
+func (c *Client) ListRemoteKeys() ([]types.ValidatorPubkey, error) {
+	var response listRemoteKeysResponse
+	if err := c.get(RemoteKeysPath, &response); err != nil {
+		return nil, fmt.Errorf("Could not list remote validator keys: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, 0, len(response.Data))
+	for _, key := range response.Data {
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(key.Pubkey))
+		if err != nil {
+			return nil, fmt.Errorf("Keymanager API returned an invalid public key '%s': %w", key.Pubkey, err)
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+// Get the fee recipient the Validator Client uses for a validator key
+func (c *Client) GetFeeRecipient(pubkey types.ValidatorPubkey) (common.Address, error) {
+	var response getFeeRecipientResponse
+	if err := c.get(fmt.Sprintf(FeeRecipientPath, hexutil.AddPrefix(pubkey.Hex())), &response); err != nil {
+		return common.Address{}, fmt.Errorf("Could not get fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	if !common.IsHexAddress(response.Data.EthAddress) {
+		return common.Address{}, fmt.Errorf("Keymanager API returned an invalid fee recipient '%s' for validator %s", response.Data.EthAddress, pubkey.Hex())
+	}
+	return common.HexToAddress(response.Data.EthAddress), nil
+}
+
+// Set the fee recipient the Validator Client uses for a validator key
+func (c *Client) SetFeeRecipient(pubkey types.ValidatorPubkey, feeRecipient common.Address) error {
+	_, err := c.request(http.MethodPost, fmt.Sprintf(FeeRecipientPath, hexutil.AddPrefix(pubkey.Hex())), feeRecipientMessage{
+		EthAddress: feeRecipient.Hex(),
+	})
+	if err != nil {
+		return fmt.Errorf("Could not set fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Encrypt a validator key into an EIP-2335 keystore with a new random password
+func (c *Client) encryptKey(key ValidatorKey) (string, string, error) {
+
+	// Get validator pubkey
+	pubkey := types.BytesToValidatorPubkey(key.Key.PublicKey().Marshal())
+
+	// Create a new password
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return "", "", fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Encrypt key
+	encryptedKey, err := c.encryptor.Encrypt(key.Key.Marshal(), password)
+	if err != nil {
+		return "", "", fmt.Errorf("Could not encrypt validator key %s: %w", pubkey.Hex(), err)
+	}
+
+	// Encode key store
+	keyStoreBytes, err := json.Marshal(validatorKey{
+		Crypto:  encryptedKey,
+		Version: c.encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    key.DerivationPath,
+		Pubkey:  pubkey,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("Could not encode validator key %s: %w", pubkey.Hex(), err)
+	}
+	return string(keyStoreBytes), password, nil
+
+}
+
+// Make a GET request to the keymanager API and decode the response
+func (c *Client) get(path string, response interface{}) error {
+	responseBody, err := c.request(http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf("could not decode response: %w", err)
+	}
+	return nil
+}
+
+// Make a POST request to the keymanager API and decode the response
+func (c *Client) post(path string, request interface{}, response interface{}) error {
+	responseBody, err := c.request(http.MethodPost, path, request)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf("could not decode response: %w", err)
+	}
+	return nil
+}
+
+// Make a request to the keymanager API
+func (c *Client) request(method string, path string, requestBody interface{}) ([]byte, error) {
+
+	// Encode the body
+	var body io.Reader
+	if requestBody != nil {
+		requestBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode request: %w", err)
+		}
+		body = bytes.NewReader(requestBytes)
+	}
+
+	// Build the request
+	request, err := http.NewRequest(method, c.url+path, body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+	if requestBody != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if c.tokenPath != "" {
+		token, err := os.ReadFile(c.tokenPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read keymanager API token: %w", err)
+		}
+		request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	// Send it
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	// Read the response
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response: %w", err)
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("HTTP status %d; response body: '%s'", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return responseBody, nil
+
+}
+
+// Make sure every key in an import request was imported, and return the ones that were duplicates.
+// The results are in the same order as the keys in the request.
+func checkImportResponse(response importResponse, pubkeys []types.ValidatorPubkey) ([]types.ValidatorPubkey, error) {
+	if len(response.Data) != len(pubkeys) {
+		return nil, fmt.Errorf("Expected %d import results but got %d", len(pubkeys), len(response.Data))
+	}
+	duplicates := []types.ValidatorPubkey{}
+	failures := []string{}
+	for i, result := range response.Data {
+		switch strings.ToLower(result.Status) {
+		case importedStatus:
+		case duplicateStatus:
+			duplicates = append(duplicates, pubkeys[i])
+		default:
+			failures = append(failures, fmt.Sprintf("%s (%s)", result.Status, result.Message))
+		}
+	}
+	if len(failures) > 0 {
+		return nil, errors.New("Some keys couldn't be imported: " + strings.Join(failures, ", "))
+	}
+	return duplicates, nil
+}
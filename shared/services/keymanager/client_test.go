@@ -0,0 +1,225 @@
+package keymanager
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+const testToken = "api-token"
+
+// A request received by the fake keymanager API
+type receivedRequest struct {
+	method string
+	path   string
+	auth   string
+	body   []byte
+}
+
+// Start a fake keymanager API that records each request and responds with the provided status and body
+func newTestServer(t *testing.T, status int, responseBody string) (*Client, *[]receivedRequest) {
+	requests := []receivedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, receivedRequest{
+			method: r.Method,
+			path:   r.URL.Path,
+			auth:   r.Header.Get("Authorization"),
+			body:   body,
+		})
+		w.WriteHeader(status)
+		w.Write([]byte(responseBody))
+	}))
+	t.Cleanup(server.Close)
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenPath, []byte(testToken+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(server.URL+"/", tokenPath)
+
+	// Keep the encryption fast for the tests
+	client.encryptor = eth2ks.New(eth2ks.WithCipher("pbkdf2"))
+	return client, &requests
+}
+
+func newTestKey(t *testing.T) *eth2types.BLSPrivateKey {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestImportKeystores(t *testing.T) {
+	client, requests := newTestServer(t, http.StatusOK, `{"data":[{"status":"imported","message":""},{"status":"duplicate","message":""}]}`)
+	keys := []ValidatorKey{
+		{Key: newTestKey(t), DerivationPath: "m/12381/3600/0/0/0"},
+		{Key: newTestKey(t), DerivationPath: "m/12381/3600/1/0/0"},
+	}
+	slashingProtection := `{"metadata":{},"data":[]}`
+	duplicates, err := client.ImportKeystores(keys, slashingProtection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(duplicates) != 1 || duplicates[0] != types.BytesToValidatorPubkey(keys[1].Key.PublicKey().Marshal()) {
+		t.Fatalf("expected the second key to be reported as a duplicate, got %v", duplicates)
+	}
+
+	if len(*requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(*requests))
+	}
+	request := (*requests)[0]
+	if request.method != http.MethodPost || request.path != KeystoresPath || request.auth != "Bearer "+testToken {
+		t.Fatalf("unexpected request %s %s (%s)", request.method, request.path, request.auth)
+	}
+	var body importKeystoresRequest
+	if err := json.Unmarshal(request.body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.SlashingProtection != slashingProtection {
+		t.Fatalf("the slashing protection wasn't passed through: [%s]", body.SlashingProtection)
+	}
+	if len(body.Keystores) != 2 || len(body.Passwords) != 2 {
+		t.Fatalf("expected 2 keystores and passwords, got %d and %d", len(body.Keystores), len(body.Passwords))
+	}
+
+	// Each keystore can be decrypted with its password and has the key's pubkey and derivation path
+	for i, keystoreString := range body.Keystores {
+		var keystore validatorKey
+		if err := json.Unmarshal([]byte(keystoreString), &keystore); err != nil {
+			t.Fatal(err)
+		}
+		if keystore.Path != keys[i].DerivationPath {
+			t.Fatalf("expected derivation path %s, got %s", keys[i].DerivationPath, keystore.Path)
+		}
+		if !bytes.Equal(keystore.Pubkey.Bytes(), keys[i].Key.PublicKey().Marshal()) {
+			t.Fatalf("keystore %d has the wrong pubkey", i)
+		}
+		decrypted, err := eth2ks.New().Decrypt(keystore.Crypto, body.Passwords[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decrypted, keys[i].Key.Marshal()) {
+			t.Fatalf("keystore %d doesn't decrypt to its key", i)
+		}
+	}
+}
+
+func TestImportKeystoresWithoutSlashingProtection(t *testing.T) {
+	client, requests := newTestServer(t, http.StatusOK, `{"data":[{"status":"imported","message":""}]}`)
+	if _, err := client.ImportKeystores([]ValidatorKey{{Key: newTestKey(t)}}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string((*requests)[0].body), "slashing_protection") {
+		t.Fatal("a blank slashing protection should be left out of the request")
+	}
+}
+
+func TestImportFailures(t *testing.T) {
+	client, _ := newTestServer(t, http.StatusOK, `{"data":[{"status":"error","message":"bad keystore"}]}`)
+	_, err := client.ImportKeystores([]ValidatorKey{{Key: newTestKey(t)}}, "")
+	if err == nil || !strings.Contains(err.Error(), "bad keystore") {
+		t.Fatalf("expected an import error, got %v", err)
+	}
+
+	client, _ = newTestServer(t, http.StatusOK, `{"data":[]}`)
+	if _, err := client.ImportKeystores([]ValidatorKey{{Key: newTestKey(t)}}, ""); err == nil {
+		t.Fatal("a response without a result for every key should be rejected")
+	}
+
+	client, _ = newTestServer(t, http.StatusUnauthorized, `{"message":"invalid token"}`)
+	_, err = client.ImportKeystores([]ValidatorKey{{Key: newTestKey(t)}}, "")
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected an HTTP status error, got %v", err)
+	}
+}
+
+func TestImportRemoteKeys(t *testing.T) {
+	client, requests := newTestServer(t, http.StatusOK, `{"data":[{"status":"imported","message":""}]}`)
+	pubkey := types.BytesToValidatorPubkey(newTestKey(t).PublicKey().Marshal())
+	if _, err := client.ImportRemoteKeys([]types.ValidatorPubkey{pubkey}, "http://web3signer:9000"); err != nil {
+		t.Fatal(err)
+	}
+	request := (*requests)[0]
+	expected := `{"remote_keys":[{"pubkey":"` + hexutil.AddPrefix(pubkey.Hex()) + `","url":"http://web3signer:9000"}]}`
+	if request.path != RemoteKeysPath || string(request.body) != expected {
+		t.Fatalf("unexpected request to %s: %s", request.path, request.body)
+	}
+}
+
+func TestListKeystores(t *testing.T) {
+	pubkey := types.BytesToValidatorPubkey(newTestKey(t).PublicKey().Marshal())
+	client, requests := newTestServer(t, http.StatusOK, `{"data":[{"validating_pubkey":"`+hexutil.AddPrefix(pubkey.Hex())+`"}]}`)
+	pubkeys, err := client.ListKeystores()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubkeys) != 1 || pubkeys[0] != pubkey {
+		t.Fatalf("unexpected pubkeys %v", pubkeys)
+	}
+	if request := (*requests)[0]; request.method != http.MethodGet || request.path != KeystoresPath {
+		t.Fatalf("unexpected request %s %s", request.method, request.path)
+	}
+
+	client, _ = newTestServer(t, http.StatusOK, `{"data":[{"validating_pubkey":"0x1234"}]}`)
+	if _, err := client.ListKeystores(); err == nil {
+		t.Fatal("an invalid pubkey should be rejected")
+	}
+}
+
+func TestFeeRecipient(t *testing.T) {
+	pubkey := types.BytesToValidatorPubkey(newTestKey(t).PublicKey().Marshal())
+	feeRecipient := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	path := "/eth/v1/validator/" + hexutil.AddPrefix(pubkey.Hex()) + "/feerecipient"
+
+	client, requests := newTestServer(t, http.StatusOK, `{"data":{"ethaddress":"`+feeRecipient.Hex()+`"}}`)
+	current, err := client.GetFeeRecipient(pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current != feeRecipient || (*requests)[0].path != path {
+		t.Fatalf("unexpected fee recipient %s from %s", current.Hex(), (*requests)[0].path)
+	}
+
+	client, requests = newTestServer(t, http.StatusAccepted, "")
+	if err := client.SetFeeRecipient(pubkey, feeRecipient); err != nil {
+		t.Fatal(err)
+	}
+	request := (*requests)[0]
+	if request.method != http.MethodPost || request.path != path || string(request.body) != `{"ethaddress":"`+feeRecipient.Hex()+`"}` {
+		t.Fatalf("unexpected request %s %s: %s", request.method, request.path, request.body)
+	}
+}
+
+func TestNoToken(t *testing.T) {
+	client, requests := newTestServer(t, http.StatusOK, `{"data":[]}`)
+	client.tokenPath = ""
+	if _, err := client.ListKeystores(); err != nil {
+		t.Fatal(err)
+	}
+	if (*requests)[0].auth != "" {
+		t.Fatalf("no token should be sent without a token file, got %s", (*requests)[0].auth)
+	}
+
+	client.tokenPath = filepath.Join(t.TempDir(), "missing")
+	if _, err := client.ListKeystores(); err == nil {
+		t.Fatal("a missing token file should be an error")
+	}
+}
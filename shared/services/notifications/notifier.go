@@ -0,0 +1,318 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	notificationQueueSize int           = 64
+	sendTimeout           time.Duration = 15 * time.Second
+)
+
+// The kinds of events the node daemon sends notifications for
+type EventType string
+
+const (
+	EventType_MinipoolStaked         EventType = "minipool-staked"
+	EventType_MinipoolPromoted       EventType = "minipool-promoted"
+	EventType_MinipoolDistributed    EventType = "minipool-distributed"
+	EventType_BondReduced            EventType = "bond-reduced"
+	EventType_BondReductionCancelled EventType = "bond-reduction-cancelled"
+	EventType_FeeRecipientError      EventType = "fee-recipient-error"
+	EventType_TaskFailing            EventType = "task-failing"
+	EventType_TaskRecovered          EventType = "task-recovered"
+	EventType_ClientUnavailable      EventType = "client-unavailable"
+	EventType_ClientRecovered        EventType = "client-recovered"
+)
+
+// Every event type, used to validate the configured filter
+var allEventTypes = []EventType{
+	EventType_MinipoolStaked,
+	EventType_MinipoolPromoted,
+	EventType_MinipoolDistributed,
+	EventType_BondReduced,
+	EventType_BondReductionCancelled,
+	EventType_FeeRecipientError,
+	EventType_TaskFailing,
+	EventType_TaskRecovered,
+	EventType_ClientUnavailable,
+	EventType_ClientRecovered,
+}
+
+// Something that happened in the node daemon that the operator should know about
+type Event struct {
+	Type     EventType       `json:"type"`
+	Time     time.Time       `json:"time"`
+	Node     *common.Address `json:"node,omitempty"`
+	Minipool *common.Address `json:"minipool,omitempty"`
+	TxHash   *common.Hash    `json:"txHash,omitempty"`
+	Title    string          `json:"title"`
+	Message  string          `json:"message"`
+}
+
+// A destination for notifications
+type Sink interface {
+	// The name of the sink, for logging
+	GetName() string
+
+	// Deliver a notification
+	Send(ctx context.Context, event Event) error
+}
+
+// Sends node daemon events to the configured sinks in the background, filtered by the configured event types
+type Notifier struct {
+	sinks   []Sink
+	filter  map[EventType]bool
+	log     log.ColorLogger
+	queue   chan Event
+	lock    sync.Mutex
+	clients map[string]bool
+
+	// The events that have already been sent once, saved so they aren't repeated after a restart
+	statePath string
+	sent      map[string]bool
+}
+
+// Create a new notifier from the Smartnode config; if no sinks are configured, notifications are discarded
+func NewNotifier(cfg *config.RocketPoolConfig, logger log.ColorLogger) (*Notifier, error) {
+	sinks, err := getSinks(cfg)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := getFilter(cfg.Smartnode.NotificationEvents.Value.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Notifier{
+		sinks:     sinks,
+		filter:    filter,
+		log:       logger,
+		queue:     make(chan Event, notificationQueueSize),
+		clients:   map[string]bool{},
+		statePath: os.ExpandEnv(cfg.Smartnode.GetNotificationStatePath(true)),
+	}
+	if len(sinks) > 0 {
+		go n.run()
+	}
+	return n, nil
+}
+
+// Check if any sinks are configured
+func (n *Notifier) IsEnabled() bool {
+	return len(n.sinks) > 0
+}
+
+// Queue an event to be sent to every sink if its type is enabled.
+// This never blocks; if the sinks are too slow to keep up, the event is dropped.
+func (n *Notifier) Notify(event Event) {
+	if !n.IsEnabled() || (n.filter != nil && !n.filter[event.Type]) {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	select {
+	case n.queue <- event:
+	default:
+		n.log.Warnf("The notification queue is full, dropping the %s notification.", event.Type)
+	}
+}
+
+// Queue an event unless one with the same type and key has already been queued, even by an earlier run of the daemon.
+// Returns true if this is the first time the event has been seen.
+func (n *Notifier) NotifyOnce(key string, event Event) bool {
+	id := fmt.Sprintf("%s/%s", event.Type, key)
+
+	n.lock.Lock()
+	if n.sent == nil {
+		sent, err := loadSentEvents(n.statePath)
+		if err != nil {
+			n.log.Warnf("Couldn't load the notification state, so some notifications may be repeated: %s", err.Error())
+			sent = map[string]bool{}
+		}
+		n.sent = sent
+	}
+	if n.sent[id] {
+		n.lock.Unlock()
+		return false
+	}
+	n.sent[id] = true
+	err := saveSentEvents(n.statePath, n.sent)
+	n.lock.Unlock()
+
+	if err != nil {
+		n.log.Warnf("Couldn't save the notification state, so the %s notification may be repeated: %s", event.Type, err.Error())
+	}
+	n.Notify(event)
+	return true
+}
+
+// Send notifications when a client becomes unavailable or recovers, based on the status reported by a client manager's CheckStatus
+func (n *Notifier) CheckClientStatus(clientType string, status *api.ClientManagerStatus) {
+	if status == nil {
+		return
+	}
+	n.checkClient(fmt.Sprintf("primary %s client", clientType), status.PrimaryClientStatus)
+	if !status.FallbackEnabled {
+		return
+	}
+	if len(status.FallbackClientStatuses) <= 1 {
+		n.checkClient(fmt.Sprintf("fallback %s client", clientType), status.FallbackClientStatus)
+		return
+	}
+	for i, fallbackStatus := range status.FallbackClientStatuses {
+		n.checkClient(fmt.Sprintf("fallback %s client #%d", clientType, i+1), fallbackStatus)
+	}
+}
+
+// Send a notification if a client's availability has changed; clients that are healthy when they're first seen don't trigger one
+func (n *Notifier) checkClient(name string, status api.ClientStatus) {
+	healthy := status.IsWorking && status.IsSynced
+
+	n.lock.Lock()
+	wasHealthy, seen := n.clients[name]
+	n.clients[name] = healthy
+	n.lock.Unlock()
+
+	if healthy == wasHealthy || (!seen && healthy) {
+		return
+	}
+	if healthy {
+		n.Notify(Event{
+			Type:    EventType_ClientRecovered,
+			Title:   fmt.Sprintf("Your %s has recovered", name),
+			Message: fmt.Sprintf("Your %s is working and synced again.", name),
+		})
+		return
+	}
+
+	reason := "it is still syncing"
+	if status.Error != "" {
+		reason = status.Error
+	}
+	n.Notify(Event{
+		Type:    EventType_ClientUnavailable,
+		Title:   fmt.Sprintf("Your %s is unavailable", name),
+		Message: fmt.Sprintf("Your %s can't be used (%s).", name, reason),
+	})
+}
+
+// Deliver queued events to every sink
+func (n *Notifier) run() {
+	for event := range n.queue {
+		for _, sink := range n.sinks {
+			ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+			err := sink.Send(ctx, event)
+			cancel()
+			if err != nil {
+				n.log.Warnf("Couldn't send the %s notification to %s: %s", event.Type, sink.GetName(), err.Error())
+			}
+		}
+	}
+}
+
+// Load the events that have already been sent once
+func loadSentEvents(path string) (map[string]bool, error) {
+	sent := map[string]bool{}
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return sent, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading notification state [%s]: %w", path, err)
+	}
+	ids := []string{}
+	err = json.Unmarshal(bytes, &ids)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing notification state [%s]: %w", path, err)
+	}
+	for _, id := range ids {
+		sent[id] = true
+	}
+	return sent, nil
+}
+
+// Save the events that have already been sent once
+func saveSentEvents(path string, sent map[string]bool) error {
+	ids := make([]string, 0, len(sent))
+	for id := range sent {
+		ids = append(ids, id)
+	}
+	bytes, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("error serializing notification state: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating notification state folder: %w", err)
+	}
+
+	// Write to a temporary file first so a partial file is never loaded
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing notification state [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error writing notification state [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Get the event types to send notifications for; nil means all of them
+func getFilter(setting string) (map[EventType]bool, error) {
+	var filter map[EventType]bool
+	for _, element := range strings.Split(setting, ",") {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+		eventType := EventType(element)
+		found := false
+		for _, knownType := range allEventTypes {
+			if eventType == knownType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown notification event type '%s'", element)
+		}
+		if filter == nil {
+			filter = map[EventType]bool{}
+		}
+		filter[eventType] = true
+	}
+	return filter, nil
+}
+
+// Get the plain-text body of a notification
+func formatText(event Event) string {
+	var builder strings.Builder
+	builder.WriteString(event.Message)
+	if event.Node != nil {
+		builder.WriteString(fmt.Sprintf("\nNode: %s", event.Node.Hex()))
+	}
+	if event.Minipool != nil {
+		builder.WriteString(fmt.Sprintf("\nMinipool: %s", event.Minipool.Hex()))
+	}
+	if event.TxHash != nil {
+		builder.WriteString(fmt.Sprintf("\nTransaction: %s", event.TxHash.Hex()))
+	}
+	return builder.String()
+}
@@ -0,0 +1,94 @@
+package notifications
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Create a notifier that sends everything to a webhook and keeps its state in a temporary folder
+func newTestNotifier(t *testing.T, dataPath string, webhookUrl string) *Notifier {
+	cfg := config.NewRocketPoolConfig(t.TempDir(), true)
+	cfg.Smartnode.DataPath.Value = dataPath
+	cfg.Smartnode.NotificationWebhookUrl.Value = webhookUrl
+	notifier, err := NewNotifier(cfg, log.NewColorLogger(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return notifier
+}
+
+// Wait for a payload to arrive, or for it to not arrive
+func receivePayload(bodies <-chan map[string]interface{}) bool {
+	select {
+	case <-bodies:
+		return true
+	case <-time.After(500 * time.Millisecond):
+		return false
+	}
+}
+
+func TestNotifyOnce(t *testing.T) {
+	bodies := make(chan map[string]interface{}, 4)
+	server := newRecordingServer(t, http.StatusOK, bodies)
+	dataPath := t.TempDir()
+	event := newTestEvent()
+
+	notifier := newTestNotifier(t, dataPath, server.URL)
+	if !notifier.NotifyOnce("minipool", event) || !receivePayload(bodies) {
+		t.Fatal("the first event should be sent")
+	}
+	if notifier.NotifyOnce("minipool", event) || receivePayload(bodies) {
+		t.Fatal("a repeated event should not be sent")
+	}
+
+	// It's remembered after a restart
+	notifier = newTestNotifier(t, dataPath, server.URL)
+	if notifier.NotifyOnce("minipool", event) || receivePayload(bodies) {
+		t.Fatal("a repeated event should not be sent after a restart")
+	}
+
+	// Other keys and event types are still sent
+	if !notifier.NotifyOnce("other minipool", event) || !receivePayload(bodies) {
+		t.Fatal("an event with a different key should be sent")
+	}
+	event.Type = EventType_MinipoolPromoted
+	if !notifier.NotifyOnce("minipool", event) || !receivePayload(bodies) {
+		t.Fatal("an event with a different type should be sent")
+	}
+}
+
+func TestNotifyFilter(t *testing.T) {
+	bodies := make(chan map[string]interface{}, 2)
+	server := newRecordingServer(t, http.StatusOK, bodies)
+	notifier := newTestNotifier(t, t.TempDir(), server.URL)
+	notifier.filter = map[EventType]bool{EventType_BondReduced: true}
+
+	notifier.Notify(newTestEvent())
+	if receivePayload(bodies) {
+		t.Fatal("an event that isn't in the filter should not be sent")
+	}
+	event := newTestEvent()
+	event.Type = EventType_BondReduced
+	notifier.Notify(event)
+	if !receivePayload(bodies) {
+		t.Fatal("an event in the filter should be sent")
+	}
+}
+
+func TestGetFilter(t *testing.T) {
+	filter, err := getFilter("")
+	if err != nil || filter != nil {
+		t.Fatalf("an empty filter should allow everything (%v): %v", filter, err)
+	}
+	filter, err = getFilter(" bond-reduced, minipool-staked ,")
+	if err != nil || len(filter) != 2 || !filter[EventType_BondReduced] || !filter[EventType_MinipoolStaked] {
+		t.Fatalf("unexpected filter %v: %v", filter, err)
+	}
+	if _, err := getFilter("bond-reduced,unknown"); err == nil {
+		t.Fatal("unknown event types should be rejected")
+	}
+}
@@ -0,0 +1,198 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Get the sinks that have been configured
+func getSinks(cfg *config.RocketPoolConfig) ([]Sink, error) {
+	sinks := []Sink{}
+	smartnode := cfg.Smartnode
+
+	if url := strings.TrimSpace(smartnode.NotificationWebhookUrl.Value.(string)); url != "" {
+		sinks = append(sinks, &webhookSink{url: url})
+	}
+	if url := strings.TrimSpace(smartnode.NotificationDiscordUrl.Value.(string)); url != "" {
+		sinks = append(sinks, &discordSink{url: url})
+	}
+	if url := strings.TrimSpace(smartnode.NotificationSlackUrl.Value.(string)); url != "" {
+		sinks = append(sinks, &slackSink{url: url})
+	}
+	if url := strings.TrimSpace(smartnode.NotificationTelegramUrl.Value.(string)); url != "" {
+		chatID := strings.TrimSpace(smartnode.NotificationTelegramChatID.Value.(string))
+		if chatID == "" {
+			return nil, fmt.Errorf("a Telegram bot URL is set for notifications, but the chat ID is missing")
+		}
+		sinks = append(sinks, &telegramSink{url: url, chatID: chatID})
+	}
+	if server := strings.TrimSpace(smartnode.NotificationSmtpServer.Value.(string)); server != "" {
+		from := strings.TrimSpace(smartnode.NotificationSmtpFrom.Value.(string))
+		to := []string{}
+		for _, address := range strings.Split(smartnode.NotificationSmtpTo.Value.(string), ",") {
+			address = strings.TrimSpace(address)
+			if address != "" {
+				to = append(to, address)
+			}
+		}
+		if from == "" || len(to) == 0 {
+			return nil, fmt.Errorf("an SMTP relay is set for notifications, but the sender or recipients are missing")
+		}
+		sinks = append(sinks, &smtpSink{server: server, from: from, to: to})
+	}
+
+	return sinks, nil
+}
+
+// Sends events as generic JSON to a webhook
+type webhookSink struct {
+	url string
+}
+
+func (s *webhookSink) GetName() string {
+	return "the webhook"
+}
+
+func (s *webhookSink) Send(ctx context.Context, event Event) error {
+	return postJson(ctx, s.url, event)
+}
+
+// Sends events to a Discord channel webhook
+type discordSink struct {
+	url string
+}
+
+func (s *discordSink) GetName() string {
+	return "Discord"
+}
+
+func (s *discordSink) Send(ctx context.Context, event Event) error {
+	return postJson(ctx, s.url, map[string]interface{}{
+		"embeds": []map[string]interface{}{{
+			"title":       event.Title,
+			"description": formatText(event),
+			"timestamp":   event.Time.UTC().Format(time.RFC3339),
+		}},
+	})
+}
+
+// Sends events to a Slack incoming webhook
+type slackSink struct {
+	url string
+}
+
+func (s *slackSink) GetName() string {
+	return "Slack"
+}
+
+func (s *slackSink) Send(ctx context.Context, event Event) error {
+	return postJson(ctx, s.url, map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", event.Title, formatText(event)),
+	})
+}
+
+// Sends events to a Telegram chat with the Bot API
+type telegramSink struct {
+	url    string
+	chatID string
+}
+
+func (s *telegramSink) GetName() string {
+	return "Telegram"
+}
+
+func (s *telegramSink) Send(ctx context.Context, event Event) error {
+	return postJson(ctx, s.url, map[string]string{
+		"chat_id": s.chatID,
+		"text":    fmt.Sprintf("%s\n\n%s", event.Title, formatText(event)),
+	})
+}
+
+// Sends events by email through an SMTP relay that doesn't require authentication
+type smtpSink struct {
+	server string
+	from   string
+	to     []string
+}
+
+func (s *smtpSink) GetName() string {
+	return "the SMTP relay"
+}
+
+func (s *smtpSink) Send(ctx context.Context, event Event) error {
+	var message bytes.Buffer
+	message.WriteString(fmt.Sprintf("From: %s\r\n", s.from))
+	message.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(s.to, ", ")))
+	message.WriteString(fmt.Sprintf("Subject: [Rocket Pool] %s\r\n", event.Title))
+	message.WriteString(fmt.Sprintf("Date: %s\r\n", event.Time.Format(time.RFC1123Z)))
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	message.WriteString(strings.ReplaceAll(formatText(event), "\n", "\r\n"))
+	message.WriteString("\r\n")
+
+	// net/smtp doesn't support contexts, so run it in the background and give up on it if it takes too long
+	result := make(chan error, 1)
+	go func() {
+		result <- smtp.SendMail(s.server, nil, s.from, s.to, message.Bytes())
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// POST a JSON payload and check that it was accepted.
+// Webhook URLs usually have their secret token in them, so errors only mention the scheme and host.
+func postJson(ctx context.Context, targetUrl string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error serializing notification: %w", err)
+	}
+	redactedUrl := redactUrl(targetUrl)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, targetUrl, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating request to %s: %w", redactedUrl, unwrapUrlError(err))
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("error sending request to %s: %w", redactedUrl, unwrapUrlError(err))
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("request to %s failed with status %d: %s", redactedUrl, response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return nil
+}
+
+// Get the scheme and host of a URL, leaving out its path, query, and credentials
+func redactUrl(rawUrl string) string {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil || parsedUrl.Host == "" {
+		return "the configured URL"
+	}
+	return fmt.Sprintf("%s://%s", parsedUrl.Scheme, parsedUrl.Host)
+}
+
+// Get the underlying error of a URL error, since its message includes the full URL
+func unwrapUrlError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
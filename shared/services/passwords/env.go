@@ -0,0 +1,91 @@
+package passwords
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Prefix of a password source that refers to a file descriptor instead of an environment variable
+const fileDescriptorPrefix string = "fd:"
+
+// Password manager that reads the password from an environment variable or file descriptor passed to the process at start-up.
+// The password is read once and cached, since a file descriptor can only be read once; the environment variable is cleared
+// afterwards so it isn't inherited by child processes.
+type EnvPasswordManager struct {
+	source   string
+	password string
+	err      error
+	once     sync.Once
+}
+
+// Create new environment password manager; the source is either the name of an environment variable or fd:N for file descriptor N
+func NewEnvPasswordManager(source string) *EnvPasswordManager {
+	return &EnvPasswordManager{
+		source: source,
+	}
+}
+
+// Check if the password has been set
+func (pm *EnvPasswordManager) IsPasswordSet() bool {
+	password, err := pm.GetPassword()
+	return (err == nil && password != "")
+}
+
+// Get the password
+func (pm *EnvPasswordManager) GetPassword() (string, error) {
+	pm.once.Do(func() {
+		pm.password, pm.err = pm.readPassword()
+	})
+	if pm.err != nil {
+		return "", pm.err
+	}
+	if pm.password == "" {
+		return "", fmt.Errorf("The node password was not provided in %s", pm.source)
+	}
+	return pm.password, nil
+}
+
+// Set the password
+func (pm *EnvPasswordManager) SetPassword(password string) error {
+	return fmt.Errorf("The node password is read from %s, so it can't be set by the Smartnode; provide it there and restart the Smartnode instead", pm.source)
+}
+
+// Delete the password
+func (pm *EnvPasswordManager) DeletePassword() error {
+	return fmt.Errorf("The node password is read from %s, so it can't be deleted by the Smartnode; remove it there instead", pm.source)
+}
+
+// Read the password from the source
+func (pm *EnvPasswordManager) readPassword() (string, error) {
+
+	// Read from a file descriptor
+	if strings.HasPrefix(pm.source, fileDescriptorPrefix) {
+		fd, err := strconv.ParseUint(strings.TrimPrefix(pm.source, fileDescriptorPrefix), 10, 32)
+		if err != nil {
+			return "", fmt.Errorf("Invalid password file descriptor '%s': %w", pm.source, err)
+		}
+		file := os.NewFile(uintptr(fd), pm.source)
+		if file == nil {
+			return "", fmt.Errorf("Invalid password file descriptor '%s'", pm.source)
+		}
+		defer file.Close()
+		password, err := io.ReadAll(file)
+		if err != nil {
+			return "", fmt.Errorf("Could not read password from %s: %w", pm.source, err)
+		}
+		return strings.TrimRight(string(password), "\r\n"), nil
+	}
+
+	// Read from an environment variable
+	if pm.source == "" {
+		return "", fmt.Errorf("No password environment variable is configured")
+	}
+	password := os.Getenv(pm.source)
+	os.Unsetenv(pm.source)
+	return password, nil
+
+}
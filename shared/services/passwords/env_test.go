@@ -0,0 +1,92 @@
+package passwords
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+)
+
+const testPassword string = "correct horse battery staple"
+
+func TestEnvPasswordFromVariable(t *testing.T) {
+	t.Setenv("RP_TEST_NODE_PASSWORD", testPassword)
+	pm := NewEnvPasswordManager("RP_TEST_NODE_PASSWORD")
+
+	password, err := pm.GetPassword()
+	if err != nil {
+		t.Fatalf("unexpected error reading password: %v", err)
+	}
+	if password != testPassword {
+		t.Fatalf("expected password %q, got %q", testPassword, password)
+	}
+
+	// The variable is cleared so child processes don't inherit it, but the password stays cached
+	if _, exists := os.LookupEnv("RP_TEST_NODE_PASSWORD"); exists {
+		t.Fatalf("password environment variable wasn't cleared")
+	}
+	if !pm.IsPasswordSet() {
+		t.Fatalf("password should still be set after the variable was cleared")
+	}
+}
+
+func TestEnvPasswordFromFileDescriptor(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("error creating pipe: %v", err)
+	}
+	_, err = writer.WriteString(testPassword + "\n")
+	writer.Close()
+	if err != nil {
+		t.Fatalf("error writing password to pipe: %v", err)
+	}
+
+	defer reader.Close()
+
+	// The manager closes the descriptor it reads, so give it a copy
+	fd, err := syscall.Dup(int(reader.Fd()))
+	if err != nil {
+		t.Fatalf("error duplicating pipe descriptor: %v", err)
+	}
+	pm := NewEnvPasswordManager(fmt.Sprintf("fd:%d", fd))
+	password, err := pm.GetPassword()
+	if err != nil {
+		t.Fatalf("unexpected error reading password: %v", err)
+	}
+	if password != testPassword {
+		t.Fatalf("expected password %q, got %q", testPassword, password)
+	}
+
+	// A file descriptor can only be read once, so later calls must be served from the cache
+	password, err = pm.GetPassword()
+	if err != nil || password != testPassword {
+		t.Fatalf("expected the cached password on the second read, got %q (%v)", password, err)
+	}
+}
+
+func TestEnvPasswordMissing(t *testing.T) {
+	pm := NewEnvPasswordManager("RP_TEST_MISSING_NODE_PASSWORD")
+	if pm.IsPasswordSet() {
+		t.Fatalf("password shouldn't be set when the variable is missing")
+	}
+	if _, err := pm.GetPassword(); err == nil {
+		t.Fatalf("expected an error when the variable is missing")
+	}
+
+	if _, err := NewEnvPasswordManager("fd:abc").GetPassword(); err == nil {
+		t.Fatalf("expected an error for an invalid file descriptor")
+	}
+	if _, err := NewEnvPasswordManager("").GetPassword(); err == nil {
+		t.Fatalf("expected an error when no source is configured")
+	}
+}
+
+func TestEnvPasswordIsReadOnly(t *testing.T) {
+	pm := NewEnvPasswordManager("RP_TEST_NODE_PASSWORD")
+	if err := pm.SetPassword(testPassword); err == nil {
+		t.Fatalf("expected setting the password to fail")
+	}
+	if err := pm.DeletePassword(); err == nil {
+		t.Fatalf("expected deleting the password to fail")
+	}
+}
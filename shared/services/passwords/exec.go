@@ -0,0 +1,91 @@
+package passwords
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Actions passed to the password helper command
+const (
+	helperActionGet    string = "get"
+	helperActionSet    string = "set"
+	helperActionDelete string = "delete"
+)
+
+// Password manager that runs a helper command to store and retrieve the password, so it can be kept in an external secret
+// store such as HashiCorp Vault, pass or systemd-creds.
+// The helper is run through the shell with the action (get, set or delete) appended as its last argument. For get it must
+// print the password to stdout, for set it receives the password on stdin, and it must exit with a non-zero code on failure.
+type ExecPasswordManager struct {
+	command string
+}
+
+// Create new helper command password manager
+func NewExecPasswordManager(command string) *ExecPasswordManager {
+	return &ExecPasswordManager{
+		command: command,
+	}
+}
+
+// Check if the password has been set
+func (pm *ExecPasswordManager) IsPasswordSet() bool {
+	password, err := pm.GetPassword()
+	return (err == nil && password != "")
+}
+
+// Get the password
+func (pm *ExecPasswordManager) GetPassword() (string, error) {
+	output, err := pm.run(helperActionGet, nil)
+	if err != nil {
+		return "", err
+	}
+	password := strings.TrimRight(string(output), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("Password helper command did not return a password")
+	}
+	return password, nil
+}
+
+// Set the password
+func (pm *ExecPasswordManager) SetPassword(password string) error {
+
+	// Check password is not set
+	if pm.IsPasswordSet() {
+		return fmt.Errorf("Password is already set")
+	}
+
+	// Check password length
+	if err := checkPasswordLength(password); err != nil {
+		return err
+	}
+
+	// Store it
+	_, err := pm.run(helperActionSet, []byte(password))
+	return err
+
+}
+
+// Delete the password
+func (pm *ExecPasswordManager) DeletePassword() error {
+	_, err := pm.run(helperActionDelete, nil)
+	return err
+}
+
+// Run the helper command with the given action
+func (pm *ExecPasswordManager) run(action string, input []byte) ([]byte, error) {
+	if pm.command == "" {
+		return nil, fmt.Errorf("No password helper command is configured")
+	}
+
+	cmd := exec.Command("sh", "-c", fmt.Sprintf("%s %s", pm.command, action))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("Password helper command failed to %s the password: %w (%s)", action, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
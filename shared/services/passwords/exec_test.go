@@ -0,0 +1,80 @@
+package passwords
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Write a helper script that keeps the password in a file next to it
+func newTestHelper(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	storePath := filepath.Join(dir, "store")
+	helperPath := filepath.Join(dir, "helper.sh")
+	script := fmt.Sprintf(`#!/bin/sh
+case "$1" in
+	get) [ -f %[1]s ] && cat %[1]s || { echo "no password stored" >&2; exit 1; } ;;
+	set) cat > %[1]s ;;
+	delete) rm -f %[1]s ;;
+	*) exit 2 ;;
+esac
+`, storePath)
+	err := os.WriteFile(helperPath, []byte(script), 0700)
+	if err != nil {
+		t.Fatalf("error writing helper script: %v", err)
+	}
+	return helperPath, storePath
+}
+
+func TestExecPasswordRoundTrip(t *testing.T) {
+	helperPath, storePath := newTestHelper(t)
+	pm := NewExecPasswordManager(helperPath)
+
+	if pm.IsPasswordSet() {
+		t.Fatalf("password shouldn't be set before it's stored")
+	}
+	if err := pm.SetPassword(testPassword); err != nil {
+		t.Fatalf("unexpected error setting password: %v", err)
+	}
+	stored, err := os.ReadFile(storePath)
+	if err != nil || string(stored) != testPassword {
+		t.Fatalf("helper didn't receive the password on stdin: %q (%v)", stored, err)
+	}
+
+	password, err := pm.GetPassword()
+	if err != nil {
+		t.Fatalf("unexpected error getting password: %v", err)
+	}
+	if password != testPassword {
+		t.Fatalf("expected password %q, got %q", testPassword, password)
+	}
+	if err := pm.SetPassword(testPassword); err == nil {
+		t.Fatalf("expected an error setting a password that's already set")
+	}
+
+	if err := pm.DeletePassword(); err != nil {
+		t.Fatalf("unexpected error deleting password: %v", err)
+	}
+	if pm.IsPasswordSet() {
+		t.Fatalf("password should be gone after it's deleted")
+	}
+}
+
+func TestExecPasswordErrors(t *testing.T) {
+	helperPath, _ := newTestHelper(t)
+	pm := NewExecPasswordManager(helperPath)
+
+	// The helper's stderr is included so failures can be diagnosed
+	_, err := pm.GetPassword()
+	if err == nil || !strings.Contains(err.Error(), "no password stored") {
+		t.Fatalf("expected the helper's error output in the error, got %v", err)
+	}
+	if err := pm.SetPassword("short"); err == nil {
+		t.Fatalf("expected an error for a password that's too short")
+	}
+	if _, err := NewExecPasswordManager("").GetPassword(); err == nil {
+		t.Fatalf("expected an error when no helper command is configured")
+	}
+}
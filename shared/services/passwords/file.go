@@ -0,0 +1,79 @@
+package passwords
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Password manager that keeps the password in a plaintext file
+type FilePasswordManager struct {
+	passwordPath string
+}
+
+// Create new file password manager
+func NewFilePasswordManager(passwordPath string) *FilePasswordManager {
+	return &FilePasswordManager{
+		passwordPath: passwordPath,
+	}
+}
+
+// Check if the password has been set
+func (pm *FilePasswordManager) IsPasswordSet() bool {
+	_, err := os.ReadFile(pm.passwordPath)
+	return (err == nil)
+}
+
+// Get the password
+func (pm *FilePasswordManager) GetPassword() (string, error) {
+
+	// Read from disk
+	password, err := os.ReadFile(pm.passwordPath)
+	if err != nil {
+		return "", fmt.Errorf("Could not read password from disk: %w", err)
+	}
+
+	// Return
+	return string(password), nil
+
+}
+
+// Set the password
+func (pm *FilePasswordManager) SetPassword(password string) error {
+
+	// Check password is not set
+	if pm.IsPasswordSet() {
+		return errors.New("Password is already set")
+	}
+
+	// Check password length
+	if err := checkPasswordLength(password); err != nil {
+		return err
+	}
+
+	// Write to disk
+	if err := os.WriteFile(pm.passwordPath, []byte(password), FileMode); err != nil {
+		return fmt.Errorf("Could not write password to disk: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Delete the password
+func (pm *FilePasswordManager) DeletePassword() error {
+
+	// Check if it exists
+	_, err := os.Stat(pm.passwordPath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error checking password file path: %w", err)
+	}
+
+	// Delete it
+	err = os.Remove(pm.passwordPath)
+	return err
+
+}
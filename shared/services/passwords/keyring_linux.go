@@ -0,0 +1,103 @@
+//go:build linux
+// +build linux
+
+package passwords
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// The type of key the password is stored as
+const keyringKeyType string = "user"
+
+// Password manager that keeps the password in the user's Linux kernel keyring, so it only ever lives in kernel memory.
+// Keys don't survive a reboot, so the password must be loaded into the keyring again (e.g. with `keyctl padd`) after each boot.
+type KeyringPasswordManager struct {
+	description string
+}
+
+// Create new kernel keyring password manager; the description is the name of the key in the user keyring
+func NewKeyringPasswordManager(description string) *KeyringPasswordManager {
+	return &KeyringPasswordManager{
+		description: description,
+	}
+}
+
+// Check if the password has been set
+func (pm *KeyringPasswordManager) IsPasswordSet() bool {
+	_, err := pm.getKeyID()
+	return (err == nil)
+}
+
+// Get the password
+func (pm *KeyringPasswordManager) GetPassword() (string, error) {
+
+	// Find the key
+	id, err := pm.getKeyID()
+	if err != nil {
+		return "", fmt.Errorf("Could not find password in the kernel keyring: %w", err)
+	}
+
+	// Read it; the first call gets the size of the payload
+	size, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, nil, 0)
+	if err != nil {
+		return "", fmt.Errorf("Could not read password from the kernel keyring: %w", err)
+	}
+	password := make([]byte, size)
+	size, err = unix.KeyctlBuffer(unix.KEYCTL_READ, id, password, 0)
+	if err != nil {
+		return "", fmt.Errorf("Could not read password from the kernel keyring: %w", err)
+	}
+
+	// Return
+	return string(password[:size]), nil
+
+}
+
+// Set the password
+func (pm *KeyringPasswordManager) SetPassword(password string) error {
+
+	// Check password is not set
+	if pm.IsPasswordSet() {
+		return errors.New("Password is already set")
+	}
+
+	// Check password length
+	if err := checkPasswordLength(password); err != nil {
+		return err
+	}
+
+	// Add it to the keyring
+	if _, err := unix.AddKey(keyringKeyType, pm.description, []byte(password), unix.KEY_SPEC_USER_KEYRING); err != nil {
+		return fmt.Errorf("Could not write password to the kernel keyring: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Delete the password
+func (pm *KeyringPasswordManager) DeletePassword() error {
+
+	// Check if it exists
+	id, err := pm.getKeyID()
+	if errors.Is(err, unix.ENOKEY) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error searching the kernel keyring for the password: %w", err)
+	}
+
+	// Unlink it
+	_, err = unix.KeyctlInt(unix.KEYCTL_UNLINK, id, unix.KEY_SPEC_USER_KEYRING, 0, 0)
+	return err
+
+}
+
+// Find the password key in the user keyring
+func (pm *KeyringPasswordManager) getKeyID() (int, error) {
+	return unix.KeyctlSearch(unix.KEY_SPEC_USER_KEYRING, keyringKeyType, pm.description, 0)
+}
@@ -0,0 +1,42 @@
+//go:build !linux
+// +build !linux
+
+package passwords
+
+import (
+	"errors"
+)
+
+var errKeyringNotSupported = errors.New("The kernel keyring password backend is only supported on Linux")
+
+// Password manager that keeps the password in the user's Linux kernel keyring; unsupported on this platform
+type KeyringPasswordManager struct {
+	description string
+}
+
+// Create new kernel keyring password manager
+func NewKeyringPasswordManager(description string) *KeyringPasswordManager {
+	return &KeyringPasswordManager{
+		description: description,
+	}
+}
+
+// Check if the password has been set
+func (pm *KeyringPasswordManager) IsPasswordSet() bool {
+	return false
+}
+
+// Get the password
+func (pm *KeyringPasswordManager) GetPassword() (string, error) {
+	return "", errKeyringNotSupported
+}
+
+// Set the password
+func (pm *KeyringPasswordManager) SetPassword(password string) error {
+	return errKeyringNotSupported
+}
+
+// Delete the password
+func (pm *KeyringPasswordManager) DeletePassword() error {
+	return errKeyringNotSupported
+}
@@ -0,0 +1,448 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Recorded forms of Beacon responses that don't serialize directly
+type attestationsResponse struct {
+	Attestations []beacon.AttestationInfo `json:"attestations"`
+	Found        bool                     `json:"found"`
+}
+type beaconBlockResponse struct {
+	Block beacon.BeaconBlock `json:"block"`
+	Found bool               `json:"found"`
+}
+type eth1DataResponse struct {
+	Data  beacon.Eth1Data `json:"data"`
+	Found bool            `json:"found"`
+}
+type validatorStatusesResponse struct {
+	Statuses []beacon.ValidatorStatus `json:"statuses"`
+}
+type committee struct {
+	Index      uint64   `json:"index"`
+	Slot       uint64   `json:"slot"`
+	Validators []string `json:"validators"`
+}
+
+// A flattened list of committees that implements beacon.Committees
+type committees []committee
+
+func (c committees) Index(idx int) uint64 {
+	return c[idx].Index
+}
+func (c committees) Slot(idx int) uint64 {
+	return c[idx].Slot
+}
+func (c committees) Validators(idx int) []string {
+	return c[idx].Validators
+}
+func (c committees) Count() int {
+	return len(c)
+}
+func (c committees) Release() {
+}
+
+// Sorts a copy of the provided pubkeys so the call key doesn't depend on their order
+func sortPubkeys(pubkeys []types.ValidatorPubkey) []string {
+	sorted := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		sorted[i] = pubkey.Hex()
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// Sorts a copy of the provided validator indices so the call key doesn't depend on their order
+func sortIndices(indices []string) []string {
+	sorted := make([]string, len(indices))
+	copy(sorted, indices)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// ==========================
+// === Recording Client ===
+// ==========================
+
+// A Beacon client that passes every call through to a live client and records its response
+type RecordingBeaconClient struct {
+	bc        beacon.Client
+	recording *Recording
+}
+
+// Creates a new Beacon client that records all responses from the provided client
+func NewRecordingBeaconClient(bc beacon.Client, recording *Recording) *RecordingBeaconClient {
+	return &RecordingBeaconClient{
+		bc:        bc,
+		recording: recording,
+	}
+}
+
+// Records a response with the provided key parameters
+func (c *RecordingBeaconClient) record(value interface{}, method string, args ...interface{}) error {
+	key, err := getKey(method, args...)
+	if err != nil {
+		return err
+	}
+	return c.recording.store(c.recording.beacon, key, value)
+}
+
+func (c *RecordingBeaconClient) GetClientType() (beacon.BeaconClientType, error) {
+	result, err := c.bc.GetClientType()
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetClientType")
+}
+
+func (c *RecordingBeaconClient) GetSyncStatus() (beacon.SyncStatus, error) {
+	result, err := c.bc.GetSyncStatus()
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetSyncStatus")
+}
+
+func (c *RecordingBeaconClient) GetEth2Config() (beacon.Eth2Config, error) {
+	result, err := c.bc.GetEth2Config()
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetEth2Config")
+}
+
+func (c *RecordingBeaconClient) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
+	result, err := c.bc.GetEth2DepositContract()
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetEth2DepositContract")
+}
+
+func (c *RecordingBeaconClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	attestations, found, err := c.bc.GetAttestations(blockId)
+	if err != nil {
+		return attestations, found, err
+	}
+	return attestations, found, c.record(attestationsResponse{Attestations: attestations, Found: found}, "GetAttestations", blockId)
+}
+
+func (c *RecordingBeaconClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	block, found, err := c.bc.GetBeaconBlock(blockId)
+	if err != nil {
+		return block, found, err
+	}
+	return block, found, c.record(beaconBlockResponse{Block: block, Found: found}, "GetBeaconBlock", blockId)
+}
+
+func (c *RecordingBeaconClient) GetBeaconHead() (beacon.BeaconHead, error) {
+	result, err := c.bc.GetBeaconHead()
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetBeaconHead")
+}
+
+func (c *RecordingBeaconClient) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	result, err := c.bc.GetValidatorStatusByIndex(index, opts)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetValidatorStatusByIndex", index, opts)
+}
+
+func (c *RecordingBeaconClient) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	result, err := c.bc.GetValidatorStatus(pubkey, opts)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetValidatorStatus", pubkey.Hex(), opts)
+}
+
+func (c *RecordingBeaconClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	result, err := c.bc.GetValidatorStatuses(pubkeys, opts)
+	if err != nil {
+		return result, err
+	}
+	response := validatorStatusesResponse{
+		Statuses: make([]beacon.ValidatorStatus, 0, len(result)),
+	}
+	for pubkey, status := range result {
+		status.Pubkey = pubkey
+		response.Statuses = append(response.Statuses, status)
+	}
+	return result, c.record(response, "GetValidatorStatuses", sortPubkeys(pubkeys), opts)
+}
+
+func (c *RecordingBeaconClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
+	result, err := c.bc.GetValidatorIndex(pubkey)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetValidatorIndex", pubkey.Hex())
+}
+
+func (c *RecordingBeaconClient) GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error) {
+	result, err := c.bc.GetValidatorSyncDuties(indices, epoch)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetValidatorSyncDuties", sortIndices(indices), epoch)
+}
+
+func (c *RecordingBeaconClient) GetValidatorLiveness(indices []string, epoch uint64) (map[string]bool, error) {
+	result, err := c.bc.GetValidatorLiveness(indices, epoch)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetValidatorLiveness", sortIndices(indices), epoch)
+}
+
+func (c *RecordingBeaconClient) GetValidatorSyncCommitteeIndices(indices []string, epoch uint64) (map[string][]uint64, error) {
+	result, err := c.bc.GetValidatorSyncCommitteeIndices(indices, epoch)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetValidatorSyncCommitteeIndices", sortIndices(indices), epoch)
+}
+
+func (c *RecordingBeaconClient) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
+	result, err := c.bc.GetValidatorProposerDuties(indices, epoch)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetValidatorProposerDuties", sortIndices(indices), epoch)
+}
+
+func (c *RecordingBeaconClient) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
+	result, err := c.bc.GetDomainData(domainType, epoch, useGenesisFork)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetDomainData", domainType, epoch, useGenesisFork)
+}
+
+func (c *RecordingBeaconClient) GetFork() (beacon.Fork, error) {
+	result, err := c.bc.GetFork()
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "GetFork")
+}
+
+func (c *RecordingBeaconClient) ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error {
+	return c.bc.ExitValidator(validatorIndex, epoch, signature)
+}
+
+func (c *RecordingBeaconClient) Close() error {
+	return c.bc.Close()
+}
+
+func (c *RecordingBeaconClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
+	data, found, err := c.bc.GetEth1DataForEth2Block(blockId)
+	if err != nil {
+		return data, found, err
+	}
+	return data, found, c.record(eth1DataResponse{Data: data, Found: found}, "GetEth1DataForEth2Block", blockId)
+}
+
+func (c *RecordingBeaconClient) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	result, err := c.bc.GetCommitteesForEpoch(epoch)
+	if err != nil {
+		return result, err
+	}
+
+	// Copy the committees out since the caller will release the underlying buffers
+	response := make(committees, result.Count())
+	for i := range response {
+		validators := result.Validators(i)
+		response[i] = committee{
+			Index:      result.Index(i),
+			Slot:       result.Slot(i),
+			Validators: make([]string, len(validators)),
+		}
+		copy(response[i].Validators, validators)
+	}
+	return result, c.record(response, "GetCommitteesForEpoch", epoch)
+}
+
+func (c *RecordingBeaconClient) ChangeWithdrawalCredentials(validatorIndex string, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error {
+	return c.bc.ChangeWithdrawalCredentials(validatorIndex, fromBlsPubkey, toExecutionAddress, signature)
+}
+
+func (c *RecordingBeaconClient) StreamEvents(ctx context.Context, topics []beacon.EventTopic, events chan<- beacon.BeaconEvent) error {
+	return c.bc.StreamEvents(ctx, topics, events)
+}
+
+// =======================
+// === Replay Client ===
+// =======================
+
+// A Beacon client that serves responses from a recording instead of a live client
+type ReplayBeaconClient struct {
+	recording *Recording
+}
+
+// Creates a new Beacon client that replays the responses in the provided recording
+func NewReplayBeaconClient(recording *Recording) *ReplayBeaconClient {
+	return &ReplayBeaconClient{
+		recording: recording,
+	}
+}
+
+// Retrieves a recorded response for the provided key parameters
+func (c *ReplayBeaconClient) replay(value interface{}, method string, args ...interface{}) error {
+	key, err := getKey(method, args...)
+	if err != nil {
+		return err
+	}
+	return c.recording.load(c.recording.beacon, key, value)
+}
+
+func (c *ReplayBeaconClient) GetClientType() (beacon.BeaconClientType, error) {
+	var result beacon.BeaconClientType
+	err := c.replay(&result, "GetClientType")
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetSyncStatus() (beacon.SyncStatus, error) {
+	var result beacon.SyncStatus
+	err := c.replay(&result, "GetSyncStatus")
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetEth2Config() (beacon.Eth2Config, error) {
+	var result beacon.Eth2Config
+	err := c.replay(&result, "GetEth2Config")
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
+	var result beacon.Eth2DepositContract
+	err := c.replay(&result, "GetEth2DepositContract")
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	var response attestationsResponse
+	err := c.replay(&response, "GetAttestations", blockId)
+	return response.Attestations, response.Found, err
+}
+
+func (c *ReplayBeaconClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	var response beaconBlockResponse
+	err := c.replay(&response, "GetBeaconBlock", blockId)
+	return response.Block, response.Found, err
+}
+
+func (c *ReplayBeaconClient) GetBeaconHead() (beacon.BeaconHead, error) {
+	var result beacon.BeaconHead
+	err := c.replay(&result, "GetBeaconHead")
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	var result beacon.ValidatorStatus
+	err := c.replay(&result, "GetValidatorStatusByIndex", index, opts)
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	var result beacon.ValidatorStatus
+	err := c.replay(&result, "GetValidatorStatus", pubkey.Hex(), opts)
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	var response validatorStatusesResponse
+	err := c.replay(&response, "GetValidatorStatuses", sortPubkeys(pubkeys), opts)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(response.Statuses))
+	for _, status := range response.Statuses {
+		result[status.Pubkey] = status
+	}
+	return result, nil
+}
+
+func (c *ReplayBeaconClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
+	var result string
+	err := c.replay(&result, "GetValidatorIndex", pubkey.Hex())
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error) {
+	var result map[string]bool
+	err := c.replay(&result, "GetValidatorSyncDuties", sortIndices(indices), epoch)
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetValidatorLiveness(indices []string, epoch uint64) (map[string]bool, error) {
+	var result map[string]bool
+	err := c.replay(&result, "GetValidatorLiveness", sortIndices(indices), epoch)
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetValidatorSyncCommitteeIndices(indices []string, epoch uint64) (map[string][]uint64, error) {
+	var result map[string][]uint64
+	err := c.replay(&result, "GetValidatorSyncCommitteeIndices", sortIndices(indices), epoch)
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
+	var result map[string]uint64
+	err := c.replay(&result, "GetValidatorProposerDuties", sortIndices(indices), epoch)
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
+	var result []byte
+	err := c.replay(&result, "GetDomainData", domainType, epoch, useGenesisFork)
+	return result, err
+}
+
+func (c *ReplayBeaconClient) GetFork() (beacon.Fork, error) {
+	var result beacon.Fork
+	err := c.replay(&result, "GetFork")
+	return result, err
+}
+
+func (c *ReplayBeaconClient) ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error {
+	return fmt.Errorf("exiting validators is not supported by the replay client")
+}
+
+func (c *ReplayBeaconClient) Close() error {
+	return nil
+}
+
+func (c *ReplayBeaconClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
+	var response eth1DataResponse
+	err := c.replay(&response, "GetEth1DataForEth2Block", blockId)
+	return response.Data, response.Found, err
+}
+
+func (c *ReplayBeaconClient) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	var result committees
+	err := c.replay(&result, "GetCommitteesForEpoch", epoch)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ReplayBeaconClient) ChangeWithdrawalCredentials(validatorIndex string, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error {
+	return fmt.Errorf("changing withdrawal credentials is not supported by the replay client")
+}
+
+func (c *ReplayBeaconClient) StreamEvents(ctx context.Context, topics []beacon.EventTopic, events chan<- beacon.BeaconEvent) error {
+	return fmt.Errorf("event streams are not supported by the replay client")
+}
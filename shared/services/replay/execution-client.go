@@ -0,0 +1,320 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Recorded forms of Execution responses that don't serialize directly
+type transactionResponse struct {
+	Transaction *types.Transaction `json:"transaction"`
+	IsPending   bool               `json:"isPending"`
+}
+
+// Gets the serializable parts of a contract call that determine its result
+func getCallArgs(call ethereum.CallMsg) []interface{} {
+	return []interface{}{call.From, call.To, call.Gas, call.GasPrice, call.GasFeeCap, call.GasTipCap, call.Value, call.Data}
+}
+
+// Gets the serializable parts of a log filter that determine its result
+func getQueryArgs(query ethereum.FilterQuery) []interface{} {
+	return []interface{}{query.BlockHash, query.FromBlock, query.ToBlock, query.Addresses, query.Topics}
+}
+
+// ==========================
+// === Recording Client ===
+// ==========================
+
+// An Execution client that passes every call through to a live client and records its response
+type RecordingExecutionClient struct {
+	ec        rocketpool.ExecutionClient
+	recording *Recording
+}
+
+// Creates a new Execution client that records all responses from the provided client
+func NewRecordingExecutionClient(ec rocketpool.ExecutionClient, recording *Recording) *RecordingExecutionClient {
+	return &RecordingExecutionClient{
+		ec:        ec,
+		recording: recording,
+	}
+}
+
+// Records a response with the provided key parameters
+func (c *RecordingExecutionClient) record(value interface{}, method string, args ...interface{}) error {
+	key, err := getKey(method, args...)
+	if err != nil {
+		return err
+	}
+	return c.recording.store(c.recording.execution, key, value)
+}
+
+func (c *RecordingExecutionClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	result, err := c.ec.CodeAt(ctx, contract, blockNumber)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "CodeAt", contract, blockNumber)
+}
+
+func (c *RecordingExecutionClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	result, err := c.ec.CallContract(ctx, call, blockNumber)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "CallContract", append(getCallArgs(call), blockNumber)...)
+}
+
+func (c *RecordingExecutionClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	result, err := c.ec.HeaderByHash(ctx, hash)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "HeaderByHash", hash)
+}
+
+func (c *RecordingExecutionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	result, err := c.ec.HeaderByNumber(ctx, number)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "HeaderByNumber", number)
+}
+
+func (c *RecordingExecutionClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	result, err := c.ec.PendingCodeAt(ctx, account)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "PendingCodeAt", account)
+}
+
+func (c *RecordingExecutionClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	result, err := c.ec.PendingNonceAt(ctx, account)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "PendingNonceAt", account)
+}
+
+func (c *RecordingExecutionClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	result, err := c.ec.SuggestGasPrice(ctx)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "SuggestGasPrice")
+}
+
+func (c *RecordingExecutionClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	result, err := c.ec.SuggestGasTipCap(ctx)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "SuggestGasTipCap")
+}
+
+func (c *RecordingExecutionClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	result, err := c.ec.EstimateGas(ctx, call)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "EstimateGas", getCallArgs(call)...)
+}
+
+func (c *RecordingExecutionClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return c.ec.SendTransaction(ctx, tx)
+}
+
+func (c *RecordingExecutionClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	result, err := c.ec.FilterLogs(ctx, query)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "FilterLogs", getQueryArgs(query)...)
+}
+
+func (c *RecordingExecutionClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return c.ec.SubscribeFilterLogs(ctx, query, ch)
+}
+
+func (c *RecordingExecutionClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	result, err := c.ec.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "TransactionReceipt", txHash)
+}
+
+func (c *RecordingExecutionClient) BlockNumber(ctx context.Context) (uint64, error) {
+	result, err := c.ec.BlockNumber(ctx)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "BlockNumber")
+}
+
+func (c *RecordingExecutionClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	result, err := c.ec.BalanceAt(ctx, account, blockNumber)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "BalanceAt", account, blockNumber)
+}
+
+func (c *RecordingExecutionClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	tx, isPending, err := c.ec.TransactionByHash(ctx, hash)
+	if err != nil {
+		return tx, isPending, err
+	}
+	return tx, isPending, c.record(transactionResponse{Transaction: tx, IsPending: isPending}, "TransactionByHash", hash)
+}
+
+func (c *RecordingExecutionClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	result, err := c.ec.NonceAt(ctx, account, blockNumber)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "NonceAt", account, blockNumber)
+}
+
+func (c *RecordingExecutionClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	result, err := c.ec.SyncProgress(ctx)
+	if err != nil {
+		return result, err
+	}
+	return result, c.record(result, "SyncProgress")
+}
+
+// =======================
+// === Replay Client ===
+// =======================
+
+// An Execution client that serves responses from a recording instead of a live client
+type ReplayExecutionClient struct {
+	recording *Recording
+}
+
+// Creates a new Execution client that replays the responses in the provided recording
+func NewReplayExecutionClient(recording *Recording) *ReplayExecutionClient {
+	return &ReplayExecutionClient{
+		recording: recording,
+	}
+}
+
+// Retrieves a recorded response for the provided key parameters
+func (c *ReplayExecutionClient) replay(value interface{}, method string, args ...interface{}) error {
+	key, err := getKey(method, args...)
+	if err != nil {
+		return err
+	}
+	return c.recording.load(c.recording.execution, key, value)
+}
+
+func (c *ReplayExecutionClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := c.replay(&result, "CodeAt", contract, blockNumber)
+	return result, err
+}
+
+func (c *ReplayExecutionClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := c.replay(&result, "CallContract", append(getCallArgs(call), blockNumber)...)
+	return result, err
+}
+
+func (c *ReplayExecutionClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	var result *types.Header
+	err := c.replay(&result, "HeaderByHash", hash)
+	return result, err
+}
+
+func (c *ReplayExecutionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var result *types.Header
+	err := c.replay(&result, "HeaderByNumber", number)
+	return result, err
+}
+
+func (c *ReplayExecutionClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var result []byte
+	err := c.replay(&result, "PendingCodeAt", account)
+	return result, err
+}
+
+func (c *ReplayExecutionClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var result uint64
+	err := c.replay(&result, "PendingNonceAt", account)
+	return result, err
+}
+
+func (c *ReplayExecutionClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := c.replay(&result, "SuggestGasPrice")
+	return result, err
+}
+
+func (c *ReplayExecutionClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := c.replay(&result, "SuggestGasTipCap")
+	return result, err
+}
+
+func (c *ReplayExecutionClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	var result uint64
+	err := c.replay(&result, "EstimateGas", getCallArgs(call)...)
+	return result, err
+}
+
+func (c *ReplayExecutionClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return fmt.Errorf("sending transactions is not supported by the replay client")
+}
+
+func (c *ReplayExecutionClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	var result []types.Log
+	err := c.replay(&result, "FilterLogs", getQueryArgs(query)...)
+	return result, err
+}
+
+func (c *ReplayExecutionClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, fmt.Errorf("log subscriptions are not supported by the replay client")
+}
+
+func (c *ReplayExecutionClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var result *types.Receipt
+	err := c.replay(&result, "TransactionReceipt", txHash)
+	return result, err
+}
+
+func (c *ReplayExecutionClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var result uint64
+	err := c.replay(&result, "BlockNumber")
+	return result, err
+}
+
+func (c *ReplayExecutionClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var result *big.Int
+	err := c.replay(&result, "BalanceAt", account, blockNumber)
+	return result, err
+}
+
+func (c *ReplayExecutionClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	var response transactionResponse
+	err := c.replay(&response, "TransactionByHash", hash)
+	return response.Transaction, response.IsPending, err
+}
+
+func (c *ReplayExecutionClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var result uint64
+	err := c.replay(&result, "NonceAt", account, blockNumber)
+	return result, err
+}
+
+func (c *ReplayExecutionClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	var result *ethereum.SyncProgress
+	err := c.replay(&result, "SyncProgress")
+	return result, err
+}
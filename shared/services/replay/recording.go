@@ -0,0 +1,150 @@
+package replay
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Filenames for the recorded responses inside a fixture directory
+const (
+	BeaconRecordingFilename    string = "beacon.json.zst"
+	ExecutionRecordingFilename string = "execution.json.zst"
+)
+
+// A set of client responses captured during a live run, keyed by the method and its arguments
+type Recording struct {
+	beacon    map[string]json.RawMessage
+	execution map[string]json.RawMessage
+	lock      sync.RWMutex
+}
+
+// Creates a new, empty recording
+func NewRecording() *Recording {
+	return &Recording{
+		beacon:    map[string]json.RawMessage{},
+		execution: map[string]json.RawMessage{},
+	}
+}
+
+// Loads a recording from the provided fixture directory
+func LoadRecording(dir string) (*Recording, error) {
+	r := NewRecording()
+	if err := loadResponses(filepath.Join(dir, BeaconRecordingFilename), r.beacon); err != nil {
+		return nil, err
+	}
+	if err := loadResponses(filepath.Join(dir, ExecutionRecordingFilename), r.execution); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Saves the recording to the provided fixture directory, creating it if necessary
+func (r *Recording) Save(dir string) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating fixture directory [%s]: %w", dir, err)
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if err := saveResponses(filepath.Join(dir, BeaconRecordingFilename), r.beacon); err != nil {
+		return err
+	}
+	return saveResponses(filepath.Join(dir, ExecutionRecordingFilename), r.execution)
+}
+
+// Get the number of recorded Beacon and Execution responses
+func (r *Recording) Count() (int, int) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return len(r.beacon), len(r.execution)
+}
+
+// Stores a response in the given response map
+func (r *Recording) store(responses map[string]json.RawMessage, key string, value interface{}) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error serializing response for %s: %w", key, err)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	responses[key] = bytes
+	return nil
+}
+
+// Retrieves a response from the given response map
+func (r *Recording) load(responses map[string]json.RawMessage, key string, value interface{}) error {
+	r.lock.RLock()
+	bytes, exists := responses[key]
+	r.lock.RUnlock()
+	if !exists {
+		return fmt.Errorf("no recorded response for %s", key)
+	}
+
+	err := json.Unmarshal(bytes, value)
+	if err != nil {
+		return fmt.Errorf("error deserializing recorded response for %s: %w", key, err)
+	}
+	return nil
+}
+
+// Creates a deterministic key for a method call from its name and arguments
+func getKey(method string, args ...interface{}) (string, error) {
+	bytes, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("error serializing arguments for %s: %w", method, err)
+	}
+	return fmt.Sprintf("%s:%s", method, crypto.Keccak256Hash(bytes).Hex()), nil
+}
+
+// Reads a compressed response map from disk
+func loadResponses(path string, responses map[string]json.RawMessage) error {
+	compressedBytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading recording [%s]: %w", path, err)
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return fmt.Errorf("error creating compression decoder: %w", err)
+	}
+	defer decoder.Close()
+	bytes, err := decoder.DecodeAll(compressedBytes, nil)
+	if err != nil {
+		return fmt.Errorf("error decompressing recording [%s]: %w", path, err)
+	}
+
+	err = json.Unmarshal(bytes, &responses)
+	if err != nil {
+		return fmt.Errorf("error deserializing recording [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Writes a response map to disk in compressed form
+func saveResponses(path string, responses map[string]json.RawMessage) error {
+	bytes, err := json.Marshal(responses)
+	if err != nil {
+		return fmt.Errorf("error serializing recording: %w", err)
+	}
+
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+	if err != nil {
+		return fmt.Errorf("error creating compression encoder: %w", err)
+	}
+	defer encoder.Close()
+	compressedBytes := encoder.EncodeAll(bytes, make([]byte, 0, len(bytes)))
+
+	err = os.WriteFile(path, compressedBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing recording [%s]: %w", path, err)
+	}
+	return nil
+}
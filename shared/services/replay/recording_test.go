@@ -0,0 +1,156 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// A Beacon client that only serves blocks and committees; every other method panics
+type fakeBeaconClient struct {
+	beacon.Client
+}
+
+func (c *fakeBeaconClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	if blockId == "missing" {
+		return beacon.BeaconBlock{}, false, nil
+	}
+	return beacon.BeaconBlock{
+		Slot:                 100,
+		ProposerIndex:        "42",
+		HasExecutionPayload:  true,
+		FeeRecipient:         common.HexToAddress("0x1234"),
+		ExecutionBlockNumber: 200,
+	}, true, nil
+}
+
+func (c *fakeBeaconClient) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	return committees{
+		{Index: 0, Slot: *epoch * 32, Validators: []string{"1", "2", "3"}},
+		{Index: 1, Slot: *epoch * 32, Validators: []string{"4", "5"}},
+	}, nil
+}
+
+// An Execution client that only serves contract calls and headers; every other method panics
+type fakeExecutionClient struct {
+	rocketpool.ExecutionClient
+}
+
+func (c *fakeExecutionClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return append(call.Data, blockNumber.Bytes()...), nil
+}
+
+func (c *fakeExecutionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{
+		Number:     number,
+		Time:       1234,
+		Difficulty: big.NewInt(0),
+	}, nil
+}
+
+func TestBeaconRoundTrip(t *testing.T) {
+	recording := NewRecording()
+	recorder := NewRecordingBeaconClient(&fakeBeaconClient{}, recording)
+
+	block, found, err := recorder.GetBeaconBlock("100")
+	if err != nil || !found {
+		t.Fatalf("unexpected result from recorder: found %t, err %v", found, err)
+	}
+	_, found, err = recorder.GetBeaconBlock("missing")
+	if err != nil || found {
+		t.Fatalf("unexpected result for missing block: found %t, err %v", found, err)
+	}
+	epoch := uint64(5)
+	_, err = recorder.GetCommitteesForEpoch(&epoch)
+	if err != nil {
+		t.Fatalf("unexpected error recording committees: %v", err)
+	}
+
+	// Persist and reload the recording
+	dir := t.TempDir()
+	if err := recording.Save(dir); err != nil {
+		t.Fatalf("error saving recording: %v", err)
+	}
+	loaded, err := LoadRecording(dir)
+	if err != nil {
+		t.Fatalf("error loading recording: %v", err)
+	}
+	beaconCount, _ := loaded.Count()
+	if beaconCount != 3 {
+		t.Fatalf("expected 3 recorded Beacon responses, got %d", beaconCount)
+	}
+
+	replayer := NewReplayBeaconClient(loaded)
+	replayedBlock, found, err := replayer.GetBeaconBlock("100")
+	if err != nil || !found {
+		t.Fatalf("unexpected result from replayer: found %t, err %v", found, err)
+	}
+	if replayedBlock.Slot != block.Slot || replayedBlock.ProposerIndex != block.ProposerIndex || replayedBlock.FeeRecipient != block.FeeRecipient || replayedBlock.ExecutionBlockNumber != block.ExecutionBlockNumber {
+		t.Fatalf("replayed block %+v doesn't match recorded block %+v", replayedBlock, block)
+	}
+	_, found, err = replayer.GetBeaconBlock("missing")
+	if err != nil || found {
+		t.Fatalf("unexpected replay result for missing block: found %t, err %v", found, err)
+	}
+
+	replayedCommittees, err := replayer.GetCommitteesForEpoch(&epoch)
+	if err != nil {
+		t.Fatalf("error replaying committees: %v", err)
+	}
+	if replayedCommittees.Count() != 2 || replayedCommittees.Slot(0) != 160 || len(replayedCommittees.Validators(1)) != 2 {
+		t.Fatalf("replayed committees don't match the recording")
+	}
+
+	// Calls that were never recorded must fail instead of returning zero values
+	if _, _, err := replayer.GetBeaconBlock("101"); err == nil {
+		t.Fatalf("expected an error for an unrecorded block")
+	}
+}
+
+func TestExecutionRoundTrip(t *testing.T) {
+	recording := NewRecording()
+	recorder := NewRecordingExecutionClient(&fakeExecutionClient{}, recording)
+
+	to := common.HexToAddress("0xabcd")
+	call := ethereum.CallMsg{To: &to, Data: []byte{0x01, 0x02}}
+	blockNumber := big.NewInt(300)
+	result, err := recorder.CallContract(context.Background(), call, blockNumber)
+	if err != nil {
+		t.Fatalf("unexpected error recording call: %v", err)
+	}
+	header, err := recorder.HeaderByNumber(context.Background(), blockNumber)
+	if err != nil {
+		t.Fatalf("unexpected error recording header: %v", err)
+	}
+
+	replayer := NewReplayExecutionClient(recording)
+	replayedResult, err := replayer.CallContract(context.Background(), call, blockNumber)
+	if err != nil {
+		t.Fatalf("error replaying call: %v", err)
+	}
+	if !bytes.Equal(replayedResult, result) {
+		t.Fatalf("replayed call result %x doesn't match recorded result %x", replayedResult, result)
+	}
+	replayedHeader, err := replayer.HeaderByNumber(context.Background(), blockNumber)
+	if err != nil {
+		t.Fatalf("error replaying header: %v", err)
+	}
+	if replayedHeader.Hash() != header.Hash() {
+		t.Fatalf("replayed header %s doesn't match recorded header %s", replayedHeader.Hash().Hex(), header.Hash().Hex())
+	}
+
+	// The same call at a different block must not be served from the recording
+	if _, err := replayer.CallContract(context.Background(), call, big.NewInt(301)); err == nil {
+		t.Fatalf("expected an error for an unrecorded block number")
+	}
+	if err := replayer.SendTransaction(context.Background(), nil); err == nil {
+		t.Fatalf("expected the replay client to refuse transactions")
+	}
+}
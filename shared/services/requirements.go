@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -359,81 +360,60 @@ var ethClientSyncLock sync.Mutex
 func checkExecutionClientStatus(ecMgr *ExecutionClientManager, cfg *config.RocketPoolConfig) (bool, rocketpool.ExecutionClient, error) {
 
 	// Check the EC status
-	mgrStatus := ecMgr.CheckStatus(cfg)
-	if ecMgr.primaryReady {
-		return true, nil, nil
+	ecMgr.CheckStatus(cfg)
+	ready, index, err := checkClientPoolStatus(ecMgr.pool, "execution")
+	if err != nil || ready {
+		return ready, nil, err
 	}
-
-	// If the primary isn't synced but there's a fallback and it is, return true
-	if ecMgr.fallbackReady {
-		if mgrStatus.PrimaryClientStatus.Error != "" {
-			log.Printf("Primary execution client is unavailable (%s), using fallback execution client...\n", mgrStatus.PrimaryClientStatus.Error)
-		} else {
-			log.Printf("Primary execution client is still syncing (%.2f%%), using fallback execution client...\n", mgrStatus.PrimaryClientStatus.SyncProgress*100)
-		}
-		return true, nil, nil
-	}
-
-	// If neither is synced, go through the status to figure out what to do
-
-	// Is the primary working and syncing? If so, wait for it
-	if mgrStatus.PrimaryClientStatus.IsWorking && mgrStatus.PrimaryClientStatus.Error == "" {
-		log.Printf("Fallback execution client is not configured or unavailable, waiting for primary execution client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryClientStatus.SyncProgress*100)
-		return false, ecMgr.primaryEc, nil
-	}
-
-	// Is the fallback working and syncing? If so, wait for it
-	if mgrStatus.FallbackEnabled && mgrStatus.FallbackClientStatus.IsWorking && mgrStatus.FallbackClientStatus.Error == "" {
-		log.Printf("Primary execution client is unavailable (%s), waiting for the fallback execution client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryClientStatus.Error, mgrStatus.FallbackClientStatus.SyncProgress*100)
-		return false, ecMgr.fallbackEc, nil
-	}
-
-	// If neither client is working, report the errors
-	if mgrStatus.FallbackEnabled {
-		return false, nil, fmt.Errorf("Primary execution client is unavailable (%s) and fallback execution client is unavailable (%s), no execution clients are ready.", mgrStatus.PrimaryClientStatus.Error, mgrStatus.FallbackClientStatus.Error)
-	}
-
-	return false, nil, fmt.Errorf("Primary execution client is unavailable (%s) and no fallback execution client is configured.", mgrStatus.PrimaryClientStatus.Error)
+	return false, ecMgr.ecs[index], nil
 }
 
 func checkBeaconClientStatus(bcMgr *BeaconClientManager) (bool, error) {
 
 	// Check the BC status
-	mgrStatus := bcMgr.CheckStatus()
-	if bcMgr.primaryReady {
-		return true, nil
-	}
+	bcMgr.CheckStatus()
+	ready, _, err := checkClientPoolStatus(bcMgr.pool, "consensus")
+	return ready, err
+}
 
-	// If the primary isn't synced but there's a fallback and it is, return true
-	if bcMgr.fallbackReady {
-		if mgrStatus.PrimaryClientStatus.Error != "" {
-			log.Printf("Primary consensus client is unavailable (%s), using fallback consensus client...\n", mgrStatus.PrimaryClientStatus.Error)
-		} else {
-			log.Printf("Primary consensus client is still syncing (%.2f%%), using fallback consensus client...\n", mgrStatus.PrimaryClientStatus.SyncProgress*100)
+// Check if any of the clients in a pool are ready. If not, returns the index of the client to wait for,
+// or an error if none of them are working.
+func checkClientPoolStatus(pool *clientPool, clientType string) (bool, int, error) {
+
+	statuses := pool.getStatuses()
+	primaryStatus := statuses[0]
+
+	// If a client is ready, use it
+	if index, ready := pool.getFirstUsable(); ready {
+		if index > 0 && !pool.isSkippingPrimary() {
+			if primaryStatus.Error != "" {
+				log.Printf("Primary %s client is unavailable (%s), using %s %s client...\n", clientType, primaryStatus.Error, pool.getName(index), clientType)
+			} else if !primaryStatus.IsSynced {
+				log.Printf("Primary %s client is still syncing (%.2f%%), using %s %s client...\n", clientType, primaryStatus.SyncProgress*100, pool.getName(index), clientType)
+			} else {
+				log.Printf("Primary %s client is behind the other clients, using %s %s client...\n", clientType, pool.getName(index), clientType)
+			}
 		}
-		return true, nil
+		return true, index, nil
 	}
 
-	// If neither is synced, go through the status to figure out what to do
-
-	// Is the primary working and syncing? If so, wait for it
-	if mgrStatus.PrimaryClientStatus.IsWorking && mgrStatus.PrimaryClientStatus.Error == "" {
-		log.Printf("Fallback consensus client is not configured or unavailable, waiting for primary consensus client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryClientStatus.SyncProgress*100)
-		return false, nil
+	// If none are ready, wait for the first one that's working and syncing
+	for index, status := range statuses {
+		if status.IsWorking && status.Error == "" {
+			log.Printf("No other %s clients are available, waiting for %s %s client to finish syncing (%.2f%%)\n", clientType, pool.getName(index), clientType, status.SyncProgress*100)
+			return false, index, nil
+		}
 	}
 
-	// Is the fallback working and syncing? If so, wait for it
-	if mgrStatus.FallbackEnabled && mgrStatus.FallbackClientStatus.IsWorking && mgrStatus.FallbackClientStatus.Error == "" {
-		log.Printf("Primary cosnensus client is unavailable (%s), waiting for the fallback consensus client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryClientStatus.Error, mgrStatus.FallbackClientStatus.SyncProgress*100)
-		return false, nil
+	// If none of the clients are working, report the errors
+	if len(statuses) == 1 {
+		return false, 0, fmt.Errorf("Primary %s client is unavailable (%s) and no fallback %s client is configured.", clientType, primaryStatus.Error, clientType)
 	}
-
-	// If neither client is working, report the errors
-	if mgrStatus.FallbackEnabled {
-		return false, fmt.Errorf("Primary consensus client is unavailable (%s) and fallback consensus client is unavailable (%s), no consensus clients are ready.", mgrStatus.PrimaryClientStatus.Error, mgrStatus.FallbackClientStatus.Error)
+	clientErrors := make([]string, len(statuses))
+	for index, status := range statuses {
+		clientErrors[index] = fmt.Sprintf("%s %s client is unavailable (%s)", pool.getName(index), clientType, status.Error)
 	}
-
-	return false, fmt.Errorf("Primary consensus client is unavailable (%s) and no fallback consensus client is configured.", mgrStatus.PrimaryClientStatus.Error)
+	return false, 0, fmt.Errorf("No %s clients are ready: %s.", clientType, strings.Join(clientErrors, ", "))
 }
 
 func waitEthClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, error) {
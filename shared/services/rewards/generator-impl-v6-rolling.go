@@ -158,6 +158,56 @@ func (r *treeGeneratorImpl_v6_rolling) generateTree(rp *rocketpool.RocketPool, c
 
 }
 
+// Calculates the rewards for an interval that's still in progress, using the snapshot as the end of the interval.
+// The rolling record has the real attestation performance of every minipool, so the participation rates aren't needed
+func (r *treeGeneratorImpl_v6_rolling) projectTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, participation map[common.Address]float64) (*RewardsFile, error) {
+
+	r.log.Printlnf("%s Projecting tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
+
+	// Provision some struct params
+	r.rp = rp
+	r.cfg = cfg
+	r.bc = bc
+	r.validNetworkCache = map[uint64]bool{
+		0: true,
+	}
+
+	// Set the network name
+	r.rewardsFile.Network = fmt.Sprint(cfg.Smartnode.Network.Value)
+	r.rewardsFile.MinipoolPerformanceFile.Network = r.rewardsFile.Network
+
+	// Get the Beacon config
+	r.beaconConfig = r.networkState.BeaconConfig
+	r.slotsPerEpoch = r.beaconConfig.SlotsPerEpoch
+
+	// Set the EL client call opts
+	r.opts = &bind.CallOpts{
+		BlockNumber: r.elSnapshotHeader.Number,
+	}
+
+	// Get the minipool count - this will be used for an error epsilon due to division truncation
+	minipoolCount := uint64(len(r.networkState.MinipoolDetails))
+	r.epsilon = big.NewInt(int64(minipoolCount))
+
+	// Calculate the RPL rewards
+	err := r.calculateRplRewards()
+	if err != nil {
+		return nil, fmt.Errorf("Error calculating RPL rewards: %w", err)
+	}
+
+	// Calculate the ETH rewards using the scores in the rolling record
+	err = r.calculateEthRewards(true)
+	if err != nil {
+		return nil, fmt.Errorf("Error calculating ETH rewards: %w", err)
+	}
+
+	// Calculate the network reward map and the totals
+	r.updateNetworksAndTotals()
+
+	return r.rewardsFile, nil
+
+}
+
 // Quickly calculates an approximate of the staker's share of the smoothing pool balance without processing Beacon performance
 // Used for approximate returns in the rETH ratio update
 func (r *treeGeneratorImpl_v6_rolling) approximateStakerShareOfSmoothingPool(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error) {
@@ -596,7 +646,7 @@ func (r *treeGeneratorImpl_v6_rolling) calculateNodeRewards() (*big.Int, *big.In
 
 	// If there weren't any successful attestations, everything goes to the pool stakers
 	if totalScore.Cmp(r.zero) == 0 || attestationCount == 0 {
-		r.log.Printlnf("WARNING: Total attestation score = %s, successful attestations = %d... sending the whole smoothing pool balance to the pool stakers.", totalScore.String(), attestationCount)
+		r.log.Warnf("Total attestation score = %s, successful attestations = %d... sending the whole smoothing pool balance to the pool stakers.", totalScore.String(), attestationCount)
 		return r.smoothingPoolBalance, big.NewInt(0), nil
 	}
 
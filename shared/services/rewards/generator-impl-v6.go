@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"math/big"
 	"sort"
 	"time"
@@ -54,6 +55,7 @@ type treeGeneratorImpl_v6 struct {
 	successfulAttestations uint64
 	zero                   *big.Int
 	genesisTime            time.Time
+	projectedParticipation map[common.Address]float64
 }
 
 // Create a new tree generator
@@ -168,6 +170,57 @@ func (r *treeGeneratorImpl_v6) generateTree(rp *rocketpool.RocketPool, cfg *conf
 
 }
 
+// Calculates the rewards for an interval that's still in progress, using the snapshot as the end of the interval
+func (r *treeGeneratorImpl_v6) projectTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, participation map[common.Address]float64) (*RewardsFile, error) {
+
+	r.log.Printlnf("%s Projecting tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
+
+	// Provision some struct params
+	r.rp = rp
+	r.cfg = cfg
+	r.bc = bc
+	r.validNetworkCache = map[uint64]bool{
+		0: true,
+	}
+
+	// Set the network name
+	r.rewardsFile.Network = fmt.Sprint(cfg.Smartnode.Network.Value)
+	r.rewardsFile.MinipoolPerformanceFile.Network = r.rewardsFile.Network
+
+	// Get the Beacon config
+	r.beaconConfig = r.networkState.BeaconConfig
+	r.slotsPerEpoch = r.beaconConfig.SlotsPerEpoch
+	r.genesisTime = time.Unix(int64(r.beaconConfig.GenesisTime), 0)
+
+	// Set the EL client call opts
+	r.opts = &bind.CallOpts{
+		BlockNumber: r.elSnapshotHeader.Number,
+	}
+
+	// Get the minipool count - this will be used for an error epsilon due to division truncation
+	minipoolCount := uint64(len(r.networkState.MinipoolDetails))
+	r.epsilon = big.NewInt(int64(minipoolCount))
+
+	// Calculate the RPL rewards
+	err := r.calculateRplRewards()
+	if err != nil {
+		return nil, fmt.Errorf("Error calculating RPL rewards: %w", err)
+	}
+
+	// Calculate the ETH rewards from the known participation rates, since processing the whole interval's duties would take too long
+	r.projectedParticipation = participation
+	err = r.calculateEthRewards(false)
+	if err != nil {
+		return nil, fmt.Errorf("Error calculating ETH rewards: %w", err)
+	}
+
+	// Calculate the network reward map and the totals
+	r.updateNetworksAndTotals()
+
+	return r.rewardsFile, nil
+
+}
+
 // Quickly calculates an approximate of the staker's share of the smoothing pool balance without processing Beacon performance
 // Used for approximate returns in the rETH ratio update
 func (r *treeGeneratorImpl_v6) approximateStakerShareOfSmoothingPool(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error) {
@@ -545,32 +598,8 @@ func (r *treeGeneratorImpl_v6) calculateEthRewards(checkBeaconPerformance bool)
 			return err
 		}
 	} else {
-		// Attestation processing is disabled, just give each minipool 1 good attestation and complete slot activity so they're all scored the same
-		// Used for approximating rETH's share during balances calculation
-		one := eth.EthToWei(1)
-		validatorReq := eth.EthToWei(32)
-		for _, nodeInfo := range r.nodeDetails {
-			// Check if the node is currently opted in for simplicity
-			if nodeInfo.IsEligible && nodeInfo.IsOptedIn && r.elEndTime.Sub(nodeInfo.OptInTime) > 0 {
-				for _, minipool := range nodeInfo.Minipools {
-					minipool.CompletedAttestations = map[uint64]bool{0: true}
-
-					// Make up an attestation
-					details := r.networkState.MinipoolDetailsByAddress[minipool.Address]
-					bond, fee := r.getMinipoolBondAndNodeFee(details, r.elEndTime)
-					minipoolScore := big.NewInt(0).Sub(one, fee)   // 1 - fee
-					minipoolScore.Mul(minipoolScore, bond)         // Multiply by bond
-					minipoolScore.Div(minipoolScore, validatorReq) // Divide by 32 to get the bond as a fraction of a total validator
-					minipoolScore.Add(minipoolScore, fee)          // Total = fee + (bond/32)(1 - fee)
-
-					// Add it to the minipool's score and the total score
-					minipool.AttestationScore.Add(minipool.AttestationScore, minipoolScore)
-					r.totalAttestationScore.Add(r.totalAttestationScore, minipoolScore)
-
-					r.successfulAttestations++
-				}
-			}
-		}
+		// Attestation processing is disabled, so make up the attestation scores instead
+		r.approximateAttestationScores()
 	}
 
 	// Determine how much ETH each node gets and how much the pool stakers get
@@ -650,12 +679,60 @@ func (r *treeGeneratorImpl_v6) calculateEthRewards(checkBeaconPerformance bool)
 
 }
 
+// Give each eligible minipool a made-up attestation score without processing the interval's duties.
+// Minipools are scored as if they had attested perfectly unless their participation rate is known, in which case
+// they're scored as if they had completed that fraction of their duties.
+func (r *treeGeneratorImpl_v6) approximateAttestationScores() {
+	one := eth.EthToWei(1)
+	validatorReq := eth.EthToWei(32)
+
+	// Scale the attestations up when projecting so partial participation doesn't get rounded away
+	attestationsPerMinipool := uint64(1)
+	if r.projectedParticipation != nil {
+		attestationsPerMinipool = projectedAttestationsPerMinipool
+	}
+
+	for _, nodeInfo := range r.nodeDetails {
+		// Check if the node is currently opted in for simplicity
+		if nodeInfo.IsEligible && nodeInfo.IsOptedIn && r.elEndTime.Sub(nodeInfo.OptInTime) > 0 {
+			for _, minipool := range nodeInfo.Minipools {
+				attestations := attestationsPerMinipool
+				rate, exists := r.projectedParticipation[minipool.Address]
+				if exists {
+					rate = math.Max(0, math.Min(1, rate))
+					attestations = uint64(math.Round(rate * float64(attestationsPerMinipool)))
+				}
+				if attestations == 0 {
+					// The minipool didn't attest at all, so it doesn't get a share
+					continue
+				}
+				minipool.CompletedAttestations = map[uint64]bool{0: true}
+
+				// Make up the attestations
+				details := r.networkState.MinipoolDetailsByAddress[minipool.Address]
+				bond, fee := r.getMinipoolBondAndNodeFee(details, r.elEndTime)
+				minipoolScore := big.NewInt(0).Sub(one, fee)   // 1 - fee
+				minipoolScore.Mul(minipoolScore, bond)         // Multiply by bond
+				minipoolScore.Div(minipoolScore, validatorReq) // Divide by 32 to get the bond as a fraction of a total validator
+				minipoolScore.Add(minipoolScore, fee)          // Total = fee + (bond/32)(1 - fee)
+				minipoolScore.Mul(minipoolScore, big.NewInt(int64(attestations)))
+
+				// Add it to the minipool's score and the total score
+				minipool.AttestationScore.Add(minipool.AttestationScore, minipoolScore)
+				r.totalAttestationScore.Add(r.totalAttestationScore, minipoolScore)
+
+				r.successfulAttestations += attestations
+			}
+		}
+	}
+}
+
 // Calculate the distribution of Smoothing Pool ETH to each node
 func (r *treeGeneratorImpl_v6) calculateNodeRewards() (*big.Int, *big.Int, error) {
 
 	// If there weren't any successful attestations, everything goes to the pool stakers
 	if r.totalAttestationScore.Cmp(r.zero) == 0 || r.successfulAttestations == 0 {
-		r.log.Printlnf("WARNING: Total attestation score = %s, successful attestations = %d... sending the whole smoothing pool balance to the pool stakers.", r.totalAttestationScore.String(), r.successfulAttestations)
+		r.log.Warnf("Total attestation score = %s, successful attestations = %d... sending the whole smoothing pool balance to the pool stakers.", r.totalAttestationScore.String(), r.successfulAttestations)
 		return r.smoothingPoolBalance, big.NewInt(0), nil
 	}
 
@@ -0,0 +1,105 @@
+package rewards
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Create a v6 generator with an opted-in node that has the provided 8 ETH minipools
+func getApproximationTestGenerator(t *testing.T, addresses ...common.Address) *treeGeneratorImpl_v6 {
+	logger := log.NewColorLogger(0)
+	networkState := &state.NetworkState{
+		MinipoolDetailsByAddress: map[common.Address]*rpstate.NativeMinipoolDetails{},
+	}
+	node := &NodeSmoothingDetails{
+		IsEligible: true,
+		IsOptedIn:  true,
+		OptInTime:  time.Unix(0, 0),
+	}
+	for _, address := range addresses {
+		networkState.MinipoolDetailsByAddress[address] = &rpstate.NativeMinipoolDetails{
+			MinipoolAddress:       address,
+			NodeDepositBalance:    eth.EthToWei(8),
+			NodeFee:               eth.EthToWei(0.14),
+			LastBondReductionTime: big.NewInt(0),
+		}
+		node.Minipools = append(node.Minipools, &MinipoolInfo{
+			Address:                 address,
+			WasActive:               true,
+			AttestationScore:        big.NewInt(0),
+			MissingAttestationSlots: map[uint64]bool{},
+			CompletedAttestations:   map[uint64]bool{},
+		})
+	}
+
+	r := newTreeGeneratorImpl_v6(&logger, "[Test]", 1, time.Unix(0, 0), time.Unix(1000, 0), 100, &types.Header{Number: big.NewInt(100)}, 1, networkState)
+	r.nodeDetails = []*NodeSmoothingDetails{node}
+	r.elEndTime = time.Unix(1000, 0)
+	r.smoothingPoolBalance = eth.EthToWei(10)
+	r.epsilon = big.NewInt(int64(len(addresses)))
+	return r
+}
+
+func TestApproximateScoresWithoutParticipation(t *testing.T) {
+	first := common.HexToAddress("0x01")
+	second := common.HexToAddress("0x02")
+	r := getApproximationTestGenerator(t, first, second)
+
+	r.approximateAttestationScores()
+	minipools := r.nodeDetails[0].Minipools
+	if minipools[0].AttestationScore.Sign() == 0 || minipools[0].AttestationScore.Cmp(minipools[1].AttestationScore) != 0 {
+		t.Fatalf("expected equal scores, got %s and %s", minipools[0].AttestationScore, minipools[1].AttestationScore)
+	}
+	if r.successfulAttestations != 2 {
+		t.Fatalf("expected 2 attestations, got %d", r.successfulAttestations)
+	}
+}
+
+func TestApproximateScoresWithParticipation(t *testing.T) {
+	perfect := common.HexToAddress("0x01")
+	half := common.HexToAddress("0x02")
+	offline := common.HexToAddress("0x03")
+	untracked := common.HexToAddress("0x04")
+	r := getApproximationTestGenerator(t, perfect, half, offline, untracked)
+	r.projectedParticipation = map[common.Address]float64{
+		perfect: 1,
+		half:    0.5,
+		offline: 0,
+	}
+
+	r.approximateAttestationScores()
+	_, _, err := r.calculateNodeRewards()
+	if err != nil {
+		t.Fatal(err)
+	}
+	minipools := r.nodeDetails[0].Minipools
+
+	// Scores scale with participation, and minipools without a known rate are scored as perfect
+	halfScore := big.NewInt(0).Mul(minipools[1].AttestationScore, big.NewInt(2))
+	if halfScore.Cmp(minipools[0].AttestationScore) != 0 {
+		t.Fatalf("expected half of %s, got %s", minipools[0].AttestationScore, minipools[1].AttestationScore)
+	}
+	if minipools[2].AttestationScore.Sign() != 0 || minipools[2].MinipoolShare.Sign() != 0 {
+		t.Fatalf("expected no score or share for an offline minipool, got %s and %s", minipools[2].AttestationScore, minipools[2].MinipoolShare)
+	}
+	if minipools[3].AttestationScore.Cmp(minipools[0].AttestationScore) != 0 {
+		t.Fatalf("expected the untracked minipool to score %s, got %s", minipools[0].AttestationScore, minipools[3].AttestationScore)
+	}
+
+	// The shares follow the scores
+	if minipools[1].MinipoolShare.Cmp(minipools[0].MinipoolShare) >= 0 {
+		t.Fatalf("expected the half minipool to earn less than %s, got %s", minipools[0].MinipoolShare, minipools[1].MinipoolShare)
+	}
+	if minipools[3].MinipoolShare.Cmp(minipools[0].MinipoolShare) != 0 {
+		t.Fatalf("expected the untracked minipool to earn %s, got %s", minipools[0].MinipoolShare, minipools[3].MinipoolShare)
+	}
+}
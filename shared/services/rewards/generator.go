@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
@@ -19,6 +20,9 @@ const (
 	SmoothingPoolDetailsBatchSize uint64 = 8
 	TestingInterval               uint64 = 1000000000 // A large number that won't ever actually be hit
 
+	// The number of attestations each minipool is credited with when projecting rewards from participation rates
+	projectedAttestationsPerMinipool uint64 = 1000
+
 	// Mainnet intervals
 	MainnetV2Interval uint64 = 4
 	MainnetV3Interval uint64 = 5
@@ -57,6 +61,11 @@ type treeGeneratorImpl interface {
 	getRulesetVersion() uint64
 }
 
+// Implemented by rulesets that can project the rewards for an interval that's still in progress
+type treeProjectorImpl interface {
+	projectTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, participation map[common.Address]float64) (*RewardsFile, error)
+}
+
 func NewTreeGenerator(logger *log.ColorLogger, logPrefix string, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, index uint64, startTime time.Time, endTime time.Time, consensusBlock uint64, elSnapshotHeader *types.Header, intervalsPassed uint64, state *state.NetworkState, rollingRecord *RollingRecord) (*TreeGenerator, error) {
 	t := &TreeGenerator{
 		logger:           logger,
@@ -168,6 +177,17 @@ func (t *TreeGenerator) GenerateTree() (*RewardsFile, error) {
 	return t.generatorImpl.generateTree(t.rp, t.cfg, t.bc)
 }
 
+// Calculates the rewards each node would get if the interval ended at the snapshot, without building the Merkle tree.
+// Participation holds the known attestation participation rates of minipools, by address; without a rolling record,
+// minipools that aren't in it are assumed to have attested perfectly.
+func (t *TreeGenerator) ProjectTree(participation map[common.Address]float64) (*RewardsFile, error) {
+	projector, ok := t.generatorImpl.(treeProjectorImpl)
+	if !ok {
+		return nil, fmt.Errorf("ruleset v%d does not support rewards projections", t.generatorImpl.getRulesetVersion())
+	}
+	return projector.projectTree(t.rp, t.cfg, t.bc, participation)
+}
+
 func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPool() (*big.Int, error) {
 	return t.approximatorImpl.approximateStakerShareOfSmoothingPool(t.rp, t.cfg, t.bc)
 }
@@ -0,0 +1,187 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/replay"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Filenames for the metadata and expected outputs inside a tree generation fixture
+const (
+	FixtureInfoFilename                    string = "fixture.json"
+	FixtureRewardsFilename                 string = "rewards.json"
+	FixtureMinipoolPerformanceFilename     string = "minipool-performance.json"
+	fixtureInfoVersion                     uint64 = 1
+	fixtureMinipoolPerformanceFileCidValue string = "---"
+)
+
+// Metadata about a recorded tree generation run
+type TreeGenerationFixtureInfo struct {
+	Version        uint64           `json:"version"`
+	Network        cfgtypes.Network `json:"network"`
+	Index          uint64           `json:"index"`
+	RulesetVersion uint64           `json:"rulesetVersion"`
+	MerkleRoot     common.Hash      `json:"merkleRoot"`
+	CanonicalRoot  common.Hash      `json:"canonicalRoot"`
+
+	// Set on fixtures that were written by hand instead of recorded; they have no client responses, so they can't be replayed
+	Synthetic bool `json:"synthetic,omitempty"`
+}
+
+// A recorded tree generation run that can be replayed offline
+type TreeGenerationFixture struct {
+	Info                         TreeGenerationFixtureInfo
+	Recording                    *replay.Recording
+	RewardsFileBytes             []byte
+	MinipoolPerformanceFileBytes []byte
+}
+
+// Serializes the rewards file and its minipool performance file the same way the watchtower saves them
+func SerializeRewardsFile(rewardsFile *RewardsFile) ([]byte, []byte, error) {
+	minipoolPerformanceBytes, err := json.Marshal(rewardsFile.MinipoolPerformanceFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error serializing minipool performance file into JSON: %w", err)
+	}
+	wrapperBytes, err := json.Marshal(rewardsFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error serializing proof wrapper into JSON: %w", err)
+	}
+	return wrapperBytes, minipoolPerformanceBytes, nil
+}
+
+// Saves a recorded tree generation run and the files it produced to the provided directory
+func SaveTreeGenerationFixture(dir string, network cfgtypes.Network, recording *replay.Recording, rewardsFile *RewardsFile, canonicalRoot common.Hash) error {
+	err := recording.Save(dir)
+	if err != nil {
+		return err
+	}
+
+	info := TreeGenerationFixtureInfo{
+		Version:        fixtureInfoVersion,
+		Network:        network,
+		Index:          rewardsFile.Index,
+		RulesetVersion: rewardsFile.RulesetVersion,
+		MerkleRoot:     common.BytesToHash(rewardsFile.MerkleTree.Root()),
+		CanonicalRoot:  canonicalRoot,
+	}
+	infoBytes, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("error serializing fixture info: %w", err)
+	}
+
+	rewardsFile.MinipoolPerformanceFileCID = fixtureMinipoolPerformanceFileCidValue
+	wrapperBytes, minipoolPerformanceBytes, err := SerializeRewardsFile(rewardsFile)
+	if err != nil {
+		return err
+	}
+
+	files := map[string][]byte{
+		FixtureInfoFilename:                infoBytes,
+		FixtureRewardsFilename:             wrapperBytes,
+		FixtureMinipoolPerformanceFilename: minipoolPerformanceBytes,
+	}
+	for filename, bytes := range files {
+		path := filepath.Join(dir, filename)
+		err = os.WriteFile(path, bytes, 0644)
+		if err != nil {
+			return fmt.Errorf("error writing fixture file [%s]: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// Loads a recorded tree generation run from the provided directory
+func LoadTreeGenerationFixture(dir string) (*TreeGenerationFixture, error) {
+	fixture := &TreeGenerationFixture{}
+
+	infoPath := filepath.Join(dir, FixtureInfoFilename)
+	infoBytes, err := os.ReadFile(infoPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture info [%s]: %w", infoPath, err)
+	}
+	err = json.Unmarshal(infoBytes, &fixture.Info)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing fixture info [%s]: %w", infoPath, err)
+	}
+	if fixture.Info.Version != fixtureInfoVersion {
+		return nil, fmt.Errorf("fixture [%s] has version %d but only version %d is supported", dir, fixture.Info.Version, fixtureInfoVersion)
+	}
+
+	fixture.RewardsFileBytes, err = os.ReadFile(filepath.Join(dir, FixtureRewardsFilename))
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture rewards file: %w", err)
+	}
+	fixture.MinipoolPerformanceFileBytes, err = os.ReadFile(filepath.Join(dir, FixtureMinipoolPerformanceFilename))
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture minipool performance file: %w", err)
+	}
+
+	if !fixture.Info.Synthetic {
+		fixture.Recording, err = replay.LoadRecording(dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return fixture, nil
+}
+
+// Regenerates the rewards tree for the fixture's interval using only its recorded client responses
+func (f *TreeGenerationFixture) Replay(logger *log.ColorLogger) (*RewardsFile, error) {
+	if f.Info.Synthetic {
+		return nil, fmt.Errorf("fixture for interval %d is synthetic and has no recording to replay", f.Info.Index)
+	}
+	cfg := config.NewRocketPoolConfig("", false)
+	cfg.ChangeNetwork(f.Info.Network)
+
+	ec := replay.NewReplayExecutionClient(f.Recording)
+	bc := replay.NewReplayBeaconClient(f.Recording)
+	rp, err := rocketpool.NewRocketPool(ec, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+	if err != nil {
+		return nil, fmt.Errorf("error creating Rocket Pool binding: %w", err)
+	}
+
+	// Get the interval's event and snapshot block
+	rewardsEvent, err := GetRewardSnapshotEvent(rp, cfg, f.Info.Index, nil)
+	if err != nil {
+		return nil, err
+	}
+	elBlockHeader, err := ec.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting execution block: %w", err)
+	}
+
+	// Get the network state at the snapshot
+	m, err := state.NewNetworkStateManager(rp, cfg, ec, bc, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, err := m.GetFullStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("error getting state for beacon slot %d: %w", rewardsEvent.ConsensusBlock.Uint64(), err)
+	}
+
+	// Generate the tree
+	generationPrefix := fmt.Sprintf("[Interval %d Replay]", f.Info.Index)
+	treegen, err := NewTreeGenerator(logger, generationPrefix, rp, cfg, bc, f.Info.Index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), networkState, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Merkle tree generator: %w", err)
+	}
+	rewardsFile, err := treegen.GenerateTree()
+	if err != nil {
+		return nil, fmt.Errorf("error generating Merkle tree: %w", err)
+	}
+	rewardsFile.MinipoolPerformanceFileCID = fixtureMinipoolPerformanceFileCidValue
+	return rewardsFile, nil
+}
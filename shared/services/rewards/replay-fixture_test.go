@@ -0,0 +1,166 @@
+package rewards
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/replay"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Environment variable pointing to an extra directory of recorded tree generation fixtures, for intervals that are too large to
+// keep in the repository. Fixtures are recorded with `rocketpool network generate-rewards-tree --record-fixture`.
+const fixturesDirEnvVar string = "RP_REWARDS_FIXTURES_DIR"
+
+// Directory of the fixtures kept in the repository; every recorded fixture here is replayed by the regular test run
+var repoFixturesDir string = filepath.Join("testdata", "fixtures")
+
+// Get the fixture directories inside the provided directory
+func getFixturePaths(t *testing.T, dir string) map[string]string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("error reading fixtures directory %s: %v", dir, err)
+	}
+	paths := map[string]string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			paths[entry.Name()] = filepath.Join(dir, entry.Name())
+		}
+	}
+	return paths
+}
+
+// Get the fixtures kept in the repository and the ones in the fixtures directory from the environment, if it's set
+func getAllFixturePaths(t *testing.T) map[string]string {
+	paths := getFixturePaths(t, repoFixturesDir)
+	if len(paths) == 0 {
+		t.Fatalf("no fixtures found in %s", repoFixturesDir)
+	}
+	if dir := os.Getenv(fixturesDirEnvVar); dir != "" {
+		for name, path := range getFixturePaths(t, dir) {
+			paths[name] = path
+		}
+	}
+	return paths
+}
+
+func TestTreeGenerationFixtureFiles(t *testing.T) {
+	for name, fixturePath := range getAllFixturePaths(t) {
+		fixturePath := fixturePath
+		t.Run(name, func(t *testing.T) {
+			fixture, err := LoadTreeGenerationFixture(fixturePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rewardsFile := &RewardsFile{}
+			if err := json.Unmarshal(fixture.RewardsFileBytes, rewardsFile); err != nil {
+				t.Fatalf("error deserializing rewards file: %v", err)
+			}
+			if err := json.Unmarshal(fixture.MinipoolPerformanceFileBytes, &rewardsFile.MinipoolPerformanceFile); err != nil {
+				t.Fatalf("error deserializing minipool performance file: %v", err)
+			}
+			if rewardsFile.Index != fixture.Info.Index || rewardsFile.RulesetVersion != fixture.Info.RulesetVersion {
+				t.Fatalf("rewards file is for interval %d (ruleset v%d) but the fixture is for interval %d (ruleset v%d)", rewardsFile.Index, rewardsFile.RulesetVersion, fixture.Info.Index, fixture.Info.RulesetVersion)
+			}
+			if common.HexToHash(rewardsFile.MerkleRoot) != fixture.Info.MerkleRoot {
+				t.Fatalf("rewards file has Merkle root %s but the fixture recorded %s", rewardsFile.MerkleRoot, fixture.Info.MerkleRoot.Hex())
+			}
+
+			// The recorded root must be rebuildable from the node entries, and every proof must check out against the canonical root
+			event := rewards.RewardsEvent{
+				Index:           big.NewInt(0).SetUint64(fixture.Info.Index),
+				ExecutionBlock:  big.NewInt(0).SetUint64(rewardsFile.ExecutionEndBlock),
+				ConsensusBlock:  big.NewInt(0).SetUint64(rewardsFile.ConsensusEndBlock),
+				MerkleRoot:      fixture.Info.CanonicalRoot,
+				IntervalsPassed: big.NewInt(0).SetUint64(rewardsFile.IntervalsPassed),
+				TreasuryRPL:     &rewardsFile.TotalRewards.ProtocolDaoRpl.Int,
+				UserETH:         &rewardsFile.TotalRewards.PoolStakerSmoothingPoolEth.Int,
+			}
+			verification, err := VerifyRewardsFile(rewardsFile, event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !verification.Valid {
+				t.Fatalf("fixture rewards file is invalid: %+v %+v", verification.Discrepancies, verification.NodeDiscrepancies)
+			}
+
+			// Replays compare the files byte-for-byte, so serialization has to round-trip exactly
+			wrapperBytes, minipoolPerformanceBytes, err := SerializeRewardsFile(rewardsFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(wrapperBytes, fixture.RewardsFileBytes) {
+				t.Errorf("rewards file doesn't round-trip byte-for-byte")
+			}
+			if !bytes.Equal(minipoolPerformanceBytes, fixture.MinipoolPerformanceFileBytes) {
+				t.Errorf("minipool performance file doesn't round-trip byte-for-byte")
+			}
+
+			// Every replay starts by getting the snapshot block, so the recording must have it
+			if fixture.Info.Synthetic {
+				// Hand-written fixtures don't have a recording
+				return
+			}
+			ec := replay.NewReplayExecutionClient(fixture.Recording)
+			header, err := ec.HeaderByNumber(context.Background(), event.ExecutionBlock)
+			if err != nil {
+				t.Fatalf("recording doesn't have the snapshot block: %v", err)
+			}
+			if header.Number.Cmp(event.ExecutionBlock) != 0 {
+				t.Errorf("recorded snapshot block is %s but the rewards file ends at %s", header.Number, event.ExecutionBlock)
+			}
+		})
+	}
+}
+
+func TestReplayTreeGeneration(t *testing.T) {
+	for name, fixturePath := range getAllFixturePaths(t) {
+		fixturePath := fixturePath
+		t.Run(name, func(t *testing.T) {
+			fixture, err := LoadTreeGenerationFixture(fixturePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fixture.Info.Synthetic {
+				t.Skipf("fixture is synthetic and has no recording to replay")
+			}
+
+			logger := log.NewColorLogger(color.FgWhite)
+			rewardsFile, err := fixture.Replay(&logger)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rewardsFile.RulesetVersion != fixture.Info.RulesetVersion {
+				t.Errorf("generated with ruleset v%d but the fixture was recorded with v%d", rewardsFile.RulesetVersion, fixture.Info.RulesetVersion)
+			}
+			root := common.BytesToHash(rewardsFile.MerkleTree.Root())
+			if root != fixture.Info.MerkleRoot {
+				t.Errorf("Merkle root %s doesn't match the recorded root %s", root.Hex(), fixture.Info.MerkleRoot.Hex())
+			}
+			if root != fixture.Info.CanonicalRoot {
+				t.Errorf("Merkle root %s doesn't match the canonical root %s", root.Hex(), fixture.Info.CanonicalRoot.Hex())
+			}
+
+			wrapperBytes, minipoolPerformanceBytes, err := SerializeRewardsFile(rewardsFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(minipoolPerformanceBytes, fixture.MinipoolPerformanceFileBytes) {
+				t.Errorf("minipool performance file doesn't match the recorded file byte-for-byte")
+			}
+			if !bytes.Equal(wrapperBytes, fixture.RewardsFileBytes) {
+				t.Errorf("rewards file doesn't match the recorded file byte-for-byte")
+			}
+		})
+	}
+}
@@ -285,7 +285,7 @@ func (r *RollingRecordManager) LoadBestRecordFromDisk(startSlot uint64, targetSl
 		fullFilename := filepath.Join(recordsPath, filename)
 		record, err := r.loadRecordFromFile(fullFilename, checksum)
 		if err != nil {
-			r.log.Printlnf("%s WARNING: error loading record from file [%s]: %s... attempting previous file", r.logPrefix, fullFilename, err.Error())
+			r.log.Warnf("%s error loading record from file [%s]: %s... attempting previous file", r.logPrefix, fullFilename, err.Error())
 			continue
 		}
 
@@ -318,7 +318,7 @@ func (r *RollingRecordManager) UpdateRecordToState(state *state.NetworkState, la
 	err := r.updateImpl(state, latestFinalizedSlot)
 	if err != nil {
 		// Revert to the latest saved state
-		r.log.Printlnf("%s WARNING: failed to update rolling record to slot %d, block %d: %s", r.logPrefix, state.BeaconSlotNumber, state.ElBlockNumber, err.Error())
+		r.log.Warnf("%s failed to update rolling record to slot %d, block %d: %s", r.logPrefix, state.BeaconSlotNumber, state.ElBlockNumber, err.Error())
 		r.log.Printlnf("%s Reverting to the last saved checkpoint to prevent corruption...", r.logPrefix)
 		_, err2 := r.LoadBestRecordFromDisk(r.startSlot, latestFinalizedSlot, r.Record.RewardsInterval)
 		if err2 != nil {
@@ -351,7 +351,7 @@ func (r *RollingRecordManager) updateImpl(state *state.NetworkState, latestFinal
 	finalTarget := latestFinalizedSlot
 	finalizedState := state
 	if finalTarget != state.BeaconSlotNumber {
-		finalizedState, err = r.mgr.GetStateForSlot(finalTarget)
+		finalizedState, err = r.mgr.GetFullStateForSlot(finalTarget)
 		if err != nil {
 			return fmt.Errorf("error getting state for latest finalized slot (%d): %w", finalTarget, err)
 		}
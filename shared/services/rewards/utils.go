@@ -29,6 +29,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/web3-storage/go-w3s-client/adder"
@@ -92,17 +93,11 @@ func GetClaimStatus(rp *rocketpool.RocketPool, nodeAddress common.Address) (uncl
 // Gets the information for an interval including the file status, the validity, and the node's rewards
 func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, interval uint64, opts *bind.CallOpts) (info IntervalInfo, err error) {
 	info.Index = interval
-	var event rewards.RewardsEvent
 
-	if cfg.Smartnode.Network.Value.(cfgtypes.Network) == cfgtypes.Network_Prater && interval < 6 {
-		// Use the hardcoded prehistoric lookup for early Prater intervals
-		event = praterPrehistoryIntervalEvents[interval]
-	} else {
-		// Get the event details for this interval
-		event, err = GetRewardSnapshotEvent(rp, cfg, interval, opts)
-		if err != nil {
-			return
-		}
+	// Get the event details for this interval
+	event, err := GetIntervalEvent(rp, cfg, interval, opts)
+	if err != nil {
+		return
 	}
 
 	info.CID = event.MerkleTreeCID
@@ -161,6 +156,15 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	return
 }
 
+// Get the event for a rewards interval, including the early Prater intervals that were submitted before the event was emitted
+func GetIntervalEvent(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, interval uint64, opts *bind.CallOpts) (rewards.RewardsEvent, error) {
+	if cfg.Smartnode.Network.Value.(cfgtypes.Network) == cfgtypes.Network_Prater && interval < 6 {
+		// Use the hardcoded prehistoric lookup for early Prater intervals
+		return praterPrehistoryIntervalEvents[interval], nil
+	}
+	return GetRewardSnapshotEvent(rp, cfg, interval, opts)
+}
+
 // Get the event for a rewards snapshot
 func GetRewardSnapshotEvent(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, interval uint64, opts *bind.CallOpts) (rewards.RewardsEvent, error) {
 
@@ -403,3 +407,34 @@ func getMinipoolBondAndNodeFee(details *rpstate.NativeMinipoolDetails, blockTime
 
 	return currentBond, currentFee
 }
+
+// Gets the start slot for the given interval
+func GetStartSlotForInterval(previousIntervalEvent rewards.RewardsEvent, bc beacon.Client, beaconConfig beacon.Eth2Config) (uint64, error) {
+	// Sanity check to confirm the BN can access the block from the previous interval
+	_, exists, err := bc.GetBeaconBlock(previousIntervalEvent.ConsensusBlock.String())
+	if err != nil {
+		return 0, fmt.Errorf("error verifying block from previous interval: %w", err)
+	}
+	if !exists {
+		return 0, fmt.Errorf("couldn't retrieve CL block from previous interval (slot %d); this likely means you checkpoint sync'd your Beacon Node and it has not backfilled to the previous interval yet so it cannot be used for tree generation", previousIntervalEvent.ConsensusBlock.Uint64())
+	}
+
+	previousEpoch := previousIntervalEvent.ConsensusBlock.Uint64() / beaconConfig.SlotsPerEpoch
+	nextEpoch := previousEpoch + 1
+	consensusStartBlock := nextEpoch * beaconConfig.SlotsPerEpoch
+
+	// Get the first block that isn't missing
+	for {
+		_, exists, err := bc.GetBeaconBlock(fmt.Sprint(consensusStartBlock))
+		if err != nil {
+			return 0, fmt.Errorf("error getting EL data for BC slot %d: %w", consensusStartBlock, err)
+		}
+		if !exists {
+			consensusStartBlock++
+		} else {
+			break
+		}
+	}
+
+	return consensusStartBlock, nil
+}
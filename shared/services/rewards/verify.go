@@ -0,0 +1,248 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/wealdtech/go-merkletree"
+	"github.com/wealdtech/go-merkletree/keccak256"
+)
+
+// A value in a rewards file that doesn't match what it should be
+type RewardsTreeDiscrepancy struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// A node whose entry in a rewards file can't be used to claim its rewards
+type NodeRewardsDiscrepancy struct {
+	Address common.Address `json:"address"`
+	Reason  string         `json:"reason"`
+}
+
+// The result of checking a rewards file against the rewards event that was submitted for its interval
+type RewardsTreeVerification struct {
+	Index               uint64                   `json:"index"`
+	Valid               bool                     `json:"valid"`
+	FileMerkleRoot      common.Hash              `json:"fileMerkleRoot"`
+	ComputedMerkleRoot  common.Hash              `json:"computedMerkleRoot"`
+	CanonicalMerkleRoot common.Hash              `json:"canonicalMerkleRoot"`
+	NodeCount           int                      `json:"nodeCount"`
+	ValidProofCount     int                      `json:"validProofCount"`
+	Discrepancies       []RewardsTreeDiscrepancy `json:"discrepancies"`
+	NodeDiscrepancies   []NodeRewardsDiscrepancy `json:"nodeDiscrepancies"`
+}
+
+// Rebuilds the Merkle tree of a rewards file from its node entries, checks every node's proof, and compares the file's totals
+// with the rewards event that was submitted for the interval
+func VerifyRewardsFile(rewardsFile *RewardsFile, event rewards.RewardsEvent) (*RewardsTreeVerification, error) {
+	verification := &RewardsTreeVerification{
+		Index:               rewardsFile.Index,
+		FileMerkleRoot:      common.HexToHash(rewardsFile.MerkleRoot),
+		CanonicalMerkleRoot: event.MerkleRoot,
+		Discrepancies:       []RewardsTreeDiscrepancy{},
+		NodeDiscrepancies:   []NodeRewardsDiscrepancy{},
+	}
+
+	// Get the nodes in a consistent order so the report is deterministic
+	addresses := make([]common.Address, 0, len(rewardsFile.NodeRewards))
+	for address := range rewardsFile.NodeRewards {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+
+	// Rebuild the leaves the same way the generator does, summing each network's rewards along the way
+	leaves := map[common.Address][]byte{}
+	totalData := make([][]byte, 0, len(addresses))
+	networkTotals := map[uint64]*NetworkRewardsInfo{}
+	for _, address := range addresses {
+		rewardsForNode := rewardsFile.NodeRewards[address]
+		collateralRpl := getQuotedBigIntValue(rewardsForNode.CollateralRpl)
+		oracleDaoRpl := getQuotedBigIntValue(rewardsForNode.OracleDaoRpl)
+		smoothingPoolEth := getQuotedBigIntValue(rewardsForNode.SmoothingPoolEth)
+
+		networkTotal, exists := networkTotals[rewardsForNode.RewardNetwork]
+		if !exists {
+			networkTotal = &NetworkRewardsInfo{
+				CollateralRpl:    NewQuotedBigInt(0),
+				OracleDaoRpl:     NewQuotedBigInt(0),
+				SmoothingPoolEth: NewQuotedBigInt(0),
+			}
+			networkTotals[rewardsForNode.RewardNetwork] = networkTotal
+		}
+		networkTotal.CollateralRpl.Add(&networkTotal.CollateralRpl.Int, collateralRpl)
+		networkTotal.OracleDaoRpl.Add(&networkTotal.OracleDaoRpl.Int, oracleDaoRpl)
+		networkTotal.SmoothingPoolEth.Add(&networkTotal.SmoothingPoolEth.Int, smoothingPoolEth)
+
+		// Ignore nodes that didn't receive any rewards
+		if collateralRpl.Sign() == 0 && oracleDaoRpl.Sign() == 0 && smoothingPoolEth.Sign() == 0 {
+			continue
+		}
+		rplRewards := big.NewInt(0).Add(collateralRpl, oracleDaoRpl)
+		nodeData := getMerkleLeafData(address, rewardsForNode.RewardNetwork, rplRewards, smoothingPoolEth)
+		leaves[address] = nodeData
+		totalData = append(totalData, nodeData)
+	}
+	verification.NodeCount = len(totalData)
+	if len(totalData) == 0 {
+		return nil, fmt.Errorf("rewards file for interval %d doesn't have any nodes with rewards", rewardsFile.Index)
+	}
+
+	// Recompute the Merkle root
+	tree, err := merkletree.NewUsing(totalData, keccak256.New(), false, true)
+	if err != nil {
+		return nil, fmt.Errorf("error generating Merkle Tree: %w", err)
+	}
+	verification.ComputedMerkleRoot = common.BytesToHash(tree.Root())
+	if verification.ComputedMerkleRoot != verification.FileMerkleRoot {
+		verification.addDiscrepancy("merkleRoot", verification.ComputedMerkleRoot.Hex(), verification.FileMerkleRoot.Hex())
+	}
+	if verification.ComputedMerkleRoot != verification.CanonicalMerkleRoot {
+		verification.addDiscrepancy("canonicalMerkleRoot", verification.CanonicalMerkleRoot.Hex(), verification.ComputedMerkleRoot.Hex())
+	}
+
+	// Check each node's proof against the canonical root, since that's what claims are checked against
+	for _, address := range addresses {
+		leaf, exists := leaves[address]
+		if !exists {
+			continue
+		}
+		proof, err := rewardsFile.NodeRewards[address].GetMerkleProof()
+		if err != nil {
+			return nil, fmt.Errorf("error deserializing Merkle proof for node %s: %w", address.Hex(), err)
+		}
+		if len(proof) == 0 {
+			verification.NodeDiscrepancies = append(verification.NodeDiscrepancies, NodeRewardsDiscrepancy{
+				Address: address,
+				Reason:  "missing Merkle proof",
+			})
+			continue
+		}
+		if !verifyMerkleProof(leaf, proof, verification.CanonicalMerkleRoot) {
+			verification.NodeDiscrepancies = append(verification.NodeDiscrepancies, NodeRewardsDiscrepancy{
+				Address: address,
+				Reason:  "Merkle proof does not match the canonical Merkle root",
+			})
+			continue
+		}
+		verification.ValidProofCount++
+	}
+
+	// Compare the header and totals with the event
+	verification.compareUint("index", event.Index, rewardsFile.Index)
+	verification.compareUint("intervalsPassed", event.IntervalsPassed, rewardsFile.IntervalsPassed)
+	verification.compareUint("consensusEndBlock", event.ConsensusBlock, rewardsFile.ConsensusEndBlock)
+	verification.compareUint("executionEndBlock", event.ExecutionBlock, rewardsFile.ExecutionEndBlock)
+	if rewardsFile.TotalRewards != nil {
+		verification.compareBig("totalRewards.protocolDaoRpl", event.TreasuryRPL, getQuotedBigIntValue(rewardsFile.TotalRewards.ProtocolDaoRpl))
+		verification.compareBig("totalRewards.poolStakerSmoothingPoolEth", event.UserETH, getQuotedBigIntValue(rewardsFile.TotalRewards.PoolStakerSmoothingPoolEth))
+	} else {
+		verification.addDiscrepancy("totalRewards", "present", "missing")
+	}
+
+	// Compare each network's rewards with the event and with the sum of its nodes' rewards
+	for network := range event.NodeRPL {
+		networkRewards, exists := rewardsFile.NetworkRewards[uint64(network)]
+		if !exists {
+			networkRewards = &NetworkRewardsInfo{}
+		}
+		prefix := fmt.Sprintf("networkRewards[%d]", network)
+		verification.compareBig(prefix+".collateralRpl", event.NodeRPL[network], getQuotedBigIntValue(networkRewards.CollateralRpl))
+		if network < len(event.TrustedNodeRPL) {
+			verification.compareBig(prefix+".oracleDaoRpl", event.TrustedNodeRPL[network], getQuotedBigIntValue(networkRewards.OracleDaoRpl))
+		}
+		if network < len(event.NodeETH) {
+			verification.compareBig(prefix+".smoothingPoolEth", event.NodeETH[network], getQuotedBigIntValue(networkRewards.SmoothingPoolEth))
+		}
+	}
+	for network, networkRewards := range rewardsFile.NetworkRewards {
+		networkTotal, exists := networkTotals[network]
+		if !exists {
+			networkTotal = &NetworkRewardsInfo{}
+		}
+		prefix := fmt.Sprintf("networkRewards[%d]", network)
+		verification.compareBig(prefix+".collateralRpl (sum of nodes)", getQuotedBigIntValue(networkRewards.CollateralRpl), getQuotedBigIntValue(networkTotal.CollateralRpl))
+		verification.compareBig(prefix+".oracleDaoRpl (sum of nodes)", getQuotedBigIntValue(networkRewards.OracleDaoRpl), getQuotedBigIntValue(networkTotal.OracleDaoRpl))
+		verification.compareBig(prefix+".smoothingPoolEth (sum of nodes)", getQuotedBigIntValue(networkRewards.SmoothingPoolEth), getQuotedBigIntValue(networkTotal.SmoothingPoolEth))
+	}
+	sort.SliceStable(verification.Discrepancies, func(i, j int) bool {
+		return verification.Discrepancies[i].Field < verification.Discrepancies[j].Field
+	})
+
+	verification.Valid = len(verification.Discrepancies) == 0 && len(verification.NodeDiscrepancies) == 0
+	return verification, nil
+}
+
+// Node data is address[20] :: network[32] :: RPL[32] :: ETH[32]
+func getMerkleLeafData(address common.Address, rewardNetwork uint64, rplRewards *big.Int, ethRewards *big.Int) []byte {
+	nodeData := make([]byte, 0, 20+32*3)
+	nodeData = append(nodeData, address.Bytes()...)
+
+	networkBytes := make([]byte, 32)
+	big.NewInt(0).SetUint64(rewardNetwork).FillBytes(networkBytes)
+	nodeData = append(nodeData, networkBytes...)
+
+	rplRewardsBytes := make([]byte, 32)
+	rplRewards.FillBytes(rplRewardsBytes)
+	nodeData = append(nodeData, rplRewardsBytes...)
+
+	ethRewardsBytes := make([]byte, 32)
+	ethRewards.FillBytes(ethRewardsBytes)
+	nodeData = append(nodeData, ethRewardsBytes...)
+
+	return nodeData
+}
+
+// Checks a proof the same way the distributor contract does, hashing each pair of nodes in sorted order
+func verifyMerkleProof(leaf []byte, proof []common.Hash, root common.Hash) bool {
+	hash := crypto.Keccak256(leaf)
+	for _, proofHash := range proof {
+		if bytes.Compare(hash, proofHash.Bytes()) <= 0 {
+			hash = crypto.Keccak256(hash, proofHash.Bytes())
+		} else {
+			hash = crypto.Keccak256(proofHash.Bytes(), hash)
+		}
+	}
+	return common.BytesToHash(hash) == root
+}
+
+func getQuotedBigIntValue(value *QuotedBigInt) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return &value.Int
+}
+
+func (v *RewardsTreeVerification) addDiscrepancy(field string, expected string, actual string) {
+	v.Discrepancies = append(v.Discrepancies, RewardsTreeDiscrepancy{
+		Field:    field,
+		Expected: expected,
+		Actual:   actual,
+	})
+}
+
+func (v *RewardsTreeVerification) compareUint(field string, expected *big.Int, actual uint64) {
+	if expected == nil {
+		return
+	}
+	if !expected.IsUint64() || expected.Uint64() != actual {
+		v.addDiscrepancy(field, expected.String(), fmt.Sprint(actual))
+	}
+}
+
+func (v *RewardsTreeVerification) compareBig(field string, expected *big.Int, actual *big.Int) {
+	if expected == nil {
+		expected = big.NewInt(0)
+	}
+	if expected.Cmp(actual) != 0 {
+		v.addDiscrepancy(field, expected.String(), actual.String())
+	}
+}
@@ -0,0 +1,131 @@
+package rewards
+
+import (
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+)
+
+// Load the rewards file of the devnet fixture and the event that was submitted for it
+func getVerifyTestCase(t *testing.T) (*RewardsFile, rewards.RewardsEvent) {
+	fixture, err := LoadTreeGenerationFixture(filepath.Join(repoFixturesDir, "devnet-interval-3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewardsFile := &RewardsFile{}
+	if err := json.Unmarshal(fixture.RewardsFileBytes, rewardsFile); err != nil {
+		t.Fatalf("error deserializing rewards file: %v", err)
+	}
+
+	network := rewardsFile.NetworkRewards[0]
+	event := rewards.RewardsEvent{
+		Index:           big.NewInt(0).SetUint64(rewardsFile.Index),
+		ExecutionBlock:  big.NewInt(0).SetUint64(rewardsFile.ExecutionEndBlock),
+		ConsensusBlock:  big.NewInt(0).SetUint64(rewardsFile.ConsensusEndBlock),
+		MerkleRoot:      fixture.Info.CanonicalRoot,
+		IntervalsPassed: big.NewInt(0).SetUint64(rewardsFile.IntervalsPassed),
+		TreasuryRPL:     big.NewInt(0).Set(&rewardsFile.TotalRewards.ProtocolDaoRpl.Int),
+		UserETH:         big.NewInt(0).Set(&rewardsFile.TotalRewards.PoolStakerSmoothingPoolEth.Int),
+		NodeRPL:         []*big.Int{big.NewInt(0).Set(&network.CollateralRpl.Int)},
+		TrustedNodeRPL:  []*big.Int{big.NewInt(0).Set(&network.OracleDaoRpl.Int)},
+		NodeETH:         []*big.Int{big.NewInt(0).Set(&network.SmoothingPoolEth.Int)},
+	}
+	return rewardsFile, event
+}
+
+// Check that a verification failed with a discrepancy for the provided field
+func requireDiscrepancy(t *testing.T, verification *RewardsTreeVerification, field string) {
+	if verification.Valid {
+		t.Fatalf("verification should have failed")
+	}
+	for _, discrepancy := range verification.Discrepancies {
+		if strings.HasPrefix(discrepancy.Field, field) {
+			return
+		}
+	}
+	t.Fatalf("expected a %s discrepancy, got %+v", field, verification.Discrepancies)
+}
+
+func TestVerifyValidRewardsFile(t *testing.T) {
+	rewardsFile, event := getVerifyTestCase(t)
+	verification, err := VerifyRewardsFile(rewardsFile, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verification.Valid {
+		t.Fatalf("rewards file should be valid: %+v %+v", verification.Discrepancies, verification.NodeDiscrepancies)
+	}
+	if verification.NodeCount != 2 || verification.ValidProofCount != 2 {
+		t.Fatalf("expected 2 valid proofs for 2 nodes, got %d for %d", verification.ValidProofCount, verification.NodeCount)
+	}
+}
+
+func TestVerifyTamperedAmount(t *testing.T) {
+	rewardsFile, event := getVerifyTestCase(t)
+	node := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	rewardsFile.NodeRewards[node].SmoothingPoolEth = NewQuotedBigInt(1)
+
+	verification, err := VerifyRewardsFile(rewardsFile, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireDiscrepancy(t, verification, "merkleRoot")
+	requireDiscrepancy(t, verification, "canonicalMerkleRoot")
+	requireDiscrepancy(t, verification, "networkRewards[0].smoothingPoolEth (sum of nodes)")
+
+	// The tampered node's leaf no longer matches its proof
+	if len(verification.NodeDiscrepancies) == 0 || verification.NodeDiscrepancies[0].Address != node {
+		t.Fatalf("expected a discrepancy for node %s, got %+v", node.Hex(), verification.NodeDiscrepancies)
+	}
+}
+
+func TestVerifyTamperedProof(t *testing.T) {
+	rewardsFile, event := getVerifyTestCase(t)
+	node := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	rewardsFile.NodeRewards[node].MerkleProof[0] = common.HexToHash("0x1234").Hex()
+
+	verification, err := VerifyRewardsFile(rewardsFile, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verification.Valid {
+		t.Fatalf("verification should have failed")
+	}
+
+	// The tree itself is still correct, so only the node with the bad proof is reported
+	if len(verification.Discrepancies) != 0 {
+		t.Fatalf("expected no file discrepancies, got %+v", verification.Discrepancies)
+	}
+	if len(verification.NodeDiscrepancies) != 1 || verification.NodeDiscrepancies[0].Address != node {
+		t.Fatalf("expected a single discrepancy for node %s, got %+v", node.Hex(), verification.NodeDiscrepancies)
+	}
+	if verification.ValidProofCount != 1 {
+		t.Fatalf("expected 1 valid proof, got %d", verification.ValidProofCount)
+	}
+}
+
+func TestVerifyWrongRoot(t *testing.T) {
+	rewardsFile, event := getVerifyTestCase(t)
+	event.MerkleRoot = common.HexToHash("0x1234")
+
+	verification, err := VerifyRewardsFile(rewardsFile, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireDiscrepancy(t, verification, "canonicalMerkleRoot")
+	for _, discrepancy := range verification.Discrepancies {
+		if discrepancy.Field == "merkleRoot" {
+			t.Fatalf("the file's own root is still consistent with its nodes: %+v", discrepancy)
+		}
+	}
+
+	// Claims are checked against the canonical root, so none of the proofs are usable
+	if verification.ValidProofCount != 0 || len(verification.NodeDiscrepancies) != verification.NodeCount {
+		t.Fatalf("expected every proof to fail against the wrong root, got %d valid and %+v", verification.ValidProofCount, verification.NodeDiscrepancies)
+	}
+}
@@ -3,6 +3,7 @@ package rocketpool
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/a8m/envsubst"
 	"github.com/fatih/color"
+	"github.com/goccy/go-json"
 	"github.com/urfave/cli"
 	"golang.org/x/crypto/ssh"
 
@@ -59,8 +61,13 @@ const (
 	nethermindAdminUrl            string = "http://127.0.0.1:7434"
 
 	DebugColor = color.FgYellow
+
+	apiServerDialTimeout time.Duration = time.Second
 )
 
+// Returned when the API server's socket can't be connected to
+var errAPIServerUnreachable = errors.New("couldn't connect to the API server")
+
 // When printing sync percents, we should avoid printing 100%.
 // This function is only called if we're still syncing,
 // and the `%0.2f` token will round up if we're above 99.99%.
@@ -100,6 +107,9 @@ type Client struct {
 	debugPrint         bool
 	ignoreSyncCheck    bool
 	forceFallbacks     bool
+	apiServerChecked   bool
+	apiServerClient    *http.Client
+	apiServerToken     string
 }
 
 func getClientStatusString(clientStatus api.ClientStatus) string {
@@ -670,6 +680,12 @@ func (c *Client) IncrementCustomNonce() {
 	c.customNonce.Add(c.customNonce, big.NewInt(1))
 }
 
+// Sets the custom nonce parameter.
+// This is used for calls that replace a pending transaction, which must use the same nonce.
+func (c *Client) SetCustomNonce(nonce *big.Int) {
+	c.customNonce = nonce
+}
+
 // Get the current Docker image used by the given container
 func (c *Client) GetDockerImage(container string) (string, error) {
 
@@ -1331,6 +1347,18 @@ func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir strin
 
 // Call the Rocket Pool API
 func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
+	// Use the API server if it's running
+	if c.isAPIServerAvailable() {
+		response, err := c.callAPIServer(append(strings.Fields(args), otherArgs...))
+		if !errors.Is(err, errAPIServerUnreachable) {
+			return response, err
+		}
+		if c.debugPrint {
+			fmt.Printf("%s, using docker exec instead.\n", err.Error())
+		}
+		c.apiServerClient = nil
+	}
+
 	// Sanitize and parse the args
 	ignoreSyncCheckFlag, forceFallbackECFlag, args := c.getApiCallArgs(args, otherArgs...)
 
@@ -1395,6 +1423,115 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 	return c.runApiCall(cmd)
 }
 
+// Check if the API server is running, and prepare a connection to it if so.
+// The server is only used if its socket accepts connections and its token can be found, otherwise the API is run with docker exec.
+func (c *Client) isAPIServerAvailable() bool {
+	if c.apiServerChecked {
+		return c.apiServerClient != nil
+	}
+	c.apiServerChecked = true
+
+	if c.client != nil {
+		return false
+	}
+	cfg, isNew, err := c.LoadConfig()
+	if err != nil || isNew {
+		return false
+	}
+
+	// A socket left behind by a server that has stopped still exists, so make sure something is listening on it
+	socketPath := cfg.Smartnode.GetApiSocketPath(false)
+	conn, err := net.DialTimeout("unix", socketPath, apiServerDialTimeout)
+	if err != nil {
+		if c.debugPrint && !errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("API server socket found but it couldn't be reached (%s), using docker exec instead.\n", err.Error())
+		}
+		return false
+	}
+	conn.Close()
+	token, err := os.ReadFile(cfg.Smartnode.GetApiTokenPath(false))
+	if err != nil {
+		if c.debugPrint {
+			fmt.Printf("API server socket found but the token couldn't be read (%s), using docker exec instead.\n", err.Error())
+		}
+		return false
+	}
+
+	c.apiServerToken = strings.TrimSpace(string(token))
+	c.apiServerClient = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+	return true
+}
+
+// Call the Rocket Pool API through the API server
+func (c *Client) callAPIServer(args []string) ([]byte, error) {
+	request := api.APIServerRequest{
+		Args:            args,
+		MaxFee:          c.maxFee,
+		MaxPrioFee:      c.maxPrioFee,
+		GasLimit:        c.gasLimit,
+		IgnoreSyncCheck: c.ignoreSyncCheck,
+		ForceFallbacks:  c.forceFallbacks,
+	}
+	if c.customNonce != nil {
+		request.Nonce = c.customNonce.String()
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing API request: %w", err)
+	}
+
+	if c.debugPrint {
+		fmt.Println("To API server:")
+		fmt.Println(string(requestBytes))
+	}
+
+	httpRequest, err := http.NewRequest(http.MethodPost, "http://rocketpool"+api.APIServerRoute, bytes.NewReader(requestBytes))
+	if err != nil {
+		return nil, fmt.Errorf("error creating API request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", "Bearer "+c.apiServerToken)
+	response, err := c.apiServerClient.Do(httpRequest)
+
+	// If the server went away, keep the gas settings so the call can be run with docker exec instead
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return nil, fmt.Errorf("%w (%s)", errAPIServerUnreachable, err.Error())
+	}
+
+	// Reset the gas settings after the call
+	defer func() {
+		c.maxFee = c.originalMaxFee
+		c.maxPrioFee = c.originalMaxPrioFee
+		c.gasLimit = c.originalGasLimit
+	}()
+
+	if err != nil {
+		return nil, fmt.Errorf("error calling the API server: %w", err)
+	}
+	defer response.Body.Close()
+
+	output, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API server response: %w", err)
+	}
+	if c.debugPrint {
+		fmt.Println("API Out:")
+		fmt.Println(string(output))
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API server returned HTTP status %d: %s", response.StatusCode, strings.TrimSpace(string(output)))
+	}
+	return output, nil
+}
+
 func (c *Client) getApiCallArgs(args string, otherArgs ...string) (string, string, string) {
 	// Sanitize arguments
 	var sanitizedArgs []string
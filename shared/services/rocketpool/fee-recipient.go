@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -60,6 +61,26 @@ func UpdateFeeRecipientFile(feeRecipient common.Address, cfg *config.RocketPoolC
 
 }
 
+// Reads the fee recipient address from the fee recipient file
+func ReadFeeRecipientFile(cfg *config.RocketPoolConfig) (common.Address, error) {
+
+	// Read the file
+	path := cfg.Smartnode.GetFeeRecipientFilePath()
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error reading fee recipient file: %w", err)
+	}
+
+	// Native mode files hold an environment variable definition
+	contents := strings.TrimSpace(string(bytes))
+	contents = strings.TrimPrefix(contents, config.FeeRecipientEnvVar+"=")
+	if !common.IsHexAddress(contents) {
+		return common.Address{}, fmt.Errorf("fee recipient file %s does not contain a valid address", path)
+	}
+	return common.HexToAddress(contents), nil
+
+}
+
 // Gets the expected contents of the fee recipient file
 func getFeeRecipientFileContents(feeRecipient common.Address, cfg *config.RocketPoolConfig) string {
 	if !cfg.IsNativeMode {
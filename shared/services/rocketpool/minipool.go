@@ -457,17 +457,17 @@ func (c *Client) DistributeBalance(address common.Address) (api.DistributeBalanc
 }
 
 // Import a validator private key for a vacant minipool
-func (c *Client) ImportKey(address common.Address, mnemonic string) (api.ChangeWithdrawalCredentialsResponse, error) {
+func (c *Client) ImportKey(address common.Address, mnemonic string) (api.ImportKeyResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool import-key %s", address.Hex()), mnemonic)
 	if err != nil {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not import validator key: %w", err)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not import validator key: %w", err)
 	}
-	var response api.ChangeWithdrawalCredentialsResponse
+	var response api.ImportKeyResponse
 	if err := json.Unmarshal(responseBytes, &response); err != nil {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not decode import-key response: %w", err)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not decode import-key response: %w", err)
 	}
 	if response.Error != "" {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not import validator key: %s", response.Error)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not import validator key: %s", response.Error)
 	}
 	return response, nil
 }
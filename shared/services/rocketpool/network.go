@@ -104,8 +104,8 @@ func (c *Client) CanGenerateRewardsTree(index uint64) (api.CanNetworkGenerateRew
 }
 
 // Set a request marker for the watchtower to generate the rewards tree for the given interval
-func (c *Client) GenerateRewardsTree(index uint64) (api.NetworkGenerateRewardsTreeResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("network generate-rewards-tree %d", index))
+func (c *Client) GenerateRewardsTree(index uint64, recordFixture bool) (api.NetworkGenerateRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network generate-rewards-tree %d %t", index, recordFixture))
 	if err != nil {
 		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree generation: %w", err)
 	}
@@ -151,6 +151,22 @@ func (c *Client) DownloadRewardsFile(interval uint64) (api.DownloadRewardsFileRe
 	return response, nil
 }
 
+// Get the event that was emitted when the rewards tree for the given interval was submitted
+func (c *Client) GetRewardsEvent(interval uint64) (api.NetworkRewardsEventResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network rewards-event %d", interval))
+	if err != nil {
+		return api.NetworkRewardsEventResponse{}, fmt.Errorf("could not get rewards event: %w", err)
+	}
+	var response api.NetworkRewardsEventResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkRewardsEventResponse{}, fmt.Errorf("could not decode rewards-event response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkRewardsEventResponse{}, fmt.Errorf("error after requesting rewards event: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check if Atlas has been deployed yet
 func (c *Client) IsAtlasDeployed() (api.IsAtlasDeployedResponse, error) {
 	responseBytes, err := c.callAPI("network is-atlas-deployed")
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
@@ -547,6 +548,102 @@ func (c *Client) NodeSync() (api.NodeSyncProgressResponse, error) {
 	return response, nil
 }
 
+// Get the status of the node daemon's tasks
+func (c *Client) NodeTaskStatus() (api.NodeTaskStatusResponse, error) {
+	responseBytes, err := c.callAPI("node task-status")
+	if err != nil {
+		return api.NodeTaskStatusResponse{}, fmt.Errorf("Could not get node task status: %w", err)
+	}
+	var response api.NodeTaskStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTaskStatusResponse{}, fmt.Errorf("Could not decode node task status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTaskStatusResponse{}, fmt.Errorf("Could not get node task status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the transactions in the node's transaction journal
+func (c *Client) NodeTransactions() (api.NodeTransactionsResponse, error) {
+	responseBytes, err := c.callAPI("node tx-list")
+	if err != nil {
+		return api.NodeTransactionsResponse{}, fmt.Errorf("Could not get node transactions: %w", err)
+	}
+	var response api.NodeTransactionsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTransactionsResponse{}, fmt.Errorf("Could not decode node transactions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTransactionsResponse{}, fmt.Errorf("Could not get node transactions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a pending transaction can be resubmitted with higher fees
+func (c *Client) CanSpeedUpNodeTransaction(hash common.Hash) (api.CanReplaceNodeTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-speed-up-tx %s", hash.Hex()))
+	if err != nil {
+		return api.CanReplaceNodeTransactionResponse{}, fmt.Errorf("Could not get can speed up transaction status: %w", err)
+	}
+	var response api.CanReplaceNodeTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanReplaceNodeTransactionResponse{}, fmt.Errorf("Could not decode can speed up transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanReplaceNodeTransactionResponse{}, fmt.Errorf("Could not get can speed up transaction status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Resubmit a pending transaction with higher fees; the custom nonce must be set to the transaction's nonce
+func (c *Client) SpeedUpNodeTransaction(hash common.Hash) (api.ReplaceNodeTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node speed-up-tx %s", hash.Hex()))
+	if err != nil {
+		return api.ReplaceNodeTransactionResponse{}, fmt.Errorf("Could not speed up transaction: %w", err)
+	}
+	var response api.ReplaceNodeTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ReplaceNodeTransactionResponse{}, fmt.Errorf("Could not decode speed up transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ReplaceNodeTransactionResponse{}, fmt.Errorf("Could not speed up transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a pending transaction can be cancelled
+func (c *Client) CanCancelNodeTransaction(hash common.Hash) (api.CanReplaceNodeTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-cancel-tx %s", hash.Hex()))
+	if err != nil {
+		return api.CanReplaceNodeTransactionResponse{}, fmt.Errorf("Could not get can cancel transaction status: %w", err)
+	}
+	var response api.CanReplaceNodeTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanReplaceNodeTransactionResponse{}, fmt.Errorf("Could not decode can cancel transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanReplaceNodeTransactionResponse{}, fmt.Errorf("Could not get can cancel transaction status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Cancel a pending transaction; the custom nonce must be set to the transaction's nonce
+func (c *Client) CancelNodeTransaction(hash common.Hash) (api.ReplaceNodeTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node cancel-tx %s", hash.Hex()))
+	if err != nil {
+		return api.ReplaceNodeTransactionResponse{}, fmt.Errorf("Could not cancel transaction: %w", err)
+	}
+	var response api.ReplaceNodeTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ReplaceNodeTransactionResponse{}, fmt.Errorf("Could not decode cancel transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ReplaceNodeTransactionResponse{}, fmt.Errorf("Could not cancel transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node has RPL rewards available to claim
 func (c *Client) CanNodeClaimRpl() (api.CanNodeClaimRplResponse, error) {
 	responseBytes, err := c.callAPI("node can-claim-rpl-rewards")
@@ -595,6 +692,38 @@ func (c *Client) NodeRewards() (api.NodeRewardsResponse, error) {
 	return response, nil
 }
 
+// Get an estimate of the node's rewards for the current interval
+func (c *Client) NodeProjectedRewards() (api.NodeProjectedRewardsResponse, error) {
+	responseBytes, err := c.callAPI("node projected-rewards")
+	if err != nil {
+		return api.NodeProjectedRewardsResponse{}, fmt.Errorf("Could not get projected node rewards: %w", err)
+	}
+	var response api.NodeProjectedRewardsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeProjectedRewardsResponse{}, fmt.Errorf("Could not decode projected node rewards response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeProjectedRewardsResponse{}, fmt.Errorf("Could not get projected node rewards: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get every payment the node received between two times
+func (c *Client) NodeIncome(startTime time.Time, endTime time.Time) (api.NodeIncomeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node income %d %d", startTime.Unix(), endTime.Unix()))
+	if err != nil {
+		return api.NodeIncomeResponse{}, fmt.Errorf("Could not get node income: %w", err)
+	}
+	var response api.NodeIncomeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeIncomeResponse{}, fmt.Errorf("Could not decode node income response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeIncomeResponse{}, fmt.Errorf("Could not get node income: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the deposit contract info for Rocket Pool and the Beacon Client
 func (c *Client) DepositContractInfo() (api.DepositContractInfoResponse, error) {
 	responseBytes, err := c.callAPI("node deposit-contract-info")
@@ -2,8 +2,10 @@ package rocketpool
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
@@ -25,6 +27,22 @@ func (c *Client) TerminateDataFolder() (api.TerminateDataFolderResponse, error)
 	return response, nil
 }
 
+// Removes the least recently used network states from the state cache until it fits within its configured size, or removes all of them
+func (c *Client) PruneStateCache(all bool) (api.PruneStateCacheResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service prune-state-cache %t", all))
+	if err != nil {
+		return api.PruneStateCacheResponse{}, fmt.Errorf("Could not prune state cache: %w", err)
+	}
+	var response api.PruneStateCacheResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PruneStateCacheResponse{}, fmt.Errorf("Could not decode prune-state-cache response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PruneStateCacheResponse{}, fmt.Errorf("Could not prune state cache: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Gets the status of the configured Execution and Beacon clients
 func (c *Client) GetClientStatus() (api.ClientStatusResponse, error) {
 	responseBytes, err := c.callAPI("service get-client-status")
@@ -41,6 +59,58 @@ func (c *Client) GetClientStatus() (api.ClientStatusResponse, error) {
 	return response, nil
 }
 
+// Checks whether any of the given validators were seen attesting in either of the last two completed epochs
+func (c *Client) GetValidatorLiveness(pubkeys []types.ValidatorPubkey) (api.ValidatorLivenessResponse, error) {
+	pubkeyStrings := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		pubkeyStrings[i] = pubkey.Hex()
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("service validator-liveness %s", strings.Join(pubkeyStrings, ",")))
+	if err != nil {
+		return api.ValidatorLivenessResponse{}, fmt.Errorf("Could not get validator liveness: %w", err)
+	}
+	var response api.ValidatorLivenessResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ValidatorLivenessResponse{}, fmt.Errorf("Could not decode validator liveness response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ValidatorLivenessResponse{}, fmt.Errorf("Could not get validator liveness: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Creates an encrypted backup of the node in the data folder, owned by the given user
+func (c *Client) CreateBackup(passphrase string, uid int, gid int) (api.CreateBackupResponse, error) {
+	responseBytes, err := c.callAPI("service create-backup", passphrase, fmt.Sprint(uid), fmt.Sprint(gid))
+	if err != nil {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not create backup: %w", err)
+	}
+	var response api.CreateBackupResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not decode create-backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not create backup: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Restores an encrypted backup that was copied into the data folder
+func (c *Client) RestoreBackup(filename string, passphrase string, force bool) (api.RestoreBackupResponse, error) {
+	responseBytes, err := c.callAPI("service restore-backup", filename, passphrase, fmt.Sprint(force))
+	if err != nil {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not restore backup: %w", err)
+	}
+	var response api.RestoreBackupResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not decode restore-backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not restore backup: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Restarts the Validator client
 func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	responseBytes, err := c.callAPI("service restart-vc")
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -16,13 +18,21 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	lokeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lodestar"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	w3skeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/signer"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -37,8 +47,10 @@ const (
 // Service instances & initializers
 var (
 	cfg                *config.RocketPoolConfig
-	passwordManager    *passwords.PasswordManager
+	passwordManager    passwords.PasswordManager
+	pinnedPasswords    passwords.PasswordManager
 	nodeWallet         *wallet.Wallet
+	nodeWallets        []*wallet.Wallet
 	ecManager          *ExecutionClientManager
 	bcManager          *BeaconClientManager
 	rocketPool         *rocketpool.RocketPool
@@ -46,10 +58,15 @@ var (
 	snapshotDelegation *contracts.SnapshotDelegation
 	beaconClient       beacon.Client
 	docker             *client.Client
+	txJournal          *transactions.Journal
+	notifier           *notifications.Notifier
+	remoteSigner       *w3skeystore.Keystore
+	keymanagerClient   *keymanager.Client
 
 	initCfg                sync.Once
 	initPasswordManager    sync.Once
 	initNodeWallet         sync.Once
+	initNodeWallets        sync.Once
 	initECManager          sync.Once
 	initBCManager          sync.Once
 	initRocketPool         sync.Once
@@ -58,6 +75,10 @@ var (
 	initSnapshotDelegation sync.Once
 	initBeaconClient       sync.Once
 	initDocker             sync.Once
+	initTxJournal          sync.Once
+	initNotifier           sync.Once
+	initRemoteSigner       sync.Once
+	initKeymanagerClient   sync.Once
 )
 
 //
@@ -68,7 +89,7 @@ func GetConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 	return getConfig(c)
 }
 
-func GetPasswordManager(c *cli.Context) (*passwords.PasswordManager, error) {
+func GetPasswordManager(c *cli.Context) (passwords.PasswordManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
 		return nil, err
@@ -76,6 +97,12 @@ func GetPasswordManager(c *cli.Context) (*passwords.PasswordManager, error) {
 	return getPasswordManager(cfg), nil
 }
 
+// Use the provided password manager instead of the configured one; unlike the other services, it's kept when the services
+// are reset
+func PinPasswordManager(pm passwords.PasswordManager) {
+	pinnedPasswords = pm
+}
+
 func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -85,6 +112,20 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	return getWallet(c, cfg, pm)
 }
 
+// Get the wallets of every node the node daemon manages; the node wallet is always first
+func GetNodeWallets(c *cli.Context) ([]*wallet.Wallet, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	pm := getPasswordManager(cfg)
+	w, err := getWallet(c, cfg, pm)
+	if err != nil {
+		return nil, err
+	}
+	return getNodeWallets(c, cfg, pm, w)
+}
+
 func GetEthClient(c *cli.Context) (*ExecutionClientManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -152,6 +193,99 @@ func GetDocker(c *cli.Context) (*client.Client, error) {
 	return getDocker()
 }
 
+func GetTransactionJournal(c *cli.Context) (*transactions.Journal, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := getEthClient(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return getTransactionJournal(cfg, ec), nil
+}
+
+func GetNotifier(c *cli.Context) (*notifications.Notifier, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getNotifier(cfg)
+}
+
+// Get the remote signer that holds the node's validator keys, or nil if it isn't enabled
+func GetRemoteSigner(c *cli.Context) (*w3skeystore.Keystore, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getRemoteSigner(cfg), nil
+}
+
+// Get the client for the Validator Client's keymanager API, or nil if it isn't enabled
+func GetKeymanagerClient(c *cli.Context) (*keymanager.Client, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getKeymanagerClient(cfg), nil
+}
+
+// Apply the global flags of a new command to services that were already created by a previous one.
+// Only needed when a long-running process (like the API server) runs several commands.
+func UpdateRequestSettings(c *cli.Context) {
+	if cfg == nil {
+		return
+	}
+	if nodeWallet != nil {
+		maxFee, maxPriorityFee := getGasSettings(c, cfg)
+		nodeWallet.SetGasSettings(maxFee, maxPriorityFee, 0)
+		for _, w := range nodeWallets {
+			w.SetGasSettings(maxFee, maxPriorityFee, 0)
+		}
+	}
+	if ecManager != nil {
+		ecManager.pool.setRequestSettings(c.GlobalBool("ignore-sync-check"), c.GlobalBool("force-fallbacks"))
+	}
+	if bcManager != nil {
+		bcManager.pool.setRequestSettings(c.GlobalBool("ignore-sync-check"), c.GlobalBool("force-fallbacks"))
+	}
+}
+
+// Discard every service instance so they're recreated from scratch the next time they're requested (e.g. after the config changes)
+func ResetServices() {
+	cfg = nil
+	passwordManager = nil
+	nodeWallet = nil
+	nodeWallets = nil
+	ecManager = nil
+	bcManager = nil
+	rocketPool = nil
+	rplFaucet = nil
+	snapshotDelegation = nil
+	beaconClient = nil
+	txJournal = nil
+	notifier = nil
+	remoteSigner = nil
+	keymanagerClient = nil
+
+	initCfg = sync.Once{}
+	initPasswordManager = sync.Once{}
+	initNodeWallet = sync.Once{}
+	initNodeWallets = sync.Once{}
+	initECManager = sync.Once{}
+	initBCManager = sync.Once{}
+	initRocketPool = sync.Once{}
+	initOneInchOracle = sync.Once{}
+	initRplFaucet = sync.Once{}
+	initSnapshotDelegation = sync.Once{}
+	initBeaconClient = sync.Once{}
+	initTxJournal = sync.Once{}
+	initNotifier = sync.Once{}
+	initRemoteSigner = sync.Once{}
+	initKeymanagerClient = sync.Once{}
+}
+
 //
 // Service instance getters
 //
@@ -168,34 +302,30 @@ func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 	return cfg, err
 }
 
-func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
+func getPasswordManager(cfg *config.RocketPoolConfig) passwords.PasswordManager {
 	initPasswordManager.Do(func() {
-		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+		if pinnedPasswords != nil {
+			passwordManager = pinnedPasswords
+			return
+		}
+		switch cfg.Smartnode.PasswordBackend.Value.(cfgtypes.PasswordBackend) {
+		case cfgtypes.PasswordBackend_Env:
+			passwordManager = passwords.NewEnvPasswordManager(cfg.Smartnode.PasswordEnvSource.Value.(string))
+		case cfgtypes.PasswordBackend_Keyring:
+			passwordManager = passwords.NewKeyringPasswordManager(cfg.Smartnode.PasswordKeyringKey.Value.(string))
+		case cfgtypes.PasswordBackend_Exec:
+			passwordManager = passwords.NewExecPasswordManager(cfg.Smartnode.PasswordHelperCommand.Value.(string))
+		default:
+			passwordManager = passwords.NewFilePasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+		}
 	})
 	return passwordManager
 }
 
-func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
+func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm passwords.PasswordManager) (*wallet.Wallet, error) {
 	var err error
 	initNodeWallet.Do(func() {
-		var maxFee *big.Int
-		maxFeeFloat := c.GlobalFloat64("maxFee")
-		if maxFeeFloat == 0 {
-			maxFeeFloat = cfg.Smartnode.ManualMaxFee.Value.(float64)
-		}
-		if maxFeeFloat != 0 {
-			maxFee = eth.GweiToWei(maxFeeFloat)
-		}
-
-		var maxPriorityFee *big.Int
-		maxPriorityFeeFloat := c.GlobalFloat64("maxPrioFee")
-		if maxPriorityFeeFloat == 0 {
-			maxPriorityFeeFloat = cfg.Smartnode.PriorityFee.Value.(float64)
-		}
-		if maxPriorityFeeFloat != 0 {
-			maxPriorityFee = eth.GweiToWei(maxPriorityFeeFloat)
-		}
-
+		maxFee, maxPriorityFee := getGasSettings(c, cfg)
 		chainId := cfg.Smartnode.GetChainID()
 
 		nodeWallet, err = wallet.NewWallet(os.ExpandEnv(cfg.Smartnode.GetWalletPath()), chainId, maxFee, maxPriorityFee, 0, pm)
@@ -203,21 +333,113 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 			return
 		}
 
+		// External node account signer
+		nodeSignerAddress := common.HexToAddress(cfg.Smartnode.NodeSignerAddress.Value.(string))
+		switch cfg.Smartnode.NodeSigner.Value.(cfgtypes.NodeSigner) {
+		case cfgtypes.NodeSigner_Clef:
+			nodeWallet.SetNodeSigner(signer.NewClefSigner(cfg.Smartnode.NodeSignerUrl.Value.(string), nodeSignerAddress))
+		case cfgtypes.NodeSigner_Rpc:
+			nodeWallet.SetNodeSigner(signer.NewEip1193Signer(cfg.Smartnode.NodeSignerUrl.Value.(string), nodeSignerAddress))
+		}
+
 		// Keystores
-		lighthouseKeystore := lhkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		lodestarKeystore := lokeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		nimbusKeystore := nmkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		prysmKeystore := prkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		tekuKeystore := tkkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		nodeWallet.AddKeystore("lighthouse", lighthouseKeystore)
-		nodeWallet.AddKeystore("lodestar", lodestarKeystore)
-		nodeWallet.AddKeystore("nimbus", nimbusKeystore)
-		nodeWallet.AddKeystore("prysm", prysmKeystore)
-		nodeWallet.AddKeystore("teku", tekuKeystore)
+		addKeystores(cfg, pm, nodeWallet)
 	})
 	return nodeWallet, err
 }
 
+func getNodeWallets(c *cli.Context, cfg *config.RocketPoolConfig, pm passwords.PasswordManager, w *wallet.Wallet) ([]*wallet.Wallet, error) {
+	var err error
+	initNodeWallets.Do(func() {
+		wallets := []*wallet.Wallet{w}
+
+		// Additional node accounts derived from the node wallet
+		var indices []uint
+		indices, err = cfg.Smartnode.GetAdditionalNodeIndices()
+		if err != nil {
+			return
+		}
+		for _, index := range indices {
+			var nodeView *wallet.Wallet
+			nodeView, err = w.GetNodeWallet(index)
+			if err != nil {
+				err = fmt.Errorf("error getting node account at index %d: %w", index, err)
+				return
+			}
+			wallets = append(wallets, nodeView)
+		}
+
+		// Separate wallets; their validator keys go in the same keystores as the node wallet's, since they share the Validator Client
+		maxFee, maxPriorityFee := getGasSettings(c, cfg)
+		chainId := cfg.Smartnode.GetChainID()
+		for _, folder := range cfg.Smartnode.GetAdditionalWalletFolders(true) {
+			walletPm := passwords.NewFilePasswordManager(filepath.Join(folder, "password"))
+			var additionalWallet *wallet.Wallet
+			additionalWallet, err = wallet.NewWallet(filepath.Join(folder, "wallet"), chainId, maxFee, maxPriorityFee, 0, walletPm)
+			if err != nil {
+				err = fmt.Errorf("error loading wallet in %s: %w", folder, err)
+				return
+			}
+			if !additionalWallet.IsInitialized() {
+				err = fmt.Errorf("wallet in %s is not initialized", folder)
+				return
+			}
+			addKeystores(cfg, pm, additionalWallet)
+			wallets = append(wallets, additionalWallet)
+		}
+
+		nodeWallets = wallets
+	})
+	return nodeWallets, err
+}
+
+// Add the validator keystores for each client to a wallet
+func addKeystores(cfg *config.RocketPoolConfig, pm passwords.PasswordManager, w *wallet.Wallet) {
+	// Validator keys go to the remote signer instead of the disk if it's enabled
+	if signer := getRemoteSigner(cfg); signer != nil {
+		w.AddKeystore("web3signer", signer)
+		return
+	}
+
+	for name, ks := range GetValidatorKeystores(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm) {
+		w.AddKeystore(name, ks)
+	}
+}
+
+// Get the keystores for each client in a validator keychain folder
+func GetValidatorKeystores(keychainPath string, pm passwords.PasswordManager) map[string]keystore.Keystore {
+	return map[string]keystore.Keystore{
+		"lighthouse": lhkeystore.NewKeystore(keychainPath, pm),
+		"lodestar":   lokeystore.NewKeystore(keychainPath, pm),
+		"nimbus":     nmkeystore.NewKeystore(keychainPath, pm),
+		"prysm":      prkeystore.NewKeystore(keychainPath, pm),
+		"teku":       tkkeystore.NewKeystore(keychainPath, pm),
+	}
+}
+
+// Get the max fee and priority fee from the global flags, falling back to the config values
+func getGasSettings(c *cli.Context, cfg *config.RocketPoolConfig) (*big.Int, *big.Int) {
+	var maxFee *big.Int
+	maxFeeFloat := c.GlobalFloat64("maxFee")
+	if maxFeeFloat == 0 {
+		maxFeeFloat = cfg.Smartnode.ManualMaxFee.Value.(float64)
+	}
+	if maxFeeFloat != 0 {
+		maxFee = eth.GweiToWei(maxFeeFloat)
+	}
+
+	var maxPriorityFee *big.Int
+	maxPriorityFeeFloat := c.GlobalFloat64("maxPrioFee")
+	if maxPriorityFeeFloat == 0 {
+		maxPriorityFeeFloat = cfg.Smartnode.PriorityFee.Value.(float64)
+	}
+	if maxPriorityFeeFloat != 0 {
+		maxPriorityFee = eth.GweiToWei(maxPriorityFeeFloat)
+	}
+
+	return maxFee, maxPriorityFee
+}
+
 func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
 	var err error
 	initECManager.Do(func() {
@@ -225,12 +447,7 @@ func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClien
 		ecManager, err = NewExecutionClientManager(cfg)
 		if err == nil {
 			// Check if the manager should ignore sync checks and/or default to using the fallback (used by the API container when driven by the CLI)
-			if c.GlobalBool("ignore-sync-check") {
-				ecManager.ignoreSyncCheck = true
-			}
-			if c.GlobalBool("force-fallbacks") {
-				ecManager.primaryReady = false
-			}
+			ecManager.pool.setRequestSettings(c.GlobalBool("ignore-sync-check"), c.GlobalBool("force-fallbacks"))
 		}
 	})
 	return ecManager, err
@@ -270,12 +487,7 @@ func getBeaconClient(c *cli.Context, cfg *config.RocketPoolConfig) (*BeaconClien
 		bcManager, err = NewBeaconClientManager(cfg)
 		if err == nil {
 			// Check if the manager should ignore sync checks and/or default to using the fallback (used by the API container when driven by the CLI)
-			if c.GlobalBool("ignore-sync-check") {
-				bcManager.ignoreSyncCheck = true
-			}
-			if c.GlobalBool("force-fallbacks") {
-				bcManager.primaryReady = false
-			}
+			bcManager.pool.setRequestSettings(c.GlobalBool("ignore-sync-check"), c.GlobalBool("force-fallbacks"))
 		}
 	})
 	return bcManager, err
@@ -288,3 +500,37 @@ func getDocker() (*client.Client, error) {
 	})
 	return docker, err
 }
+
+func getTransactionJournal(cfg *config.RocketPoolConfig, ec *ExecutionClientManager) *transactions.Journal {
+	initTxJournal.Do(func() {
+		txJournal = transactions.NewJournal(os.ExpandEnv(cfg.Smartnode.GetTxJournalPath(true)), ec)
+	})
+	return txJournal
+}
+
+func getNotifier(cfg *config.RocketPoolConfig) (*notifications.Notifier, error) {
+	var err error
+	initNotifier.Do(func() {
+		notifier, err = notifications.NewNotifier(cfg, log.NewColorLogger(color.FgHiMagenta))
+	})
+	return notifier, err
+}
+
+func getRemoteSigner(cfg *config.RocketPoolConfig) *w3skeystore.Keystore {
+	initRemoteSigner.Do(func() {
+		if cfg.Smartnode.UseRemoteSigner.Value == true {
+			remoteSigner = w3skeystore.NewKeystore(cfg.Smartnode.RemoteSignerUrl.Value.(string))
+		}
+	})
+	return remoteSigner
+}
+
+func getKeymanagerClient(cfg *config.RocketPoolConfig) *keymanager.Client {
+	initKeymanagerClient.Do(func() {
+		url := cfg.Smartnode.GetKeymanagerApiUrl()
+		if cfg.Smartnode.UseKeymanagerApi.Value == true && url != "" {
+			keymanagerClient = keymanager.NewClient(url, cfg.Smartnode.GetKeymanagerApiTokenPath())
+		}
+	})
+	return keymanagerClient
+}
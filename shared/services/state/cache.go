@@ -0,0 +1,288 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	stateCacheVersion    uint64 = 2 // Version 1 could hold incrementally updated states
+	stateCacheExtension  string = ".json.zst"
+	stateCacheFileFormat string = "%d-%s" + stateCacheExtension
+)
+
+// A persistent cache of network states for finalized slots.
+// Entries are keyed by the Beacon slot and the hash of its Execution block, so a state can never be loaded for the wrong chain.
+type StateCache struct {
+	path    string
+	maxSize uint64
+	lock    sync.Mutex
+	log     *log.ColorLogger
+}
+
+// The result of pruning the cache
+type StateCachePruneResult struct {
+	RemovedFiles   int
+	FreedBytes     uint64
+	RemainingBytes uint64
+}
+
+// The serialized form of a network state; the lookups are rebuilt when it's loaded
+type cachedNetworkState struct {
+	Version                uint64                           `json:"version"`
+	ElBlockNumber          uint64                           `json:"elBlockNumber"`
+	ElBlockHash            common.Hash                      `json:"elBlockHash"`
+	BeaconSlotNumber       uint64                           `json:"beaconSlotNumber"`
+	BeaconConfig           beacon.Eth2Config                `json:"beaconConfig"`
+	NetworkDetails         *rpstate.NetworkDetails          `json:"networkDetails"`
+	NodeDetails            []rpstate.NativeNodeDetails      `json:"nodeDetails"`
+	MinipoolDetails        []rpstate.NativeMinipoolDetails  `json:"minipoolDetails"`
+	ValidatorDetails       []cachedValidatorStatus          `json:"validatorDetails"`
+	OracleDaoMemberDetails []rpstate.OracleDaoMemberDetails `json:"oracleDaoMemberDetails"`
+}
+
+// A validator status along with the pubkey it was requested for, which isn't set on the status of validators that don't exist yet
+type cachedValidatorStatus struct {
+	Pubkey types.ValidatorPubkey  `json:"pubkey"`
+	Status beacon.ValidatorStatus `json:"status"`
+}
+
+// A file in the cache
+type stateCacheEntry struct {
+	path    string
+	size    uint64
+	modTime time.Time
+}
+
+// Create a new state cache in the provided folder; maxSize is the most it can hold on disk, in bytes
+func NewStateCache(path string, maxSize uint64, log *log.ColorLogger) *StateCache {
+	return &StateCache{
+		path:    path,
+		maxSize: maxSize,
+		log:     log,
+	}
+}
+
+// Load the network state for the provided slot and Execution block hash from the cache.
+// Returns false if it isn't in the cache.
+func (c *StateCache) Load(slotNumber uint64, elBlockHash common.Hash) (*NetworkState, bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	path := c.getFilePath(slotNumber, elBlockHash)
+	compressedBytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading cached state [%s]: %w", path, err)
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("error creating compression decoder: %w", err)
+	}
+	defer decoder.Close()
+	bytes, err := decoder.DecodeAll(compressedBytes, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("error decompressing cached state [%s]: %w", path, err)
+	}
+
+	var cached cachedNetworkState
+	err = json.Unmarshal(bytes, &cached)
+	if err != nil {
+		return nil, false, fmt.Errorf("error deserializing cached state [%s]: %w", path, err)
+	}
+	if cached.Version != stateCacheVersion {
+		// Written by a different version of the cache, so it can't be trusted
+		return nil, false, nil
+	}
+
+	// Mark the entry as recently used so it's the last to be evicted
+	now := time.Now()
+	err = os.Chtimes(path, now, now)
+	if err != nil {
+		c.logLine(log.Level_Warn, "couldn't update the access time of cached state [%s]: %s", path, err.Error())
+	}
+
+	state := &NetworkState{
+		ElBlockNumber:          cached.ElBlockNumber,
+		BeaconSlotNumber:       cached.BeaconSlotNumber,
+		BeaconConfig:           cached.BeaconConfig,
+		NetworkDetails:         cached.NetworkDetails,
+		NodeDetails:            cached.NodeDetails,
+		MinipoolDetails:        cached.MinipoolDetails,
+		ValidatorDetails:       make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(cached.ValidatorDetails)),
+		OracleDaoMemberDetails: cached.OracleDaoMemberDetails,
+	}
+	for _, validator := range cached.ValidatorDetails {
+		state.ValidatorDetails[validator.Pubkey] = validator.Status
+	}
+	state.createLookups()
+	return state, true, nil
+}
+
+// Save a network state to the cache, evicting the least recently used states if the cache has grown too large
+func (c *StateCache) Save(state *NetworkState, elBlockHash common.Hash) error {
+	cached := cachedNetworkState{
+		Version:                stateCacheVersion,
+		ElBlockNumber:          state.ElBlockNumber,
+		ElBlockHash:            elBlockHash,
+		BeaconSlotNumber:       state.BeaconSlotNumber,
+		BeaconConfig:           state.BeaconConfig,
+		NetworkDetails:         state.NetworkDetails,
+		NodeDetails:            state.NodeDetails,
+		MinipoolDetails:        state.MinipoolDetails,
+		ValidatorDetails:       make([]cachedValidatorStatus, 0, len(state.ValidatorDetails)),
+		OracleDaoMemberDetails: state.OracleDaoMemberDetails,
+	}
+	for pubkey, status := range state.ValidatorDetails {
+		cached.ValidatorDetails = append(cached.ValidatorDetails, cachedValidatorStatus{
+			Pubkey: pubkey,
+			Status: status,
+		})
+	}
+
+	bytes, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("error serializing state for slot %d: %w", state.BeaconSlotNumber, err)
+	}
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+	if err != nil {
+		return fmt.Errorf("error creating compression encoder: %w", err)
+	}
+	defer encoder.Close()
+	compressedBytes := encoder.EncodeAll(bytes, make([]byte, 0, len(bytes)/8))
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	err = os.MkdirAll(c.path, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating state cache folder [%s]: %w", c.path, err)
+	}
+
+	// Write to a temporary file first so a partial file is never loaded
+	path := c.getFilePath(state.BeaconSlotNumber, elBlockHash)
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, compressedBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing cached state [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error writing cached state [%s]: %w", path, err)
+	}
+
+	_, err = c.prune(c.maxSize)
+	return err
+}
+
+// Remove the least recently used states from the cache until it's no larger than maxSize bytes
+func (c *StateCache) Prune(maxSize uint64) (StateCachePruneResult, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.prune(maxSize)
+}
+
+// Remove the least recently used states from the cache until it's no larger than maxSize bytes; the lock must be held
+func (c *StateCache) prune(maxSize uint64) (StateCachePruneResult, error) {
+	result := StateCachePruneResult{}
+	entries, err := c.getEntries()
+	if err != nil {
+		return result, err
+	}
+	for _, entry := range entries {
+		result.RemainingBytes += entry.size
+	}
+
+	// Oldest first
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	for _, entry := range entries {
+		if result.RemainingBytes <= maxSize {
+			break
+		}
+		err = os.Remove(entry.path)
+		if err != nil {
+			return result, fmt.Errorf("error removing cached state [%s]: %w", entry.path, err)
+		}
+		result.RemovedFiles++
+		result.FreedBytes += entry.size
+		result.RemainingBytes -= entry.size
+	}
+
+	if result.RemovedFiles > 0 {
+		c.logLine(log.Level_Info, "Removed %d states from the state cache, freeing %d bytes.", result.RemovedFiles, result.FreedBytes)
+	}
+	return result, nil
+}
+
+// Get all of the files in the cache
+func (c *StateCache) getEntries() ([]stateCacheEntry, error) {
+	files, err := os.ReadDir(c.path)
+	if os.IsNotExist(err) {
+		return []stateCacheEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating state cache folder [%s]: %w", c.path, err)
+	}
+
+	entries := make([]stateCacheEntry, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), stateCacheExtension) {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			return nil, fmt.Errorf("error reading cached state [%s]: %w", file.Name(), err)
+		}
+		entries = append(entries, stateCacheEntry{
+			path:    filepath.Join(c.path, file.Name()),
+			size:    uint64(info.Size()),
+			modTime: info.ModTime(),
+		})
+	}
+	return entries, nil
+}
+
+// Get the path of the file for the provided slot and Execution block hash
+func (c *StateCache) getFilePath(slotNumber uint64, elBlockHash common.Hash) string {
+	return filepath.Join(c.path, fmt.Sprintf(stateCacheFileFormat, slotNumber, elBlockHash.Hex()))
+}
+
+// Logs a line at the provided level if the logger is specified
+func (c *StateCache) logLine(level log.Level, format string, v ...interface{}) {
+	if c.log != nil {
+		c.log.Logf(level, format, v...)
+	}
+}
+
+// Rebuild the node and minipool lookups from the detail slices
+func (s *NetworkState) createLookups() {
+	s.NodeDetailsByAddress = make(map[common.Address]*rpstate.NativeNodeDetails, len(s.NodeDetails))
+	s.MinipoolDetailsByAddress = make(map[common.Address]*rpstate.NativeMinipoolDetails, len(s.MinipoolDetails))
+	s.MinipoolDetailsByNode = map[common.Address][]*rpstate.NativeMinipoolDetails{}
+	for i, details := range s.NodeDetails {
+		s.NodeDetailsByAddress[details.NodeAddress] = &s.NodeDetails[i]
+	}
+	for i, details := range s.MinipoolDetails {
+		s.MinipoolDetailsByAddress[details.MinipoolAddress] = &s.MinipoolDetails[i]
+		s.MinipoolDetailsByNode[details.NodeAddress] = append(s.MinipoolDetailsByNode[details.NodeAddress], &s.MinipoolDetails[i])
+	}
+}
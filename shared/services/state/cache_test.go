@@ -0,0 +1,228 @@
+package state
+
+import (
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Create a state with a node, two minipools and a validator for each of them, one of which doesn't exist on Beacon yet
+func newCacheTestState(slot uint64) (*NetworkState, types.ValidatorPubkey, types.ValidatorPubkey) {
+	state := newTestState(slot, common.HexToAddress("0xaa"), 2)
+	state.ElBlockNumber = slot + 1000
+	state.BeaconConfig = beacon.Eth2Config{SlotsPerEpoch: 32, SecondsPerSlot: 12, GenesisTime: 1606824023}
+	state.NetworkDetails = &rpstate.NetworkDetails{RewardIndex: 7, RplPrice: big.NewInt(12345)}
+
+	existing := types.BytesToValidatorPubkey(common.LeftPadBytes([]byte{0x01}, types.ValidatorPubkeyLength))
+	pending := types.BytesToValidatorPubkey(common.LeftPadBytes([]byte{0x02}, types.ValidatorPubkeyLength))
+	state.MinipoolDetails[0].Pubkey = existing
+	state.MinipoolDetails = append(state.MinipoolDetails, rpstate.NativeMinipoolDetails{
+		MinipoolAddress: common.HexToAddress("0x03"),
+		NodeAddress:     state.NodeDetails[0].NodeAddress,
+		Pubkey:          pending,
+		PenaltyCount:    big.NewInt(0),
+	})
+	state.ValidatorDetails = map[types.ValidatorPubkey]beacon.ValidatorStatus{
+		existing: {Pubkey: existing, Index: "42", Balance: 32000000000, Status: beacon.ValidatorState_ActiveOngoing, Exists: true},
+		pending:  {},
+	}
+	state.createLookups()
+	return state, existing, pending
+}
+
+// Serialize the details of a state for comparisons; validators are left out since their map can't be serialized
+func serializeState(t *testing.T, state *NetworkState) string {
+	bytes, err := json.Marshal([]interface{}{
+		state.ElBlockNumber,
+		state.BeaconSlotNumber,
+		state.BeaconConfig,
+		state.NetworkDetails,
+		state.NodeDetails,
+		state.MinipoolDetails,
+		state.OracleDaoMemberDetails,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bytes)
+}
+
+func TestStateCacheRoundTrip(t *testing.T) {
+	cache := NewStateCache(t.TempDir(), 1<<30, nil)
+	state, existing, pending := newCacheTestState(100)
+	hash := common.HexToHash("0x1234")
+	if err := cache.Save(state, hash); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, found, err := cache.Load(100, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatalf("saved state wasn't found")
+	}
+	if serializeState(t, loaded) != serializeState(t, state) {
+		t.Fatalf("loaded state differs from the saved one:\nsaved:  %s\nloaded: %s", serializeState(t, state), serializeState(t, loaded))
+	}
+	if !reflect.DeepEqual(loaded.ValidatorDetails, state.ValidatorDetails) {
+		t.Fatalf("loaded validators differ from the saved ones:\nsaved:  %+v\nloaded: %+v", state.ValidatorDetails, loaded.ValidatorDetails)
+	}
+
+	// The lookups point into the loaded slices
+	node := state.NodeDetails[0].NodeAddress
+	if loaded.NodeDetailsByAddress[node] != &loaded.NodeDetails[0] {
+		t.Fatalf("node lookup wasn't rebuilt")
+	}
+	if loaded.MinipoolDetailsByAddress[common.HexToAddress("0x03")] != &loaded.MinipoolDetails[1] {
+		t.Fatalf("minipool lookup wasn't rebuilt")
+	}
+	if len(loaded.MinipoolDetailsByNode[node]) != 2 {
+		t.Fatalf("expected 2 minipools for the node, got %d", len(loaded.MinipoolDetailsByNode[node]))
+	}
+
+	// Validators that don't exist yet are still keyed by the pubkey they were requested for
+	if status, exists := loaded.ValidatorDetails[pending]; !exists || status.Exists {
+		t.Fatalf("expected a placeholder status for the pending validator, got %+v (exists %t)", status, exists)
+	}
+	if status := loaded.ValidatorDetails[existing]; !status.Exists || status.Index != "42" {
+		t.Fatalf("unexpected status for the existing validator: %+v", status)
+	}
+}
+
+func TestStateCacheMissesWrongHash(t *testing.T) {
+	cache := NewStateCache(t.TempDir(), 1<<30, nil)
+	state, _, _ := newCacheTestState(100)
+	if err := cache.Save(state, common.HexToHash("0x1234")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same slot on a different chain must not be loaded
+	_, found, err := cache.Load(100, common.HexToHash("0x5678"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Fatalf("state was loaded for the wrong EL block hash")
+	}
+}
+
+func TestStateCacheRejectsOtherVersions(t *testing.T) {
+	cache := NewStateCache(t.TempDir(), 1<<30, nil)
+	state, _, _ := newCacheTestState(100)
+	hash := common.HexToHash("0x1234")
+	if err := cache.Save(state, hash); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rewrite the entry as if an older version of the cache had saved it
+	path := cache.getFilePath(100, hash)
+	compressedBytes, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decoder.Close()
+	bytes, err := decoder.DecodeAll(compressedBytes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cached cachedNetworkState
+	if err := json.Unmarshal(bytes, &cached); err != nil {
+		t.Fatal(err)
+	}
+	cached.Version = stateCacheVersion - 1
+	bytes, err = json.Marshal(cached)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer encoder.Close()
+	if err := os.WriteFile(path, encoder.EncodeAll(bytes, nil), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, found, err := cache.Load(100, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Fatalf("state from a different cache version was loaded")
+	}
+}
+
+func TestStateCachePrunesLeastRecentlyUsed(t *testing.T) {
+	cache := NewStateCache(t.TempDir(), 1<<30, nil)
+	hash := common.HexToHash("0x1234")
+	sizes := map[uint64]uint64{}
+	start := time.Now().Add(-time.Hour)
+	for i, slot := range []uint64{1, 2, 3} {
+		state, _, _ := newCacheTestState(slot)
+		if err := cache.Save(state, hash); err != nil {
+			t.Fatal(err)
+		}
+
+		// Save them a minute apart so the order doesn't depend on the filesystem's timestamp resolution
+		path := cache.getFilePath(slot, hash)
+		modTime := start.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes[slot] = uint64(info.Size())
+	}
+
+	// Loading the oldest state makes it the most recently used
+	if _, found, err := cache.Load(1, hash); err != nil || !found {
+		t.Fatalf("couldn't load slot 1 (found %t, err %v)", found, err)
+	}
+
+	requireCached := func(expected ...uint64) {
+		t.Helper()
+		for _, slot := range []uint64{1, 2, 3} {
+			_, err := os.Stat(cache.getFilePath(slot, hash))
+			shouldExist := false
+			for _, expectedSlot := range expected {
+				shouldExist = shouldExist || expectedSlot == slot
+			}
+			if shouldExist && err != nil {
+				t.Fatalf("slot %d should still be cached: %v", slot, err)
+			}
+			if !shouldExist && !os.IsNotExist(err) {
+				t.Fatalf("slot %d should have been pruned", slot)
+			}
+		}
+	}
+
+	result, err := cache.Prune(sizes[1] + sizes[3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RemovedFiles != 1 || result.FreedBytes != sizes[2] || result.RemainingBytes != sizes[1]+sizes[3] {
+		t.Fatalf("unexpected prune result: %+v", result)
+	}
+	requireCached(1, 3)
+
+	if _, err := cache.Prune(sizes[1]); err != nil {
+		t.Fatal(err)
+	}
+	requireCached(1)
+}
@@ -0,0 +1,99 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The Execution layer reads used to build a network state at a single EL block.
+// States are built on top of this instead of the contracts directly so incremental updates can be checked against full
+// refreshes without a live network.
+type stateReader interface {
+	getNetworkDetails() (*rpstate.NetworkDetails, error)
+	getAllNodeDetails() ([]rpstate.NativeNodeDetails, error)
+	getAllMinipoolDetails() ([]rpstate.NativeMinipoolDetails, error)
+	getNodeDetails(address common.Address) (rpstate.NativeNodeDetails, error)
+	getMinipoolDetails(address common.Address) (rpstate.NativeMinipoolDetails, error)
+	getOracleDaoMemberDetails() ([]rpstate.OracleDaoMemberDetails, error)
+	getEthBalances(addresses []common.Address) ([]*big.Int, error)
+	getLatestMinipoolDelegate() (common.Address, error)
+	getStateChanges(fromBlock uint64, toBlock uint64) (*stateChanges, error)
+	calculateCompleteMinipoolShares(minipoolDetails []*rpstate.NativeMinipoolDetails, beaconBalances []*big.Int) error
+}
+
+// Reads the network state from the Rocket Pool contracts
+type chainStateReader struct {
+	cfg       *config.RocketPoolConfig
+	rp        *rocketpool.RocketPool
+	contracts *rpstate.NetworkContracts
+	opts      *bind.CallOpts
+}
+
+// Create a reader for the network state at the provided EL block
+func newChainStateReader(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, elBlockNumber uint64) (*chainStateReader, error) {
+	multicallerAddress := common.HexToAddress(cfg.Smartnode.GetMulticallAddress())
+	balanceBatcherAddress := common.HexToAddress(cfg.Smartnode.GetBalanceBatcherAddress())
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(elBlockNumber),
+	}
+	contracts, err := rpstate.NewNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network contracts: %w", err)
+	}
+	return &chainStateReader{
+		cfg:       cfg,
+		rp:        rp,
+		contracts: contracts,
+		opts:      opts,
+	}, nil
+}
+
+func (r *chainStateReader) getNetworkDetails() (*rpstate.NetworkDetails, error) {
+	return rpstate.NewNetworkDetails(r.rp, r.contracts)
+}
+
+func (r *chainStateReader) getAllNodeDetails() ([]rpstate.NativeNodeDetails, error) {
+	return rpstate.GetAllNativeNodeDetails(r.rp, r.contracts)
+}
+
+func (r *chainStateReader) getAllMinipoolDetails() ([]rpstate.NativeMinipoolDetails, error) {
+	return rpstate.GetAllNativeMinipoolDetails(r.rp, r.contracts)
+}
+
+func (r *chainStateReader) getNodeDetails(address common.Address) (rpstate.NativeNodeDetails, error) {
+	return rpstate.GetNativeNodeDetails(r.rp, r.contracts, address)
+}
+
+func (r *chainStateReader) getMinipoolDetails(address common.Address) (rpstate.NativeMinipoolDetails, error) {
+	return rpstate.GetNativeMinipoolDetails(r.rp, r.contracts, address)
+}
+
+func (r *chainStateReader) getOracleDaoMemberDetails() ([]rpstate.OracleDaoMemberDetails, error) {
+	return rpstate.GetAllOracleDaoMemberDetails(r.rp, r.contracts)
+}
+
+func (r *chainStateReader) getEthBalances(addresses []common.Address) ([]*big.Int, error) {
+	return r.contracts.BalanceBatcher.GetEthBalances(addresses, r.opts)
+}
+
+func (r *chainStateReader) getLatestMinipoolDelegate() (common.Address, error) {
+	address, err := r.rp.GetAddress("rocketMinipoolDelegate", r.opts)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting the latest minipool delegate: %w", err)
+	}
+	return *address, nil
+}
+
+func (r *chainStateReader) getStateChanges(fromBlock uint64, toBlock uint64) (*stateChanges, error) {
+	return getStateChanges(r.cfg, r.rp, r.contracts, fromBlock, toBlock, r.opts)
+}
+
+func (r *chainStateReader) calculateCompleteMinipoolShares(minipoolDetails []*rpstate.NativeMinipoolDetails, beaconBalances []*big.Int) error {
+	return rpstate.CalculateCompleteMinipoolShares(r.rp, r.contracts, minipoolDetails, beaconBalances)
+}
@@ -0,0 +1,618 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	// The most EL blocks an incremental update can span before a full refresh is cheaper
+	maxIncrementalBlocks uint64 = 7200
+
+	// The most nodes or minipools an incremental update can re-query before a full refresh is cheaper
+	maxIncrementalEntities int = 500
+
+	// The most differences to log when an incremental state doesn't match a full refresh
+	maxLoggedDifferences int = 10
+
+	// How often states are created from scratch even when they could be updated, as a consistency check
+	fullStateRefreshInterval time.Duration = time.Hour
+)
+
+// Rocket Pool events that change the details of a minipool or node
+var (
+	minipoolManagerEvents = []string{"MinipoolCreated", "MinipoolDestroyed"}
+	minipoolEvents        = []string{"StatusUpdated", "BondReduced", "MinipoolPromoted", "MinipoolVacancyPrepared"}
+	bondReducerEvents     = []string{"BeginBondReduction", "ReductionCancelled"}
+	depositPoolEvents     = []string{"DepositAssigned"}
+	nodeStakingEvents     = []string{"RPLStaked", "RPLWithdrawn", "RPLSlashed"}
+	nodeManagerEvents     = []string{"NodeRegistered", "NodeRewardNetworkChanged", "NodeSmoothingPoolStateChanged", "NodeTimezoneLocationSet"}
+	tokenEvents           = []string{"Transfer"}
+)
+
+// Events from contracts whose ABIs aren't part of the network contracts, by signature
+const (
+	withdrawalAddressSetEvent string = "NodeWithdrawalAddressSet(address,address,uint256)"
+	distributorCreatedEvent   string = "ProxyCreated(address)"
+	delegateUpgradedEvent     string = "DelegateUpgraded(address,address,uint256)"
+	delegateRolledBackEvent   string = "DelegateRolledBack(address,address,uint256)"
+	penaltyUpdatedEvent       string = "PenaltyUpdated(address,uint256,uint256)"
+)
+
+// The nodes and minipools affected by the Rocket Pool events between two EL blocks
+type stateChanges struct {
+	createdMinipools   map[common.Address]common.Address
+	destroyedMinipools map[common.Address]bool
+	minipools          map[common.Address]bool
+	registeredNodes    map[common.Address]bool
+	nodes              map[common.Address]bool
+	slashedNodes       map[common.Address]bool
+	distributors       map[common.Address]bool
+}
+
+// Updates a previous snapshot of the Rocket Pool network to the provided Beacon slot, re-querying only the nodes and minipools
+// that have changed since it was created. If nodeAddress is provided, the previous state must only contain that node.
+// Returns an error if the update can't be done incrementally, in which case a new state should be created from scratch.
+func UpdateNetworkState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, bc beacon.Client, log *log.ColorLogger, previous *NetworkState, slotNumber uint64, beaconConfig beacon.Eth2Config, nodeAddress *common.Address) (*NetworkState, error) {
+	// Get the execution block for the given slot
+	elBlockNumber, err := getElBlockForSlot(bc, slotNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the update goes forward and isn't too large
+	if slotNumber < previous.BeaconSlotNumber || elBlockNumber < previous.ElBlockNumber {
+		return nil, fmt.Errorf("slot %d is before the previous state's slot %d", slotNumber, previous.BeaconSlotNumber)
+	}
+	if elBlockNumber-previous.ElBlockNumber > maxIncrementalBlocks {
+		return nil, fmt.Errorf("EL block %d is too far ahead of the previous state's block %d", elBlockNumber, previous.ElBlockNumber)
+	}
+
+	// Get the relevant network contracts
+	reader, err := newChainStateReader(cfg, rp, elBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return updateNetworkState(reader, bc, log, previous, slotNumber, elBlockNumber, beaconConfig, nodeAddress)
+}
+
+// Updates a previous snapshot of the Rocket Pool network to the provided slot and its EL block
+func updateNetworkState(reader stateReader, bc beacon.Client, log *log.ColorLogger, previous *NetworkState, slotNumber uint64, elBlockNumber uint64, beaconConfig beacon.Eth2Config, nodeAddress *common.Address) (*NetworkState, error) {
+	// Create the state wrapper
+	state := &NetworkState{
+		BeaconSlotNumber: slotNumber,
+		ElBlockNumber:    elBlockNumber,
+		BeaconConfig:     beaconConfig,
+		log:              log,
+	}
+
+	state.logLine("Updating network state from EL block %d to %d, Beacon slot %d", previous.ElBlockNumber, elBlockNumber, slotNumber)
+	start := time.Now()
+
+	// Network details
+	var err error
+	state.NetworkDetails, err = reader.getNetworkDetails()
+	if err != nil {
+		return nil, fmt.Errorf("error getting network details: %w", err)
+	}
+	state.logLine("1/6 - Retrieved network details (%s so far)", time.Since(start))
+
+	// Get the nodes and minipools that emitted events
+	changes := newStateChanges()
+	if elBlockNumber > previous.ElBlockNumber {
+		changes, err = reader.getStateChanges(previous.ElBlockNumber+1, elBlockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("error getting network events: %w", err)
+		}
+	}
+
+	// Resolve the events that only identify a node indirectly
+	if len(changes.distributors) > 0 {
+		for _, node := range previous.NodeDetails {
+			if changes.distributors[node.FeeDistributorAddress] {
+				changes.nodes[node.NodeAddress] = true
+			}
+		}
+	}
+	for node := range changes.slashedNodes {
+		for _, mpd := range previous.MinipoolDetailsByNode[node] {
+			changes.minipools[mpd.MinipoolAddress] = true
+		}
+	}
+
+	// Minipools following the latest delegate have a new effective delegate when it's upgraded
+	latestDelegate, err := reader.getLatestMinipoolDelegate()
+	if err != nil {
+		return nil, err
+	}
+	for _, mpd := range previous.MinipoolDetails {
+		if mpd.UseLatestDelegate && mpd.EffectiveDelegate != latestDelegate {
+			changes.minipools[mpd.MinipoolAddress] = true
+		}
+	}
+
+	// Ignore changes to entities that aren't tracked by the previous state
+	isTrackedNode := func(address common.Address) bool {
+		if nodeAddress != nil {
+			return address == *nodeAddress
+		}
+		_, exists := previous.NodeDetailsByAddress[address]
+		return exists
+	}
+	for minipoolAddress := range changes.minipools {
+		if _, exists := previous.MinipoolDetailsByAddress[minipoolAddress]; !exists {
+			delete(changes.minipools, minipoolAddress)
+		}
+	}
+	for minipoolAddress, node := range changes.createdMinipools {
+		if nodeAddress != nil && node != *nodeAddress {
+			delete(changes.createdMinipools, minipoolAddress)
+			continue
+		}
+		changes.nodes[node] = true
+	}
+	for address := range changes.nodes {
+		if !isTrackedNode(address) && (nodeAddress != nil || !changes.registeredNodes[address]) {
+			delete(changes.nodes, address)
+		}
+	}
+
+	// Withdrawals and priority fees move ETH without emitting events, so the balances are all read in one batch to find the
+	// minipools whose balance shares have changed
+	minipoolAddresses := make([]common.Address, len(previous.MinipoolDetails))
+	for i, mpd := range previous.MinipoolDetails {
+		minipoolAddresses[i] = mpd.MinipoolAddress
+	}
+	minipoolBalances, err := reader.getEthBalances(minipoolAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool balances: %w", err)
+	}
+	for i, mpd := range previous.MinipoolDetails {
+		if mpd.Balance == nil || minipoolBalances[i].Cmp(mpd.Balance) != 0 {
+			changes.minipools[mpd.MinipoolAddress] = true
+		}
+	}
+
+	// The owners of changed minipools need to be re-queried too
+	for minipoolAddress := range changes.minipools {
+		changes.nodes[previous.MinipoolDetailsByAddress[minipoolAddress].NodeAddress] = true
+	}
+	for minipoolAddress := range changes.destroyedMinipools {
+		if mpd, exists := previous.MinipoolDetailsByAddress[minipoolAddress]; exists {
+			changes.nodes[mpd.NodeAddress] = true
+		}
+	}
+
+	// A change to the RPL price or collateral limits changes the effective stake of every node
+	if previous.NetworkDetails.RplPrice.Cmp(state.NetworkDetails.RplPrice) != 0 ||
+		previous.NetworkDetails.MinCollateralFraction.Cmp(state.NetworkDetails.MinCollateralFraction) != 0 ||
+		previous.NetworkDetails.MaxCollateralFraction.Cmp(state.NetworkDetails.MaxCollateralFraction) != 0 {
+		for _, node := range previous.NodeDetails {
+			changes.nodes[node.NodeAddress] = true
+		}
+	}
+	if len(changes.nodes) > maxIncrementalEntities || len(changes.minipools)+len(changes.createdMinipools) > maxIncrementalEntities {
+		return nil, fmt.Errorf("too many changes to update incrementally (%d nodes, %d minipools)", len(changes.nodes), len(changes.minipools)+len(changes.createdMinipools))
+	}
+
+	// Node details
+	state.NodeDetails = make([]rpstate.NativeNodeDetails, 0, len(previous.NodeDetails)+len(changes.nodes))
+	for _, node := range previous.NodeDetails {
+		address := node.NodeAddress
+		if changes.nodes[address] {
+			node, err = reader.getNodeDetails(address)
+			if err != nil {
+				return nil, fmt.Errorf("error getting details for node %s: %w", address.Hex(), err)
+			}
+		}
+		state.NodeDetails = append(state.NodeDetails, node)
+	}
+	newNodes := []common.Address{}
+	for address := range changes.nodes {
+		if _, exists := previous.NodeDetailsByAddress[address]; !exists {
+			newNodes = append(newNodes, address)
+		}
+	}
+	sortAddresses(newNodes)
+	for _, address := range newNodes {
+		node, err := reader.getNodeDetails(address)
+		if err != nil {
+			return nil, fmt.Errorf("error getting details for node %s: %w", address.Hex(), err)
+		}
+		state.NodeDetails = append(state.NodeDetails, node)
+	}
+
+	// Nodes that weren't re-queried still need their current ETH and distributor balances
+	err = updateNodeBalances(reader, state.NodeDetails)
+	if err != nil {
+		return nil, err
+	}
+	state.logLine("2/6 - Updated node details (%s so far)", time.Since(start))
+
+	// Minipool details
+	requeried := map[common.Address]bool{}
+	state.MinipoolDetails = make([]rpstate.NativeMinipoolDetails, 0, len(previous.MinipoolDetails)+len(changes.createdMinipools))
+	for _, mpd := range previous.MinipoolDetails {
+		address := mpd.MinipoolAddress
+		if changes.destroyedMinipools[address] {
+			continue
+		}
+		if changes.minipools[address] {
+			mpd, err = reader.getMinipoolDetails(address)
+			if err != nil {
+				return nil, fmt.Errorf("error getting details for minipool %s: %w", address.Hex(), err)
+			}
+			requeried[address] = true
+		}
+		state.MinipoolDetails = append(state.MinipoolDetails, mpd)
+	}
+	newMinipools := make([]common.Address, 0, len(changes.createdMinipools))
+	for address := range changes.createdMinipools {
+		if _, exists := previous.MinipoolDetailsByAddress[address]; !exists && !changes.destroyedMinipools[address] {
+			newMinipools = append(newMinipools, address)
+		}
+	}
+	sortAddresses(newMinipools)
+	for _, address := range newMinipools {
+		mpd, err := reader.getMinipoolDetails(address)
+		if err != nil {
+			return nil, fmt.Errorf("error getting details for minipool %s: %w", address.Hex(), err)
+		}
+		requeried[address] = true
+		state.MinipoolDetails = append(state.MinipoolDetails, mpd)
+	}
+	state.logLine("3/6 - Updated %d node and %d minipool details (%s so far)", len(changes.nodes), len(requeried), time.Since(start))
+
+	// Create the lookups
+	state.createLookups()
+
+	// Calculate avg node fees and distributor shares; these are calculated in place, so they can't share values with the previous state
+	for i := range state.NodeDetails {
+		details := &state.NodeDetails[i]
+		details.AverageNodeFee = big.NewInt(0)
+		details.DistributorBalanceUserETH = big.NewInt(0)
+		details.DistributorBalanceNodeETH = big.NewInt(0)
+		rpstate.CalculateAverageFeeAndDistributorShares(nil, nil, *details, state.MinipoolDetailsByNode[details.NodeAddress])
+	}
+
+	// Oracle DAO member details
+	if nodeAddress == nil {
+		state.OracleDaoMemberDetails, err = reader.getOracleDaoMemberDetails()
+		if err != nil {
+			return nil, fmt.Errorf("error getting Oracle DAO details: %w", err)
+		}
+	}
+	state.logLine("4/6 - Retrieved Oracle DAO details (%s so far)", time.Since(start))
+
+	// Get the validator stats from Beacon
+	pubkeys := make([]types.ValidatorPubkey, 0, len(state.MinipoolDetails))
+	emptyPubkey := types.ValidatorPubkey{}
+	for _, mpd := range state.MinipoolDetails {
+		if mpd.Pubkey != emptyPubkey {
+			pubkeys = append(pubkeys, mpd.Pubkey)
+		}
+	}
+	state.ValidatorDetails, err = bc.GetValidatorStatuses(pubkeys, &beacon.ValidatorStatusOptions{
+		Slot: &slotNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	state.logLine("5/6 - Retrieved validator details (total time: %s)", time.Since(start))
+
+	// Get the complete node and user shares for the minipools whose contract or Beacon balance changed
+	mpds := []*rpstate.NativeMinipoolDetails{}
+	beaconBalances := []*big.Int{}
+	for i, mpd := range state.MinipoolDetails {
+		validator := state.ValidatorDetails[mpd.Pubkey]
+		previousValidator := previous.ValidatorDetails[mpd.Pubkey]
+		if !requeried[mpd.MinipoolAddress] && validator.Exists == previousValidator.Exists && validator.Balance == previousValidator.Balance {
+			continue
+		}
+		mpds = append(mpds, &state.MinipoolDetails[i])
+		if !validator.Exists {
+			beaconBalances = append(beaconBalances, big.NewInt(0))
+		} else {
+			beaconBalances = append(beaconBalances, eth.GweiToWei(float64(validator.Balance)))
+		}
+	}
+	err = reader.calculateCompleteMinipoolShares(mpds, beaconBalances)
+	if err != nil {
+		return nil, err
+	}
+	state.logLine("6/6 - Calculated complete node and user balance shares for %d minipools (total time: %s)", len(mpds), time.Since(start))
+
+	return state, nil
+}
+
+// Updates a previous snapshot of a single node to the provided Beacon slot.
+// Also gets the total effective RPL stake of the network for convenience since this is required by several node routines.
+func UpdateNetworkStateForNode(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, bc beacon.Client, log *log.ColorLogger, previous *NetworkState, slotNumber uint64, beaconConfig beacon.Eth2Config, nodeAddress common.Address, calculateTotalEffectiveStake bool) (*NetworkState, *big.Int, error) {
+	state, err := UpdateNetworkState(cfg, rp, bc, log, previous, slotNumber, beaconConfig, &nodeAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !calculateTotalEffectiveStake {
+		return state, nil, nil
+	}
+
+	// Get the total network effective RPL stake
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	contracts, err := rpstate.NewNetworkContracts(rp, common.HexToAddress(cfg.Smartnode.GetMulticallAddress()), common.HexToAddress(cfg.Smartnode.GetBalanceBatcherAddress()), opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting network contracts: %w", err)
+	}
+	totalEffectiveStake, err := rpstate.GetTotalEffectiveRplStake(rp, contracts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error calculating total effective RPL stake for the network: %w", err)
+	}
+	return state, totalEffectiveStake, nil
+}
+
+// Get the nodes and minipools affected by Rocket Pool events between the provided EL blocks (inclusive)
+func getStateChanges(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, contracts *rpstate.NetworkContracts, fromBlock uint64, toBlock uint64, opts *bind.CallOpts) (*stateChanges, error) {
+	changes := newStateChanges()
+	intervalSize, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the IDs of the events emitted by the network contracts
+	topics := []common.Hash{}
+	eventNames := map[common.Hash]string{}
+	addresses := []common.Address{}
+	contractEvents := []struct {
+		contract *rocketpool.Contract
+		events   []string
+	}{
+		{contracts.RocketMinipoolManager, minipoolManagerEvents},
+		{contracts.RocketMinipoolBondReducer, bondReducerEvents},
+		{contracts.RocketDepositPool, depositPoolEvents},
+		{contracts.RocketNodeStaking, nodeStakingEvents},
+		{contracts.RocketNodeManager, nodeManagerEvents},
+		{contracts.RocketTokenRETH, tokenEvents},
+		{contracts.RocketTokenRPL, tokenEvents},
+		{contracts.RocketTokenRPLFixedSupply, tokenEvents},
+	}
+	for _, contractEvent := range contractEvents {
+		if contractEvent.contract == nil {
+			return nil, fmt.Errorf("network contracts are missing a contract required for incremental updates")
+		}
+		ids, err := getEventIDs(contractEvent.contract.ABI, contractEvent.events)
+		if err != nil {
+			return nil, err
+		}
+		for i, id := range ids {
+			if _, exists := eventNames[id]; !exists {
+				topics = append(topics, id)
+				eventNames[id] = contractEvent.events[i]
+			}
+		}
+		addresses = append(addresses, *contractEvent.contract.Address)
+	}
+	for _, contractEvent := range []struct {
+		contract *rocketpool.Contract
+		event    string
+	}{
+		{contracts.RocketStorage, withdrawalAddressSetEvent},
+		{contracts.RocketNodeDistributorFactory, distributorCreatedEvent},
+	} {
+		if contractEvent.contract == nil {
+			return nil, fmt.Errorf("network contracts are missing a contract required for incremental updates")
+		}
+		id := crypto.Keccak256Hash([]byte(contractEvent.event))
+		topics = append(topics, id)
+		eventNames[id] = contractEvent.event
+		addresses = append(addresses, *contractEvent.contract.Address)
+	}
+
+	// Get the network contract events
+	from := big.NewInt(0).SetUint64(fromBlock)
+	to := big.NewInt(0).SetUint64(toBlock)
+	logs, err := eth.GetLogs(rp, addresses, [][]common.Hash{topics}, big.NewInt(int64(intervalSize)), from, to, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network contract events: %w", err)
+	}
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+
+		// The new distributor's address isn't indexed
+		if eventNames[log.Topics[0]] == distributorCreatedEvent {
+			if len(log.Data) >= common.HashLength {
+				changes.distributors[common.BytesToAddress(log.Data[:common.HashLength])] = true
+			}
+			continue
+		}
+		if len(log.Topics) < 2 {
+			continue
+		}
+		first := common.BytesToAddress(log.Topics[1].Bytes())
+		switch eventNames[log.Topics[0]] {
+		case "MinipoolCreated":
+			if len(log.Topics) > 2 {
+				changes.createdMinipools[first] = common.BytesToAddress(log.Topics[2].Bytes())
+			}
+		case "MinipoolDestroyed":
+			changes.destroyedMinipools[first] = true
+		case "BeginBondReduction", "ReductionCancelled", "DepositAssigned":
+			changes.minipools[first] = true
+		case "NodeRegistered":
+			changes.registeredNodes[first] = true
+			changes.nodes[first] = true
+		case "RPLSlashed":
+			changes.slashedNodes[first] = true
+			changes.nodes[first] = true
+		case "Transfer":
+			changes.nodes[first] = true
+			if len(log.Topics) > 2 {
+				changes.nodes[common.BytesToAddress(log.Topics[2].Bytes())] = true
+			}
+		default:
+			changes.nodes[first] = true
+		}
+	}
+
+	// Minipools emit their own events and penalties are applied by a contract outside the network contracts, so these have to
+	// be filtered by topic alone
+	minipoolAbi, err := rp.GetABI("rocketMinipoolDelegate", opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool ABI: %w", err)
+	}
+	minipoolTopics, err := getEventIDs(minipoolAbi, minipoolEvents)
+	if err != nil {
+		return nil, err
+	}
+	penaltyTopic := crypto.Keccak256Hash([]byte(penaltyUpdatedEvent))
+	minipoolTopics = append(minipoolTopics,
+		crypto.Keccak256Hash([]byte(delegateUpgradedEvent)),
+		crypto.Keccak256Hash([]byte(delegateRolledBackEvent)),
+		penaltyTopic,
+	)
+	logs, err = eth.GetLogs(rp, nil, [][]common.Hash{minipoolTopics}, big.NewInt(int64(intervalSize)), from, to, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool events: %w", err)
+	}
+	for _, log := range logs {
+		if len(log.Topics) > 0 && log.Topics[0] == penaltyTopic {
+			if len(log.Topics) > 1 {
+				changes.minipools[common.BytesToAddress(log.Topics[1].Bytes())] = true
+			}
+			continue
+		}
+		changes.minipools[log.Address] = true
+	}
+
+	return changes, nil
+}
+
+// Create an empty set of changes
+func newStateChanges() *stateChanges {
+	return &stateChanges{
+		createdMinipools:   map[common.Address]common.Address{},
+		destroyedMinipools: map[common.Address]bool{},
+		minipools:          map[common.Address]bool{},
+		registeredNodes:    map[common.Address]bool{},
+		nodes:              map[common.Address]bool{},
+		slashedNodes:       map[common.Address]bool{},
+		distributors:       map[common.Address]bool{},
+	}
+}
+
+// Get the IDs of the provided events, failing if any of them are missing from the ABI
+func getEventIDs(contractAbi *abi.ABI, names []string) ([]common.Hash, error) {
+	ids := make([]common.Hash, len(names))
+	for i, name := range names {
+		event, exists := contractAbi.Events[name]
+		if !exists {
+			return nil, fmt.Errorf("event %s was not found in the contract ABI", name)
+		}
+		ids[i] = event.ID
+	}
+	return ids, nil
+}
+
+// Refresh the ETH balances and fee distributor balances of the provided nodes
+func updateNodeBalances(reader stateReader, nodeDetails []rpstate.NativeNodeDetails) error {
+	addresses := make([]common.Address, len(nodeDetails))
+	distributorAddresses := make([]common.Address, len(nodeDetails))
+	for i, details := range nodeDetails {
+		addresses[i] = details.NodeAddress
+		distributorAddresses[i] = details.FeeDistributorAddress
+	}
+
+	balances, err := reader.getEthBalances(addresses)
+	if err != nil {
+		return fmt.Errorf("error getting node balances: %w", err)
+	}
+	distributorBalances, err := reader.getEthBalances(distributorAddresses)
+	if err != nil {
+		return fmt.Errorf("error getting distributor balances: %w", err)
+	}
+	for i := range nodeDetails {
+		nodeDetails[i].BalanceETH = balances[i]
+		nodeDetails[i].DistributorBalance = distributorBalances[i]
+	}
+	return nil
+}
+
+// Compare an incrementally updated state with one created from scratch at the same slot, returning a description of each difference.
+// A node's pending withdrawal address and a minipool's choice to follow the latest delegate change without emitting an event, so
+// incremental updates can't track them; they're only refreshed by full states and aren't counted as differences.
+func compareStates(incremental *NetworkState, full *NetworkState) []string {
+	differences := []string{}
+	if len(incremental.NodeDetails) != len(full.NodeDetails) {
+		differences = append(differences, fmt.Sprintf("node count is %d but should be %d", len(incremental.NodeDetails), len(full.NodeDetails)))
+	}
+	if len(incremental.MinipoolDetails) != len(full.MinipoolDetails) {
+		differences = append(differences, fmt.Sprintf("minipool count is %d but should be %d", len(incremental.MinipoolDetails), len(full.MinipoolDetails)))
+	}
+
+	for _, expected := range full.NodeDetails {
+		actual, exists := incremental.NodeDetailsByAddress[expected.NodeAddress]
+		if !exists {
+			differences = append(differences, fmt.Sprintf("node %s is missing", expected.NodeAddress.Hex()))
+			continue
+		}
+		node := *actual
+		node.PendingWithdrawalAddress = expected.PendingWithdrawalAddress
+		if !jsonEqual(node, expected) {
+			differences = append(differences, fmt.Sprintf("node %s is out of date", expected.NodeAddress.Hex()))
+		}
+	}
+	for _, expected := range full.MinipoolDetails {
+		actual, exists := incremental.MinipoolDetailsByAddress[expected.MinipoolAddress]
+		if !exists {
+			differences = append(differences, fmt.Sprintf("minipool %s is missing", expected.MinipoolAddress.Hex()))
+			continue
+		}
+		mpd := *actual
+		if mpd.UseLatestDelegate != expected.UseLatestDelegate {
+			mpd.UseLatestDelegate = expected.UseLatestDelegate
+			mpd.EffectiveDelegate = expected.EffectiveDelegate
+		}
+		if !jsonEqual(mpd, expected) {
+			differences = append(differences, fmt.Sprintf("minipool %s is out of date", expected.MinipoolAddress.Hex()))
+		}
+	}
+	return differences
+}
+
+// Check if two values have the same JSON representation, which normalizes the big.Int fields
+func jsonEqual(a interface{}, b interface{}) bool {
+	aBytes, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bBytes, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(aBytes) == string(bBytes)
+}
+
+// Sort addresses so new entities are added in a deterministic order
+func sortAddresses(addresses []common.Address) {
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+}
@@ -0,0 +1,409 @@
+package state
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Create a state with one node and one minipool owned by it
+func newTestState(slot uint64, withdrawalAddress common.Address, penaltyCount int64) *NetworkState {
+	node := common.HexToAddress("0x01")
+	state := &NetworkState{
+		BeaconSlotNumber: slot,
+		NodeDetails: []rpstate.NativeNodeDetails{
+			{NodeAddress: node, WithdrawalAddress: withdrawalAddress, DepositCreditBalance: big.NewInt(0)},
+		},
+		MinipoolDetails: []rpstate.NativeMinipoolDetails{
+			{MinipoolAddress: common.HexToAddress("0x02"), NodeAddress: node, PenaltyCount: big.NewInt(penaltyCount)},
+		},
+	}
+	state.createLookups()
+	return state
+}
+
+func discardLog(level log.Level, slot uint64, format string, v ...interface{}) {}
+
+func TestCompareStates(t *testing.T) {
+	full := newTestState(10, common.HexToAddress("0xaa"), 0)
+	if differences := compareStates(newTestState(10, common.HexToAddress("0xaa"), 0), full); len(differences) != 0 {
+		t.Fatalf("identical states had differences: %v", differences)
+	}
+
+	differences := compareStates(newTestState(10, common.HexToAddress("0xbb"), 1), full)
+	if len(differences) != 2 {
+		t.Fatalf("expected a node and a minipool difference, got %v", differences)
+	}
+
+	missing := newTestState(10, common.HexToAddress("0xaa"), 0)
+	missing.MinipoolDetails = nil
+	missing.createLookups()
+	if differences := compareStates(missing, full); len(differences) != 2 {
+		t.Fatalf("expected a count and a missing minipool difference, got %v", differences)
+	}
+}
+
+func TestTrackedStateUsesUpdatesUntilRefresh(t *testing.T) {
+	tracked := &trackedState{}
+	creates, updates := 0, 0
+	create := func(slot uint64) func() (*NetworkState, error) {
+		return func() (*NetworkState, error) {
+			creates++
+			return newTestState(slot, common.HexToAddress("0xaa"), 0), nil
+		}
+	}
+	update := func(slot uint64) func(*NetworkState) (*NetworkState, error) {
+		return func(previous *NetworkState) (*NetworkState, error) {
+			updates++
+			return newTestState(slot, common.HexToAddress("0xaa"), 0), nil
+		}
+	}
+
+	state, full, err := tracked.get(10, create(10), update(10), discardLog)
+	if err != nil || !full || state.BeaconSlotNumber != 10 || creates != 1 {
+		t.Fatalf("first state should be created from scratch (full %t, creates %d, err %v)", full, creates, err)
+	}
+	state, full, err = tracked.get(11, create(11), update(11), discardLog)
+	if err != nil || full || state.BeaconSlotNumber != 11 || updates != 1 || creates != 1 {
+		t.Fatalf("second state should be updated (full %t, creates %d, updates %d, err %v)", full, creates, updates, err)
+	}
+
+	// A consistent refresh keeps incremental updates enabled
+	tracked.lastFullRefresh = time.Now().Add(-2 * fullStateRefreshInterval)
+	_, full, err = tracked.get(12, create(12), update(12), discardLog)
+	if err != nil || !full || creates != 2 || tracked.incrementalDisabled {
+		t.Fatalf("refresh should create a full state and keep updates enabled (full %t, creates %d, err %v)", full, creates, err)
+	}
+}
+
+func TestTrackedStateDisablesUpdatesOnDifference(t *testing.T) {
+	tracked := &trackedState{}
+	creates, updates := 0, 0
+	create := func(slot uint64) func() (*NetworkState, error) {
+		return func() (*NetworkState, error) {
+			creates++
+			return newTestState(slot, common.HexToAddress("0xaa"), 1), nil
+		}
+	}
+	staleUpdate := func(slot uint64) func(*NetworkState) (*NetworkState, error) {
+		return func(previous *NetworkState) (*NetworkState, error) {
+			updates++
+			return newTestState(slot, common.HexToAddress("0xaa"), 0), nil
+		}
+	}
+
+	if _, _, err := tracked.get(10, create(10), staleUpdate(10), discardLog); err != nil {
+		t.Fatal(err)
+	}
+	tracked.lastFullRefresh = time.Time{}
+	state, full, err := tracked.get(11, create(11), staleUpdate(11), discardLog)
+	if err != nil || !full || state.MinipoolDetails[0].PenaltyCount.Int64() != 1 {
+		t.Fatalf("the full state should be returned when the update is stale (full %t, err %v)", full, err)
+	}
+	if !tracked.incrementalDisabled {
+		t.Fatal("incremental updates should be disabled after a difference")
+	}
+
+	// Later states are always created from scratch
+	_, full, err = tracked.get(12, create(12), staleUpdate(12), discardLog)
+	if err != nil || !full || updates != 1 || creates != 3 {
+		t.Fatalf("states should be created from scratch once updates are disabled (full %t, creates %d, updates %d, err %v)", full, creates, updates, err)
+	}
+}
+
+// An in-memory Rocket Pool network. Changes made to it are recorded at the EL block they happen in, the same way the events
+// a real network emits for them would be.
+type fakeChain struct {
+	elBlock        uint64
+	network        rpstate.NetworkDetails
+	nodes          []rpstate.NativeNodeDetails
+	minipools      []rpstate.NativeMinipoolDetails
+	balances       map[common.Address]*big.Int
+	validators     map[types.ValidatorPubkey]beacon.ValidatorStatus
+	latestDelegate common.Address
+	events         map[uint64]*stateChanges
+	detailReads    map[common.Address]int
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		network: rpstate.NetworkDetails{
+			RplPrice:              big.NewInt(1e16),
+			MinCollateralFraction: big.NewInt(1e17),
+			MaxCollateralFraction: big.NewInt(15e17),
+		},
+		balances:    map[common.Address]*big.Int{},
+		validators:  map[types.ValidatorPubkey]beacon.ValidatorStatus{},
+		events:      map[uint64]*stateChanges{},
+		detailReads: map[common.Address]int{},
+	}
+}
+
+// Get the events recorded in the current block
+func (c *fakeChain) emit() *stateChanges {
+	changes, exists := c.events[c.elBlock]
+	if !exists {
+		changes = newStateChanges()
+		c.events[c.elBlock] = changes
+	}
+	return changes
+}
+
+func (c *fakeChain) addNode(address common.Address, distributor common.Address) {
+	c.nodes = append(c.nodes, rpstate.NativeNodeDetails{
+		Exists:                 true,
+		NodeAddress:            address,
+		FeeDistributorAddress:  distributor,
+		RplStake:               big.NewInt(1000),
+		CollateralisationRatio: big.NewInt(2e18),
+		DepositCreditBalance:   big.NewInt(0),
+	})
+	c.balances[address] = big.NewInt(1e18)
+	c.balances[distributor] = big.NewInt(0)
+}
+
+func (c *fakeChain) addMinipool(address common.Address, node common.Address, pubkey byte) {
+	c.minipools = append(c.minipools, rpstate.NativeMinipoolDetails{
+		Exists:            true,
+		MinipoolAddress:   address,
+		NodeAddress:       node,
+		Pubkey:            types.ValidatorPubkey{pubkey},
+		Status:            types.Staking,
+		NodeFee:           big.NewInt(14e16),
+		NodeRefundBalance: big.NewInt(0),
+		PenaltyCount:      big.NewInt(0),
+		EffectiveDelegate: c.latestDelegate,
+	})
+	c.balances[address] = big.NewInt(0)
+	c.validators[types.ValidatorPubkey{pubkey}] = beacon.ValidatorStatus{Pubkey: types.ValidatorPubkey{pubkey}, Balance: 32e9, Exists: true}
+}
+
+func (c *fakeChain) node(address common.Address) *rpstate.NativeNodeDetails {
+	for i := range c.nodes {
+		if c.nodes[i].NodeAddress == address {
+			return &c.nodes[i]
+		}
+	}
+	return nil
+}
+
+func (c *fakeChain) minipool(address common.Address) *rpstate.NativeMinipoolDetails {
+	for i := range c.minipools {
+		if c.minipools[i].MinipoolAddress == address {
+			return &c.minipools[i]
+		}
+	}
+	return nil
+}
+
+// Values that are calculated in place get fresh ones on every read, like they do from the contracts
+func (c *fakeChain) readNode(node rpstate.NativeNodeDetails) rpstate.NativeNodeDetails {
+	node.BalanceETH = big.NewInt(0).Set(c.balances[node.NodeAddress])
+	node.DistributorBalance = big.NewInt(0).Set(c.balances[node.FeeDistributorAddress])
+	node.AverageNodeFee = big.NewInt(0)
+	node.DistributorBalanceUserETH = big.NewInt(0)
+	node.DistributorBalanceNodeETH = big.NewInt(0)
+	return node
+}
+
+func (c *fakeChain) readMinipool(mpd rpstate.NativeMinipoolDetails) rpstate.NativeMinipoolDetails {
+	mpd.Balance = big.NewInt(0).Set(c.balances[mpd.MinipoolAddress])
+	return mpd
+}
+
+func (c *fakeChain) getNetworkDetails() (*rpstate.NetworkDetails, error) {
+	network := c.network
+	return &network, nil
+}
+
+func (c *fakeChain) getAllNodeDetails() ([]rpstate.NativeNodeDetails, error) {
+	nodes := make([]rpstate.NativeNodeDetails, len(c.nodes))
+	for i, node := range c.nodes {
+		nodes[i] = c.readNode(node)
+	}
+	return nodes, nil
+}
+
+func (c *fakeChain) getAllMinipoolDetails() ([]rpstate.NativeMinipoolDetails, error) {
+	minipools := make([]rpstate.NativeMinipoolDetails, len(c.minipools))
+	for i, mpd := range c.minipools {
+		minipools[i] = c.readMinipool(mpd)
+	}
+	return minipools, nil
+}
+
+func (c *fakeChain) getNodeDetails(address common.Address) (rpstate.NativeNodeDetails, error) {
+	c.detailReads[address]++
+	return c.readNode(*c.node(address)), nil
+}
+
+func (c *fakeChain) getMinipoolDetails(address common.Address) (rpstate.NativeMinipoolDetails, error) {
+	c.detailReads[address]++
+	return c.readMinipool(*c.minipool(address)), nil
+}
+
+func (c *fakeChain) getOracleDaoMemberDetails() ([]rpstate.OracleDaoMemberDetails, error) {
+	return []rpstate.OracleDaoMemberDetails{}, nil
+}
+
+func (c *fakeChain) getEthBalances(addresses []common.Address) ([]*big.Int, error) {
+	balances := make([]*big.Int, len(addresses))
+	for i, address := range addresses {
+		balances[i] = big.NewInt(0)
+		if balance, exists := c.balances[address]; exists {
+			balances[i].Set(balance)
+		}
+	}
+	return balances, nil
+}
+
+func (c *fakeChain) getLatestMinipoolDelegate() (common.Address, error) {
+	return c.latestDelegate, nil
+}
+
+func (c *fakeChain) getStateChanges(fromBlock uint64, toBlock uint64) (*stateChanges, error) {
+	changes := newStateChanges()
+	for block := fromBlock; block <= toBlock; block++ {
+		events, exists := c.events[block]
+		if !exists {
+			continue
+		}
+		for address, node := range events.createdMinipools {
+			changes.createdMinipools[address] = node
+		}
+		for _, set := range []struct{ from, to map[common.Address]bool }{
+			{events.destroyedMinipools, changes.destroyedMinipools},
+			{events.minipools, changes.minipools},
+			{events.registeredNodes, changes.registeredNodes},
+			{events.nodes, changes.nodes},
+			{events.slashedNodes, changes.slashedNodes},
+			{events.distributors, changes.distributors},
+		} {
+			for address := range set.from {
+				set.to[address] = true
+			}
+		}
+	}
+	return changes, nil
+}
+
+// Split balances in half between the node and the users
+func (c *fakeChain) calculateCompleteMinipoolShares(minipoolDetails []*rpstate.NativeMinipoolDetails, beaconBalances []*big.Int) error {
+	for i, mpd := range minipoolDetails {
+		total := big.NewInt(0).Add(beaconBalances[i], mpd.Balance)
+		total.Sub(total, mpd.NodeRefundBalance)
+		mpd.NodeShareOfBeaconBalance = big.NewInt(0).Div(beaconBalances[i], big.NewInt(2))
+		mpd.UserShareOfBeaconBalance = big.NewInt(0).Sub(beaconBalances[i], mpd.NodeShareOfBeaconBalance)
+		mpd.NodeShareOfBalanceIncludingBeacon = big.NewInt(0).Div(total, big.NewInt(2))
+		mpd.UserShareOfBalanceIncludingBeacon = big.NewInt(0).Sub(total, mpd.NodeShareOfBalanceIncludingBeacon)
+	}
+	return nil
+}
+
+// A Beacon client that reports the fake chain's validators
+type fakeStateBeaconClient struct {
+	beacon.Client
+	chain *fakeChain
+}
+
+func (bc *fakeStateBeaconClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	statuses := make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		statuses[pubkey] = bc.chain.validators[pubkey]
+	}
+	return statuses, nil
+}
+
+func TestUpdateMatchesCreate(t *testing.T) {
+	chain := newFakeChain()
+	bc := &fakeStateBeaconClient{chain: chain}
+	nodeA, nodeB, nodeC, nodeD := common.HexToAddress("0xa1"), common.HexToAddress("0xb1"), common.HexToAddress("0xc1"), common.HexToAddress("0xd1")
+	distributorA := common.HexToAddress("0xa2")
+	mp1, mp2, mp3, mp4, mp5, mp6 := common.HexToAddress("0x11"), common.HexToAddress("0x12"), common.HexToAddress("0x13"), common.HexToAddress("0x14"), common.HexToAddress("0x15"), common.HexToAddress("0x16")
+
+	chain.elBlock = 100
+	chain.latestDelegate = common.HexToAddress("0xde1")
+	chain.addNode(nodeA, distributorA)
+	chain.addNode(nodeB, common.HexToAddress("0xb2"))
+	chain.addNode(nodeC, common.HexToAddress("0xc2"))
+	chain.addNode(nodeD, common.HexToAddress("0xd2"))
+	chain.addMinipool(mp1, nodeA, 1)
+	chain.addMinipool(mp2, nodeA, 2)
+	chain.addMinipool(mp3, nodeB, 3)
+	chain.addMinipool(mp4, nodeC, 4)
+	chain.addMinipool(mp6, nodeD, 6)
+	chain.minipool(mp2).UseLatestDelegate = true
+	previous, err := createNetworkState(chain, bc, nil, 1000, chain.elBlock, beacon.Eth2Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A withdrawal address change (NodeWithdrawalAddressSet)
+	chain.elBlock = 101
+	chain.node(nodeB).WithdrawalAddress = common.HexToAddress("0xb3")
+	chain.emit().nodes[nodeB] = true
+
+	// Skimmed rewards and priority fees, which don't emit events
+	chain.elBlock = 102
+	chain.balances[mp1] = big.NewInt(5e16)
+	chain.balances[distributorA] = big.NewInt(3e16)
+
+	// A new minipool (MinipoolCreated) and a penalty (PenaltyUpdated)
+	chain.elBlock = 103
+	chain.addMinipool(mp5, nodeC, 5)
+	chain.node(nodeC).MinipoolCount = big.NewInt(2)
+	chain.emit().createdMinipools[mp5] = nodeC
+	chain.minipool(mp4).PenaltyCount = big.NewInt(1)
+	chain.emit().minipools[mp4] = true
+
+	// A delegate upgrade changes the effective delegate of minipools that follow the latest one without any minipool events
+	chain.elBlock = 104
+	chain.latestDelegate = common.HexToAddress("0xde2")
+	chain.minipool(mp2).EffectiveDelegate = chain.latestDelegate
+
+	// Beacon balances change every epoch
+	chain.elBlock = 110
+	validator := chain.validators[types.ValidatorPubkey{3}]
+	validator.Balance += 1e6
+	chain.validators[types.ValidatorPubkey{3}] = validator
+
+	chain.detailReads = map[common.Address]int{}
+	updated, err := updateNetworkState(chain, bc, nil, previous, 1010, chain.elBlock, beacon.Eth2Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reads := chain.detailReads
+	full, err := createNetworkState(chain, bc, nil, 1010, chain.elBlock, beacon.Eth2Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if differences := compareStates(updated, full); len(differences) != 0 {
+		t.Fatalf("updated state doesn't match a new state at the same slot: %v", differences)
+	}
+	if !reflect.DeepEqual(updated.ValidatorDetails, full.ValidatorDetails) {
+		t.Errorf("updated validator details don't match a new state at the same slot")
+	}
+	if updated.ElBlockNumber != full.ElBlockNumber || updated.BeaconSlotNumber != full.BeaconSlotNumber {
+		t.Errorf("updated state is for block %d, slot %d but should be for block %d, slot %d", updated.ElBlockNumber, updated.BeaconSlotNumber, full.ElBlockNumber, full.BeaconSlotNumber)
+	}
+
+	// Only the changed entities and the owners of changed minipools should have been re-queried
+	for _, address := range []common.Address{nodeA, nodeB, nodeC, mp1, mp2, mp4, mp5} {
+		if reads[address] != 1 {
+			t.Errorf("%s should have been re-queried once but was re-queried %d times", address.Hex(), reads[address])
+		}
+	}
+	for _, address := range []common.Address{nodeD, mp3, mp6} {
+		if reads[address] != 0 {
+			t.Errorf("%s didn't change but was re-queried %d times", address.Hex(), reads[address])
+		}
+	}
+}
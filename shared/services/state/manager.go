@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -24,21 +25,46 @@ type NetworkStateManager struct {
 	Network      cfgtypes.Network
 	ChainID      uint
 	BeaconConfig beacon.Eth2Config
+	cache        *StateCache
+	latestState  *trackedState
+	nodeStates   map[common.Address]*trackedState
+	lock         sync.Mutex
+}
+
+// The latest state built by the manager, which later states can be incrementally updated from
+type trackedState struct {
+	state               *NetworkState
+	lastFullRefresh     time.Time
+	incrementalDisabled bool
+	lock                sync.Mutex
 }
 
 // Create a new manager for the network state
 func NewNetworkStateManager(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, bc beacon.Client, log *log.ColorLogger) (*NetworkStateManager, error) {
+	return newNetworkStateManager(rp, cfg, ec, bc, log, cfg.Smartnode.UseStateCache.Value == true)
+}
+
+// Create a new manager for the network state that always builds states from its clients, even if the state cache is enabled.
+// This is for callers that need every client call to happen, such as when the responses are being recorded.
+func NewUncachedNetworkStateManager(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, bc beacon.Client, log *log.ColorLogger) (*NetworkStateManager, error) {
+	return newNetworkStateManager(rp, cfg, ec, bc, log, false)
+}
+
+// Create a new manager for the network state, optionally with the state cache
+func newNetworkStateManager(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, bc beacon.Client, log *log.ColorLogger, useCache bool) (*NetworkStateManager, error) {
 
 	// Create the manager
 	m := &NetworkStateManager{
-		cfg:     cfg,
-		rp:      rp,
-		ec:      ec,
-		bc:      bc,
-		log:     log,
-		Config:  cfg,
-		Network: cfg.Smartnode.Network.Value.(cfgtypes.Network),
-		ChainID: cfg.Smartnode.GetChainID(),
+		cfg:         cfg,
+		rp:          rp,
+		ec:          ec,
+		bc:          bc,
+		log:         log,
+		Config:      cfg,
+		Network:     cfg.Smartnode.Network.Value.(cfgtypes.Network),
+		ChainID:     cfg.Smartnode.GetChainID(),
+		latestState: &trackedState{},
+		nodeStates:  map[common.Address]*trackedState{},
 	}
 
 	// Get the Beacon config info
@@ -48,6 +74,12 @@ func NewNetworkStateManager(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 		return nil, err
 	}
 
+	// Create the state cache
+	if useCache {
+		maxSize := cfg.Smartnode.StateCacheSize.Value.(uint64) * 1024 * 1024
+		m.cache = NewStateCache(cfg.Smartnode.GetStateCachePath(true), maxSize, log)
+	}
+
 	return m, nil
 
 }
@@ -58,7 +90,7 @@ func (m *NetworkStateManager) GetHeadState() (*NetworkState, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error getting latest Beacon slot: %w", err)
 	}
-	return m.getState(targetSlot)
+	return m.getState(targetSlot, true)
 }
 
 // Get the state of the network for a single node using the latest Execution layer block, along with the total effective RPL stake for the network
@@ -72,7 +104,13 @@ func (m *NetworkStateManager) GetHeadStateForNode(nodeAddress common.Address, ca
 
 // Get the state of the network at the provided Beacon slot
 func (m *NetworkStateManager) GetStateForSlot(slotNumber uint64) (*NetworkState, error) {
-	return m.getState(slotNumber)
+	return m.getState(slotNumber, true)
+}
+
+// Get the state of the network at the provided Beacon slot, always creating it from scratch instead of incrementally updating a previous state.
+// Use this for anything that has to be exact, such as rewards tree generation.
+func (m *NetworkStateManager) GetFullStateForSlot(slotNumber uint64) (*NetworkState, error) {
+	return m.getState(slotNumber, false)
 }
 
 // Gets the latest valid block
@@ -121,7 +159,7 @@ func (m *NetworkStateManager) GetLatestProposedBeaconBlock(targetSlot uint64) (b
 
 		// If the block was missing, try the previous one
 		if !exists {
-			m.logLine("Slot %d was missing, trying the previous one...", targetSlot)
+			m.logLine(log.Level_Info, targetSlot, "Slot %d was missing, trying the previous one...", targetSlot)
 			targetSlot--
 		} else {
 			return block, nil
@@ -129,27 +167,184 @@ func (m *NetworkStateManager) GetLatestProposedBeaconBlock(targetSlot uint64) (b
 	}
 }
 
-// Get the state of the network at the provided Beacon slot
-func (m *NetworkStateManager) getState(slotNumber uint64) (*NetworkState, error) {
-	state, err := CreateNetworkState(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig)
+// Get the state of the network at the provided Beacon slot, using the state cache if it's enabled and the slot is finalized
+func (m *NetworkStateManager) getState(slotNumber uint64, allowIncremental bool) (*NetworkState, error) {
+	if m.cache == nil {
+		state, _, err := m.buildState(slotNumber, allowIncremental)
+		return state, err
+	}
+
+	// Only finalized states are cached, since they can never change
+	elBlockHash, finalized, err := m.getFinalizedBlockHash(slotNumber)
+	if err != nil {
+		m.logLine(log.Level_Warn, slotNumber, "couldn't check the state cache for slot %d: %s", slotNumber, err.Error())
+		finalized = false
+	}
+	if finalized {
+		state, exists, err := m.cache.Load(slotNumber, elBlockHash)
+		if err != nil {
+			m.logLine(log.Level_Warn, slotNumber, "couldn't load the state for slot %d from the cache: %s", slotNumber, err.Error())
+		} else if exists {
+			m.logLine(log.Level_Info, slotNumber, "Loaded network state for slot %d from the cache.", slotNumber)
+			state.log = m.log
+			m.latestState.set(state, true)
+			return state, nil
+		}
+	}
+
+	state, fullRefresh, err := m.buildState(slotNumber, allowIncremental)
 	if err != nil {
 		return nil, err
 	}
+
+	// Only states created from scratch are cached, so loading a cached state is always as good as a full refresh
+	if finalized && fullRefresh {
+		err = m.cache.Save(state, elBlockHash)
+		if err != nil {
+			m.logLine(log.Level_Warn, slotNumber, "couldn't save the state for slot %d to the cache: %s", slotNumber, err.Error())
+		}
+	}
 	return state, nil
 }
 
+// Build the state of the network at the provided Beacon slot, incrementally updating the latest state if allowed and possible.
+// Returns true if the state was created from scratch.
+func (m *NetworkStateManager) buildState(slotNumber uint64, allowIncremental bool) (*NetworkState, bool, error) {
+	create := func() (*NetworkState, error) {
+		return CreateNetworkState(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig)
+	}
+	if !allowIncremental {
+		state, err := create()
+		if err != nil {
+			return nil, false, err
+		}
+		m.latestState.set(state, true)
+		return state, true, nil
+	}
+	update := func(previous *NetworkState) (*NetworkState, error) {
+		return UpdateNetworkState(m.cfg, m.rp, m.bc, m.log, previous, slotNumber, m.BeaconConfig, nil)
+	}
+	return m.latestState.get(slotNumber, create, update, m.logLine)
+}
+
+// Get the hash of the Execution block for the provided slot, and whether or not the slot has been finalized
+func (m *NetworkStateManager) getFinalizedBlockHash(slotNumber uint64) (common.Hash, bool, error) {
+	head, err := m.bc.GetBeaconHead()
+	if err != nil {
+		return common.Hash{}, false, fmt.Errorf("error getting Beacon chain head: %w", err)
+	}
+	if slotNumber >= head.FinalizedEpoch*m.BeaconConfig.SlotsPerEpoch {
+		return common.Hash{}, false, nil
+	}
+
+	beaconBlock, exists, err := m.bc.GetBeaconBlock(fmt.Sprint(slotNumber))
+	if err != nil {
+		return common.Hash{}, false, fmt.Errorf("error getting Beacon block for slot %d: %w", slotNumber, err)
+	}
+	if !exists {
+		return common.Hash{}, false, fmt.Errorf("slot %d did not have a Beacon block", slotNumber)
+	}
+	header, err := m.ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(beaconBlock.ExecutionBlockNumber))
+	if err != nil {
+		return common.Hash{}, false, fmt.Errorf("error getting EL block %d: %w", beaconBlock.ExecutionBlockNumber, err)
+	}
+	return header.Hash(), true, nil
+}
+
 // Get the state of the network for a specific node only at the provided Beacon slot
 func (m *NetworkStateManager) getStateForNode(nodeAddress common.Address, slotNumber uint64, calculateTotalEffectiveStake bool) (*NetworkState, *big.Int, error) {
-	state, totalEffectiveStake, err := CreateNetworkStateForNode(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig, nodeAddress, calculateTotalEffectiveStake)
+	m.lock.Lock()
+	tracked, exists := m.nodeStates[nodeAddress]
+	if !exists {
+		tracked = &trackedState{}
+		m.nodeStates[nodeAddress] = tracked
+	}
+	m.lock.Unlock()
+
+	var totalEffectiveStake *big.Int
+	create := func() (*NetworkState, error) {
+		state, total, err := CreateNetworkStateForNode(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig, nodeAddress, calculateTotalEffectiveStake)
+		totalEffectiveStake = total
+		return state, err
+	}
+	update := func(previous *NetworkState) (*NetworkState, error) {
+		state, total, err := UpdateNetworkStateForNode(m.cfg, m.rp, m.bc, m.log, previous, slotNumber, m.BeaconConfig, nodeAddress, calculateTotalEffectiveStake)
+		totalEffectiveStake = total
+		return state, err
+	}
+	state, _, err := tracked.get(slotNumber, create, update, m.logLine)
 	if err != nil {
 		return nil, nil, err
 	}
 	return state, totalEffectiveStake, nil
 }
 
-// Logs a line if the logger is specified
-func (m *NetworkStateManager) logLine(format string, v ...interface{}) {
+// Get the state for the provided slot by updating the tracked state if possible, or by creating it from scratch if not.
+// States are periodically created from scratch even when they could be updated, and compared with the update to make sure it was consistent;
+// if it wasn't, incremental updates are disabled from then on since they can't be trusted. Returns true if the state was created from scratch.
+func (t *trackedState) get(slotNumber uint64, create func() (*NetworkState, error), update func(*NetworkState) (*NetworkState, error), logLine func(log.Level, uint64, string, ...interface{})) (*NetworkState, bool, error) {
+	t.lock.Lock()
+	previous := t.state
+	refreshDue := time.Since(t.lastFullRefresh) >= fullStateRefreshInterval
+	incrementalDisabled := t.incrementalDisabled
+	t.lock.Unlock()
+
+	// Update the previous state if it's not too old
+	var updated *NetworkState
+	if previous != nil && !incrementalDisabled && slotNumber >= previous.BeaconSlotNumber {
+		var err error
+		updated, err = update(previous)
+		if err != nil {
+			logLine(log.Level_Warn, slotNumber, "Couldn't update the network state incrementally, creating it from scratch instead: %s", err.Error())
+		} else if !refreshDue {
+			t.set(updated, false)
+			return updated, false, nil
+		}
+	}
+
+	state, err := create()
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Make sure the incremental update didn't miss anything
+	if updated != nil {
+		differences := compareStates(updated, state)
+		if len(differences) > 0 {
+			logLine(log.Level_Error, slotNumber, "the incrementally updated network state for slot %d had %d differences from a full refresh, so incremental updates have been disabled:", slotNumber, len(differences))
+			for i, difference := range differences {
+				if i == maxLoggedDifferences {
+					logLine(log.Level_Error, slotNumber, "\t...")
+					break
+				}
+				logLine(log.Level_Error, slotNumber, "\t%s", difference)
+			}
+			t.lock.Lock()
+			t.incrementalDisabled = true
+			t.lock.Unlock()
+		}
+	}
+
+	t.set(state, true)
+	return state, true, nil
+}
+
+// Track a new state if it's at least as recent as the current one
+func (t *trackedState) set(state *NetworkState, fullRefresh bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.state != nil && state.BeaconSlotNumber < t.state.BeaconSlotNumber {
+		return
+	}
+	t.state = state
+	if fullRefresh {
+		t.lastFullRefresh = time.Now()
+	}
+}
+
+// Logs a line about the provided slot if the logger is specified
+func (m *NetworkStateManager) logLine(level log.Level, slotNumber uint64, format string, v ...interface{}) {
 	if m.log != nil {
-		m.log.Printlnf(format, v)
+		m.log.With(log.Slot(slotNumber)).Logf(level, format, v...)
 	}
 }
@@ -0,0 +1,39 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Beacon client that only knows its config
+type configOnlyBeaconClient struct {
+	beacon.Client
+}
+
+func (c *configOnlyBeaconClient) GetEth2Config() (beacon.Eth2Config, error) {
+	return beacon.Eth2Config{SlotsPerEpoch: 32}, nil
+}
+
+func TestUncachedManagerIgnoresStateCache(t *testing.T) {
+	cfg := config.NewRocketPoolConfig(t.TempDir(), true)
+	cfg.Smartnode.UseStateCache.Value = true
+	cfg.Smartnode.StateCacheSize.Value = uint64(1)
+
+	m, err := NewNetworkStateManager(nil, cfg, nil, &configOnlyBeaconClient{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.cache == nil {
+		t.Fatal("the manager should use the state cache when it's enabled")
+	}
+
+	m, err = NewUncachedNetworkStateManager(nil, cfg, nil, &configOnlyBeaconClient{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.cache != nil {
+		t.Fatal("the uncached manager should not use the state cache")
+	}
+}
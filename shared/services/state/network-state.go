@@ -51,94 +51,72 @@ type NetworkState struct {
 
 // Creates a snapshot of the entire Rocket Pool network state, on both the Execution and Consensus layers
 func CreateNetworkState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, ec rocketpool.ExecutionClient, bc beacon.Client, log *log.ColorLogger, slotNumber uint64, beaconConfig beacon.Eth2Config) (*NetworkState, error) {
-	// Get the relevant network contracts
-	multicallerAddress := common.HexToAddress(cfg.Smartnode.GetMulticallAddress())
-	balanceBatcherAddress := common.HexToAddress(cfg.Smartnode.GetBalanceBatcherAddress())
-
 	// Get the execution block for the given slot
-	beaconBlock, exists, err := bc.GetBeaconBlock(fmt.Sprintf("%d", slotNumber))
+	elBlockNumber, err := getElBlockForSlot(bc, slotNumber)
 	if err != nil {
-		return nil, fmt.Errorf("error getting Beacon block for slot %d: %w", slotNumber, err)
-	}
-	if !exists {
-		return nil, fmt.Errorf("slot %d did not have a Beacon block", slotNumber)
+		return nil, err
 	}
 
-	// Get the corresponding block on the EL
-	elBlockNumber := beaconBlock.ExecutionBlockNumber
-	opts := &bind.CallOpts{
-		BlockNumber: big.NewInt(0).SetUint64(elBlockNumber),
+	// Get the relevant network contracts
+	reader, err := newChainStateReader(cfg, rp, elBlockNumber)
+	if err != nil {
+		return nil, err
 	}
+	return createNetworkState(reader, bc, log, slotNumber, elBlockNumber, beaconConfig)
+}
 
+// Creates a snapshot of the entire Rocket Pool network state at the provided slot and its EL block
+func createNetworkState(reader stateReader, bc beacon.Client, log *log.ColorLogger, slotNumber uint64, elBlockNumber uint64, beaconConfig beacon.Eth2Config) (*NetworkState, error) {
 	// Create the state wrapper
 	state := &NetworkState{
-		NodeDetailsByAddress:     map[common.Address]*rpstate.NativeNodeDetails{},
-		MinipoolDetailsByAddress: map[common.Address]*rpstate.NativeMinipoolDetails{},
-		MinipoolDetailsByNode:    map[common.Address][]*rpstate.NativeMinipoolDetails{},
-		BeaconSlotNumber:         slotNumber,
-		ElBlockNumber:            elBlockNumber,
-		BeaconConfig:             beaconConfig,
-		log:                      log,
+		BeaconSlotNumber: slotNumber,
+		ElBlockNumber:    elBlockNumber,
+		BeaconConfig:     beaconConfig,
+		log:              log,
 	}
 
 	state.logLine("Getting network state for EL block %d, Beacon slot %d", elBlockNumber, slotNumber)
 	start := time.Now()
 
-	// Network contracts and details
-	contracts, err := rpstate.NewNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, opts)
-	if err != nil {
-		return nil, fmt.Errorf("error getting network contracts: %w", err)
-	}
-	state.NetworkDetails, err = rpstate.NewNetworkDetails(rp, contracts)
+	// Network details
+	var err error
+	state.NetworkDetails, err = reader.getNetworkDetails()
 	if err != nil {
 		return nil, fmt.Errorf("error getting network details: %w", err)
 	}
 	state.logLine("1/6 - Retrieved network details (%s so far)", time.Since(start))
 
 	// Node details
-	state.NodeDetails, err = rpstate.GetAllNativeNodeDetails(rp, contracts)
+	state.NodeDetails, err = reader.getAllNodeDetails()
 	if err != nil {
 		return nil, fmt.Errorf("error getting all node details: %w", err)
 	}
 	state.logLine("2/6 - Retrieved node details (%s so far)", time.Since(start))
 
 	// Minipool details
-	state.MinipoolDetails, err = rpstate.GetAllNativeMinipoolDetails(rp, contracts)
+	state.MinipoolDetails, err = reader.getAllMinipoolDetails()
 	if err != nil {
 		return nil, fmt.Errorf("error getting all minipool details: %w", err)
 	}
 	state.logLine("3/6 - Retrieved minipool details (%s so far)", time.Since(start))
 
-	// Create the node lookup
-	for i, details := range state.NodeDetails {
-		state.NodeDetailsByAddress[details.NodeAddress] = &state.NodeDetails[i]
-	}
-
-	// Create the minipool lookups
+	// Create the lookups
+	state.createLookups()
 	pubkeys := make([]types.ValidatorPubkey, 0, len(state.MinipoolDetails))
 	emptyPubkey := types.ValidatorPubkey{}
-	for i, details := range state.MinipoolDetails {
-		state.MinipoolDetailsByAddress[details.MinipoolAddress] = &state.MinipoolDetails[i]
+	for _, details := range state.MinipoolDetails {
 		if details.Pubkey != emptyPubkey {
 			pubkeys = append(pubkeys, details.Pubkey)
 		}
-
-		// The map of nodes to minipools
-		nodeList, exists := state.MinipoolDetailsByNode[details.NodeAddress]
-		if !exists {
-			nodeList = []*rpstate.NativeMinipoolDetails{}
-		}
-		nodeList = append(nodeList, &state.MinipoolDetails[i])
-		state.MinipoolDetailsByNode[details.NodeAddress] = nodeList
 	}
 
-	// Calculate avg node fees and distributor shares
+	// Calculate avg node fees and distributor shares; this only uses the provided details, so it doesn't need the contracts
 	for _, details := range state.NodeDetails {
-		rpstate.CalculateAverageFeeAndDistributorShares(rp, contracts, details, state.MinipoolDetailsByNode[details.NodeAddress])
+		rpstate.CalculateAverageFeeAndDistributorShares(nil, nil, details, state.MinipoolDetailsByNode[details.NodeAddress])
 	}
 
 	// Oracle DAO member details
-	state.OracleDaoMemberDetails, err = rpstate.GetAllOracleDaoMemberDetails(rp, contracts)
+	state.OracleDaoMemberDetails, err = reader.getOracleDaoMemberDetails()
 	if err != nil {
 		return nil, fmt.Errorf("error getting Oracle DAO details: %w", err)
 	}
@@ -166,16 +144,27 @@ func CreateNetworkState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool,
 			beaconBalances[i] = eth.GweiToWei(float64(validator.Balance))
 		}
 	}
-	err = rpstate.CalculateCompleteMinipoolShares(rp, contracts, mpds, beaconBalances)
+	err = reader.calculateCompleteMinipoolShares(mpds, beaconBalances)
 	if err != nil {
 		return nil, err
 	}
-	state.ValidatorDetails = statusMap
 	state.logLine("6/6 - Calculated complete node and user balance shares (total time: %s)", time.Since(start))
 
 	return state, nil
 }
 
+// Get the number of the EL block included in the Beacon block at the provided slot
+func getElBlockForSlot(bc beacon.Client, slotNumber uint64) (uint64, error) {
+	beaconBlock, exists, err := bc.GetBeaconBlock(fmt.Sprintf("%d", slotNumber))
+	if err != nil {
+		return 0, fmt.Errorf("error getting Beacon block for slot %d: %w", slotNumber, err)
+	}
+	if !exists {
+		return 0, fmt.Errorf("slot %d did not have a Beacon block", slotNumber)
+	}
+	return beaconBlock.ExecutionBlockNumber, nil
+}
+
 // Creates a snapshot of the Rocket Pool network, but only for a single node
 // Also gets the total effective RPL stake of the network for convenience since this is required by several node routines
 func CreateNetworkStateForNode(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, ec rocketpool.ExecutionClient, bc beacon.Client, log *log.ColorLogger, slotNumber uint64, beaconConfig beacon.Eth2Config, nodeAddress common.Address, calculateTotalEffectiveStake bool) (*NetworkState, *big.Int, error) {
@@ -424,6 +413,6 @@ func (s *NetworkState) CalculateTrueEffectiveStakes(scaleByParticipation bool) (
 // Logs a line if the logger is specified
 func (s *NetworkState) logLine(format string, v ...interface{}) {
 	if s.log != nil {
-		s.log.Printlnf(format, v...)
+		s.log.With(log.Slot(s.BeaconSlotNumber)).Printlnf(format, v...)
 	}
 }
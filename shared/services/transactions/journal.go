@@ -0,0 +1,326 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Config
+const (
+	maxFinishedEntries int           = 1000
+	droppedTimeout     time.Duration = time.Hour
+)
+
+// The status of a transaction in the journal
+type TransactionStatus string
+
+const (
+	TransactionStatus_Pending   TransactionStatus = "pending"
+	TransactionStatus_Confirmed TransactionStatus = "confirmed"
+	TransactionStatus_Failed    TransactionStatus = "failed"
+	TransactionStatus_Replaced  TransactionStatus = "replaced"
+	TransactionStatus_Dropped   TransactionStatus = "dropped"
+)
+
+// A transaction submitted by the Smartnode
+type JournalEntry struct {
+	Hash           common.Hash       `json:"hash"`
+	From           common.Address    `json:"from"`
+	To             *common.Address   `json:"to"`
+	Nonce          uint64            `json:"nonce"`
+	Purpose        string            `json:"purpose"`
+	Value          *big.Int          `json:"value"`
+	Data           hexutil.Bytes     `json:"data"`
+	GasLimit       uint64            `json:"gasLimit"`
+	MaxFee         *big.Int          `json:"maxFee"`
+	MaxPriorityFee *big.Int          `json:"maxPriorityFee"`
+	Status         TransactionStatus `json:"status"`
+	BlockNumber    uint64            `json:"blockNumber,omitempty"`
+	Replaces       *common.Hash      `json:"replaces,omitempty"`
+	SubmitTime     time.Time         `json:"submitTime"`
+	UpdateTime     time.Time         `json:"updateTime"`
+}
+
+// A persistent record of the transactions submitted by the Smartnode, shared by every process that uses the same data directory
+type Journal struct {
+	path string
+	ec   rocketpool.ExecutionClient
+}
+
+// Create a new journal that's stored at the provided path
+func NewJournal(path string, ec rocketpool.ExecutionClient) *Journal {
+	return &Journal{
+		path: path,
+		ec:   ec,
+	}
+}
+
+// Record a newly submitted transaction. If it replaces an earlier one with the same nonce, replaces is the hash of that transaction.
+func (j *Journal) Record(tx *types.Transaction, from common.Address, purpose string, replaces *common.Hash) (JournalEntry, error) {
+	now := time.Now()
+	entry := JournalEntry{
+		Hash:           tx.Hash(),
+		From:           from,
+		To:             tx.To(),
+		Nonce:          tx.Nonce(),
+		Purpose:        purpose,
+		Value:          tx.Value(),
+		Data:           tx.Data(),
+		GasLimit:       tx.Gas(),
+		MaxFee:         tx.GasFeeCap(),
+		MaxPriorityFee: tx.GasTipCap(),
+		Status:         TransactionStatus_Pending,
+		Replaces:       replaces,
+		SubmitTime:     now,
+		UpdateTime:     now,
+	}
+
+	err := j.update(func(entries []JournalEntry) ([]JournalEntry, error) {
+		for _, existing := range entries {
+			if existing.Hash == entry.Hash {
+				return entries, nil
+			}
+		}
+		return append(entries, entry), nil
+	})
+	if err != nil {
+		return JournalEntry{}, fmt.Errorf("error recording transaction %s: %w", entry.Hash.Hex(), err)
+	}
+	return entry, nil
+}
+
+// Record a transaction that has just been submitted by looking it up from the Execution client, recovering its sender from its signature
+func (j *Journal) RecordSubmitted(hash common.Hash, purpose string) (JournalEntry, error) {
+	tx, err := j.getTransaction(hash)
+	if err != nil {
+		return JournalEntry{}, err
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return JournalEntry{}, fmt.Errorf("error getting sender of transaction %s: %w", hash.Hex(), err)
+	}
+	return j.Record(tx, from, purpose, nil)
+}
+
+// Get a transaction from the journal; returns false if it isn't in the journal
+func (j *Journal) GetEntry(hash common.Hash) (JournalEntry, bool, error) {
+	entries, err := j.GetEntries()
+	if err != nil {
+		return JournalEntry{}, false, err
+	}
+	for _, entry := range entries {
+		if entry.Hash == hash {
+			return entry, true, nil
+		}
+	}
+	return JournalEntry{}, false, nil
+}
+
+// Get every transaction in the journal, oldest first
+func (j *Journal) GetEntries() ([]JournalEntry, error) {
+	var entries []JournalEntry
+	err := j.withLock(func() error {
+		var err error
+		entries, err = j.load()
+		return err
+	})
+	return entries, err
+}
+
+// Check the Execution client for the latest status of every pending transaction in the journal
+func (j *Journal) UpdateStatuses() error {
+	return j.update(func(entries []JournalEntry) ([]JournalEntry, error) {
+		nonces := map[common.Address]uint64{}
+		for i := range entries {
+			entry := &entries[i]
+			if entry.Status != TransactionStatus_Pending {
+				continue
+			}
+
+			// Check if it's been included in a block
+			receipt, err := j.ec.TransactionReceipt(context.Background(), entry.Hash)
+			if err != nil && !errors.Is(err, ethereum.NotFound) {
+				return nil, fmt.Errorf("error getting receipt for transaction %s: %w", entry.Hash.Hex(), err)
+			}
+			if receipt != nil {
+				setMined(entries, entry, receipt)
+				continue
+			}
+
+			// Check if another transaction with the same nonce has been included in a block instead
+			nonce, exists := nonces[entry.From]
+			if !exists {
+				nonce, err = j.ec.NonceAt(context.Background(), entry.From, nil)
+				if err != nil {
+					return nil, fmt.Errorf("error getting nonce of %s: %w", entry.From.Hex(), err)
+				}
+				nonces[entry.From] = nonce
+			}
+			if entry.Nonce < nonce {
+				setStatus(entry, TransactionStatus_Replaced)
+				continue
+			}
+
+			// Check if the Execution client has forgotten about it
+			if time.Since(entry.SubmitTime) > droppedTimeout {
+				_, _, err := j.ec.TransactionByHash(context.Background(), entry.Hash)
+				if errors.Is(err, ethereum.NotFound) {
+					setStatus(entry, TransactionStatus_Dropped)
+				}
+			}
+		}
+		return entries, nil
+	})
+}
+
+// Mark a transaction as mined, along with any other transactions that shared its nonce
+func setMined(entries []JournalEntry, mined *JournalEntry, receipt *types.Receipt) {
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		setStatus(mined, TransactionStatus_Confirmed)
+	} else {
+		setStatus(mined, TransactionStatus_Failed)
+	}
+	mined.BlockNumber = receipt.BlockNumber.Uint64()
+
+	for i := range entries {
+		entry := &entries[i]
+		if entry.From == mined.From && entry.Nonce == mined.Nonce && entry.Hash != mined.Hash && entry.Status == TransactionStatus_Pending {
+			setStatus(entry, TransactionStatus_Replaced)
+		}
+	}
+}
+
+// Set the status of a transaction
+func setStatus(entry *JournalEntry, status TransactionStatus) {
+	entry.Status = status
+	entry.UpdateTime = time.Now()
+}
+
+// Get a transaction from the Execution client, retrying for a little while since it may not have propagated yet
+func (j *Journal) getTransaction(hash common.Hash) (*types.Transaction, error) {
+	for i := 0; ; i++ {
+		tx, _, err := j.ec.TransactionByHash(context.Background(), hash)
+		if err == nil {
+			return tx, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) || i == 29 {
+			return nil, fmt.Errorf("error getting transaction %s: %w", hash.Hex(), err)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// Modify the journal's entries and save them
+func (j *Journal) update(modify func([]JournalEntry) ([]JournalEntry, error)) error {
+	return j.withLock(func() error {
+		entries, err := j.load()
+		if err != nil {
+			return err
+		}
+		entries, err = modify(entries)
+		if err != nil {
+			return err
+		}
+		return j.save(prune(entries))
+	})
+}
+
+// Remove the oldest finished transactions if there are too many
+func prune(entries []JournalEntry) []JournalEntry {
+	finished := 0
+	for _, entry := range entries {
+		if entry.Status != TransactionStatus_Pending {
+			finished++
+		}
+	}
+	if finished <= maxFinishedEntries {
+		return entries
+	}
+
+	sort.SliceStable(entries, func(i, k int) bool {
+		return entries[i].SubmitTime.Before(entries[k].SubmitTime)
+	})
+	toRemove := finished - maxFinishedEntries
+	pruned := make([]JournalEntry, 0, len(entries)-toRemove)
+	for _, entry := range entries {
+		if toRemove > 0 && entry.Status != TransactionStatus_Pending {
+			toRemove--
+			continue
+		}
+		pruned = append(pruned, entry)
+	}
+	return pruned
+}
+
+// Run a function while holding the journal's file lock, so the daemons and the API never modify it at the same time
+func (j *Journal) withLock(f func() error) error {
+	err := os.MkdirAll(filepath.Dir(j.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating transaction journal folder: %w", err)
+	}
+	lockFile, err := os.OpenFile(j.path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening transaction journal lock: %w", err)
+	}
+	defer lockFile.Close()
+
+	err = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX)
+	if err != nil {
+		return fmt.Errorf("error locking transaction journal: %w", err)
+	}
+	defer syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
+
+	return f()
+}
+
+// Load the journal from disk; the lock must be held
+func (j *Journal) load() ([]JournalEntry, error) {
+	bytes, err := os.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return []JournalEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading transaction journal [%s]: %w", j.path, err)
+	}
+
+	var entries []JournalEntry
+	err = json.Unmarshal(bytes, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing transaction journal [%s]: %w", j.path, err)
+	}
+	return entries, nil
+}
+
+// Save the journal to disk; the lock must be held
+func (j *Journal) save(entries []JournalEntry) error {
+	bytes, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("error serializing transaction journal: %w", err)
+	}
+
+	// Write to a temporary file first so a partial file is never loaded
+	tempPath := j.path + ".tmp"
+	err = os.WriteFile(tempPath, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing transaction journal [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, j.path)
+	if err != nil {
+		return fmt.Errorf("error writing transaction journal [%s]: %w", j.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package transactions
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Execution client that knows about a fixed set of transactions
+type fakeExecutionClient struct {
+	rocketpool.ExecutionClient
+	transactions map[common.Hash]*types.Transaction
+	receipts     map[common.Hash]*types.Receipt
+	nonce        uint64
+}
+
+func newFakeExecutionClient() *fakeExecutionClient {
+	return &fakeExecutionClient{
+		transactions: map[common.Hash]*types.Transaction{},
+		receipts:     map[common.Hash]*types.Receipt{},
+	}
+}
+
+func (c *fakeExecutionClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	tx, exists := c.transactions[hash]
+	if !exists {
+		return nil, false, ethereum.NotFound
+	}
+	return tx, true, nil
+}
+
+func (c *fakeExecutionClient) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, exists := c.receipts[hash]
+	if !exists {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (c *fakeExecutionClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return c.nonce, nil
+}
+
+// Sign a transaction with a new key and return it along with its sender
+func signTestTransaction(t *testing.T, nonce uint64, maxFee int64) (*types.Transaction, common.Address) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x1234")
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(maxFee),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func TestRecordSubmitted(t *testing.T) {
+	ec := newFakeExecutionClient()
+	tx, from := signTestTransaction(t, 3, 100)
+	ec.transactions[tx.Hash()] = tx
+	journal := NewJournal(filepath.Join(t.TempDir(), "journal.json"), ec)
+
+	entry, err := journal.RecordSubmitted(tx.Hash(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.From != from || entry.Nonce != 3 || entry.MaxFee.Int64() != 100 || entry.Status != TransactionStatus_Pending {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+
+	// Recording it again doesn't add a duplicate
+	if _, err := journal.RecordSubmitted(tx.Hash(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := journal.GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Hash != tx.Hash() || entries[0].Purpose != "test" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+
+	// The journal is shared through the file
+	saved, exists, err := NewJournal(journal.path, ec).GetEntry(tx.Hash())
+	if err != nil || !exists || saved.From != from {
+		t.Fatalf("transaction wasn't saved (exists %t): %v", exists, err)
+	}
+}
+
+func TestUpdateStatuses(t *testing.T) {
+	ec := newFakeExecutionClient()
+	journal := NewJournal(filepath.Join(t.TempDir(), "journal.json"), ec)
+
+	// A transaction, its replacement, and an unrelated one that failed
+	original, from := signTestTransaction(t, 0, 100)
+	failed, _ := signTestTransaction(t, 0, 100)
+	originalEntry, err := journal.Record(original, from, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replacement := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 0, GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(115), Gas: 21000})
+	if _, err := journal.Record(replacement, from, "test", &originalEntry.Hash); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := journal.Record(failed, common.HexToAddress("0x5678"), "test", nil); err != nil {
+		t.Fatal(err)
+	}
+	ec.receipts[replacement.Hash()] = &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(10)}
+	ec.receipts[failed.Hash()] = &types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(11)}
+
+	if err := journal.UpdateStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[common.Hash]TransactionStatus{
+		original.Hash():    TransactionStatus_Replaced,
+		replacement.Hash(): TransactionStatus_Confirmed,
+		failed.Hash():      TransactionStatus_Failed,
+	}
+	entries, err := journal.GetEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Status != expected[entry.Hash] {
+			t.Fatalf("transaction %s has status %s, expected %s", entry.Hash.Hex(), entry.Status, expected[entry.Hash])
+		}
+	}
+}
+
+func TestUpdateStatusesNonceUsed(t *testing.T) {
+	ec := newFakeExecutionClient()
+	journal := NewJournal(filepath.Join(t.TempDir(), "journal.json"), ec)
+	tx, from := signTestTransaction(t, 4, 100)
+	if _, err := journal.Record(tx, from, "test", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Still pending while the nonce hasn't been used
+	ec.nonce = 4
+	if err := journal.UpdateStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	if entry, _, _ := journal.GetEntry(tx.Hash()); entry.Status != TransactionStatus_Pending {
+		t.Fatalf("expected a pending transaction, got %s", entry.Status)
+	}
+
+	// Replaced once something else used it
+	ec.nonce = 5
+	if err := journal.UpdateStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	if entry, _, _ := journal.GetEntry(tx.Hash()); entry.Status != TransactionStatus_Replaced {
+		t.Fatalf("expected a replaced transaction, got %s", entry.Status)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	start := time.Now()
+	entries := []JournalEntry{}
+	for i := 0; i < maxFinishedEntries+5; i++ {
+		entries = append(entries, JournalEntry{
+			Hash:       common.BigToHash(big.NewInt(int64(i))),
+			Status:     TransactionStatus_Confirmed,
+			SubmitTime: start.Add(time.Duration(i) * time.Second),
+		})
+	}
+	pending := JournalEntry{Hash: common.HexToHash("0xff"), Status: TransactionStatus_Pending, SubmitTime: start}
+	entries = append(entries, pending)
+
+	pruned := prune(entries)
+	if len(pruned) != maxFinishedEntries+1 {
+		t.Fatalf("expected %d entries, got %d", maxFinishedEntries+1, len(pruned))
+	}
+	if pruned[0].Hash != pending.Hash {
+		t.Fatal("pending transactions should never be pruned")
+	}
+	if pruned[1].Hash != common.BigToHash(big.NewInt(5)) {
+		t.Fatalf("the oldest finished transactions should be pruned first, but the oldest left is %s", pruned[1].Hash.Hex())
+	}
+}
@@ -0,0 +1,214 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	MinFeeBumpPercent   uint64        = 10
+	receiptPollInterval time.Duration = 12 * time.Second
+)
+
+// The rules for automatically replacing stuck transactions with ones that pay higher fees
+type FeeBumpPolicy struct {
+	// How long to wait for a transaction to be included in a block before bumping its fees; 0 disables bumping
+	Interval time.Duration
+
+	// The percentage to raise the max fee and priority fee by each time
+	Percent uint64
+
+	// The highest max fee a bumped transaction can have
+	MaxFee *big.Int
+}
+
+// Get the fee bump policy from the Smartnode config.
+// Bumped fees never go over the automatic transaction gas threshold or the manual max fee, if they're set.
+func GetFeeBumpPolicy(cfg *config.RocketPoolConfig) FeeBumpPolicy {
+	percent := cfg.Smartnode.TxBumpPercent.Value.(uint64)
+	if percent < MinFeeBumpPercent {
+		percent = MinFeeBumpPercent
+	}
+	maxFeeGwei := cfg.Smartnode.TxBumpMaxFee.Value.(float64)
+	for _, limit := range []float64{
+		cfg.Smartnode.AutoTxGasThreshold.Value.(float64),
+		cfg.Smartnode.ManualMaxFee.Value.(float64),
+	} {
+		if limit > 0 && limit < maxFeeGwei {
+			maxFeeGwei = limit
+		}
+	}
+	return FeeBumpPolicy{
+		Interval: time.Duration(cfg.Smartnode.TxBumpInterval.Value.(uint64)) * time.Minute,
+		Percent:  percent,
+		MaxFee:   eth.GweiToWei(maxFeeGwei),
+	}
+}
+
+// Get the fees of a transaction after one bump, and whether or not they can be bumped without exceeding the max fee.
+// Execution clients only accept replacements that raise both fees by at least 10%.
+func GetBumpedFees(maxFee *big.Int, maxPriorityFee *big.Int, percent uint64, limit *big.Int) (*big.Int, *big.Int, bool) {
+	if percent < MinFeeBumpPercent {
+		percent = MinFeeBumpPercent
+	}
+	newMaxFee := bumpFee(maxFee, percent)
+	newMaxPriorityFee := bumpFee(maxPriorityFee, percent)
+	if limit != nil && newMaxFee.Cmp(limit) > 0 {
+		return nil, nil, false
+	}
+	return newMaxFee, newMaxPriorityFee, true
+}
+
+// Raise a fee by the provided percentage, rounding up
+func bumpFee(fee *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(int64(100+percent)))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// Create and sign a transaction that replaces a pending one with the same nonce
+func SignReplacement(w *wallet.Wallet, entry JournalEntry, to *common.Address, value *big.Int, data []byte, gasLimit uint64, maxFee *big.Int, maxPriorityFee *big.Int) (*types.Transaction, error) {
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	if opts.From != entry.From {
+		return nil, fmt.Errorf("transaction %s was sent by %s, not the node wallet (%s)", entry.Hash.Hex(), entry.From.Hex(), opts.From.Hex())
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   w.GetChainID(),
+		Nonce:     entry.Nonce,
+		GasTipCap: maxPriorityFee,
+		GasFeeCap: maxFee,
+		Gas:       gasLimit,
+		To:        to,
+		Value:     value,
+		Data:      data,
+	})
+	return opts.Signer(opts.From, tx)
+}
+
+// Print a transaction's details to the logger, record it in the journal and wait for it to be included in a block.
+// If it gets stuck, it's replaced with copies that pay higher fees according to the configured policy.
+func (j *Journal) PrintAndWaitForTransaction(cfg *config.RocketPoolConfig, hash common.Hash, purpose string, w *wallet.Wallet, logger *log.ColorLogger) error {
+
+	txLog := logger.With(log.TxHash(hash))
+	txWatchUrl := cfg.Smartnode.GetTxWatchUrl()
+	txLog.Printlnf("Transaction has been submitted with hash %s.", hash.Hex())
+	if txWatchUrl != "" {
+		txLog.Printlnf("You may follow its progress by visiting:")
+		txLog.Printlnf("%s/%s\n", txWatchUrl, hash.Hex())
+	}
+
+	txLog.Println("Waiting for the transaction to be validated...")
+	entry, err := j.RecordSubmitted(hash, purpose)
+	if err != nil {
+		// It's been broadcast either way, so wait for it without the journal
+		txLog.Warnf("couldn't record the transaction in the journal, so it won't be replaced if it gets stuck: %s", err.Error())
+		receipt, err := utils.WaitForTransaction(j.ec, hash)
+		if err != nil {
+			return fmt.Errorf("Error waiting for transaction: %w", err)
+		}
+		if receipt.Status == types.ReceiptStatusFailed {
+			return fmt.Errorf("Error waiting for transaction: Transaction failed with status 0")
+		}
+		return nil
+	}
+
+	// Every transaction submitted for this nonce, any of which may be the one that gets included
+	policy := GetFeeBumpPolicy(cfg)
+	hashes := []common.Hash{entry.Hash}
+	latest := entry
+	lastSubmission := time.Now()
+	maxFeeReached := false
+
+	for {
+		// Get the latest nonce first, so if it's moved on, any of these that were included will have a receipt
+		nonce, err := j.ec.NonceAt(context.Background(), entry.From, nil)
+		if err != nil {
+			return fmt.Errorf("Error waiting for transaction: %w", err)
+		}
+
+		// Check if any of them have been included
+		for _, txHash := range hashes {
+			receipt, err := j.ec.TransactionReceipt(context.Background(), txHash)
+			if err != nil && !errors.Is(err, ethereum.NotFound) {
+				return fmt.Errorf("Error waiting for transaction: %w", err)
+			}
+			if receipt == nil {
+				continue
+			}
+			err = j.UpdateStatuses()
+			if err != nil {
+				txLog.Warnf("couldn't update the transaction journal: %s", err.Error())
+			}
+			if txHash != entry.Hash {
+				txLog.With(log.TxHash(txHash)).Printlnf("Replacement transaction %s was included in block %d.", txHash.Hex(), receipt.BlockNumber.Uint64())
+			}
+			if receipt.Status == types.ReceiptStatusFailed {
+				return fmt.Errorf("Error waiting for transaction: Transaction failed with status 0")
+			}
+			return nil
+		}
+
+		// Check if something else has used the nonce, like a cancellation from the CLI
+		if nonce > entry.Nonce {
+			err = j.UpdateStatuses()
+			if err != nil {
+				txLog.Warnf("couldn't update the transaction journal: %s", err.Error())
+			}
+			return fmt.Errorf("Error waiting for transaction: another transaction with nonce %d was included instead of %s", entry.Nonce, entry.Hash.Hex())
+		}
+
+		// Bump the fees if it's stuck
+		if policy.Interval > 0 && !maxFeeReached && time.Since(lastSubmission) >= policy.Interval {
+			maxFee, maxPriorityFee, ok := GetBumpedFees(latest.MaxFee, latest.MaxPriorityFee, policy.Percent, policy.MaxFee)
+			if !ok {
+				txLog.With(log.TxHash(latest.Hash)).Printlnf("Transaction %s is still pending, but its fees can't be raised any further without going over the limit of %.2f gwei.", latest.Hash.Hex(), eth.WeiToGwei(policy.MaxFee))
+				maxFeeReached = true
+			} else {
+				replacement, err := j.replace(w, latest, maxFee, maxPriorityFee)
+				if err != nil {
+					txLog.With(log.TxHash(latest.Hash)).Warnf("couldn't replace stuck transaction %s: %s", latest.Hash.Hex(), err.Error())
+				} else {
+					txLog.With(log.TxHash(replacement.Hash)).Printlnf("Transaction %s was still pending after %s, replaced it with %s (max fee %.2f gwei, priority fee %.2f gwei).",
+						latest.Hash.Hex(), policy.Interval, replacement.Hash.Hex(), eth.WeiToGwei(maxFee), eth.WeiToGwei(maxPriorityFee))
+					hashes = append(hashes, replacement.Hash)
+					latest = replacement
+				}
+				lastSubmission = time.Now()
+			}
+		}
+
+		time.Sleep(receiptPollInterval)
+	}
+
+}
+
+// Submit a copy of a pending transaction with higher fees and record it in the journal
+func (j *Journal) replace(w *wallet.Wallet, entry JournalEntry, maxFee *big.Int, maxPriorityFee *big.Int) (JournalEntry, error) {
+	tx, err := SignReplacement(w, entry, entry.To, entry.Value, entry.Data, entry.GasLimit, maxFee, maxPriorityFee)
+	if err != nil {
+		return JournalEntry{}, err
+	}
+	err = j.ec.SendTransaction(context.Background(), tx)
+	if err != nil {
+		return JournalEntry{}, err
+	}
+	replaces := entry.Hash
+	return j.Record(tx, entry.From, entry.Purpose, &replaces)
+}
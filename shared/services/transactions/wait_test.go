@@ -0,0 +1,63 @@
+package transactions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+func TestGetBumpedFees(t *testing.T) {
+	// Fees are raised by the percentage, rounding up
+	maxFee, maxPriorityFee, ok := GetBumpedFees(big.NewInt(1000), big.NewInt(101), 15, nil)
+	if !ok || maxFee.Int64() != 1150 || maxPriorityFee.Int64() != 117 {
+		t.Fatalf("unexpected bumped fees %s / %s (ok %t)", maxFee, maxPriorityFee, ok)
+	}
+
+	// Execution clients won't accept anything below the minimum
+	maxFee, _, _ = GetBumpedFees(big.NewInt(1000), big.NewInt(100), 1, nil)
+	if maxFee.Int64() != 1100 {
+		t.Fatalf("expected the minimum bump, got %s", maxFee)
+	}
+
+	// The limit can't be exceeded
+	if _, _, ok := GetBumpedFees(big.NewInt(1000), big.NewInt(100), 15, big.NewInt(1149)); ok {
+		t.Fatal("the fees were bumped over the limit")
+	}
+	if _, _, ok := GetBumpedFees(big.NewInt(1000), big.NewInt(100), 15, big.NewInt(1150)); !ok {
+		t.Fatal("the fees should be bumped up to the limit")
+	}
+}
+
+func TestFeeBumpPolicyOffByDefault(t *testing.T) {
+	cfg := config.NewRocketPoolConfig(t.TempDir(), false)
+	if policy := GetFeeBumpPolicy(cfg); policy.Interval != 0 {
+		t.Fatalf("fee bumping should be disabled by default, but the interval is %s", policy.Interval)
+	}
+}
+
+func TestFeeBumpPolicyMaxFee(t *testing.T) {
+	cfg := config.NewRocketPoolConfig(t.TempDir(), false)
+	cfg.Smartnode.TxBumpMaxFee.Value = float64(150)
+
+	for _, test := range []struct {
+		autoTxGasThreshold float64
+		manualMaxFee       float64
+		expected           float64
+	}{
+		{autoTxGasThreshold: 0, manualMaxFee: 0, expected: 150},
+		{autoTxGasThreshold: 200, manualMaxFee: 0, expected: 150},
+		{autoTxGasThreshold: 100, manualMaxFee: 0, expected: 100},
+		{autoTxGasThreshold: 100, manualMaxFee: 50, expected: 50},
+		{autoTxGasThreshold: 0, manualMaxFee: 75, expected: 75},
+	} {
+		cfg.Smartnode.AutoTxGasThreshold.Value = test.autoTxGasThreshold
+		cfg.Smartnode.ManualMaxFee.Value = test.manualMaxFee
+		policy := GetFeeBumpPolicy(cfg)
+		if policy.MaxFee.Cmp(eth.GweiToWei(test.expected)) != 0 {
+			t.Fatalf("expected a max fee of %.0f gwei with an auto TX threshold of %.0f and a manual max fee of %.0f, got %.2f",
+				test.expected, test.autoTxGasThreshold, test.manualMaxFee, eth.WeiToGwei(policy.MaxFee))
+		}
+	}
+}
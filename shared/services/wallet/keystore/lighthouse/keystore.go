@@ -29,7 +29,7 @@ const (
 // Lighthouse keystore
 type Keystore struct {
 	keystorePath string
-	pm           *passwords.PasswordManager
+	pm           passwords.PasswordManager
 	encryptor    *eth2ks.Encryptor
 }
 
@@ -43,7 +43,7 @@ type validatorKey struct {
 }
 
 // Create new lighthouse keystore
-func NewKeystore(keystorePath string, passwordManager *passwords.PasswordManager) *Keystore {
+func NewKeystore(keystorePath string, passwordManager passwords.PasswordManager) *Keystore {
 	return &Keystore{
 		keystorePath: keystorePath,
 		pm:           passwordManager,
@@ -30,7 +30,7 @@ const (
 // Lodestar keystore
 type Keystore struct {
 	keystorePath string
-	pm           *passwords.PasswordManager
+	pm           passwords.PasswordManager
 	encryptor    *eth2ks.Encryptor
 }
 
@@ -44,7 +44,7 @@ type validatorKey struct {
 }
 
 // Create new lodestar keystore
-func NewKeystore(keystorePath string, passwordManager *passwords.PasswordManager) *Keystore {
+func NewKeystore(keystorePath string, passwordManager passwords.PasswordManager) *Keystore {
 	return &Keystore{
 		keystorePath: keystorePath,
 		pm:           passwordManager,
@@ -34,7 +34,7 @@ const (
 // Prysm keystore
 type Keystore struct {
 	keystorePath string
-	pm           *passwords.PasswordManager
+	pm           passwords.PasswordManager
 	as           *accountStore
 	encryptor    *eth2ks.Encryptor
 }
@@ -58,7 +58,7 @@ type walletConfig struct {
 }
 
 // Create new prysm keystore
-func NewKeystore(keystorePath string, passwordManager *passwords.PasswordManager) *Keystore {
+func NewKeystore(keystorePath string, passwordManager passwords.PasswordManager) *Keystore {
 	return &Keystore{
 		keystorePath: keystorePath,
 		pm:           passwordManager,
@@ -28,7 +28,7 @@ const (
 // Teku keystore
 type Keystore struct {
 	keystorePath string
-	pm           *passwords.PasswordManager
+	pm           passwords.PasswordManager
 	encryptor    *eth2ks.Encryptor
 }
 
@@ -42,7 +42,7 @@ type validatorKey struct {
 }
 
 // Create new teku keystore
-func NewKeystore(keystorePath string, passwordManager *passwords.PasswordManager) *Keystore {
+func NewKeystore(keystorePath string, passwordManager passwords.PasswordManager) *Keystore {
 	return &Keystore{
 		keystorePath: keystorePath,
 		pm:           passwordManager,
@@ -0,0 +1,251 @@
+package web3signer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	PublicKeysPath string        = "/api/v1/eth2/publicKeys"
+	SignPath       string        = "/api/v1/eth2/sign/%s"
+	RequestTimeout time.Duration = 30 * time.Second
+)
+
+// Signing request types
+const (
+	voluntaryExitType        string = "VOLUNTARY_EXIT"
+	blsToExecutionChangeType string = "BLS_TO_EXECUTION_CHANGE"
+)
+
+// Keystore that imports validator keys into a Web3Signer-compatible remote signer instead of writing them to disk.
+// Keys can't be exported from the signer, so it also signs the messages that would otherwise need the local key.
+type Keystore struct {
+	url        string
+	client     http.Client
+	keymanager *keymanager.Client
+}
+
+// The fork and genesis details the signer uses to compute the signature domain
+type ForkInfo struct {
+	PreviousVersion       []byte
+	CurrentVersion        []byte
+	Epoch                 uint64
+	GenesisValidatorsRoot []byte
+}
+
+// Signing API requests and responses
+type forkInfoMessage struct {
+	Fork struct {
+		PreviousVersion string `json:"previous_version"`
+		CurrentVersion  string `json:"current_version"`
+		Epoch           string `json:"epoch"`
+	} `json:"fork"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+}
+type voluntaryExitMessage struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+type blsToExecutionChangeMessage struct {
+	ValidatorIndex     string `json:"validator_index"`
+	FromBlsPubkey      string `json:"from_bls_pubkey"`
+	ToExecutionAddress string `json:"to_execution_address"`
+}
+type signRequest struct {
+	Type                 string                       `json:"type"`
+	ForkInfo             forkInfoMessage              `json:"fork_info"`
+	SigningRoot          string                       `json:"signingRoot"`
+	VoluntaryExit        *voluntaryExitMessage        `json:"voluntary_exit,omitempty"`
+	BlsToExecutionChange *blsToExecutionChangeMessage `json:"bls_to_execution_change,omitempty"`
+}
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+// Create new Web3Signer keystore
+func NewKeystore(url string) *Keystore {
+	return &Keystore{
+		url: strings.TrimSuffix(url, "/"),
+		client: http.Client{
+			Timeout: RequestTimeout,
+		},
+		keymanager: keymanager.NewClient(url, ""),
+	}
+}
+
+// Get the keystore directory; the keys live in the remote signer, so there isn't one
+func (ks *Keystore) GetKeystoreDir() string {
+	return ""
+}
+
+// Store a validator key by importing it into the remote signer; a key the signer already has is left as is
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	_, err := ks.keymanager.ImportKeystores([]keymanager.ValidatorKey{{
+		Key:            key,
+		DerivationPath: derivationPath,
+	}}, "")
+	if err != nil {
+		return fmt.Errorf("Could not import validator key %s into the remote signer: %w", types.BytesToValidatorPubkey(key.PublicKey().Marshal()).Hex(), err)
+	}
+	return nil
+}
+
+// Load a private key; remote signers never export their keys, so this is always empty
+func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	return nil, nil
+}
+
+// Get the public keys of every key the remote signer holds
+func (ks *Keystore) GetPublicKeys() ([]types.ValidatorPubkey, error) {
+	var response []string
+	if err := ks.get(PublicKeysPath, &response); err != nil {
+		return nil, fmt.Errorf("Could not get public keys from the remote signer: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, 0, len(response))
+	for _, pubkeyString := range response {
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(pubkeyString))
+		if err != nil {
+			return nil, fmt.Errorf("Remote signer returned an invalid public key '%s': %w", pubkeyString, err)
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+// Check if the remote signer holds the key for a public key
+func (ks *Keystore) HasKey(pubkey types.ValidatorPubkey) (bool, error) {
+	pubkeys, err := ks.GetPublicKeys()
+	if err != nil {
+		return false, err
+	}
+	for _, candidate := range pubkeys {
+		if candidate == pubkey {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Have the remote signer sign a voluntary exit message
+func (ks *Keystore) SignVoluntaryExit(pubkey types.ValidatorPubkey, forkInfo ForkInfo, signingRoot [32]byte, validatorIndex string, epoch uint64) (types.ValidatorSignature, error) {
+	return ks.sign(pubkey, signRequest{
+		Type:        voluntaryExitType,
+		ForkInfo:    getForkInfoMessage(forkInfo),
+		SigningRoot: hexutil.AddPrefix(common.Bytes2Hex(signingRoot[:])),
+		VoluntaryExit: &voluntaryExitMessage{
+			Epoch:          fmt.Sprint(epoch),
+			ValidatorIndex: validatorIndex,
+		},
+	})
+}
+
+// Have the remote signer sign a withdrawal credentials change message with a withdrawal key it holds
+func (ks *Keystore) SignWithdrawalCredsChange(withdrawalPubkey types.ValidatorPubkey, forkInfo ForkInfo, signingRoot [32]byte, validatorIndex string, newWithdrawalAddress common.Address) (types.ValidatorSignature, error) {
+	return ks.sign(withdrawalPubkey, signRequest{
+		Type:        blsToExecutionChangeType,
+		ForkInfo:    getForkInfoMessage(forkInfo),
+		SigningRoot: hexutil.AddPrefix(common.Bytes2Hex(signingRoot[:])),
+		BlsToExecutionChange: &blsToExecutionChangeMessage{
+			ValidatorIndex:     validatorIndex,
+			FromBlsPubkey:      hexutil.AddPrefix(withdrawalPubkey.Hex()),
+			ToExecutionAddress: newWithdrawalAddress.Hex(),
+		},
+	})
+}
+
+// Send a signing request for a key to the remote signer
+func (ks *Keystore) sign(pubkey types.ValidatorPubkey, request signRequest) (types.ValidatorSignature, error) {
+
+	// The signer responds with either a JSON object or the bare signature, depending on the Accept header
+	responseBody, err := ks.request(http.MethodPost, fmt.Sprintf(SignPath, hexutil.AddPrefix(pubkey.Hex())), request)
+	if err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("Could not get %s signature for %s from the remote signer: %w", request.Type, pubkey.Hex(), err)
+	}
+	signatureString := strings.TrimSpace(string(responseBody))
+	var response signResponse
+	if err := json.Unmarshal(responseBody, &response); err == nil && response.Signature != "" {
+		signatureString = response.Signature
+	}
+
+	// Decode it
+	signature, err := types.HexToValidatorSignature(hexutil.RemovePrefix(signatureString))
+	if err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("Remote signer returned an invalid signature '%s': %w", signatureString, err)
+	}
+	return signature, nil
+
+}
+
+// Make a GET request to the remote signer and decode the response
+func (ks *Keystore) get(path string, response interface{}) error {
+	responseBody, err := ks.request(http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf("could not decode response: %w", err)
+	}
+	return nil
+}
+
+// Make a request to the remote signer
+func (ks *Keystore) request(method string, path string, requestBody interface{}) ([]byte, error) {
+
+	// Encode the body
+	var body io.Reader
+	if requestBody != nil {
+		requestBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode request: %w", err)
+		}
+		body = bytes.NewReader(requestBytes)
+	}
+
+	// Send the request
+	request, err := http.NewRequest(method, ks.url+path, body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+	if requestBody != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	response, err := ks.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	// Read the response
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP status %d; response body: '%s'", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return responseBody, nil
+
+}
+
+func getForkInfoMessage(forkInfo ForkInfo) forkInfoMessage {
+	var message forkInfoMessage
+	message.Fork.PreviousVersion = hexutil.AddPrefix(common.Bytes2Hex(forkInfo.PreviousVersion))
+	message.Fork.CurrentVersion = hexutil.AddPrefix(common.Bytes2Hex(forkInfo.CurrentVersion))
+	message.Fork.Epoch = fmt.Sprint(forkInfo.Epoch)
+	message.GenesisValidatorsRoot = hexutil.AddPrefix(common.Bytes2Hex(forkInfo.GenesisValidatorsRoot))
+	return message
+}
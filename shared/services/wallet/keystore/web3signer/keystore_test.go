@@ -0,0 +1,108 @@
+package web3signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+var (
+	testPubkey    = "0x" + strings.Repeat("a1", types.ValidatorPubkeyLength)
+	testSignature = "0x" + strings.Repeat("b2", types.ValidatorSignatureLength)
+)
+
+// Start a fake signer that holds testPubkey and returns testSignature, recording the last signing request
+func newTestSigner(t *testing.T, bareSignature bool, lastRequest *signRequest) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == PublicKeysPath:
+			json.NewEncoder(w).Encode([]string{testPubkey})
+		case r.Method == http.MethodPost && r.URL.Path == fmt.Sprintf(SignPath, testPubkey):
+			if err := json.NewDecoder(r.Body).Decode(lastRequest); err != nil {
+				t.Errorf("error decoding signing request: %s", err)
+			}
+			if bareSignature {
+				fmt.Fprintln(w, testSignature)
+			} else {
+				json.NewEncoder(w).Encode(signResponse{Signature: testSignature})
+			}
+		default:
+			http.Error(w, "unknown key", http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetPublicKeys(t *testing.T) {
+	server := newTestSigner(t, false, &signRequest{})
+	ks := NewKeystore(server.URL + "/")
+
+	pubkeys, err := ks.GetPublicKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubkeys) != 1 || "0x"+pubkeys[0].Hex() != testPubkey {
+		t.Fatalf("unexpected public keys %v", pubkeys)
+	}
+
+	has, err := ks.HasKey(pubkeys[0])
+	if err != nil || !has {
+		t.Fatalf("the signer should have the key (err %v)", err)
+	}
+	has, err = ks.HasKey(types.ValidatorPubkey{})
+	if err != nil || has {
+		t.Fatalf("the signer should not have an unknown key (err %v)", err)
+	}
+}
+
+func TestSignVoluntaryExit(t *testing.T) {
+	for _, bareSignature := range []bool{false, true} {
+		var request signRequest
+		server := newTestSigner(t, bareSignature, &request)
+		ks := NewKeystore(server.URL)
+
+		pubkey, _ := types.HexToValidatorPubkey(testPubkey[2:])
+		forkInfo := ForkInfo{
+			PreviousVersion:       []byte{0, 0, 0, 1},
+			CurrentVersion:        []byte{0, 0, 0, 2},
+			Epoch:                 100,
+			GenesisValidatorsRoot: make([]byte, 32),
+		}
+		signature, err := ks.SignVoluntaryExit(pubkey, forkInfo, [32]byte{1}, "42", 200)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if "0x"+signature.Hex() != testSignature {
+			t.Fatalf("unexpected signature %s (bare %t)", signature.Hex(), bareSignature)
+		}
+
+		if request.Type != voluntaryExitType || request.VoluntaryExit == nil {
+			t.Fatalf("unexpected signing request %+v", request)
+		}
+		if request.VoluntaryExit.Epoch != "200" || request.VoluntaryExit.ValidatorIndex != "42" {
+			t.Fatalf("unexpected exit message %+v", *request.VoluntaryExit)
+		}
+		if request.ForkInfo.Fork.CurrentVersion != "0x00000002" || request.ForkInfo.Fork.Epoch != "100" {
+			t.Fatalf("unexpected fork info %+v", request.ForkInfo)
+		}
+		if !strings.HasPrefix(request.SigningRoot, "0x01") {
+			t.Fatalf("unexpected signing root %s", request.SigningRoot)
+		}
+	}
+}
+
+func TestSignUnknownKey(t *testing.T) {
+	server := newTestSigner(t, false, &signRequest{})
+	ks := NewKeystore(server.URL)
+
+	_, err := ks.SignVoluntaryExit(types.ValidatorPubkey{}, ForkInfo{}, [32]byte{}, "1", 1)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected an HTTP 404 error, got %v", err)
+	}
+}
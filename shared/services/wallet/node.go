@@ -10,9 +10,41 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
 )
 
+// Signs transactions and messages for the node account in place of the wallet's derived key
+type NodeSigner interface {
+	// Get the address of the account the signer signs for
+	GetAddress() common.Address
+
+	// Sign a transaction
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
+	// Sign a message with the EIP-191 personal message prefix
+	SignMessage(message []byte) ([]byte, error)
+}
+
+// Returned when the node key is requested while an external signer holds the node account
+var errNodeKeyInSigner = errors.New("The node account is held by an external signer, so its private key isn't available")
+
+// Use an external signer for the node account instead of the key derived from the wallet
+func (w *Wallet) SetNodeSigner(signer NodeSigner) {
+	w.nodeSigner = signer
+	w.nodeKey = nil
+	w.nodeKeyPath = ""
+}
+
+// Check if the node account is signed for by an external signer
+func (w *Wallet) HasNodeSigner() bool {
+	return w.nodeSigner != nil
+}
+
 // Get the node account
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
@@ -21,6 +53,13 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
 	}
 
+	// Use the external signer's account if there is one
+	if w.nodeSigner != nil {
+		return accounts.Account{
+			Address: w.nodeSigner.GetAddress(),
+		}, nil
+	}
+
 	// Get private key
 	privateKey, path, err := w.getNodePrivateKey()
 	if err != nil {
@@ -45,6 +84,41 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
 }
 
+// Get a view of the wallet that uses the node account at the provided index instead of the wallet's own.
+// The view shares the wallet's seed and keystores, but has its own copy of the wallet store and its own key caches so it can be used
+// alongside the wallet and other views; validator keys created through the wallet after the view was made aren't visible to it.
+func (w *Wallet) GetNodeWallet(index uint) (*Wallet, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, errors.New("Wallet is not initialized")
+	}
+
+	// Copy the wallet without its key caches; the external signer only signs for the wallet's own node account
+	view := *w
+	view.nodeKey = nil
+	view.nodeKeyPath = ""
+	view.nodeIndex = &index
+	view.nodeSigner = nil
+	view.validatorKeys = map[uint]*eth2types.BLSPrivateKey{}
+
+	// Copy the store; the encrypted seed is only ever read, so its contents can be shared
+	ws := *w.ws
+	ws.Crypto = make(map[string]interface{}, len(w.ws.Crypto))
+	for key, value := range w.ws.Crypto {
+		ws.Crypto[key] = value
+	}
+	view.ws = &ws
+
+	// Copy the keystore list so keystores added to the wallet later don't race with the view
+	view.keystores = make(map[string]keystore.Keystore, len(w.keystores))
+	for name, ks := range w.keystores {
+		view.keystores[name] = ks
+	}
+	return &view, nil
+
+}
+
 // Get a transactor for the node account
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
@@ -53,19 +127,59 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
-	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
-	if err != nil {
-		return nil, err
+	// Create the transactor, signing with the external signer if there is one
+	var transactor *bind.TransactOpts
+	if w.nodeSigner != nil {
+		from := w.nodeSigner.GetAddress()
+		transactor = &bind.TransactOpts{
+			From: from,
+			Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				if address != from {
+					return nil, bind.ErrNotAuthorized
+				}
+				return w.nodeSigner.SignTx(tx, w.chainID)
+			},
+		}
+	} else {
+		privateKey, _, err := w.getNodePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		transactor, err = bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Create & return transactor
-	transactor, err := bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+	// Apply the gas settings & return
 	transactor.GasFeeCap = w.maxFee
 	transactor.GasTipCap = w.maxPriorityFee
 	transactor.GasLimit = w.gasLimit
 	transactor.Context = context.Background()
-	return transactor, err
+	return transactor, nil
+
+}
+
+// Sign a transaction for the node account
+func (w *Wallet) signTx(tx *types.Transaction) (*types.Transaction, error) {
+
+	// Use the external signer if there is one
+	if w.nodeSigner != nil {
+		return w.nodeSigner.SignTx(tx, w.chainID)
+	}
+
+	// Get private key
+	privateKey, _, err := w.getNodePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign it
+	signedTx, err := types.SignTx(tx, types.NewLondonSigner(w.chainID), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing TX: %w", err)
+	}
+	return signedTx, nil
 
 }
 
@@ -91,13 +205,22 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 // Get the node private key
 func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 
+	// Never derive the node key from the seed when an external signer holds the node account
+	if w.nodeSigner != nil {
+		return nil, "", errNodeKeyInSigner
+	}
+
 	// Check for cached node key
 	if w.nodeKey != nil {
 		return w.nodeKey, w.nodeKeyPath, nil
 	}
 
 	// Get derived key
-	derivedKey, path, err := w.getNodeDerivedKey(w.ws.WalletIndex)
+	index := w.ws.WalletIndex
+	if w.nodeIndex != nil {
+		index = *w.nodeIndex
+	}
+	derivedKey, path, err := w.getNodeDerivedKey(index)
 	if err != nil {
 		return nil, "", err
 	}
@@ -122,10 +245,11 @@ func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 func (w *Wallet) getNodeDerivedKey(index uint) (*hdkeychain.ExtendedKey, string, error) {
 
 	// Get derivation path
-	if w.ws.DerivationPath == "" {
-		w.ws.DerivationPath = DefaultNodeKeyPath
+	derivationPathFormat := w.ws.DerivationPath
+	if derivationPathFormat == "" {
+		derivationPathFormat = DefaultNodeKeyPath
 	}
-	derivationPath := fmt.Sprintf(w.ws.DerivationPath, index)
+	derivationPath := fmt.Sprintf(derivationPathFormat, index)
 
 	// Parse derivation path
 	path, err := accounts.ParseDerivationPath(derivationPath)
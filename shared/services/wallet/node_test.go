@@ -0,0 +1,157 @@
+package wallet
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+)
+
+// A node signer that only reports its address
+type fakeNodeSigner struct {
+	address common.Address
+}
+
+func (s *fakeNodeSigner) GetAddress() common.Address {
+	return s.address
+}
+
+func (s *fakeNodeSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return tx, nil
+}
+
+func (s *fakeNodeSigner) SignMessage(message []byte) ([]byte, error) {
+	return make([]byte, 65), nil
+}
+
+// Create an initialized wallet in a temporary directory
+func newTestWallet(t *testing.T) *Wallet {
+	dir := t.TempDir()
+	pm := passwords.NewFilePasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("test wallet password"); err != nil {
+		t.Fatalf("error setting password: %v", err)
+	}
+	w, err := NewWallet(filepath.Join(dir, "wallet"), 1, nil, nil, 0, pm)
+	if err != nil {
+		t.Fatalf("error creating wallet: %v", err)
+	}
+
+	// Scrypt is too slow for tests
+	w.encryptor = eth2ks.New(eth2ks.WithCipher("pbkdf2"))
+	if _, err := w.Initialize(DefaultNodeKeyPath, 0); err != nil {
+		t.Fatalf("error initializing wallet: %v", err)
+	}
+	return w
+}
+
+func TestNodeSignerHoldsNodeKey(t *testing.T) {
+	w := newTestWallet(t)
+
+	// Cache the derived key first so the signer has to clear it
+	derivedAccount, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatalf("error getting derived node account: %v", err)
+	}
+
+	signerAddress := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	w.SetNodeSigner(&fakeNodeSigner{address: signerAddress})
+	if w.nodeKey != nil {
+		t.Fatalf("setting a node signer should clear the cached node key")
+	}
+
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatalf("error getting node account: %v", err)
+	}
+	if account.Address != signerAddress {
+		t.Fatalf("expected node account %s, got %s", signerAddress.Hex(), account.Address.Hex())
+	}
+	if _, err := w.GetNodePrivateKeyBytes(); !errors.Is(err, errNodeKeyInSigner) {
+		t.Fatalf("expected the node key to be unavailable with a signer, got %v", err)
+	}
+	if w.nodeKey != nil {
+		t.Fatalf("the node key shouldn't be derived with a signer")
+	}
+
+	// Other node accounts in the wallet still use their derived keys
+	view, err := w.GetNodeWallet(0)
+	if err != nil {
+		t.Fatalf("error getting node wallet view: %v", err)
+	}
+	viewAccount, err := view.GetNodeAccount()
+	if err != nil {
+		t.Fatalf("error getting node wallet view account: %v", err)
+	}
+	if viewAccount.Address != derivedAccount.Address {
+		t.Fatalf("expected node wallet view account %s, got %s", derivedAccount.Address.Hex(), viewAccount.Address.Hex())
+	}
+}
+
+func TestNodeWalletViewsAreIndependent(t *testing.T) {
+	w := newTestWallet(t)
+	views := make([]*Wallet, 2)
+	for i := range views {
+		view, err := w.GetNodeWallet(uint(i + 1))
+		if err != nil {
+			t.Fatalf("error getting node wallet view %d: %v", i+1, err)
+		}
+		views[i] = view
+	}
+
+	// Use the wallet and both views at once; run with -race to catch shared state
+	all := append([]*Wallet{w}, views...)
+	addresses := make([]common.Address, len(all))
+	errs := make([]error, len(all))
+	var wg sync.WaitGroup
+	for i, wallet := range all {
+		i, wallet := i, wallet
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			account, err := wallet.GetNodeAccount()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			addresses[i] = account.Address
+			for index := uint(0); index < 3; index++ {
+				if _, err := wallet.GetValidatorKeyAt(index); err != nil {
+					errs[i] = err
+					return
+				}
+			}
+			_, errs[i] = wallet.GetNodeAccountTransactor()
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("error using wallet %d: %v", i, err)
+		}
+	}
+
+	// Each view has its own node account
+	seen := map[common.Address]bool{}
+	for i, address := range addresses {
+		if seen[address] {
+			t.Fatalf("wallet %d has the same node account %s as another wallet", i, address.Hex())
+		}
+		seen[address] = true
+	}
+
+	// The views' caches and stores are their own
+	if len(w.validatorKeys) != 3 || len(views[0].validatorKeys) != 3 {
+		t.Fatalf("expected each wallet to cache its own 3 validator keys, got %d and %d", len(w.validatorKeys), len(views[0].validatorKeys))
+	}
+	views[0].ws.NextAccount = 10
+	if w.ws.NextAccount == 10 || views[1].ws.NextAccount == 10 {
+		t.Fatalf("views shouldn't share the wallet store")
+	}
+}
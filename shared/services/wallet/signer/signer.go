@@ -0,0 +1,201 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/goccy/go-json"
+)
+
+// Config
+const (
+	RequestTimeout time.Duration = 2 * time.Minute
+)
+
+// The JSON-RPC methods a signer uses
+type rpcMethods struct {
+	signTransaction string
+	signMessage     string
+}
+
+var (
+	// Clef's external API
+	clefMethods = rpcMethods{
+		signTransaction: "account_signTransaction",
+		signMessage:     "account_signData",
+	}
+
+	// The standard wallet methods used by EIP-1193 providers and signers such as Web3Signer's eth1 mode
+	eip1193Methods = rpcMethods{
+		signTransaction: "eth_signTransaction",
+		signMessage:     "personal_sign",
+	}
+)
+
+// Signs transactions and messages for the node account by sending them to an external signer over JSON-RPC,
+// so the node account's private key never has to be loaded by the Smartnode.
+// Requests that need to be approved by hand block until the signer responds or the request times out.
+type RpcSigner struct {
+	url     string
+	address common.Address
+	methods rpcMethods
+	isClef  bool
+}
+
+// The transaction fields sent to the signer
+type transactionArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+	Type                 hexutil.Uint64  `json:"type"`
+}
+
+// Signers either return the raw signed transaction or an object that contains it
+type signTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// Create a signer that uses Clef's external API
+func NewClefSigner(url string, address common.Address) *RpcSigner {
+	return &RpcSigner{
+		url:     url,
+		address: address,
+		methods: clefMethods,
+		isClef:  true,
+	}
+}
+
+// Create a signer that uses the standard eth_signTransaction and personal_sign methods
+func NewEip1193Signer(url string, address common.Address) *RpcSigner {
+	return &RpcSigner{
+		url:     url,
+		address: address,
+		methods: eip1193Methods,
+	}
+}
+
+// Get the address of the account the signer signs for
+func (s *RpcSigner) GetAddress() common.Address {
+	return s.address
+}
+
+// Sign a transaction
+func (s *RpcSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+
+	// Build the request
+	args := transactionArgs{
+		From:                 s.address,
+		To:                   tx.To(),
+		Gas:                  hexutil.Uint64(tx.Gas()),
+		MaxFeePerGas:         (*hexutil.Big)(tx.GasFeeCap()),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap()),
+		Value:                (*hexutil.Big)(tx.Value()),
+		Nonce:                hexutil.Uint64(tx.Nonce()),
+		Data:                 tx.Data(),
+		ChainID:              (*hexutil.Big)(chainID),
+		Type:                 hexutil.Uint64(types.DynamicFeeTxType),
+	}
+
+	// Sign it
+	var result json.RawMessage
+	if err := s.call(&result, s.methods.signTransaction, args); err != nil {
+		return nil, fmt.Errorf("Error signing TX with the external signer: %w", err)
+	}
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err != nil {
+		var response signTransactionResult
+		if err := json.Unmarshal(result, &response); err != nil {
+			return nil, fmt.Errorf("Error decoding the external signer's response: %w", err)
+		}
+		raw = response.Raw
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("Error unmarshalling TX signed by the external signer: %w", err)
+	}
+
+	// Make sure the signer signed what it was asked to, with the right account
+	sender, err := types.Sender(types.NewLondonSigner(chainID), signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error recovering the sender of the TX signed by the external signer: %w", err)
+	}
+	if sender != s.address {
+		return nil, fmt.Errorf("External signer signed the TX with %s instead of %s", sender.Hex(), s.address.Hex())
+	}
+	if signedTx.Nonce() != tx.Nonce() ||
+		signedTx.Gas() != tx.Gas() ||
+		signedTx.GasFeeCap().Cmp(tx.GasFeeCap()) != 0 ||
+		signedTx.GasTipCap().Cmp(tx.GasTipCap()) != 0 ||
+		signedTx.Value().Cmp(tx.Value()) != 0 ||
+		!bytes.Equal(signedTx.Data(), tx.Data()) ||
+		!equalAddresses(signedTx.To(), tx.To()) {
+		return nil, fmt.Errorf("External signer returned a TX that doesn't match the one it was asked to sign")
+	}
+	return signedTx, nil
+
+}
+
+// Sign a message with the EIP-191 personal message prefix
+func (s *RpcSigner) SignMessage(message []byte) ([]byte, error) {
+	var signature hexutil.Bytes
+	var err error
+	if s.isClef {
+		err = s.call(&signature, s.methods.signMessage, "text/plain", s.address, hexutil.Bytes(message))
+	} else {
+		err = s.call(&signature, s.methods.signMessage, hexutil.Bytes(message), s.address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error signing message with the external signer: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("External signer returned a signature with %d bytes instead of %d", len(signature), crypto.SignatureLength)
+	}
+
+	// Make sure the message was signed by the right account; signers return V as 27 or 28, but recovery needs 0 or 1
+	recoverable := common.CopyBytes(signature)
+	if recoverable[crypto.RecoveryIDOffset] >= 27 {
+		recoverable[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash(message), recoverable)
+	if err != nil {
+		return nil, fmt.Errorf("Error recovering the signer of the message signed by the external signer: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != s.address {
+		return nil, fmt.Errorf("External signer signed the message with %s instead of %s", signer.Hex(), s.address.Hex())
+	}
+	return signature, nil
+}
+
+// Call a method on the signer
+func (s *RpcSigner) call(result interface{}, method string, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	defer cancel()
+	client, err := rpc.DialContext(ctx, s.url)
+	if err != nil {
+		return fmt.Errorf("error connecting to %s: %w", s.url, err)
+	}
+	defer client.Close()
+	return client.CallContext(ctx, result, method, args...)
+}
+
+func equalAddresses(a *common.Address, b *common.Address) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
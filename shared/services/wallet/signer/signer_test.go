@@ -0,0 +1,171 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/goccy/go-json"
+)
+
+var testChainID = big.NewInt(17000)
+
+// A JSON-RPC request sent to the signer
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+// Start a JSON-RPC server that answers every request with the provided result
+func newSignerServer(t *testing.T, methods *[]string, result interface{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("error decoding request: %v", err)
+			return
+		}
+		*methods = append(*methods, request.Method)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  result,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// Create a transaction to sign
+func newTestTx(nonce uint64) *types.Transaction {
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(30e9),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1e18),
+	})
+}
+
+// Sign a transaction and serialize it the way a signer returns it
+func signTestTx(t *testing.T, tx *types.Transaction, key *ecdsa.PrivateKey) hexutil.Bytes {
+	signedTx, err := types.SignTx(tx, types.NewLondonSigner(testChainID), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+// Sign a message with the personal message prefix and a V of 27 or 28, like a signer does
+func signTestMessage(t *testing.T, message []byte, key *ecdsa.PrivateKey) hexutil.Bytes {
+	signature, err := crypto.Sign(accounts.TextHash(message), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature
+}
+
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, common.Address) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func TestSignTx(t *testing.T) {
+	key, address := newTestKey(t)
+	tx := newTestTx(5)
+	raw := signTestTx(t, tx, key)
+
+	tests := []struct {
+		name   string
+		signer func(url string) *RpcSigner
+		result interface{}
+		method string
+	}{
+		{"raw response", func(url string) *RpcSigner { return NewEip1193Signer(url, address) }, raw, "eth_signTransaction"},
+		{"object response", func(url string) *RpcSigner { return NewClefSigner(url, address) }, signTransactionResult{Raw: raw}, "account_signTransaction"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			methods := []string{}
+			server := newSignerServer(t, &methods, test.result)
+			signedTx, err := test.signer(server.URL).SignTx(tx, testChainID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signedTx.Hash() != crypto.Keccak256Hash(raw) {
+				t.Fatalf("expected TX %s, got %s", crypto.Keccak256Hash(raw).Hex(), signedTx.Hash().Hex())
+			}
+			if len(methods) != 1 || methods[0] != test.method {
+				t.Fatalf("expected a call to %s, got %v", test.method, methods)
+			}
+		})
+	}
+}
+
+func TestSignTxWithWrongSender(t *testing.T) {
+	_, address := newTestKey(t)
+	otherKey, otherAddress := newTestKey(t)
+	tx := newTestTx(5)
+
+	methods := []string{}
+	server := newSignerServer(t, &methods, signTestTx(t, tx, otherKey))
+	_, err := NewEip1193Signer(server.URL, address).SignTx(tx, testChainID)
+	if err == nil || !strings.Contains(err.Error(), otherAddress.Hex()) {
+		t.Fatalf("expected a wrong sender error, got %v", err)
+	}
+}
+
+func TestSignTxWithDifferentTx(t *testing.T) {
+	key, address := newTestKey(t)
+	tx := newTestTx(5)
+
+	// The signer signs a TX with a different nonce than the one it was asked to sign
+	methods := []string{}
+	server := newSignerServer(t, &methods, signTestTx(t, newTestTx(6), key))
+	_, err := NewEip1193Signer(server.URL, address).SignTx(tx, testChainID)
+	if err == nil || !strings.Contains(err.Error(), "doesn't match") {
+		t.Fatalf("expected a mismatched TX error, got %v", err)
+	}
+}
+
+func TestSignMessage(t *testing.T) {
+	key, address := newTestKey(t)
+	otherKey, otherAddress := newTestKey(t)
+	message := []byte("rocket pool")
+
+	methods := []string{}
+	server := newSignerServer(t, &methods, signTestMessage(t, message, key))
+	signature, err := NewEip1193Signer(server.URL, address).SignMessage(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signature) != crypto.SignatureLength || methods[0] != "personal_sign" {
+		t.Fatalf("unexpected signature %x from %v", signature, methods)
+	}
+
+	// A message signed by a different account is rejected
+	server = newSignerServer(t, &methods, signTestMessage(t, message, otherKey))
+	_, err = NewClefSigner(server.URL, address).SignMessage(message)
+	if err == nil || !strings.Contains(err.Error(), otherAddress.Hex()) {
+		t.Fatalf("expected a wrong signer error, got %v", err)
+	}
+}
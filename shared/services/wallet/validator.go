@@ -63,7 +63,51 @@ func (w *Wallet) GetValidatorKeyByPubkey(pubkey rptypes.ValidatorPubkey) (*eth2t
 	}
 
 	// Load the key from the wallet's keystores
-	return w.LoadValidatorKey(pubkey)
+	key, err := w.LoadValidatorKey(pubkey)
+	if err == nil {
+		return key, nil
+	}
+
+	// Keys held by a remote signer can't be loaded, so fall back to deriving it from the wallet's seed
+	for index := uint(0); index < w.ws.NextAccount; index++ {
+		derivedKey, _, derivationErr := w.getValidatorPrivateKey(index)
+		if derivationErr != nil {
+			return nil, derivationErr
+		}
+		if bytes.Equal(pubkey.Bytes(), derivedKey.PublicKey().Marshal()) {
+			return derivedKey, nil
+		}
+	}
+	return nil, err
+
+}
+
+// Get a validator key by public key along with its derivation path.
+// The path is blank if the key wasn't derived from the wallet's seed.
+func (w *Wallet) GetValidatorKeyAndPathByPubkey(pubkey rptypes.ValidatorPubkey) (*eth2types.BLSPrivateKey, string, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, "", errors.New("Wallet is not initialized")
+	}
+
+	// Find the key in the wallet's seed to get its path
+	for index := uint(0); index < w.ws.NextAccount; index++ {
+		key, path, err := w.getValidatorPrivateKey(index)
+		if err != nil {
+			return nil, "", err
+		}
+		if bytes.Equal(pubkey.Bytes(), key.PublicKey().Marshal()) {
+			return key, path, nil
+		}
+	}
+
+	// Fall back to the wallet's keystores
+	key, err := w.LoadValidatorKey(pubkey)
+	if err != nil {
+		return nil, "", err
+	}
+	return key, "", nil
 
 }
 
@@ -141,6 +185,10 @@ func (w *Wallet) DeleteValidatorStores() error {
 
 	for name := range w.keystores {
 		keystorePath := w.keystores[name].GetKeystoreDir()
+		if keystorePath == "" {
+			// Remote keystores don't have a directory
+			continue
+		}
 		err := os.RemoveAll(keystorePath)
 		if err != nil {
 			return fmt.Errorf("error deleting validator directory for %s: %w", name, err)
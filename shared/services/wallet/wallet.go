@@ -36,7 +36,7 @@ type Wallet struct {
 
 	// Core
 	walletPath string
-	pm         *passwords.PasswordManager
+	pm         passwords.PasswordManager
 	encryptor  *eth2ks.Encryptor
 	chainID    *big.Int
 
@@ -51,6 +51,12 @@ type Wallet struct {
 	nodeKey     *ecdsa.PrivateKey
 	nodeKeyPath string
 
+	// Overrides the wallet index of the node account if set
+	nodeIndex *uint
+
+	// Signs for the node account instead of its derived key if set
+	nodeSigner NodeSigner
+
 	// Validator key caches
 	validatorKeys map[uint]*eth2types.BLSPrivateKey
 
@@ -75,7 +81,7 @@ type walletStore struct {
 }
 
 // Create new wallet
-func NewWallet(walletPath string, chainId uint, maxFee *big.Int, maxPriorityFee *big.Int, gasLimit uint64, passwordManager *passwords.PasswordManager) (*Wallet, error) {
+func NewWallet(walletPath string, chainId uint, maxFee *big.Int, maxPriorityFee *big.Int, gasLimit uint64, passwordManager passwords.PasswordManager) (*Wallet, error) {
 
 	// Initialize wallet
 	w := &Wallet{
@@ -100,6 +106,13 @@ func NewWallet(walletPath string, chainId uint, maxFee *big.Int, maxPriorityFee
 
 }
 
+// Set the desired gas settings for transactions created by the wallet
+func (w *Wallet) SetGasSettings(maxFee *big.Int, maxPriorityFee *big.Int, gasLimit uint64) {
+	w.maxFee = maxFee
+	w.maxPriorityFee = maxPriorityFee
+	w.gasLimit = gasLimit
+}
+
 // Gets the wallet's chain ID
 func (w *Wallet) GetChainID() *big.Int {
 	copy := big.NewInt(0).Set(w.chainID)
@@ -272,22 +285,15 @@ func (w *Wallet) Delete() error {
 
 // Signs a serialized TX using the wallet's private key
 func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
-	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
-	if err != nil {
-		return nil, err
-	}
-
 	tx := types.Transaction{}
-	err = tx.UnmarshalBinary(serializedTx)
+	err := tx.UnmarshalBinary(serializedTx)
 	if err != nil {
 		return nil, fmt.Errorf("Error unmarshalling TX: %w", err)
 	}
 
-	signer := types.NewLondonSigner(w.chainID)
-	signedTx, err := types.SignTx(&tx, signer, privateKey)
+	signedTx, err := w.signTx(&tx)
 	if err != nil {
-		return nil, fmt.Errorf("Error signing TX: %w", err)
+		return nil, err
 	}
 
 	signedData, err := signedTx.MarshalBinary()
@@ -300,6 +306,18 @@ func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
 
 // Signs an arbitrary message using the wallet's private key
 func (w *Wallet) SignMessage(message string) ([]byte, error) {
+	// Use the external signer if there is one
+	if w.nodeSigner != nil {
+		signedMessage, err := w.nodeSigner.SignMessage([]byte(message))
+		if err != nil {
+			return nil, err
+		}
+		if signedMessage[crypto.RecoveryIDOffset] < 27 {
+			signedMessage[crypto.RecoveryIDOffset] += 27
+		}
+		return signedMessage, nil
+	}
+
 	// Get the wallet's private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -1,6 +1,20 @@
 package api
 
+// The route the API server accepts commands on
+const APIServerRoute string = "/api"
+
 type APIResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+// A request to the API server; the global flags are the ones the CLI would pass to the API via docker exec
+type APIServerRequest struct {
+	Args            []string `json:"args"`
+	MaxFee          float64  `json:"maxFee"`
+	MaxPrioFee      float64  `json:"maxPrioFee"`
+	GasLimit        uint64   `json:"gasLimit"`
+	Nonce           string   `json:"nonce"`
+	IgnoreSyncCheck bool     `json:"ignoreSyncCheck"`
+	ForceFallbacks  bool     `json:"forceFallbacks"`
+}
@@ -112,8 +112,10 @@ type ChangeWithdrawalCredentialsResponse struct {
 }
 
 type ImportKeyResponse struct {
-	Status string `json:"status"`
-	Error  string `json:"error"`
+	Status             string `json:"status"`
+	Error              string `json:"error"`
+	LoadedByKeymanager bool   `json:"loadedByKeymanager"`
+	KeymanagerError    string `json:"keymanagerError"`
 }
 
 type CanProcessWithdrawalResponse struct {
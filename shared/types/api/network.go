@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
 )
 
 type NodeFeeResponse struct {
@@ -84,6 +85,12 @@ type DownloadRewardsFileResponse struct {
 	Error  string `json:"error"`
 }
 
+type NetworkRewardsEventResponse struct {
+	Status string               `json:"status"`
+	Error  string               `json:"error"`
+	Event  rewards.RewardsEvent `json:"event"`
+}
+
 type IsAtlasDeployedResponse struct {
 	Status          string `json:"status"`
 	Error           string `json:"error"`
@@ -10,6 +10,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -341,6 +342,81 @@ type NodeRewardsResponse struct {
 	TxHash                      common.Hash   `json:"txHash"`
 }
 
+type NodeProjectedRewardsResponse struct {
+	Status                    string    `json:"status"`
+	Error                     string    `json:"error"`
+	Index                     uint64    `json:"index"`
+	IntervalStart             time.Time `json:"intervalStart"`
+	IntervalEnd               time.Time `json:"intervalEnd"`
+	SnapshotSlot              uint64    `json:"snapshotSlot"`
+	SnapshotTime              time.Time `json:"snapshotTime"`
+	UsedRollingRecord         bool      `json:"usedRollingRecord"`
+	RecordSlot                uint64    `json:"recordSlot"`
+	UsedTrackedPerformance    bool      `json:"usedTrackedPerformance"`
+	TrackedEpochs             uint64    `json:"trackedEpochs"`
+	CollateralRpl             float64   `json:"collateralRpl"`
+	OracleDaoRpl              float64   `json:"oracleDaoRpl"`
+	SmoothingPoolEth          float64   `json:"smoothingPoolEth"`
+	ProjectedCollateralRpl    float64   `json:"projectedCollateralRpl"`
+	ProjectedOracleDaoRpl     float64   `json:"projectedOracleDaoRpl"`
+	ProjectedSmoothingPoolEth float64   `json:"projectedSmoothingPoolEth"`
+}
+
+// The duties a validator has performed or missed since the node daemon started tracking it
+type ValidatorPerformance struct {
+	MinipoolAddress     common.Address `json:"minipoolAddress"`
+	ValidatorIndex      string         `json:"validatorIndex"`
+	AttestationDuties   uint64         `json:"attestationDuties"`
+	MissedAttestations  uint64         `json:"missedAttestations"`
+	TotalInclusionDelay uint64         `json:"totalInclusionDelay"`
+	ProposalDuties      uint64         `json:"proposalDuties"`
+	MissedProposals     uint64         `json:"missedProposals"`
+	SyncDuties          uint64         `json:"syncDuties"`
+	MissedSyncDuties    uint64         `json:"missedSyncDuties"`
+}
+
+// The performance of a node's validators during the current rewards interval, written to disk by the node daemon
+type ValidatorPerformanceSnapshot struct {
+	IntervalStartEpoch uint64                 `json:"intervalStartEpoch"`
+	StartEpoch         uint64                 `json:"startEpoch"`
+	LastEpoch          uint64                 `json:"lastEpoch"`
+	Validators         []ValidatorPerformance `json:"validators"`
+}
+
+// The kinds of income a node can receive
+type IncomeEventType string
+
+const (
+	IncomeEventType_CollateralRpl        IncomeEventType = "collateral_rpl"
+	IncomeEventType_OracleDaoRpl         IncomeEventType = "odao_rpl"
+	IncomeEventType_RplRewards           IncomeEventType = "rpl_rewards"
+	IncomeEventType_SmoothingPoolEth     IncomeEventType = "smoothing_pool_eth"
+	IncomeEventType_MinipoolDistribution IncomeEventType = "minipool_distribution"
+	IncomeEventType_MinipoolWithdrawal   IncomeEventType = "minipool_withdrawal"
+	IncomeEventType_MinipoolRefund       IncomeEventType = "minipool_refund"
+	IncomeEventType_FeeDistributor       IncomeEventType = "fee_distributor"
+)
+
+// A single payment to the node
+type IncomeEvent struct {
+	Time            time.Time       `json:"time"`
+	Block           uint64          `json:"block"`
+	Type            IncomeEventType `json:"type"`
+	Asset           string          `json:"asset"`
+	Amount          *big.Int        `json:"amount"`
+	TxHash          common.Hash     `json:"txHash"`
+	RewardsInterval *uint64         `json:"rewardsInterval,omitempty"`
+	Minipool        *common.Address `json:"minipool,omitempty"`
+}
+
+type NodeIncomeResponse struct {
+	Status     string        `json:"status"`
+	Error      string        `json:"error"`
+	StartBlock uint64        `json:"startBlock"`
+	EndBlock   uint64        `json:"endBlock"`
+	Events     []IncomeEvent `json:"events"`
+}
+
 type DepositContractInfoResponse struct {
 	Status                string         `json:"status"`
 	Error                 string         `json:"error"`
@@ -538,3 +614,48 @@ type NodeEthBalanceResponse struct {
 	Error   string   `json:"error"`
 	Balance *big.Int `json:"balance"`
 }
+
+type NodeTaskStatus struct {
+	Name        string         `json:"name"`
+	Node        common.Address `json:"node"`
+	Enabled     bool           `json:"enabled"`
+	Interval    time.Duration  `json:"interval"`
+	Running     bool           `json:"running"`
+	LastRunTime time.Time      `json:"lastRunTime"`
+	LastError   string         `json:"lastError"`
+	NextRunTime time.Time      `json:"nextRunTime"`
+}
+type NodeTaskStatusFile struct {
+	UpdateTime time.Time        `json:"updateTime"`
+	Tasks      []NodeTaskStatus `json:"tasks"`
+}
+type NodeTaskStatusResponse struct {
+	Status          string           `json:"status"`
+	Error           string           `json:"error"`
+	StatusAvailable bool             `json:"statusAvailable"`
+	UpdateTime      time.Time        `json:"updateTime"`
+	Tasks           []NodeTaskStatus `json:"tasks"`
+}
+
+type NodeTransactionsResponse struct {
+	Status       string                      `json:"status"`
+	Error        string                      `json:"error"`
+	Transactions []transactions.JournalEntry `json:"transactions"`
+}
+type CanReplaceNodeTransactionResponse struct {
+	Status            string                    `json:"status"`
+	Error             string                    `json:"error"`
+	CanReplace        bool                      `json:"canReplace"`
+	NotFound          bool                      `json:"notFound"`
+	NotPending        bool                      `json:"notPending"`
+	WrongSender       bool                      `json:"wrongSender"`
+	Transaction       transactions.JournalEntry `json:"transaction"`
+	MinMaxFee         *big.Int                  `json:"minMaxFee"`
+	MinMaxPriorityFee *big.Int                  `json:"minMaxPriorityFee"`
+	GasInfo           rocketpool.GasInfo        `json:"gasInfo"`
+}
+type ReplaceNodeTransactionResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
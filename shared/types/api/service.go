@@ -1,6 +1,11 @@
 package api
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
 
 type TerminateDataFolderResponse struct {
 	Status        string `json:"status"`
@@ -8,6 +13,15 @@ type TerminateDataFolderResponse struct {
 	FolderExisted bool   `json:"folderExisted"`
 }
 
+type PruneStateCacheResponse struct {
+	Status         string `json:"status"`
+	Error          string `json:"error"`
+	CacheEnabled   bool   `json:"cacheEnabled"`
+	RemovedFiles   int    `json:"removedFiles"`
+	FreedBytes     uint64 `json:"freedBytes"`
+	RemainingBytes uint64 `json:"remainingBytes"`
+}
+
 type CreateFeeRecipientFileResponse struct {
 	Status      string         `json:"status"`
 	Error       string         `json:"error"`
@@ -25,9 +39,10 @@ type ClientStatus struct {
 
 // This is a wrapper for the manager's overall status report
 type ClientManagerStatus struct {
-	PrimaryClientStatus  ClientStatus `json:"primaryEcStatus"`
-	FallbackEnabled      bool         `json:"fallbackEnabled"`
-	FallbackClientStatus ClientStatus `json:"fallbackEcStatus"`
+	PrimaryClientStatus    ClientStatus   `json:"primaryEcStatus"`
+	FallbackEnabled        bool           `json:"fallbackEnabled"`
+	FallbackClientStatus   ClientStatus   `json:"fallbackEcStatus"`
+	FallbackClientStatuses []ClientStatus `json:"fallbackClientStatuses"`
 }
 
 type ClientStatusResponse struct {
@@ -41,3 +56,33 @@ type RestartVcResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type ValidatorLivenessResponse struct {
+	Status      string                  `json:"status"`
+	Error       string                  `json:"error"`
+	Epochs      []uint64                `json:"epochs"`
+	LivePubkeys []types.ValidatorPubkey `json:"livePubkeys"`
+}
+
+type CreateBackupResponse struct {
+	Status           string                  `json:"status"`
+	Error            string                  `json:"error"`
+	Filename         string                  `json:"filename"`
+	FileCount        int                     `json:"fileCount"`
+	ValidatorPubkeys []types.ValidatorPubkey `json:"validatorPubkeys"`
+	PasswordIncluded bool                    `json:"passwordIncluded"`
+}
+
+type RestoreBackupResponse struct {
+	Status                     string                  `json:"status"`
+	Error                      string                  `json:"error"`
+	Created                    time.Time               `json:"created"`
+	SmartnodeVersion           string                  `json:"smartnodeVersion"`
+	FileCount                  int                     `json:"fileCount"`
+	ValidatorPubkeys           []types.ValidatorPubkey `json:"validatorPubkeys"`
+	LivenessEpochs             []uint64                `json:"livenessEpochs"`
+	LivePubkeys                []types.ValidatorPubkey `json:"livePubkeys"`
+	WalletExists               bool                    `json:"walletExists"`
+	SlashingProtectionImported bool                    `json:"slashingProtectionImported"`
+	Restored                   bool                    `json:"restored"`
+}
@@ -61,11 +61,13 @@ type RebuildWalletResponse struct {
 }
 
 type ExportWalletResponse struct {
-	Status            string `json:"status"`
-	Error             string `json:"error"`
-	Password          string `json:"password"`
-	Wallet            string `json:"wallet"`
-	AccountPrivateKey string `json:"accountPrivateKey"`
+	Status            string         `json:"status"`
+	Error             string         `json:"error"`
+	Password          string         `json:"password"`
+	Wallet            string         `json:"wallet"`
+	AccountPrivateKey string         `json:"accountPrivateKey"`
+	HasNodeSigner     bool           `json:"hasNodeSigner"`
+	NodeSignerAddress common.Address `json:"nodeSignerAddress"`
 }
 
 type SetEnsNameResponse struct {
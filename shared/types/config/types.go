@@ -7,6 +7,10 @@ type ParameterType string
 type ExecutionClient string
 type ConsensusClient string
 type RewardsMode string
+type LogFormat string
+type LogLevel string
+type PasswordBackend string
+type NodeSigner string
 type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
@@ -83,6 +87,39 @@ const (
 	RewardsMode_Generate RewardsMode = "generate"
 )
 
+// Enum to describe the daemon log formats
+const (
+	LogFormat_Unknown LogFormat = ""
+	LogFormat_Color   LogFormat = "color"
+	LogFormat_Json    LogFormat = "json"
+)
+
+// Enum to describe the minimum level of the daemon logs
+const (
+	LogLevel_Unknown LogLevel = ""
+	LogLevel_Debug   LogLevel = "debug"
+	LogLevel_Info    LogLevel = "info"
+	LogLevel_Warn    LogLevel = "warn"
+	LogLevel_Error   LogLevel = "error"
+)
+
+// Enum to describe where the node wallet password is stored
+const (
+	PasswordBackend_Unknown PasswordBackend = ""
+	PasswordBackend_File    PasswordBackend = "file"
+	PasswordBackend_Env     PasswordBackend = "env"
+	PasswordBackend_Keyring PasswordBackend = "keyring"
+	PasswordBackend_Exec    PasswordBackend = "exec"
+)
+
+// Enum to describe what signs for the node account
+const (
+	NodeSigner_Unknown NodeSigner = ""
+	NodeSigner_Local   NodeSigner = "local"
+	NodeSigner_Clef    NodeSigner = "clef"
+	NodeSigner_Rpc     NodeSigner = "rpc"
+)
+
 // Enum to identify MEV-boost relays
 const (
 	MevRelayID_Unknown            MevRelayID = ""
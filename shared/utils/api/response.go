@@ -3,14 +3,31 @@ package api
 import (
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"os"
 	"reflect"
 
 	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
+// The key of the App metadata entry that redirects API responses; the API server sets it on each request's App
+// so concurrent commands capture their own responses
+const ResponseWriterKey string = "apiResponseWriter"
+
+// Get the destination for a command's API responses
+func GetResponseWriter(c *cli.Context) io.Writer {
+	if c != nil && c.App != nil {
+		if writer, ok := c.App.Metadata[ResponseWriterKey].(io.Writer); ok {
+			return writer
+		}
+	}
+	return os.Stdout
+}
+
 func ZeroIfNil(in **big.Int) {
 	if *in == nil {
 		*in = big.NewInt(0)
@@ -19,12 +36,12 @@ func ZeroIfNil(in **big.Int) {
 
 // Print an API response
 // response must be a pointer to a struct type with Error and Status string fields
-func PrintResponse(response interface{}, responseError error) {
+func PrintResponse(c *cli.Context, response interface{}, responseError error) {
 
 	// Check response type
 	r := reflect.ValueOf(response)
 	if !(r.Kind() == reflect.Ptr && r.Type().Elem().Kind() == reflect.Struct) {
-		PrintErrorResponse(errors.New("Invalid API response"))
+		PrintErrorResponse(c, errors.New("Invalid API response"))
 		return
 	}
 
@@ -38,7 +55,7 @@ func PrintResponse(response interface{}, responseError error) {
 	sf := r.Elem().FieldByName("Status")
 	ef := r.Elem().FieldByName("Error")
 	if !(sf.IsValid() && sf.CanSet() && sf.Kind() == reflect.String && ef.IsValid() && ef.CanSet() && ef.Kind() == reflect.String) {
-		PrintErrorResponse(errors.New("Invalid API response"))
+		PrintErrorResponse(c, errors.New("Invalid API response"))
 		return
 	}
 
@@ -57,16 +74,16 @@ func PrintResponse(response interface{}, responseError error) {
 	// Encode
 	responseBytes, err := json.Marshal(response)
 	if err != nil {
-		PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
+		PrintErrorResponse(c, fmt.Errorf("Could not encode API response: %w", err))
 		return
 	}
 
 	// Print
-	fmt.Println(string(responseBytes))
+	fmt.Fprintln(GetResponseWriter(c), string(responseBytes))
 
 }
 
 // Print an API error response
-func PrintErrorResponse(err error) {
-	PrintResponse(&api.APIResponse{}, err)
+func PrintErrorResponse(c *cli.Context, err error) {
+	PrintResponse(c, &api.APIResponse{}, err)
 }
@@ -59,13 +59,14 @@ func PrintAndWaitForTransaction(cfg *config.RocketPoolConfig, hash common.Hash,
 
 	txWatchUrl := cfg.Smartnode.GetTxWatchUrl()
 	hashString := hash.String()
+	txLog := logger.With(log.TxHash(hash))
 
-	logger.Printlnf("Transaction has been submitted with hash %s.", hashString)
+	txLog.Printlnf("Transaction has been submitted with hash %s.", hashString)
 	if txWatchUrl != "" {
-		logger.Printlnf("You may follow its progress by visiting:")
-		logger.Printlnf("%s/%s\n", txWatchUrl, hashString)
+		txLog.Printlnf("You may follow its progress by visiting:")
+		txLog.Printlnf("%s/%s\n", txWatchUrl, hashString)
 	}
-	logger.Println("Waiting for the transaction to be validated...")
+	txLog.Println("Waiting for the transaction to be validated...")
 
 	// Wait for the TX to be included in a block
 	if _, err := utils.WaitForTransaction(ec, hash); err != nil {
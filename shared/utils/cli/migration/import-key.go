@@ -33,13 +33,22 @@ func ImportKey(c *cli.Context, rp *rocketpool.Client, minipoolAddress common.Add
 
 	// Import the key
 	fmt.Printf("Importing validator key... ")
-	_, err := rp.ImportKey(minipoolAddress, mnemonic)
+	response, err := rp.ImportKey(minipoolAddress, mnemonic)
 	if err != nil {
 		fmt.Printf("error importing validator key: %s\n", err.Error())
 		return false
 	}
 	fmt.Println("done!")
 
+	// The VC doesn't need to be restarted if it loaded the key through its keymanager API
+	if response.LoadedByKeymanager {
+		fmt.Print("Your Validator Client has loaded your validator's key without restarting.\n\n")
+		return true
+	}
+	if response.KeymanagerError != "" {
+		fmt.Printf("%sCouldn't load the key with your Validator Client's keymanager API: %s%s\n\n", colorYellow, response.KeymanagerError, colorReset)
+	}
+
 	// Restart the VC if necessary
 	if c.Bool("no-restart") {
 		return true
@@ -0,0 +1,103 @@
+package log
+
+import (
+	"bytes"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// Standard field keys
+const (
+	Key_Task     string = "task"
+	Key_Node     string = "node"
+	Key_Minipool string = "minipool"
+	Key_TxHash   string = "txHash"
+	Key_Slot     string = "slot"
+	Key_Error    string = "error"
+)
+
+// A key / value pair attached to a log line.
+// Fields are only written in JSON output; color output only shows the message.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// The name of the daemon task that logged the line
+func Task(name string) Field {
+	return Field{Key: Key_Task, Value: name}
+}
+
+// The node the line is about
+func Node(address common.Address) Field {
+	return Field{Key: Key_Node, Value: address.Hex()}
+}
+
+// The minipool the line is about
+func Minipool(address common.Address) Field {
+	return Field{Key: Key_Minipool, Value: address.Hex()}
+}
+
+// The transaction the line is about
+func TxHash(hash common.Hash) Field {
+	return Field{Key: Key_TxHash, Value: hash.Hex()}
+}
+
+// The Beacon slot the line is about
+func Slot(slot uint64) Field {
+	return Field{Key: Key_Slot, Value: slot}
+}
+
+// The error that caused the line to be logged
+func Err(err error) Field {
+	return Field{Key: Key_Error, Value: err.Error()}
+}
+
+// Serializes JSON lines so they aren't interleaved
+var jsonLock sync.Mutex
+
+// Write a line as a JSON object; later fields with the same key replace earlier ones
+func writeJson(level Level, message string, fields []Field) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{"time":`)
+	writeJsonValue(&buffer, time.Now().UTC().Format(time.RFC3339Nano))
+	buffer.WriteString(`,"level":`)
+	writeJsonValue(&buffer, level.String())
+	buffer.WriteString(`,"msg":`)
+	writeJsonValue(&buffer, message)
+
+	for i, field := range fields {
+		overridden := false
+		for _, later := range fields[i+1:] {
+			if later.Key == field.Key {
+				overridden = true
+				break
+			}
+		}
+		if overridden {
+			continue
+		}
+		buffer.WriteByte(',')
+		writeJsonValue(&buffer, field.Key)
+		buffer.WriteByte(':')
+		writeJsonValue(&buffer, field.Value)
+	}
+	buffer.WriteString("}\n")
+
+	jsonLock.Lock()
+	defer jsonLock.Unlock()
+	log.Writer().Write(buffer.Bytes())
+}
+
+// Write a single JSON value, falling back to its string representation if it can't be serialized
+func writeJsonValue(buffer *bytes.Buffer, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		data, _ = json.Marshal(err.Error())
+	}
+	buffer.Write(data)
+}
@@ -1,16 +1,19 @@
 package log
 
 import (
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/fatih/color"
 )
 
-// Logger with ANSI color output
+// Logger with ANSI color output, or structured JSON output if it has been enabled with SetFormat
 type ColorLogger struct {
 	Color       color.Attribute
 	sprintFunc  func(a ...interface{}) string
 	sprintfFunc func(format string, a ...interface{}) string
+	fields      []Field
 }
 
 // Create new color logger
@@ -22,22 +25,89 @@ func NewColorLogger(colorAttr color.Attribute) ColorLogger {
 	}
 }
 
-// Print values
-func (l *ColorLogger) Print(v ...interface{}) {
-	log.Print(l.sprintFunc(v...))
+// Create a copy of the logger that adds the provided fields to every line it logs
+func (l ColorLogger) With(fields ...Field) ColorLogger {
+	combined := make([]Field, 0, len(l.fields)+len(fields))
+	combined = append(combined, l.fields...)
+	combined = append(combined, fields...)
+	l.fields = combined
+	return l
+}
+
+// Print values at the info level; warnings and errors should use Warnf and Errorf instead so they get the right level
+func (l ColorLogger) Print(v ...interface{}) {
+	message := fmt.Sprint(v...)
+	l.output(Level_Info, message, func() string {
+		return l.sprintFunc(v...)
+	}, log.Print)
 }
 
 // Print values with a newline
-func (l *ColorLogger) Println(v ...interface{}) {
-	log.Println(l.sprintFunc(v...))
+func (l ColorLogger) Println(v ...interface{}) {
+	message := strings.TrimSuffix(fmt.Sprintln(v...), "\n")
+	l.output(Level_Info, message, func() string {
+		return l.sprintFunc(v...)
+	}, log.Println)
 }
 
 // Print a formatted string
-func (l *ColorLogger) Printf(format string, v ...interface{}) {
-	log.Print(l.sprintfFunc(format, v...))
+func (l ColorLogger) Printf(format string, v ...interface{}) {
+	message := fmt.Sprintf(format, v...)
+	l.output(Level_Info, message, func() string {
+		return l.sprintfFunc(format, v...)
+	}, log.Print)
 }
 
 // Print a formatted string with a newline
-func (l *ColorLogger) Printlnf(format string, v ...interface{}) {
-	log.Println(l.sprintfFunc(format, v...))
+func (l ColorLogger) Printlnf(format string, v ...interface{}) {
+	message := fmt.Sprintf(format, v...)
+	l.output(Level_Info, message, func() string {
+		return l.sprintfFunc(format, v...)
+	}, log.Println)
+}
+
+// Log a formatted string at the provided level
+func (l ColorLogger) Logf(level Level, format string, v ...interface{}) {
+	message := fmt.Sprintf(format, v...)
+	l.output(level, message, func() string {
+		return l.sprintFunc(level.prefix() + message)
+	}, log.Println)
+}
+
+// Log a formatted string at the debug level
+func (l ColorLogger) Debugf(format string, v ...interface{}) {
+	l.Logf(Level_Debug, format, v...)
+}
+
+// Log a formatted string at the info level
+func (l ColorLogger) Infof(format string, v ...interface{}) {
+	l.Logf(Level_Info, format, v...)
+}
+
+// Log a formatted string at the warning level
+func (l ColorLogger) Warnf(format string, v ...interface{}) {
+	l.Logf(Level_Warn, format, v...)
+}
+
+// Log a formatted string at the error level
+func (l ColorLogger) Errorf(format string, v ...interface{}) {
+	l.Logf(Level_Error, format, v...)
+}
+
+// Log an error at the error level
+func (l ColorLogger) Error(err error) {
+	l.Logf(Level_Error, "%s", err.Error())
+}
+
+// Write a line in the configured format if its level is enabled
+func (l ColorLogger) output(level Level, message string, colorize func() string, print func(v ...interface{})) {
+	format, minLevel := getSettings()
+	if level < minLevel {
+		return
+	}
+	if format == Format_Json {
+		writeJson(level, message, l.fields)
+		return
+	}
+	print(colorize())
 }
@@ -0,0 +1,130 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// Capture everything written by the loggers with the provided settings, restoring the defaults when the test ends
+func captureOutput(t *testing.T, format Format, minLevel Level) *bytes.Buffer {
+	var buffer bytes.Buffer
+	writer, flags := log.Writer(), log.Flags()
+	log.SetOutput(&buffer)
+	log.SetFlags(0)
+	Configure(format, minLevel)
+	t.Cleanup(func() {
+		log.SetOutput(writer)
+		log.SetFlags(flags)
+		Configure(Format_Color, Level_Info)
+	})
+	return &buffer
+}
+
+// Parse every JSON line in the buffer
+func readJsonLines(t *testing.T, buffer *bytes.Buffer) []map[string]interface{} {
+	lines := []map[string]interface{}{}
+	for _, line := range strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("line [%s] is not valid JSON: %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestJsonOutput(t *testing.T) {
+	buffer := captureOutput(t, Format_Json, Level_Debug)
+	node := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	logger := NewColorLogger(0).With(Task("prices"), Node(node))
+
+	logger.Printlnf("Submitting price for block %d", 100)
+	logger.With(Task("balances"), Slot(12)).Warnf("Slow response")
+	logger.With(Err(errors.New("bad response"))).Error(errors.New("submission failed"))
+
+	lines := readJsonLines(t, buffer)
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d: %s", len(lines), buffer.String())
+	}
+	for _, line := range lines {
+		if _, exists := line["time"]; !exists {
+			t.Fatalf("line is missing its time: %v", line)
+		}
+		if line[Key_Node] != node.Hex() {
+			t.Fatalf("expected node %s, got %v", node.Hex(), line[Key_Node])
+		}
+	}
+
+	if lines[0]["level"] != "info" || lines[0]["msg"] != "Submitting price for block 100" || lines[0][Key_Task] != "prices" {
+		t.Fatalf("unexpected info line: %v", lines[0])
+	}
+
+	// Fields added later replace ones with the same key, and the message has no level prefix
+	if lines[1]["level"] != "warn" || lines[1]["msg"] != "Slow response" || lines[1][Key_Task] != "balances" || lines[1][Key_Slot] != float64(12) {
+		t.Fatalf("unexpected warning line: %v", lines[1])
+	}
+	if lines[2]["level"] != "error" || lines[2]["msg"] != "submission failed" || lines[2][Key_Error] != "bad response" {
+		t.Fatalf("unexpected error line: %v", lines[2])
+	}
+
+	// Duplicate keys are only written once
+	if strings.Count(strings.Split(buffer.String(), "\n")[1], `"task"`) != 1 {
+		t.Fatalf("overridden field was written: %s", buffer.String())
+	}
+}
+
+func TestLevelFiltering(t *testing.T) {
+	buffer := captureOutput(t, Format_Json, Level_Warn)
+	logger := NewColorLogger(0)
+
+	logger.Debugf("debug")
+	logger.Infof("info")
+	logger.Println("print")
+	logger.Printlnf("Error submitting price")
+	logger.Warnf("warning")
+	logger.Errorf("error")
+	logger.Error(errors.New("failure"))
+
+	// Print logs at the info level no matter what its message says
+	lines := readJsonLines(t, buffer)
+	messages := []string{}
+	for _, line := range lines {
+		messages = append(messages, line["msg"].(string))
+	}
+	if strings.Join(messages, ",") != "warning,error,failure" {
+		t.Fatalf("expected only warnings and errors, got %v", messages)
+	}
+}
+
+func TestColorOutput(t *testing.T) {
+	buffer := captureOutput(t, Format_Color, Level_Debug)
+	logger := NewColorLogger(0).With(Task("prices"))
+
+	logger.Printlnf("Checking prices")
+	logger.Debugf("Block %d", 100)
+	logger.Warnf("Slow response")
+	logger.Errorf("Submission failed")
+
+	// Fields are only written in JSON output
+	expected := "Checking prices\nDEBUG: Block 100\nWARNING: Slow response\nERROR: Submission failed\n"
+	if buffer.String() != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, buffer.String())
+	}
+
+	Configure(Format_Color, Level_Error)
+	buffer.Reset()
+	logger.Warnf("Slow response")
+	logger.Errorf("Submission failed")
+	if buffer.String() != "ERROR: Submission failed\n" {
+		t.Fatalf("expected only the error, got %s", buffer.String())
+	}
+}
@@ -0,0 +1,96 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// The output format of every logger
+type Format string
+
+const (
+	// Human-readable lines with ANSI colors
+	Format_Color Format = "color"
+
+	// One JSON object per line, with the level and fields as keys
+	Format_Json Format = "json"
+)
+
+// The severity of a log line
+type Level int
+
+const (
+	Level_Debug Level = iota
+	Level_Info
+	Level_Warn
+	Level_Error
+)
+
+// Process-wide logger settings
+var (
+	settingsLock sync.RWMutex
+	format       Format = Format_Color
+	minLevel     Level  = Level_Info
+)
+
+// Set the output format and minimum level of every logger in this process
+func Configure(newFormat Format, newMinLevel Level) {
+	settingsLock.Lock()
+	defer settingsLock.Unlock()
+	format = newFormat
+	minLevel = newMinLevel
+}
+
+// Get the output format and minimum level
+func getSettings() (Format, Level) {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+	return format, minLevel
+}
+
+// Parse a log level from its name
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return Level_Debug, nil
+	case "info":
+		return Level_Info, nil
+	case "warn", "warning":
+		return Level_Warn, nil
+	case "error":
+		return Level_Error, nil
+	default:
+		return Level_Info, fmt.Errorf("unknown log level '%s'", name)
+	}
+}
+
+// Get the name of the level
+func (l Level) String() string {
+	switch l {
+	case Level_Debug:
+		return "debug"
+	case Level_Info:
+		return "info"
+	case Level_Warn:
+		return "warn"
+	case Level_Error:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Get the prefix for lines of this level in color output
+func (l Level) prefix() string {
+	switch l {
+	case Level_Debug:
+		return "DEBUG: "
+	case Level_Warn:
+		return "WARNING: "
+	case Level_Error:
+		return "ERROR: "
+	default:
+		return ""
+	}
+}
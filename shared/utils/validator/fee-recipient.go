@@ -193,3 +193,37 @@ func StopValidator(cfg *config.RocketPoolConfig, bc beacon.Client, log *log.Colo
 	return nil
 
 }
+
+// Check if the validator container is running; this only works in Docker mode
+func IsValidatorRunning(cfg *config.RocketPoolConfig, bc beacon.Client, d *client.Client) (bool, error) {
+
+	// Get validator container name
+	if cfg.Smartnode.ProjectName.Value == "" {
+		return false, errors.New("Rocket Pool docker project name not set")
+	}
+	var containerName string
+	clientType, _ := bc.GetClientType()
+	switch clientType {
+	case beacon.SplitProcess:
+		containerName = cfg.Smartnode.ProjectName.Value.(string) + ValidatorContainerSuffix
+	case beacon.SingleProcess:
+		containerName = cfg.Smartnode.ProjectName.Value.(string) + BeaconContainerSuffix
+	default:
+		return false, fmt.Errorf("Can't check the validator, unknown client type '%d'", clientType)
+	}
+
+	// Get all containers
+	containers, err := d.ContainerList(context.Background(), types.ContainerListOptions{All: true})
+	if err != nil {
+		return false, fmt.Errorf("Could not get docker containers: %w", err)
+	}
+
+	// Check the validator container's state
+	for _, container := range containers {
+		if container.Names[0] == "/"+containerName {
+			return container.State == "running", nil
+		}
+	}
+	return false, nil
+
+}
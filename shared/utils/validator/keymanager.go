@@ -0,0 +1,137 @@
+package validator
+
+import (
+	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Load new validator keys into the validator process through its keymanager API, or restart it if the API can't be used
+func LoadValidatorKeys(cfg *config.RocketPoolConfig, bc beacon.Client, log *log.ColorLogger, d *client.Client, km *keymanager.Client, keys []keymanager.ValidatorKey, feeRecipient common.Address) error {
+
+	// Try the keymanager API first; new keys haven't signed anything, so they don't need any slashing protection
+	if km != nil {
+		err := ImportValidatorKeys(cfg, log, km, keys, feeRecipient, "")
+		if err == nil {
+			if log != nil {
+				log.Printlnf("Loaded %d validator key(s) with the Validator Client's keymanager API.", len(keys))
+			}
+			return nil
+		}
+		if log != nil {
+			log.Warnf("Couldn't load the validator keys with the Validator Client's keymanager API (%s), restarting it instead.", err.Error())
+		}
+	}
+
+	// Fall back to a restart
+	return RestartValidator(cfg, bc, log, d)
+
+}
+
+// Import validator keys into the validator process through its keymanager API and set their fee recipient.
+// If the keys have signed anything before, slashingProtection is their EIP-3076 interchange; otherwise it's blank.
+// If the keys are held by the remote signer, the validator process is told to use them from the signer instead.
+func ImportValidatorKeys(cfg *config.RocketPoolConfig, log *log.ColorLogger, km *keymanager.Client, keys []keymanager.ValidatorKey, feeRecipient common.Address, slashingProtection string) error {
+
+	// Get the pubkeys
+	pubkeys := make([]types.ValidatorPubkey, len(keys))
+	for i, key := range keys {
+		pubkeys[i] = types.BytesToValidatorPubkey(key.Key.PublicKey().Marshal())
+	}
+
+	// Import the keys
+	var duplicates []types.ValidatorPubkey
+	var err error
+	if cfg.Smartnode.UseRemoteSigner.Value == true {
+		duplicates, err = km.ImportRemoteKeys(pubkeys, cfg.Smartnode.RemoteSignerUrl.Value.(string))
+	} else {
+		duplicates, err = km.ImportKeystores(keys, slashingProtection)
+	}
+	if err != nil {
+		return err
+	}
+	if log != nil {
+		for _, pubkey := range duplicates {
+			log.Warnf("Validator key %s was already loaded in the Validator Client, so it wasn't imported again.", pubkey.Hex())
+		}
+	}
+
+	// The validator process only reads the fee recipient file when it starts, so set it on each new key explicitly
+	for _, pubkey := range pubkeys {
+		if err := km.SetFeeRecipient(pubkey, feeRecipient); err != nil {
+			return err
+		}
+	}
+	return nil
+
+}
+
+// Set the fee recipient of the node's minipool validator keys in the validator process through its keymanager API, or restart it if the API can't be used
+func UpdateFeeRecipient(cfg *config.RocketPoolConfig, bc beacon.Client, log *log.ColorLogger, d *client.Client, km *keymanager.Client, minipoolPubkeys []types.ValidatorPubkey, feeRecipient common.Address) error {
+
+	// Try the keymanager API first
+	if km != nil {
+		updatedCount, err := SyncFeeRecipient(cfg, km, minipoolPubkeys, feeRecipient)
+		if err == nil {
+			if log != nil {
+				log.Printlnf("Updated the fee recipient of %d validator key(s) with the Validator Client's keymanager API.", updatedCount)
+			}
+			return nil
+		}
+		if log != nil {
+			log.Warnf("Couldn't update the fee recipient with the Validator Client's keymanager API (%s), restarting it instead.", err.Error())
+		}
+	}
+
+	// Fall back to a restart
+	return RestartValidator(cfg, bc, log, d)
+
+}
+
+// Make sure the node's minipool validator keys in the validator process use the given fee recipient, and return how many had to be updated.
+// Fee recipients set through the keymanager API take precedence over the fee recipient file, so this has to be kept in sync with it.
+// Any other keys loaded in the validator process aren't Rocket Pool's to manage, so their fee recipients are left alone.
+func SyncFeeRecipient(cfg *config.RocketPoolConfig, km *keymanager.Client, minipoolPubkeys []types.ValidatorPubkey, feeRecipient common.Address) (int, error) {
+
+	// Get the keys loaded in the validator process
+	var pubkeys []types.ValidatorPubkey
+	var err error
+	if cfg.Smartnode.UseRemoteSigner.Value == true {
+		pubkeys, err = km.ListRemoteKeys()
+	} else {
+		pubkeys, err = km.ListKeystores()
+	}
+	if err != nil {
+		return 0, err
+	}
+	isMinipool := make(map[types.ValidatorPubkey]bool, len(minipoolPubkeys))
+	for _, pubkey := range minipoolPubkeys {
+		isMinipool[pubkey] = true
+	}
+
+	// Update the minipool keys with a different fee recipient
+	updatedCount := 0
+	for _, pubkey := range pubkeys {
+		if !isMinipool[pubkey] {
+			continue
+		}
+		currentFeeRecipient, err := km.GetFeeRecipient(pubkey)
+		if err != nil {
+			return updatedCount, err
+		}
+		if currentFeeRecipient == feeRecipient {
+			continue
+		}
+		if err := km.SetFeeRecipient(pubkey, feeRecipient); err != nil {
+			return updatedCount, err
+		}
+		updatedCount++
+	}
+	return updatedCount, nil
+
+}
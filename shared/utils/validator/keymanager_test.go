@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+func TestSyncFeeRecipientOnlyUpdatesMinipoolKeys(t *testing.T) {
+	minipoolPubkey := types.BytesToValidatorPubkey(make([]byte, types.ValidatorPubkeyLength))
+	otherPubkey := minipoolPubkey
+	otherPubkey[0] = 1
+	oldFeeRecipient := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	feeRecipient := common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	// A Validator Client with a minipool key and a key that isn't Rocket Pool's, both using the old fee recipient
+	updated := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == keymanager.KeystoresPath:
+			fmt.Fprintf(w, `{"data":[{"validating_pubkey":"%s"},{"validating_pubkey":"%s"}]}`, hexutil.AddPrefix(minipoolPubkey.Hex()), hexutil.AddPrefix(otherPubkey.Hex()))
+		case r.Method == http.MethodGet:
+			fmt.Fprintf(w, `{"data":{"ethaddress":"%s"}}`, oldFeeRecipient.Hex())
+		default:
+			updated = append(updated, strings.Split(r.URL.Path, "/")[4])
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.NewRocketPoolConfig(t.TempDir(), false)
+	km := keymanager.NewClient(server.URL, "")
+	updatedCount, err := SyncFeeRecipient(cfg, km, []types.ValidatorPubkey{minipoolPubkey}, feeRecipient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updatedCount != 1 || len(updated) != 1 || updated[0] != hexutil.AddPrefix(minipoolPubkey.Hex()) {
+		t.Fatalf("expected only the minipool key to be updated, got %d update(s) for %v", updatedCount, updated)
+	}
+}
@@ -27,15 +27,32 @@ func GetWithdrawalKey(mnemonic string, index uint, validatorKeyPath string) (*et
 // Get a voluntary exit message signature for a given validator key and index
 func GetSignedWithdrawalCredsChangeMessage(withdrawalKey *eth2types.BLSPrivateKey, validatorIndex string, newWithdrawalAddress common.Address, signatureDomain []byte) (types.ValidatorSignature, error) {
 
+	// Get signing root
+	withdrawalPubkey := types.BytesToValidatorPubkey(withdrawalKey.PublicKey().Marshal())
+	srHash, err := GetWithdrawalCredsChangeSigningRoot(withdrawalPubkey, validatorIndex, newWithdrawalAddress, signatureDomain)
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+
+	// Sign message
+	signature := withdrawalKey.Sign(srHash[:]).Marshal()
+
+	// Return
+	return types.BytesToValidatorSignature(signature), nil
+
+}
+
+// Get the signing root of a withdrawal credentials change message for a given withdrawal pubkey and validator index
+func GetWithdrawalCredsChangeSigningRoot(withdrawalPubkey types.ValidatorPubkey, validatorIndex string, newWithdrawalAddress common.Address, signatureDomain []byte) ([32]byte, error) {
+
 	// Get the withdrawal pubkey
-	withdrawalPubkey := withdrawalKey.PublicKey().Marshal()
 	withdrawalPubkeyBuffer := [48]byte{}
-	copy(withdrawalPubkeyBuffer[:], withdrawalPubkey)
+	copy(withdrawalPubkeyBuffer[:], withdrawalPubkey.Bytes())
 
 	// Convert the validator index to a uint
 	indexNum, err := strconv.ParseUint(validatorIndex, 10, 64)
 	if err != nil {
-		return types.ValidatorSignature{}, fmt.Errorf("error parsing validator index (%s): %w", validatorIndex, err)
+		return [32]byte{}, fmt.Errorf("error parsing validator index (%s): %w", validatorIndex, err)
 	}
 
 	// Build withdrawal creds change message
@@ -48,7 +65,7 @@ func GetSignedWithdrawalCredsChangeMessage(withdrawalKey *eth2types.BLSPrivateKe
 	// Get object root
 	or, err := message.HashTreeRoot()
 	if err != nil {
-		return types.ValidatorSignature{}, err
+		return [32]byte{}, err
 	}
 
 	// Get signing root
@@ -56,16 +73,6 @@ func GetSignedWithdrawalCredsChangeMessage(withdrawalKey *eth2types.BLSPrivateKe
 		ObjectRoot: or[:],
 		Domain:     signatureDomain,
 	}
-
-	srHash, err := sr.HashTreeRoot()
-	if err != nil {
-		return types.ValidatorSignature{}, err
-	}
-
-	// Sign message
-	signature := withdrawalKey.Sign(srHash[:]).Marshal()
-
-	// Return
-	return types.BytesToValidatorSignature(signature), nil
+	return sr.HashTreeRoot()
 
 }